@@ -23,6 +23,7 @@ import (
 
 	"github.com/drone/go-convert/convert/cloudbuild"
 	"github.com/drone/go-convert/convert/harness/downgrader"
+	"github.com/drone/go-convert/internal/slug"
 
 	"github.com/google/subcommands"
 )
@@ -39,18 +40,20 @@ type Cloudbuild struct {
 
 	downgrade   bool
 	beforeAfter bool
+	trigger     bool
 }
 
 func (*Cloudbuild) Name() string     { return "cloudbuild" }
 func (*Cloudbuild) Synopsis() string { return "converts a cloudbuild pipeline" }
 func (*Cloudbuild) Usage() string {
-	return `cloudbuild [-downgrade] [cloudbuild.yaml]
+	return `cloudbuild [-downgrade] [-trigger] [cloudbuild.yaml]
 `
 }
 
 func (c *Cloudbuild) SetFlags(f *flag.FlagSet) {
 	f.BoolVar(&c.downgrade, "downgrade", false, "downgrade to the legacy yaml format")
 	f.BoolVar(&c.beforeAfter, "before-after", false, "print the befor and after")
+	f.BoolVar(&c.trigger, "trigger", false, "convert a cloudbuild trigger")
 
 	f.StringVar(&c.org, "org", "default", "harness organization")
 	f.StringVar(&c.proj, "project", "default", "harness project")
@@ -83,16 +86,29 @@ func (c *Cloudbuild) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{
 	converter := cloudbuild.New(
 		cloudbuild.WithDockerhub(c.dockerConn),
 		cloudbuild.WithKubernetes(c.kubeName, c.kubeConn),
+		cloudbuild.WithConnector(c.repoConn),
+		cloudbuild.WithOrganization(c.org),
+		cloudbuild.WithProject(c.proj),
+		cloudbuild.WithPipeline(slug.Create(c.name)),
 	)
-	after, err := converter.ConvertBytes(before)
+
+	var after []byte
+	if c.trigger {
+		// convert the trigger yaml from the cloudbuild
+		// format to the harness trigger yaml format.
+		after, err = converter.ConvertTriggerBytes(before)
+	} else {
+		after, err = converter.ConvertBytes(before)
+	}
 	if err != nil {
 		log.Println(err)
 		return subcommands.ExitFailure
 	}
 
 	// downgrade from the v1 harness yaml format
-	// to the v0 harness yaml format.
-	if c.downgrade {
+	// to the v0 harness yaml format. triggers are
+	// already in the v0 harness yaml format.
+	if c.downgrade && !c.trigger {
 		// downgrade to the v0 yaml
 		d := downgrader.New(
 			downgrader.WithCodebase(c.repoName, c.repoConn),
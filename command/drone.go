@@ -24,21 +24,26 @@ import (
 	"strings"
 
 	"github.com/drone/go-convert/convert/drone"
+	"github.com/drone/go-convert/convert/drone/render"
 	"github.com/drone/go-convert/convert/harness/downgrader"
 
 	"github.com/google/subcommands"
 )
 
 type Drone struct {
-	name       string
-	proj       string
-	org        string
-	repoName   string
-	repoConn   string
-	kubeName   string
-	kubeConn   string
-	dockerConn string
-	orgSecrets string
+	name         string
+	proj         string
+	org          string
+	repoName     string
+	repoConn     string
+	kubeName     string
+	kubeConn     string
+	dockerConn   string
+	orgSecrets   string
+	secretConn   string
+	secretScheme string
+	branch       string
+	event        string
 
 	downgrade   bool
 	beforeAfter bool
@@ -47,7 +52,7 @@ type Drone struct {
 func (*Drone) Name() string     { return "drone" }
 func (*Drone) Synopsis() string { return "converts a drone pipeline" }
 func (*Drone) Usage() string {
-	return `drone [-downgrade] <path to .drone.yml>
+	return `drone [-downgrade] <path to .drone.yml|.drone.jsonnet|.drone.star>
 `
 }
 
@@ -64,6 +69,10 @@ func (c *Drone) SetFlags(f *flag.FlagSet) {
 	f.StringVar(&c.kubeName, "kube-namespace", "", "kubernets namespace")
 	f.StringVar(&c.dockerConn, "docker-connector", "", "dockerhub connector")
 	f.StringVar(&c.orgSecrets, "org-secrets", "", "organization secrets, comma separated")
+	f.StringVar(&c.secretConn, "secret-manager", "", "secret manager connector used to resolve kind: secret documents")
+	f.StringVar(&c.secretScheme, "secret-scheme", "hashicorpvault", "secret reference scheme of the secret manager")
+	f.StringVar(&c.branch, "branch", "main", "build branch used to evaluate jsonnet and starlark files")
+	f.StringVar(&c.event, "event", "push", "build event used to evaluate jsonnet and starlark files")
 }
 
 func (c *Drone) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
@@ -87,6 +96,16 @@ func (c *Drone) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) su
 		return subcommands.ExitFailure
 	}
 
+	// the build context is used to evaluate jsonnet
+	// and starlark configuration files.
+	build := render.Build{
+		Branch: c.branch,
+		Event:  c.event,
+	}
+	repo := render.Repo{
+		Slug: c.repoName,
+	}
+
 	// convert the pipeline yaml from the drone
 	// convert the input yaml
 	var after []byte
@@ -99,8 +118,10 @@ func (c *Drone) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) su
 			drone.WithKubernetes(c.kubeName, c.kubeConn),
 			drone.WithDockerhub(c.dockerConn),
 			drone.WithOrgSecrets(orgSecrets...),
+			drone.WithBuild(build),
+			drone.WithRepo(repo),
 		)
-		after, err = converter.ConvertOld(bytes.NewReader(before))
+		after, err = converter.ConvertFileOld(path)
 		if err != nil {
 			log.Println("Error using OldConverter:", err)
 			return subcommands.ExitFailure
@@ -111,8 +132,12 @@ func (c *Drone) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) su
 			drone.WithKubernetes(c.kubeName, c.kubeConn),
 			drone.WithDockerhub(c.dockerConn),
 			drone.WithOrgSecrets(orgSecrets...),
+			drone.WithSecretManager(c.secretConn),
+			drone.WithSecretScheme(c.secretScheme),
+			drone.WithBuild(build),
+			drone.WithRepo(repo),
 		)
-		after, err = converter.ConvertBytes(before)
+		after, err = converter.ConvertFile(path)
 		if err != nil {
 			log.Println(err)
 			return subcommands.ExitFailure
@@ -15,11 +15,16 @@
 package command
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"flag"
 	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/drone/go-convert/convert/harness/downgrader"
 	"github.com/drone/go-convert/convert/jenkins"
@@ -30,6 +35,11 @@ import (
 type Jenkins struct {
 	token      string
 	attempts   int
+	llm        string
+	llmURL     string
+	llmModel   string
+	llmTemp    float64
+	llmBackoff time.Duration
 	name       string
 	proj       string
 	org        string
@@ -39,26 +49,37 @@ type Jenkins struct {
 	kubeConn   string
 	dockerConn string
 	format     string
+	library    string
+	libraryTpl string
 
 	downgrade   bool
 	beforeAfter bool
 	debug       bool
+	report      bool
 }
 
 func (*Jenkins) Name() string     { return "jenkins" }
 func (*Jenkins) Synopsis() string { return "converts a jenkins pipeline" }
 func (*Jenkins) Usage() string {
-	return `jenkins [-token] [-downgrade] [Jenkinsfile]
+	return `jenkins [-token] [-llm] [-downgrade] [-report] [-library dir] [-library-templates dir] [Jenkinsfile]
 `
 }
 
 func (c *Jenkins) SetFlags(f *flag.FlagSet) {
 	f.StringVar(&c.token, "token", "", "chat gpt token")
 	f.IntVar(&c.attempts, "attempts", 1, "chat gtp generation attempts")
+	f.StringVar(&c.llm, "llm", "", "llm provider (openai, anthropic, ollama)")
+	f.StringVar(&c.llmURL, "llm-url", "", "llm api base url")
+	f.StringVar(&c.llmModel, "llm-model", "", "llm model name")
+	f.Float64Var(&c.llmTemp, "llm-temperature", 0, "llm sampling temperature")
+	f.DurationVar(&c.llmBackoff, "llm-backoff", time.Second, "pause before retrying a failed llm request")
 	f.BoolVar(&c.downgrade, "downgrade", false, "downgrade to the legacy yaml format")
 	f.BoolVar(&c.beforeAfter, "before-after", false, "print the befor and after")
 	f.StringVar(&c.format, "format", "github", "configure the intermediate yaml format")
 	f.BoolVar(&c.debug, "debug", false, "enable message debugging")
+	f.BoolVar(&c.report, "report", false, "print the conversion report to stderr")
+	f.StringVar(&c.library, "library", "", "shared library checkout, comma separated")
+	f.StringVar(&c.libraryTpl, "library-templates", "", "convert the shared library steps to templates in the directory")
 
 	f.StringVar(&c.org, "org", "default", "harness organization")
 	f.StringVar(&c.proj, "project", "default", "harness project")
@@ -95,21 +116,74 @@ func (c *Jenkins) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{})
 		jenkins.WithDockerhub(c.dockerConn),
 		jenkins.WithKubernetes(c.kubeName, c.kubeConn),
 		jenkins.WithToken(c.token),
+		jenkins.WithAttempts(c.attempts),
+		jenkins.WithBackoff(c.llmBackoff),
 		jenkins.WithFormatString(c.format),
 	}
 
+	// convert the jenkinsfile using the llm provider
+	// instead of the offline converter.
+	if c.llm != "" {
+		client, err := jenkins.NewLLMClient(c.llm, jenkins.LLMConfig{
+			BaseURL:     c.llmURL,
+			Token:       c.token,
+			Model:       c.llmModel,
+			Temperature: c.llmTemp,
+		})
+		if err != nil {
+			log.Println(err)
+			return subcommands.ExitFailure
+		}
+		opts = append(opts, jenkins.WithLLM(client))
+	}
+
 	if c.debug {
 		opts = append(opts, jenkins.WithDebug())
 	}
 
+	// inline the shared library steps, or reference the
+	// shared library steps as step templates.
+	if c.library != "" {
+		for _, library := range strings.Split(c.library, ",") {
+			opts = append(opts, jenkins.WithLibrary(library))
+		}
+	}
+	if c.libraryTpl != "" {
+		opts = append(opts, jenkins.WithLibraryTemplates())
+	}
+
 	// convert the pipeline yaml from the jenkins
 	// format to the harness yaml format.
-	after, err := jenkins.New(opts...).ConvertBytes(before)
+	var after []byte
+	var report *jenkins.Report
+	converter := jenkins.New(opts...)
+	if c.token == "" && c.llm == "" {
+		after, report, err = converter.ConvertWithReport(bytes.NewBuffer(before))
+	} else {
+		after, err = converter.ConvertBytes(before)
+	}
 	if err != nil {
 		log.Println(err)
 		return subcommands.ExitFailure
 	}
 
+	// write the shared library step templates to the
+	// templates directory.
+	if c.libraryTpl != "" {
+		if err := writeTemplates(converter, c.libraryTpl); err != nil {
+			log.Println(err)
+			return subcommands.ExitFailure
+		}
+	}
+
+	// print the report of jenkinsfile constructs that
+	// could not be converted.
+	if c.report && report != nil {
+		out, _ := json.MarshalIndent(report, "", "  ")
+		os.Stderr.Write(out)
+		os.Stderr.WriteString("\n")
+	}
+
 	// downgrade from the v1 harness yaml format
 	// to the v0 harness yaml format.
 	if c.downgrade {
@@ -139,3 +213,22 @@ func (c *Jenkins) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{})
 
 	return subcommands.ExitSuccess
 }
+
+// writeTemplates converts the shared library steps to
+// step templates, and writes the templates to the
+// directory.
+func writeTemplates(converter *jenkins.Converter, dir string) error {
+	templates, _, err := converter.ConvertLibrary()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for name, out := range templates {
+		if err := os.WriteFile(filepath.Join(dir, name+".yaml"), out, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -15,9 +15,12 @@
 package command
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"os"
@@ -27,6 +30,7 @@ import (
 	"github.com/drone/go-convert/convert/harness"
 
 	"github.com/drone/go-convert/convert/harness/downgrader"
+	"github.com/drone/go-convert/convert/jenkins"
 	"github.com/drone/go-convert/convert/jenkinsjson"
 
 	"github.com/google/subcommands"
@@ -54,12 +58,19 @@ type JenkinsJson struct {
 	beforeAfter     bool
 	outputDir       string
 	disableConversionForSteps    string
+	merge           bool
+	configFile      string
+	strict          bool
+	report          bool
+	library         string
+	libraryTpl      string
 }
 
 func (*JenkinsJson) Name() string     { return "jenkinsjson" }
 func (*JenkinsJson) Synopsis() string { return "converts a jenkinsjson pipeline" }
 func (*JenkinsJson) Usage() string {
-	return `jenkinsjson [-downgrade] [--intelligence] [--no-random-id] [-infrastructure cloud|kubernetes|local] [-os linux|mac|windows] [-arch amd64|arm64] [jenkinsjson.json]
+	return `jenkinsjson [-downgrade] [--intelligence] [--no-random-id] [-infrastructure cloud|kubernetes|local] [-os linux|mac|windows] [-arch amd64|arm64] [-config mapping.yaml] [-library dir] [-library-templates dir] [-strict] [-report] [jenkinsjson.json]
+jenkinsjson -merge [run1.json] [run2.json] ...
 `
 }
 
@@ -68,6 +79,9 @@ func (c *JenkinsJson) SetFlags(f *flag.FlagSet) {
 	f.BoolVar(&c.useIntelligence, "intelligence", false, "Use Harness intelligence features")
 	f.BoolVar(&c.noRandomId, "no-random-id", false, "Generate random ID for pipeline")
 	f.BoolVar(&c.beforeAfter, "before-after", false, "print the befor and after")
+	f.BoolVar(&c.merge, "merge", false, "merge the traces of multiple runs of the same pipeline")
+	f.BoolVar(&c.strict, "strict", false, "fail if the pipeline includes unsupported steps")
+	f.BoolVar(&c.report, "report", false, "print the conversion report to stderr")
 	f.StringVar(&c.outputDir, "output-dir", "", "directory where the output should be saved")
 	f.StringVar(&c.	disableConversionForSteps, "disable-conversion-for-steps", "", "comma-separated list of step types to disable conversion for")
 
@@ -80,6 +94,9 @@ func (c *JenkinsJson) SetFlags(f *flag.FlagSet) {
 	f.StringVar(&c.kubeName, "kube-namespace", "", "kubernets namespace")
 	f.StringVar(&c.dockerConn, "docker-connector", "", "dockerhub connector")
 	f.StringVar(&c.defaultImage, "default-image", "alpine", "default image for run step")
+	f.StringVar(&c.configFile, "config", "", "step mapping configuration file")
+	f.StringVar(&c.library, "library", "", "shared library checkout, comma separated")
+	f.StringVar(&c.libraryTpl, "library-templates", "", "convert the shared library steps to templates in the directory")
 
 	// Infrastructure configuration flags
 	f.StringVar(&c.infrastructure, "infrastructure", "cloud", "infrastructure type (cloud, kubernetes, local)")
@@ -88,7 +105,9 @@ func (c *JenkinsJson) SetFlags(f *flag.FlagSet) {
 }
 
 func (c *JenkinsJson) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
-	if f.NArg() >= 1 {
+	if f.NArg() >= 1 && c.merge {
+		return c.processTraces(f.Args())
+	} else if f.NArg() >= 1 {
 		return processArgs(f, c)
 	} else {
 		log.Println("No file(s) specified")
@@ -253,63 +272,184 @@ func (c *JenkinsJson) processFile(filePath string, file *os.File) subcommands.Ex
 		return subcommands.ExitFailure
 	}
 
-	// create converter with options
-	options := []jenkinsjson.Option{}
-	options = append(options, jenkinsjson.WithUseIntelligence(c.useIntelligence))
+	// convert the pipeline yaml from the jenkinsjson format to the harness yaml format
+	converter := jenkinsjson.New(c.options()...)
+	after, report, err := converter.ConvertWithReport(bytes.NewReader(before))
+	if err != nil {
+		log.Println(err)
+		return subcommands.ExitFailure
+	}
 
-	// add ignored steps option
-	if c.disableConversionForSteps != "" {
-		options = append(options, jenkinsjson.WithDisableConversionForSteps(c.disableConversionForSteps))
+	// write the shared library step templates to the
+	// templates directory.
+	if err := c.writeLibraryTemplates(); err != nil {
+		log.Println(err)
+		return subcommands.ExitFailure
 	}
 
-	// add infrastructure options if specified
-	if c.infrastructure != "" {
-		options = append(options, jenkinsjson.WithInfrastructure(c.infrastructure))
+	// print the report of the converted, approximated,
+	// skipped and failed steps.
+	c.printReport(report)
+
+	// downgrade from the v1 harness yaml format to the v0 harness yaml format
+	if c.downgrade {
+		after, err = c.downgradeYaml(after)
+		if err != nil {
+			log.Println(err)
+			return subcommands.ExitFailure
+		}
 	}
-	if c.os != "" {
-		options = append(options, jenkinsjson.WithOS(c.os))
+
+	// Write the YAML
+	file.WriteString("\n---\n")
+	if c.beforeAfter {
+		file.Write(before)
+		file.WriteString("\n---\n")
 	}
-	if c.arch != "" {
-		options = append(options, jenkinsjson.WithArch(c.arch))
+
+	file.Write(after)
+
+	return subcommands.ExitSuccess
+}
+
+// processTraces merges the traces of multiple runs of the
+// same pipeline and converts them to a single pipeline.
+func (c *JenkinsJson) processTraces(paths []string) subcommands.ExitStatus {
+	var befores [][]byte
+	var traces []io.Reader
+	for _, path := range paths {
+		before, err := ioutil.ReadFile(path)
+		if err != nil {
+			log.Println(err)
+			return subcommands.ExitFailure
+		}
+		befores = append(befores, before)
+		traces = append(traces, bytes.NewReader(before))
 	}
 
-	// convert the pipeline yaml from the jenkinsjson format to the harness yaml format
-	converter := jenkinsjson.New(options...)
-	after, err := converter.ConvertBytes(before)
+	converter := jenkinsjson.New(c.options()...)
+	after, report, err := converter.ConvertTracesWithReport(traces...)
 	if err != nil {
 		log.Println(err)
 		return subcommands.ExitFailure
 	}
 
+	// write the shared library step templates to the
+	// templates directory.
+	if err := c.writeLibraryTemplates(); err != nil {
+		log.Println(err)
+		return subcommands.ExitFailure
+	}
+
+	// print the report of the converted, approximated,
+	// skipped and failed steps of the merged trace.
+	c.printReport(report)
+
 	// downgrade from the v1 harness yaml format to the v0 harness yaml format
 	if c.downgrade {
-		// downgrade to the v0 yaml
-		d := downgrader.New(
-			downgrader.WithCodebase(c.repoName, c.repoConn),
-			downgrader.WithDockerhub(c.dockerConn),
-			downgrader.WithKubernetes(c.kubeName, c.kubeConn),
-			downgrader.WithName(c.name),
-			downgrader.WithOrganization(c.org),
-			downgrader.WithProject(c.proj),
-			downgrader.WithDefaultImage(c.defaultImage),
-			downgrader.WithIntelligence(c.useIntelligence),
-			downgrader.WithRandomId(!c.noRandomId),
-		)
-		after, err = d.Downgrade(after)
+		after, err = c.downgradeYaml(after)
 		if err != nil {
 			log.Println(err)
 			return subcommands.ExitFailure
 		}
 	}
 
-	// Write the YAML
+	// the merged pipeline is named after the first trace.
+	file := getOutputFile(c, paths[0])
 	file.WriteString("\n---\n")
 	if c.beforeAfter {
-		file.Write(before)
-		file.WriteString("\n---\n")
+		for _, before := range befores {
+			file.Write(before)
+			file.WriteString("\n---\n")
+		}
 	}
-
 	file.Write(after)
-
 	return subcommands.ExitSuccess
 }
+
+// printReport prints the conversion report to stderr, if
+// the report is enabled.
+func (c *JenkinsJson) printReport(report *jenkinsjson.Report) {
+	if c.report && report != nil {
+		out, _ := json.MarshalIndent(report, "", "  ")
+		os.Stderr.Write(out)
+		os.Stderr.WriteString("\n")
+	}
+}
+
+// options returns the converter options.
+func (c *JenkinsJson) options() []jenkinsjson.Option {
+	options := []jenkinsjson.Option{}
+	options = append(options, jenkinsjson.WithUseIntelligence(c.useIntelligence))
+
+	// add strict option
+	if c.strict {
+		options = append(options, jenkinsjson.WithStrict(true))
+	}
+
+	// add step mapping option
+	if c.configFile != "" {
+		options = append(options, jenkinsjson.WithConfigFile(c.configFile))
+	}
+
+	// inline the shared library steps, or reference the
+	// shared library steps as step templates.
+	if c.library != "" {
+		for _, library := range strings.Split(c.library, ",") {
+			options = append(options, jenkinsjson.WithLibrary(library))
+		}
+	}
+	if c.libraryTpl != "" {
+		options = append(options, jenkinsjson.WithLibraryTemplates())
+	}
+
+	// add ignored steps option
+	if c.disableConversionForSteps != "" {
+		options = append(options, jenkinsjson.WithDisableConversionForSteps(c.disableConversionForSteps))
+	}
+
+	// add infrastructure options if specified
+	if c.infrastructure != "" {
+		options = append(options, jenkinsjson.WithInfrastructure(c.infrastructure))
+	}
+	if c.os != "" {
+		options = append(options, jenkinsjson.WithOS(c.os))
+	}
+	if c.arch != "" {
+		options = append(options, jenkinsjson.WithArch(c.arch))
+	}
+	return options
+}
+
+// writeLibraryTemplates converts the shared library steps
+// to step templates in the templates directory, if
+// configured.
+func (c *JenkinsJson) writeLibraryTemplates() error {
+	if c.libraryTpl == "" {
+		return nil
+	}
+	var opts []jenkins.Option
+	if c.library != "" {
+		for _, library := range strings.Split(c.library, ",") {
+			opts = append(opts, jenkins.WithLibrary(library))
+		}
+	}
+	return writeTemplates(jenkins.New(opts...), c.libraryTpl)
+}
+
+// downgradeYaml downgrades the v1 harness yaml to the v0
+// harness yaml.
+func (c *JenkinsJson) downgradeYaml(after []byte) ([]byte, error) {
+	d := downgrader.New(
+		downgrader.WithCodebase(c.repoName, c.repoConn),
+		downgrader.WithDockerhub(c.dockerConn),
+		downgrader.WithKubernetes(c.kubeName, c.kubeConn),
+		downgrader.WithName(c.name),
+		downgrader.WithOrganization(c.org),
+		downgrader.WithProject(c.proj),
+		downgrader.WithDefaultImage(c.defaultImage),
+		downgrader.WithIntelligence(c.useIntelligence),
+		downgrader.WithRandomId(!c.noRandomId),
+	)
+	return d.Downgrade(after)
+}
@@ -17,12 +17,15 @@ package command
 import (
 	"context"
 	"flag"
+	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/drone/go-convert/convert/harness/downgrader"
 	"github.com/drone/go-convert/convert/jenkinsxml"
+	"github.com/drone/go-convert/internal/slug"
 
 	"github.com/google/subcommands"
 )
@@ -36,21 +39,30 @@ type JenkinsXml struct {
 	kubeName   string
 	kubeConn   string
 	dockerConn string
+	library    string
 
 	downgrade   bool
 	beforeAfter bool
+	trigger     bool
+	bulk        bool
+	output      string
 }
 
 func (*JenkinsXml) Name() string     { return "jenkinsxml" }
 func (*JenkinsXml) Synopsis() string { return "converts a jenkins job xml file" }
 func (*JenkinsXml) Usage() string {
-	return `jenkinsxml [-downgrade] [job.xml]
+	return `jenkinsxml [-downgrade] [-trigger] [-library dir] [job.xml]
+jenkinsxml -bulk [-output dir] [-library dir] [jobs]
 `
 }
 
 func (c *JenkinsXml) SetFlags(f *flag.FlagSet) {
 	f.BoolVar(&c.downgrade, "downgrade", false, "downgrade to the legacy yaml format")
 	f.BoolVar(&c.beforeAfter, "before-after", false, "print the before and after")
+	f.BoolVar(&c.trigger, "trigger", false, "convert the job build triggers")
+	f.BoolVar(&c.bulk, "bulk", false, "convert every job in the jenkins jobs directory")
+	f.StringVar(&c.output, "output", "harness", "output directory for the bulk conversion")
+	f.StringVar(&c.library, "library", "", "shared library checkout, comma separated")
 
 	f.StringVar(&c.org, "org", "default", "harness organization")
 	f.StringVar(&c.proj, "project", "default", "harness project")
@@ -65,6 +77,10 @@ func (c *JenkinsXml) SetFlags(f *flag.FlagSet) {
 func (c *JenkinsXml) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
 	path := f.Arg(0)
 
+	if c.bulk {
+		return c.executeBulk(path)
+	}
+
 	// if the user does not specify the path as
 	// a command line arg, assume the default path.
 	if path == "" {
@@ -80,19 +96,32 @@ func (c *JenkinsXml) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{
 
 	// convert the pipeline yaml from the gitlab
 	// format to the harness yaml format.
-	converter := jenkinsxml.New(
+	converter := jenkinsxml.New(append(c.libraryOptions(),
 		jenkinsxml.WithDockerhub(c.dockerConn),
 		jenkinsxml.WithKubernetes(c.kubeName, c.kubeConn),
-	)
-	after, err := converter.ConvertBytes(before)
+		jenkinsxml.WithConnector(c.repoConn),
+		jenkinsxml.WithOrganization(c.org),
+		jenkinsxml.WithProject(c.proj),
+		jenkinsxml.WithPipeline(slug.Create(c.name)),
+	)...)
+
+	var after []byte
+	if c.trigger {
+		// convert the job build triggers to the harness
+		// trigger yaml format.
+		after, err = converter.ConvertTriggerBytes(before)
+	} else {
+		after, err = converter.ConvertBytes(before)
+	}
 	if err != nil {
 		log.Println(err)
 		return subcommands.ExitFailure
 	}
 
 	// downgrade from the v1 harness yaml format
-	// to the v0 harness yaml format.
-	if c.downgrade {
+	// to the v0 harness yaml format. triggers are
+	// already in the v0 harness yaml format.
+	if c.downgrade && !c.trigger {
 		// downgrade to the v0 yaml
 		d := downgrader.New(
 			downgrader.WithCodebase(c.repoName, c.repoConn),
@@ -119,3 +148,50 @@ func (c *JenkinsXml) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{
 
 	return subcommands.ExitSuccess
 }
+
+// executeBulk converts every job in the jenkins jobs
+// directory and writes the pipelines, templates and
+// summary report to the output directory.
+func (c *JenkinsXml) executeBulk(path string) subcommands.ExitStatus {
+	// if the user does not specify the path as
+	// a command line arg, assume the default path.
+	if path == "" {
+		path = "jobs"
+	}
+
+	converter := jenkinsxml.New(append(c.libraryOptions(),
+		jenkinsxml.WithDockerhub(c.dockerConn),
+		jenkinsxml.WithKubernetes(c.kubeName, c.kubeConn),
+		jenkinsxml.WithConnector(c.repoConn),
+	)...)
+	summary, err := converter.ConvertDir(path, c.output)
+	if err != nil {
+		log.Println(err)
+		return subcommands.ExitFailure
+	}
+
+	for _, job := range summary.Jobs {
+		fmt.Printf("%s %s\n", strings.ToUpper(job.Status), job.FilePath)
+	}
+	fmt.Printf("Converted %d of %d job(s), skipped %d, failed %d, extracted %d template(s) into %s\n",
+		summary.Counts.Converted,
+		summary.Counts.Jobs,
+		summary.Counts.Skipped,
+		summary.Counts.Failed,
+		summary.Counts.Templates,
+		c.output,
+	)
+	return subcommands.ExitSuccess
+}
+
+// libraryOptions returns the options to inline the
+// shared library steps.
+func (c *JenkinsXml) libraryOptions() []jenkinsxml.Option {
+	var opts []jenkinsxml.Option
+	if c.library != "" {
+		for _, library := range strings.Split(c.library, ",") {
+			opts = append(opts, jenkinsxml.WithLibrary(library))
+		}
+	}
+	return opts
+}
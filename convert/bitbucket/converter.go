@@ -132,7 +132,6 @@ func (d *Converter) convert() ([]byte, error) {
 
 	for _, steps := range d.config.Pipelines.Default {
 		if steps.Stage != nil {
-			// TODO support for fast-fail
 			d.stage = steps.Stage // push the stage to the state
 			stage := d.convertStage()
 			pipeline.Stages = append(pipeline.Stages, stage)
@@ -159,7 +158,12 @@ func (d *Converter) convertStage() *harness.Stage {
 		// TODO Delegate
 		// TODO Platform
 		// TODO Runtime
-		// TODO Envs
+	}
+
+	// if the stage defines a deployment environment,
+	// expose the environment to the stage steps.
+	if envs := convertDeployment(d.stage.Deployment); len(envs) != 0 {
+		spec.Envs = envs
 	}
 
 	// find the step with the largest size and use that
@@ -190,8 +194,14 @@ func (d *Converter) convertStage() *harness.Stage {
 		Name: "build",
 		Type: "ci",
 		Spec: spec,
-		// TODO When
-		// TODO Failure
+		When: convertCondition(d.stage.Condition),
+	}
+
+	// if the stage, or any parallel group or step in the
+	// stage, is configured to fail fast, abort the stage
+	// on failure.
+	if extractFailFast(d.stage) {
+		stage.Failure = convertFailFast()
 	}
 
 	// find the unique selectors and append
@@ -217,16 +227,20 @@ func (d *Converter) convertStage() *harness.Stage {
 	// default services
 	// TODO
 
+	// TODO manual stage triggers are not supported. The
+	// v1 spec has no approval step to pause execution.
+
 	for _, steps := range d.stage.Steps {
 		if steps.Parallel != nil {
-			// TODO parallel steps
-			// TODO fast fail
 			d.steps = steps // push the parallel step to the state
 			step := d.convertParallel()
 			spec.Steps = append(spec.Steps, step)
 		}
 		if steps.Step != nil {
 			d.step = steps.Step // push the step to the state
+
+			// TODO manual step triggers are not supported.
+
 			step := d.convertStep()
 			spec.Steps = append(spec.Steps, step)
 		}
@@ -234,9 +248,14 @@ func (d *Converter) convertStage() *harness.Stage {
 
 	// if the stage has a single step, and that step is a
 	// group step, we can eliminate the un-necessary group
-	// and add the steps directly to the stage.
-	if len(spec.Steps) == 1 {
+	// and add the steps directly to the stage. If the group
+	// defines conditional execution, the condition is moved
+	// to the stage, unless the stage defines its own.
+	if len(spec.Steps) == 1 && (spec.Steps[0].When == nil || stage.When == nil) {
 		if group, ok := spec.Steps[0].Spec.(*harness.StepGroup); ok {
+			if when := spec.Steps[0].When; when != nil {
+				stage.When = when
+			}
 			spec.Steps = group.Steps
 		}
 	}
@@ -356,19 +375,23 @@ func (d *Converter) convertStep() *harness.Step {
 		}
 	}
 
-	// and loop through each after script item
+	// and loop through each after script item. The after
+	// script executes regardless of whether or not the
+	// step succeeded.
 	for _, script := range d.step.ScriptAfter {
 		d.script = script
 
 		// if a pipe step
 		if script.Pipe != nil {
 			step := d.convertPipeStep()
+			step.When = convertAfterScript()
 			spec.Steps = append(spec.Steps, step)
 		}
 
 		// else if a script step
 		if script.Pipe == nil {
 			step := d.convertScriptStep()
+			step.When = convertAfterScript()
 			spec.Steps = append(spec.Steps, step)
 		}
 	}
@@ -376,7 +399,11 @@ func (d *Converter) convertStep() *harness.Step {
 	// if there is only a single step, no need to
 	// create a step group.
 	if len(spec.Steps) == 1 {
-		return spec.Steps[0]
+		step := spec.Steps[0]
+		if when := convertCondition(d.step.Condition); when != nil {
+			step.When = when
+		}
+		return step
 	}
 
 	// else create the step group wrapper.
@@ -384,6 +411,7 @@ func (d *Converter) convertStep() *harness.Step {
 		Type: "group",
 		Spec: spec,
 		Name: d.identifiers.Generate(d.step.Name, "group"),
+		When: convertCondition(d.step.Condition),
 	}
 }
 
@@ -393,11 +421,11 @@ func (d *Converter) convertScriptStep() *harness.Step {
 
 	// create the run spec
 	spec := &harness.StepExec{
-		Run: d.script.Text,
+		Run:  d.script.Text,
+		Envs: convertStepEnvs(d.step),
 
 		// TODO configure an optional connector
 		// TODO configure pull policy
-		// TODO configure envs
 		// TODO configure volumes
 		// TODO configure resources
 	}
@@ -441,23 +469,24 @@ func (d *Converter) convertScriptStep() *harness.Step {
 }
 
 // helper function converts a pipe step to a
-// harness plugin step.
+// harness plugin step. Well-known pipes are converted
+// to native harness plugins; all other pipes execute
+// the pipe image.
 func (d *Converter) convertPipeStep() *harness.Step {
 	pipe := d.script.Pipe
 
 	// create the plugin spec
-	spec := &harness.StepPlugin{
-		Image: strings.TrimPrefix(pipe.Image, "docker://"),
+	spec := convertPipe(pipe)
 
-		// TODO configure an optional connector
-		// TODO configure envs
-		// TODO configure volumes
-	}
+	// TODO configure an optional connector
+	// TODO configure volumes
 
-	// append the plugin spec variables
-	spec.With = map[string]interface{}{}
-	for key, val := range pipe.Variables {
-		spec.With[key] = val
+	// append the deployment and oidc environment variables
+	for key, val := range convertStepEnvs(d.step) {
+		if spec.Envs == nil {
+			spec.Envs = map[string]string{}
+		}
+		spec.Envs[key] = val
 	}
 
 	// create the plugin step wrapper
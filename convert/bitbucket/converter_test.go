@@ -79,16 +79,21 @@ func TestConvert(t *testing.T) {
 
 		"testdata/parallel/example1.yaml",
 		"testdata/parallel/example2.yaml",
-		"testdata/parallel/example3.yaml", // TODO fail-fast
-		"testdata/parallel/example4.yaml", // TODO fail-fast
+		"testdata/parallel/example3.yaml",
+		"testdata/parallel/example4.yaml",
+
+		"testdata/pipes/example1.yaml",
+		"testdata/pipes/example2.yaml",
+		"testdata/pipes/example3.yaml",
 
 		"testdata/stages/example1.yaml",
 		"testdata/stages/example2.yaml",
-		"testdata/stages/example3.yaml", // TODO changesets
-		"testdata/stages/example4.yaml", // TODO changesets
-		"testdata/stages/example5.yaml", // TODO deploy, trigger
+		"testdata/stages/example3.yaml",
+		"testdata/stages/example4.yaml",
+		"testdata/stages/example5.yaml",
 		"testdata/stages/example6.yaml",
-		"testdata/stages/example7.yaml", // TODO trigger
+		"testdata/stages/example7.yaml",
+		"testdata/stages/example8.yaml",
 
 		"testdata/steps/example1.yaml",
 		"testdata/steps/example2.yaml",
@@ -98,18 +103,19 @@ func TestConvert(t *testing.T) {
 		"testdata/steps/example6.yaml",
 		"testdata/steps/example7.yaml",
 		"testdata/steps/example8.yaml",
-		"testdata/steps/example9.yaml",  // TODO trigger, deploy
-		"testdata/steps/example10.yaml", // TODO oidc
-		"testdata/steps/example11.yaml", // TODO trigger
+		"testdata/steps/example9.yaml",
+		"testdata/steps/example10.yaml",
+		"testdata/steps/example11.yaml",
 		"testdata/steps/example12.yaml", // TODO artifacts
-		"testdata/steps/example13.yaml", // TODO changeset
-		"testdata/steps/example14.yaml", // TODO changeset
+		"testdata/steps/example13.yaml",
+		"testdata/steps/example14.yaml",
 		"testdata/steps/example15.yaml",
 		"testdata/steps/example16.yaml",
 		"testdata/steps/example17.yaml",
 		"testdata/steps/example18.yaml", // TODO artifacts
 		"testdata/steps/example19.yaml", // TODO artifacts
 		"testdata/steps/example20.yaml",
+		"testdata/steps/example21.yaml",
 	}
 
 	for _, test := range tests {
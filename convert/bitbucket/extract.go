@@ -159,3 +159,20 @@ func extractServices(stage *bitbucket.Stage) []string {
 
 	return unique
 }
+
+// helper function returns true if the stage, or any
+// parallel group or step within the stage, is configured
+// to fail fast.
+func extractFailFast(stage *bitbucket.Stage) bool {
+	for _, step := range stage.Steps {
+		if step.Parallel != nil && step.Parallel.FailFast {
+			return true
+		}
+	}
+	for _, step := range extractSteps(stage) {
+		if step.FailFast {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,242 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitbucket
+
+import (
+	"strings"
+
+	bitbucket "github.com/drone/go-convert/convert/bitbucket/yaml"
+	harness "github.com/drone/spec/dist/go"
+)
+
+//
+// this file contains the registry of well-known bitbucket
+// pipes, and helper functions that convert pipes to native
+// harness plugin steps.
+//
+
+// pipeFunc converts the pipe variables to a harness
+// plugin step spec.
+type pipeFunc func(vars map[string]string) *harness.StepPlugin
+
+// pipes is a registry of well-known bitbucket pipes, keyed
+// by the pipe image name without the tag.
+var pipes = map[string]pipeFunc{
+	"atlassian/aws-s3-deploy":      convertPipeS3Deploy,
+	"atlassian/aws-ecr-push-image": convertPipeECRPush,
+	"atlassian/docker-push":        convertPipeDockerPush,
+	"atlassian/slack-notify":       convertPipeSlack,
+	"sonarsource/sonarcloud-scan":  convertPipeSonarCloud,
+	"atlassian/sonarcloud-scan":    convertPipeSonarCloud,
+	"sonarsource/sonarqube-scan":   convertPipeSonarQube,
+	"atlassian/ssh-run":            convertPipeSSH,
+	"atlassian/scp-deploy":         convertPipeSCP,
+}
+
+// helper function returns the pipe name, without the
+// docker:// prefix or image tag.
+func pipeName(image string) string {
+	name := strings.TrimPrefix(image, "docker://")
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name = name[:i]
+	}
+	return name
+}
+
+// helper function converts a bitbucket pipe to a harness
+// plugin step spec. If the pipe is not a well-known pipe,
+// the pipe image is executed as-is, with the pipe variables
+// passed as environment variables.
+func convertPipe(pipe *bitbucket.Pipe) *harness.StepPlugin {
+	if fn, ok := pipes[pipeName(pipe.Image)]; ok {
+		return fn(pipe.Variables)
+	}
+	var envs map[string]string
+	if len(pipe.Variables) != 0 {
+		envs = map[string]string{}
+		for key, val := range pipe.Variables {
+			envs[key] = val
+		}
+	}
+	return &harness.StepPlugin{
+		Image: strings.TrimPrefix(pipe.Image, "docker://"),
+		Envs:  envs,
+	}
+}
+
+// helper function converts the atlassian/aws-s3-deploy
+// pipe to an s3 upload step.
+func convertPipeS3Deploy(vars map[string]string) *harness.StepPlugin {
+	// the bucket name may include the target path
+	// (e.g. my-bucket/path/to/folder)
+	bucket, target, _ := strings.Cut(vars["S3_BUCKET"], "/")
+	if target == "" {
+		target = "/"
+	}
+	source := vars["LOCAL_PATH"]
+	if source == "" {
+		source = "."
+	}
+	with := map[string]interface{}{
+		"bucket":       bucket,
+		"region":       vars["AWS_DEFAULT_REGION"],
+		"source":       strings.TrimSuffix(source, "/") + "/**/*",
+		"strip_prefix": strings.TrimSuffix(source, "/") + "/",
+		"target":       target,
+	}
+	setIfNotEmpty(with, "access_key", vars["AWS_ACCESS_KEY_ID"])
+	setIfNotEmpty(with, "secret_key", vars["AWS_SECRET_ACCESS_KEY"])
+	setIfNotEmpty(with, "assume_role", vars["AWS_OIDC_ROLE_ARN"])
+	setIfNotEmpty(with, "acl", vars["ACL"])
+	setIfNotEmpty(with, "cache_control", vars["CACHE_CONTROL"])
+	setIfNotEmpty(with, "content_encoding", vars["CONTENT_ENCODING"])
+	if vars["DELETE_FLAG"] == "true" {
+		with["delete"] = true
+	}
+	return &harness.StepPlugin{
+		Image: "plugins/s3",
+		With:  with,
+	}
+}
+
+// helper function converts the atlassian/aws-ecr-push-image
+// pipe to an ecr build and push step.
+func convertPipeECRPush(vars map[string]string) *harness.StepPlugin {
+	with := map[string]interface{}{
+		"repo":   vars["IMAGE_NAME"],
+		"region": vars["AWS_DEFAULT_REGION"],
+		"tags":   convertPipeTags(vars["TAGS"]),
+	}
+	setIfNotEmpty(with, "access_key", vars["AWS_ACCESS_KEY_ID"])
+	setIfNotEmpty(with, "secret_key", vars["AWS_SECRET_ACCESS_KEY"])
+	setIfNotEmpty(with, "assume_role", vars["AWS_OIDC_ROLE_ARN"])
+	return &harness.StepPlugin{
+		Image: "plugins/kaniko-ecr",
+		With:  with,
+	}
+}
+
+// helper function converts the atlassian/docker-push pipe
+// to a docker build and push step.
+func convertPipeDockerPush(vars map[string]string) *harness.StepPlugin {
+	repo := vars["IMAGE"]
+	if repo == "" {
+		repo = vars["IMAGE_NAME"]
+	}
+	with := map[string]interface{}{
+		"repo": repo,
+		"tags": convertPipeTags(vars["TAGS"]),
+	}
+	setIfNotEmpty(with, "registry", vars["REGISTRY"])
+	setIfNotEmpty(with, "username", vars["USERNAME"])
+	setIfNotEmpty(with, "password", vars["PASSWORD"])
+	setIfNotEmpty(with, "dockerfile", vars["DOCKERFILE"])
+	return &harness.StepPlugin{
+		Image: "plugins/kaniko:latest",
+		With:  with,
+	}
+}
+
+// helper function converts the atlassian/slack-notify
+// pipe to a slack plugin step.
+func convertPipeSlack(vars map[string]string) *harness.StepPlugin {
+	message := vars["MESSAGE"]
+	if pretext := vars["PRETEXT"]; pretext != "" {
+		message = pretext + "\n" + message
+	}
+	with := map[string]interface{}{
+		"webhook": vars["WEBHOOK_URL"],
+	}
+	setIfNotEmpty(with, "template", message)
+	return &harness.StepPlugin{
+		Image: "plugins/slack",
+		With:  with,
+	}
+}
+
+// helper function converts the sonarcloud-scan pipe to a
+// sonar plugin step.
+func convertPipeSonarCloud(vars map[string]string) *harness.StepPlugin {
+	with := map[string]interface{}{
+		"sonar_host":  "https://sonarcloud.io",
+		"sonar_token": vars["SONAR_TOKEN"],
+	}
+	setIfNotEmpty(with, "sources", vars["SONAR_SOURCES"])
+	setIfNotEmpty(with, "extra_args", vars["EXTRA_ARGS"])
+	return &harness.StepPlugin{
+		Image: "aosapps/drone-sonar-plugin",
+		With:  with,
+	}
+}
+
+// helper function converts the sonarqube-scan pipe to a
+// sonar plugin step.
+func convertPipeSonarQube(vars map[string]string) *harness.StepPlugin {
+	spec := convertPipeSonarCloud(vars)
+	spec.With["sonar_host"] = vars["SONAR_HOST_URL"]
+	return spec
+}
+
+// helper function converts the atlassian/ssh-run pipe to
+// an ssh plugin step.
+func convertPipeSSH(vars map[string]string) *harness.StepPlugin {
+	with := map[string]interface{}{
+		"host":     vars["SERVER"],
+		"username": vars["SSH_USER"],
+	}
+	setIfNotEmpty(with, "port", vars["PORT"])
+	setIfNotEmpty(with, "key", vars["SSH_KEY"])
+	// TODO the command is the path to a local script
+	// when the mode is script, which must be copied to
+	// the remote server.
+	setIfNotEmpty(with, "script", vars["COMMAND"])
+	return &harness.StepPlugin{
+		Image: "appleboy/drone-ssh",
+		With:  with,
+	}
+}
+
+// helper function converts the atlassian/scp-deploy pipe
+// to an scp plugin step.
+func convertPipeSCP(vars map[string]string) *harness.StepPlugin {
+	with := map[string]interface{}{
+		"host":     vars["SERVER"],
+		"username": vars["USER"],
+		"source":   vars["LOCAL_PATH"],
+		"target":   vars["REMOTE_PATH"],
+	}
+	setIfNotEmpty(with, "key", vars["SSH_KEY"])
+	return &harness.StepPlugin{
+		Image: "appleboy/drone-scp",
+		With:  with,
+	}
+}
+
+// helper function splits the space-separated pipe image
+// tags. If no tags are defined, the latest tag is used.
+func convertPipeTags(tags string) []string {
+	if fields := strings.Fields(tags); len(fields) != 0 {
+		return fields
+	}
+	return []string{"latest"}
+}
+
+// helper function sets the map value if the value is
+// not empty.
+func setIfNotEmpty(m map[string]interface{}, key, value string) {
+	if value != "" {
+		m[key] = value
+	}
+}
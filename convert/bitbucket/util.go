@@ -215,6 +215,88 @@ func convertDefault(config *bitbucket.Config) *harness.Default {
 	return def
 }
 
+// helper function converts a bitbucket changeset condition
+// to a harness when clause that evaluates the changed paths.
+func convertCondition(cond *bitbucket.Condition) *harness.When {
+	if cond == nil || cond.Changesets == nil || len(cond.Changesets.IncludePaths) == 0 {
+		return nil
+	}
+	return &harness.When{
+		Cond: []map[string]*harness.Expr{
+			{
+				"paths": &harness.Expr{
+					In: cond.Changesets.IncludePaths,
+				},
+			},
+		},
+	}
+}
+
+// helper function returns a harness stage failure strategy
+// that aborts the stage when any step fails. This emulates
+// the bitbucket fail-fast behavior.
+func convertFailFast() *harness.FailureList {
+	return &harness.FailureList{
+		Items: []*harness.Failure{
+			{
+				Errors: []string{"all"},
+				Action: &harness.FailureAction{
+					Type: "abort",
+					Spec: &harness.Abort{},
+				},
+			},
+		},
+	}
+}
+
+// helper function returns a harness when clause that
+// always executes the step, regardless of whether or not
+// previous steps succeeded. This emulates the bitbucket
+// after-script behavior.
+func convertAfterScript() *harness.When {
+	return &harness.When{
+		Cond: []map[string]*harness.Expr{
+			{
+				"status": &harness.Expr{
+					In: []string{"success", "failure"},
+				},
+			},
+		},
+	}
+}
+
+// helper function converts a bitbucket deployment
+// environment to harness environment variables. Bitbucket
+// exposes the deployment environment to deployment steps
+// using the BITBUCKET_DEPLOYMENT_ENVIRONMENT variable.
+func convertDeployment(deployment string) map[string]string {
+	if deployment == "" {
+		return nil
+	}
+	return map[string]string{
+		"BITBUCKET_DEPLOYMENT_ENVIRONMENT": deployment,
+	}
+}
+
+// oidcTokenSecret is the harness secret that provides the
+// OpenID Connect token to steps that enable oidc.
+const oidcTokenSecret = "bitbucket_step_oidc_token"
+
+// helper function returns the environment variables that
+// bitbucket exposes to the step. Steps that enable oidc
+// read the token from the BITBUCKET_STEP_OIDC_TOKEN
+// variable, which is sourced from a harness secret.
+func convertStepEnvs(step *bitbucket.Step) map[string]string {
+	envs := convertDeployment(step.Deployment)
+	if step.Oidc {
+		if envs == nil {
+			envs = map[string]string{}
+		}
+		envs["BITBUCKET_STEP_OIDC_TOKEN"] = fmt.Sprintf("<+secrets.getValue(%q)>", oidcTokenSecret)
+	}
+	return envs
+}
+
 // helper function converts an integer of minutes to a time
 // duration string.
 func minuteToDurationString(v int64) string {
@@ -37,6 +37,10 @@ type Converter struct {
 	kubeNamespace string
 	kubeConnector string
 	dockerhubConn string
+	repoConnector string
+	organization  string
+	project       string
+	pipeline      string
 	identifiers   *store.Identifiers
 }
 
@@ -60,6 +64,18 @@ func New(options ...Option) *Converter {
 		d.kubeNamespace = "default"
 	}
 
+	// set the default harness organization, project
+	// and pipeline used by converted triggers.
+	if d.organization == "" {
+		d.organization = "default"
+	}
+	if d.project == "" {
+		d.project = "default"
+	}
+	if d.pipeline == "" {
+		d.pipeline = "default"
+	}
+
 	// set the runtime to kubernetes if the kubernetes
 	// connector is configured.
 	if d.kubeConnector != "" {
@@ -133,6 +149,10 @@ func (d *Converter) convert(src *cloudbuild.Config) ([]byte, error) {
 		pipeline.Options.Timeout = convertTimeout(v)
 	}
 
+	// convert secret manager, inline and legacy kms
+	// secrets to harness secret expressions.
+	secrets := convertSecrets(src)
+
 	spec := &harness.StageCI{
 		Cache: nil, // No Google equivalent
 		Envs:  nil,
@@ -141,13 +161,16 @@ func (d *Converter) convert(src *cloudbuild.Config) ([]byte, error) {
 			Arch: harness.ArchAmd64.String(),
 		},
 		Runtime: d.convertRuntime(src),
-		Steps:   d.convertSteps(src),
+		Steps:   d.convertSteps(src, secrets),
 	}
 
 	// add global environment variables
 	uniqueVols := map[string]struct{}{}
 	if opts := src.Options; opts != nil {
-		spec.Envs = convertEnv(opts.Env)
+		spec.Envs = mergeEnv(
+			convertEnv(opts.Env),
+			convertSecretEnv(opts.Secretenv, secrets),
+		)
 
 		// add global volumes
 		if vols := opts.Volumes; len(vols) > 0 {
@@ -193,10 +216,6 @@ func (d *Converter) convert(src *cloudbuild.Config) ([]byte, error) {
 		})
 	}
 
-	// TODO src.Secrets
-	// TODO src.Availablesecrets
-	// TODO opts.Secretenv
-
 	// append steps to publish artifacts
 	if v := src.Artifacts; v != nil {
 		// TODO
@@ -268,19 +287,37 @@ func (d *Converter) convertRuntime(src *cloudbuild.Config) *harness.Runtime {
 	}
 }
 
-func (d *Converter) convertSteps(src *cloudbuild.Config) []*harness.Step {
+func (d *Converter) convertSteps(src *cloudbuild.Config, secrets map[string]string) []*harness.Step {
 	var steps []*harness.Step
-	for _, step := range src.Steps {
-		// skip git clone steps by default
-		if strings.HasPrefix(step.Name, "gcr.io/cloud-builders/git") {
-			continue
+	for _, group := range groupSteps(src.Steps) {
+		var items []*harness.Step
+		for _, step := range group {
+			items = append(items, d.convertStep(src, step, secrets))
+		}
+		switch len(items) {
+		case 0:
+		case 1:
+			steps = append(steps, items[0])
+		default:
+			steps = append(steps, &harness.Step{
+				Name: d.identifiers.Generate("parallel"),
+				Type: "parallel",
+				Spec: &harness.StepParallel{
+					Steps: items,
+				},
+			})
 		}
-		steps = append(steps, d.convertStep(src, step))
 	}
 	return steps
 }
 
-func (d *Converter) convertStep(src *cloudbuild.Config, srcstep *cloudbuild.Step) *harness.Step {
+func (d *Converter) convertStep(src *cloudbuild.Config, srcstep *cloudbuild.Step, secrets map[string]string) *harness.Step {
+	// combine the step environment variables with the
+	// step secret environment variables.
+	envs := mergeEnv(
+		convertEnv(srcstep.Env),
+		convertSecretEnv(srcstep.Secretenv, secrets),
+	)
 
 	return &harness.Step{
 		Name: d.identifiers.Generate(
@@ -306,17 +343,80 @@ func (d *Converter) convertStep(src *cloudbuild.Config, srcstep *cloudbuild.Step
 			Entrypoint: srcstep.Entrypoint,
 			Args:       srcstep.Args,
 			Run:        srcstep.Script,
-			Envs:       convertEnv(srcstep.Env),
+			Envs:       envs,
 			Resources:  nil, // No Google equivalent
 			Reports:    nil, // No Google equivalent
 			Mount:      createMounts(src, srcstep),
 
 			// TODO support step.dir
-			// TODO support step.secretEnv
 		},
 	}
 }
 
+// helper function groups the steps by their position in
+// the waitFor dependency graph. Steps in the same group have
+// no dependencies on one another and can run in parallel.
+// If no step defines waitFor, the steps run sequentially.
+func groupSteps(src []*cloudbuild.Step) [][]*cloudbuild.Step {
+	var graph bool
+	for _, step := range src {
+		if len(step.Waitfor) != 0 {
+			graph = true
+			break
+		}
+	}
+
+	// compute the depth of each step, where the depth is
+	// the number of steps on the longest path it waits for.
+	// A step without waitFor waits for all previous steps,
+	// and a step that waits for "-" starts immediately.
+	// Skipped steps remain in the graph to resolve step
+	// identifiers, but do not increase the depth.
+	ends := make([]int, len(src))
+	index := map[string]int{}
+	var groups [][]*cloudbuild.Step
+	for i, step := range src {
+		depth := 0
+		switch {
+		case !graph:
+			depth = len(groups)
+		case len(step.Waitfor) == 0:
+			for j := 0; j < i; j++ {
+				if ends[j] > depth {
+					depth = ends[j]
+				}
+			}
+		default:
+			for _, id := range step.Waitfor {
+				// a step can only wait for a previous step,
+				// unknown identifiers are ignored.
+				if j, ok := index[id]; ok && ends[j] > depth {
+					depth = ends[j]
+				}
+			}
+		}
+		if step.ID != "" {
+			index[step.ID] = i
+		}
+		if skipStep(step) {
+			ends[i] = depth
+			continue
+		}
+		ends[i] = depth + 1
+		for len(groups) <= depth {
+			groups = append(groups, nil)
+		}
+		groups[depth] = append(groups[depth], step)
+	}
+	return groups
+}
+
+// helper function returns true if the step should be
+// skipped. Git clone steps are skipped by default.
+func skipStep(step *cloudbuild.Step) bool {
+	return strings.HasPrefix(step.Name, "gcr.io/cloud-builders/git")
+}
+
 func createFailurestrategy(src *cloudbuild.Step) *harness.FailureList {
 	if src.Allowfailure == false && len(src.Allowexitcodes) == 0 {
 		return nil
@@ -403,6 +503,16 @@ func convertEnv(src []string) map[string]string {
 	}
 }
 
+// helper function merges one or more maps of environment
+// variables into a single map. If the merged map is empty,
+// a nil value is returned.
+func mergeEnv(env ...map[string]string) map[string]string {
+	if dst := combineEnv(env...); len(dst) != 0 {
+		return dst
+	}
+	return nil
+}
+
 // helper function combines one or more maps of environment
 // variables into a single map.
 func combineEnv(env ...map[string]string) map[string]string {
@@ -437,6 +547,11 @@ func replaceAll(in *harness.Config, envs map[string]string) (*harness.Config, er
 		b = bytes.ReplaceAll(b, []byte("${"+before+"}"), []byte(after))
 	}
 
+	// unescape the dollar sign. Cloud Build uses $$ to
+	// reference shell variables, such as secrets, that are
+	// not substituted.
+	b = bytes.ReplaceAll(b, []byte("$$"), []byte("$"))
+
 	// unarmarshal the yaml
 	out, err := harness.ParseBytes(b)
 	if err != nil {
@@ -69,3 +69,67 @@ func TestConvert(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertTrigger(t *testing.T) {
+	tests, err := filepath.Glob("testdata/triggers/*.yaml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			// convert the trigger file from cloudbuild to harness
+			converter := New()
+			tmp1, err := converter.ConvertTriggerFile(test)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			// unmarshal the converted yaml file to a map
+			got := map[string]interface{}{}
+			if err := yaml.Unmarshal(tmp1, &got); err != nil {
+				t.Error(err)
+				return
+			}
+
+			// parse the golden yaml file
+			data, err := ioutil.ReadFile(test + ".golden")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			// unmarshal the golden yaml file to a map
+			want := map[string]interface{}{}
+			if err := yaml.Unmarshal(data, &want); err != nil {
+				t.Error(err)
+				return
+			}
+
+			// compare the converted yaml to the golden file
+			if diff := cmp.Diff(got, want); diff != "" {
+				t.Errorf("Unexpected conversion result")
+				t.Log(diff)
+			}
+		})
+	}
+}
+
+func TestGlobToRegexp(t *testing.T) {
+	tests := []struct {
+		glob string
+		want string
+	}{
+		{"src/**", `src/.*`},
+		{"src/*.go", `src/[^/]*\.go`},
+		{"go.mod", `go\.mod`},
+		{"doc?.md", `doc[^/]\.md`},
+	}
+	for _, test := range tests {
+		if got := globToRegexp(test.glob); got != test.want {
+			t.Errorf("Want regexp %q for glob %q, got %q", test.want, test.glob, got)
+		}
+	}
+}
@@ -33,3 +33,35 @@ func WithKubernetes(namespace, connector string) Option {
 		d.kubeConnector = connector
 	}
 }
+
+// WithConnector returns an option to set the git
+// connector used by converted triggers.
+func WithConnector(connector string) Option {
+	return func(d *Converter) {
+		d.repoConnector = connector
+	}
+}
+
+// WithOrganization returns an option to set the harness
+// organization used by converted triggers.
+func WithOrganization(organization string) Option {
+	return func(d *Converter) {
+		d.organization = organization
+	}
+}
+
+// WithProject returns an option to set the harness
+// project used by converted triggers.
+func WithProject(project string) Option {
+	return func(d *Converter) {
+		d.project = project
+	}
+}
+
+// WithPipeline returns an option to set the harness
+// pipeline identifier used by converted triggers.
+func WithPipeline(pipeline string) Option {
+	return func(d *Converter) {
+		d.pipeline = pipeline
+	}
+}
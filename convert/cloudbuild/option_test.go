@@ -20,6 +20,10 @@ func TestOptions(t *testing.T) {
 	p := New(
 		WithDockerhub("account.docker"),
 		WithKubernetes("namespace", "connector.kubernetes"),
+		WithConnector("connector.github"),
+		WithOrganization("organization"),
+		WithProject("project"),
+		WithPipeline("pipeline"),
 	)
 
 	if got, want := p.kubeConnector, "connector.kubernetes"; got != want {
@@ -34,6 +38,18 @@ func TestOptions(t *testing.T) {
 	if got, want := p.dockerhubConn, "account.docker"; got != want {
 		t.Errorf("Want docker connector %q, got %q", want, got)
 	}
+	if got, want := p.repoConnector, "connector.github"; got != want {
+		t.Errorf("Want repository connector %q, got %q", want, got)
+	}
+	if got, want := p.organization, "organization"; got != want {
+		t.Errorf("Want organization %q, got %q", want, got)
+	}
+	if got, want := p.project, "project"; got != want {
+		t.Errorf("Want project %q, got %q", want, got)
+	}
+	if got, want := p.pipeline, "pipeline"; got != want {
+		t.Errorf("Want pipeline %q, got %q", want, got)
+	}
 }
 
 func TestOptions_Defaults(t *testing.T) {
@@ -51,4 +67,13 @@ func TestOptions_Defaults(t *testing.T) {
 	if got, want := p.dockerhubConn, ""; got != want {
 		t.Errorf("Want docker connector %q, got %q", want, got)
 	}
+	if got, want := p.organization, "default"; got != want {
+		t.Errorf("Want organization %q, got %q", want, got)
+	}
+	if got, want := p.project, "default"; got != want {
+		t.Errorf("Want project %q, got %q", want, got)
+	}
+	if got, want := p.pipeline, "default"; got != want {
+		t.Errorf("Want pipeline %q, got %q", want, got)
+	}
 }
@@ -0,0 +1,93 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudbuild
+
+import (
+	"regexp"
+	"strings"
+
+	cloudbuild "github.com/drone/go-convert/convert/cloudbuild/yaml"
+)
+
+// regular expression matches characters that are not
+// permitted in a harness secret identifier.
+var secretIdentifierRE = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// helper function returns a map of secret environment
+// variable names to harness secret expressions, collected
+// from the Secret Manager, inline and legacy KMS secrets.
+func convertSecrets(src *cloudbuild.Config) map[string]string {
+	dst := map[string]string{}
+	for _, secret := range src.Secrets {
+		// legacy kms encrypted secrets. the encrypted
+		// value cannot be migrated, so the secret must be
+		// re-created in harness using the variable name.
+		for name := range secret.SecretEnv {
+			dst[name] = secretExpr(name)
+		}
+	}
+	if v := src.Availablesecrets; v != nil {
+		for _, secret := range v.Inline {
+			for name := range secret.EnvMap {
+				dst[name] = secretExpr(name)
+			}
+		}
+		for _, secret := range v.SecretManager {
+			if secret.Env == "" {
+				continue
+			}
+			dst[secret.Env] = secretExpr(
+				secretName(secret.VersionName),
+			)
+		}
+	}
+	return dst
+}
+
+// helper function returns the environment variables for
+// the named secrets. Secrets that are not declared in the
+// build configuration are ignored.
+func convertSecretEnv(names []string, secrets map[string]string) map[string]string {
+	dst := map[string]string{}
+	for _, name := range names {
+		if expr, ok := secrets[name]; ok {
+			dst[name] = expr
+		}
+	}
+	if len(dst) == 0 {
+		return nil
+	}
+	return dst
+}
+
+// helper function returns the secret name from the Secret
+// Manager version resource name, for example
+// projects/<project>/secrets/<name>/versions/<version>.
+func secretName(version string) string {
+	parts := strings.Split(version, "/")
+	for i := 0; i < len(parts)-1; i++ {
+		if parts[i] == "secrets" {
+			return parts[i+1]
+		}
+	}
+	return version
+}
+
+// helper function returns a harness secret expression
+// for the named secret.
+func secretExpr(name string) string {
+	name = secretIdentifierRE.ReplaceAllString(name, "_")
+	return `<+secrets.getValue("` + name + `")>`
+}
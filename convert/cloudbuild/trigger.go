@@ -0,0 +1,267 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudbuild
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	cloudbuild "github.com/drone/go-convert/convert/cloudbuild/yaml"
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	"github.com/drone/go-convert/internal/slug"
+
+	"github.com/ghodss/yaml"
+)
+
+// regular expression matches Cloud Build payload bindings
+// in trigger substitutions, for example $(body.ref).
+var payloadBindingRE = regexp.MustCompile(`\$\(body\.([^)]+)\)`)
+
+// default pull request actions. Cloud Build runs pull
+// request triggers when a pull request is opened or
+// updated.
+var defaultPullRequestActions = []string{
+	"Open",
+	"Reopen",
+	"Synchronize",
+}
+
+// ConvertTrigger converts a Cloud Build trigger to a
+// Harness trigger.
+func (d *Converter) ConvertTrigger(r io.Reader) ([]byte, error) {
+	src, err := cloudbuild.ParseTrigger(r)
+	if err != nil {
+		return nil, err
+	}
+	return d.convertTrigger(src)
+}
+
+// ConvertTriggerBytes converts a Cloud Build trigger to a
+// Harness trigger.
+func (d *Converter) ConvertTriggerBytes(b []byte) ([]byte, error) {
+	return d.ConvertTrigger(
+		bytes.NewBuffer(b),
+	)
+}
+
+// ConvertTriggerFile converts a Cloud Build trigger to a
+// Harness trigger.
+func (d *Converter) ConvertTriggerFile(p string) ([]byte, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return d.ConvertTrigger(f)
+}
+
+// convertTrigger converts a Cloud Build trigger to a
+// Harness trigger.
+func (d *Converter) convertTrigger(src *cloudbuild.Trigger) ([]byte, error) {
+	dst := &v0.Trigger{
+		Name:               src.Name,
+		ID:                 slug.Create(src.Name),
+		Enabled:            !src.Disabled,
+		Description:        src.Description,
+		Org:                d.organization,
+		Project:            d.project,
+		PipelineIdentifier: d.pipeline,
+		Source: &v0.TriggerSource{
+			Type: "Webhook",
+			Spec: d.convertTriggerSource(src),
+		},
+	}
+
+	// TODO src.Build
+	// TODO src.Filename
+	// the build configuration is converted separately and
+	// the trigger executes the configured harness pipeline.
+
+	if len(src.Tags) != 0 {
+		dst.Tags = map[string]string{}
+		for _, tag := range src.Tags {
+			dst.Tags[tag] = ""
+		}
+	}
+
+	// convert substitutions to pipeline variables
+	if len(src.Substitutions) != 0 {
+		input, err := d.convertTriggerInput(src.Substitutions)
+		if err != nil {
+			return nil, err
+		}
+		dst.InputYaml = input
+	}
+
+	return yaml.Marshal(
+		struct {
+			Trigger *v0.Trigger `json:"trigger"`
+		}{dst},
+	)
+}
+
+// helper function converts the trigger event source to
+// a harness webhook source.
+func (d *Converter) convertTriggerSource(src *cloudbuild.Trigger) *v0.TriggerSourceSpec {
+	github := src.Github
+	if github == nil {
+		// TODO src.Pubsubconfig
+		// TODO src.Webhookconfig
+		// TODO src.Triggertemplate
+		// pubsub, webhook and cloud source repository
+		// triggers are converted to custom webhooks.
+		return &v0.TriggerSourceSpec{
+			Type: "Custom",
+			Spec: &v0.TriggerCustom{
+				PayloadConditions: convertChangedFiles(src),
+				HeaderConditions:  []*v0.TriggerCondition{},
+			},
+		}
+	}
+
+	spec := &v0.TriggerGit{
+		ConnectorRef:      d.repoConnector,
+		RepoName:          github.Name,
+		PayloadConditions: convertChangedFiles(src),
+		HeaderConditions:  []*v0.TriggerCondition{},
+		Actions:           []string{},
+	}
+	event := &v0.TriggerWebhook{
+		Type: "Push",
+		Spec: spec,
+	}
+
+	switch {
+	case github.Pullrequest != nil:
+		event.Type = "PullRequest"
+		spec.Actions = defaultPullRequestActions
+
+		// TODO github.Pullrequest.Commentcontrol
+		// TODO github.Pullrequest.Invertregex
+		if v := github.Pullrequest; v.Branch != "" && !v.Invertregex {
+			spec.PayloadConditions = append(spec.PayloadConditions, &v0.TriggerCondition{
+				Key:      "targetBranch",
+				Operator: "Regex",
+				Value:    v.Branch,
+			})
+		}
+	case github.Push != nil:
+		switch v := github.Push; {
+		case v.Invertregex:
+			// TODO github.Push.Invertregex
+		case v.Tag != "":
+			spec.PayloadConditions = append(spec.PayloadConditions, &v0.TriggerCondition{
+				Key:      "<+trigger.payload.ref>",
+				Operator: "Regex",
+				Value:    convertTagRegexp(v.Tag),
+			})
+		case v.Branch != "":
+			spec.PayloadConditions = append(spec.PayloadConditions, &v0.TriggerCondition{
+				Key:      "targetBranch",
+				Operator: "Regex",
+				Value:    v.Branch,
+			})
+		}
+	}
+
+	return &v0.TriggerSourceSpec{
+		Type: "Github",
+		Spec: event,
+	}
+}
+
+// helper function converts the trigger substitutions to
+// a pipeline input yaml.
+func (d *Converter) convertTriggerInput(src map[string]string) (string, error) {
+	var keys []string
+	for key := range src {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var vars []*v0.Variable
+	for _, key := range keys {
+		vars = append(vars, &v0.Variable{
+			Name:  key,
+			Type:  "String",
+			Value: payloadBindingRE.ReplaceAllString(src[key], "<+trigger.payload.$1>"),
+		})
+	}
+
+	out, err := yaml.Marshal(map[string]interface{}{
+		"pipeline": map[string]interface{}{
+			"identifier": d.pipeline,
+			"variables":  vars,
+		},
+	})
+	return string(out), err
+}
+
+// helper function converts the included files to a
+// changed files payload condition.
+func convertChangedFiles(src *cloudbuild.Trigger) []*v0.TriggerCondition {
+	// TODO src.Ignoredfiles
+	if len(src.Includedfiles) == 0 {
+		return []*v0.TriggerCondition{}
+	}
+	var patterns []string
+	for _, glob := range src.Includedfiles {
+		patterns = append(patterns, globToRegexp(glob))
+	}
+	return []*v0.TriggerCondition{
+		{
+			Key:      "changedFiles",
+			Operator: "Regex",
+			Value:    "^(" + strings.Join(patterns, "|") + ")$",
+		},
+	}
+}
+
+// helper function converts a tag regular expression to
+// a regular expression that matches the git reference.
+func convertTagRegexp(src string) string {
+	if strings.HasPrefix(src, "^") {
+		return "^refs/tags/" + strings.TrimPrefix(src, "^")
+	}
+	return "^refs/tags/.*" + src
+}
+
+// helper function converts a Cloud Build file glob to a
+// regular expression. The double star matches any number
+// of path segments, and the single star matches any
+// characters except the path separator.
+func globToRegexp(glob string) string {
+	var sb strings.Builder
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				sb.WriteString(".*")
+				i++
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return sb.String()
+}
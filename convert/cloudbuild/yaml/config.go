@@ -50,7 +50,13 @@ type (
 	// https://cloud.google.com/build/docs/api/reference/rest/v1/projects.builds#Build.Secrets
 	AvailableSecrets struct {
 		SecretManager []*SecretManagerSecret `yaml:"secretManager,omitempty"`
-		Inline        []*Secret              `yaml:"inline,omitempty"`
+		Inline        []*InlineSecret        `yaml:"inline,omitempty"`
+	}
+
+	// https://cloud.google.com/build/docs/api/reference/rest/v1/projects.builds#inlinesecret
+	InlineSecret struct {
+		KMSKeyName string            `yaml:"kmsKeyName,omitempty"`
+		EnvMap     map[string]string `yaml:"envMap,omitempty"`
 	}
 
 	// https://cloud.google.com/build/docs/api/reference/rest/v1/projects.builds#mavenartifact
@@ -98,8 +104,8 @@ type (
 
 	// https://cloud.google.com/build/docs/api/reference/rest/v1/projects.builds#secretmanagersecret
 	SecretManagerSecret struct {
-		VersionName string            `yaml:"versionName,omitempty"`
-		Env         map[string]string `yaml:"env,omitempty"`
+		VersionName string `yaml:"versionName,omitempty"`
+		Env         string `yaml:"env,omitempty"`
 	}
 
 	// https://cloud.google.com/build/docs/api/reference/rest/v1/projects.builds#buildstep
@@ -113,7 +119,7 @@ type (
 		ID             string        `yaml:"id,omitempty"`
 		Waitfor        []string      `yaml:"waitFor,omitempty"`
 		Entrypoint     string        `yaml:"entrypoint,omitempty"`
-		Secretenv      string        `yaml:"secretEnv,omitempty"`
+		Secretenv      []string      `yaml:"secretEnv,omitempty"`
 		Volumes        []*Volume     `yaml:"volumes,omitempty"`
 		Timeout        time.Duration `yaml:"timeout,omitempty"`
 		Script         string        `yaml:"script,omitempty"`
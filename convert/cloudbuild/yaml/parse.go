@@ -53,3 +53,31 @@ func ParseFile(p string) (*Config, error) {
 	defer f.Close()
 	return Parse(f)
 }
+
+// ParseTrigger parses the trigger configuration from
+// io.Reader r.
+func ParseTrigger(r io.Reader) (*Trigger, error) {
+	out := new(Trigger)
+	dec := yaml.NewDecoder(r)
+	err := dec.Decode(out)
+	return out, err
+}
+
+// ParseTriggerBytes parses the trigger configuration from
+// bytes b.
+func ParseTriggerBytes(b []byte) (*Trigger, error) {
+	return ParseTrigger(
+		bytes.NewBuffer(b),
+	)
+}
+
+// ParseTriggerFile parses the trigger configuration from
+// path p.
+func ParseTriggerFile(p string) (*Trigger, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseTrigger(f)
+}
@@ -47,3 +47,28 @@ func TestParseFile_Error(t *testing.T) {
 		t.Errorf("Expect error when file not exists")
 	}
 }
+
+func TestParseTriggerFile(t *testing.T) {
+	out, err := ParseTriggerFile("testdata/triggers/github.yaml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if out.Github == nil || out.Github.Push == nil {
+		t.Errorf("Expect github push event")
+		return
+	}
+	if got, want := out.Github.Push.Branch, "^main$"; got != want {
+		t.Errorf("Want branch %q, got %q", want, got)
+	}
+	if got, want := len(out.Includedfiles), 1; got != want {
+		t.Errorf("Want %d included files, got %d", want, got)
+	}
+}
+
+func TestParseTriggerFile_Error(t *testing.T) {
+	_, err := ParseTriggerFile("testdata/file-does-not-exist.yaml")
+	if err == nil {
+		t.Errorf("Expect error when file not exists")
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yaml
+
+type (
+	// https://cloud.google.com/build/docs/api/reference/rest/v1/projects.triggers#resource:-buildtrigger
+	Trigger struct {
+		Name            string            `yaml:"name,omitempty"`
+		Description     string            `yaml:"description,omitempty"`
+		Disabled        bool              `yaml:"disabled,omitempty"`
+		Tags            []string          `yaml:"tags,omitempty"`
+		Github          *GithubEvents     `yaml:"github,omitempty"`
+		Pubsubconfig    *PubsubConfig     `yaml:"pubsubConfig,omitempty"`
+		Webhookconfig   *WebhookConfig    `yaml:"webhookConfig,omitempty"`
+		Triggertemplate *RepoSource       `yaml:"triggerTemplate,omitempty"`
+		Substitutions   map[string]string `yaml:"substitutions,omitempty"`
+		Includedfiles   []string          `yaml:"includedFiles,omitempty"`
+		Ignoredfiles    []string          `yaml:"ignoredFiles,omitempty"`
+		Filename        string            `yaml:"filename,omitempty"`
+		Filter          string            `yaml:"filter,omitempty"`
+		Serviceaccount  string            `yaml:"serviceAccount,omitempty"`
+		Build           *Config           `yaml:"build,omitempty"`
+	}
+
+	// https://cloud.google.com/build/docs/api/reference/rest/v1/projects.triggers#githubeventsconfig
+	GithubEvents struct {
+		Owner       string             `yaml:"owner,omitempty"`
+		Name        string             `yaml:"name,omitempty"`
+		Push        *PushFilter        `yaml:"push,omitempty"`
+		Pullrequest *PullRequestFilter `yaml:"pullRequest,omitempty"`
+	}
+
+	// https://cloud.google.com/build/docs/api/reference/rest/v1/projects.triggers#pushfilter
+	PushFilter struct {
+		Branch      string `yaml:"branch,omitempty"`
+		Tag         string `yaml:"tag,omitempty"`
+		Invertregex bool   `yaml:"invertRegex,omitempty"`
+	}
+
+	// https://cloud.google.com/build/docs/api/reference/rest/v1/projects.triggers#pullrequestfilter
+	PullRequestFilter struct {
+		Branch         string `yaml:"branch,omitempty"`
+		Commentcontrol string `yaml:"commentControl,omitempty"` // ENUM
+		Invertregex    bool   `yaml:"invertRegex,omitempty"`
+	}
+
+	// https://cloud.google.com/build/docs/api/reference/rest/v1/projects.triggers#pubsubconfig
+	PubsubConfig struct {
+		Topic          string `yaml:"topic,omitempty"`
+		Subscription   string `yaml:"subscription,omitempty"`
+		Serviceaccount string `yaml:"serviceAccountEmail,omitempty"`
+	}
+
+	// https://cloud.google.com/build/docs/api/reference/rest/v1/projects.triggers#webhookconfig
+	WebhookConfig struct {
+		Secret string `yaml:"secret,omitempty"`
+	}
+
+	// https://cloud.google.com/build/docs/api/reference/rest/v1/RepoSource
+	RepoSource struct {
+		Projectid   string `yaml:"projectId,omitempty"`
+		Reponame    string `yaml:"repoName,omitempty"`
+		Branchname  string `yaml:"branchName,omitempty"`
+		Tagname     string `yaml:"tagName,omitempty"`
+		Commitsha   string `yaml:"commitSha,omitempty"`
+		Dir         string `yaml:"dir,omitempty"`
+		Invertregex bool   `yaml:"invertRegex,omitempty"`
+	}
+)
@@ -73,11 +73,90 @@ const (
 	// Approval
 	StepTypeVerify = "Verify"
 
+	// Chaos
+	StepTypeChaos = "Chaos"
+
 	// IACM
 	StepTypeIACMTerraformPlugin = "IACMTerraformPlugin"
 	StepTypeIACMOpenTofuPlugin  = "IACMOpenTofuPlugin"
 
 	// Terraform
-	StepTypeTerraformPlan  = "TerraformPlan"
-	StepTypeTerraformApply = "TerraformApply"
+	StepTypeTerraformPlan     = "TerraformPlan"
+	StepTypeTerraformApply    = "TerraformApply"
+	StepTypeTerraformDestroy  = "TerraformDestroy"
+	StepTypeTerraformRollback = "TerraformRollback"
+
+	// Terragrunt
+	StepTypeTerragruntPlan     = "TerragruntPlan"
+	StepTypeTerragruntApply    = "TerragruntApply"
+	StepTypeTerragruntDestroy  = "TerragruntDestroy"
+	StepTypeTerragruntRollback = "TerragruntRollback"
+
+	// CloudFormation
+	StepTypeCloudformationCreateStack   = "CreateStack"
+	StepTypeCloudformationDeleteStack   = "DeleteStack"
+	StepTypeCloudformationRollbackStack = "RollbackStack"
+
+	// CD / ECS
+	StepTypeEcsRollingDeploy             = "EcsRollingDeploy"
+	StepTypeEcsRollingRollback           = "EcsRollingRollback"
+	StepTypeEcsCanaryDeploy              = "EcsCanaryDeploy"
+	StepTypeEcsCanaryDelete              = "EcsCanaryDelete"
+	StepTypeEcsBlueGreenCreateService    = "EcsBlueGreenCreateService"
+	StepTypeEcsBlueGreenSwapTargetGroups = "EcsBlueGreenSwapTargetGroups"
+	StepTypeEcsBlueGreenRollback         = "EcsBlueGreenRollback"
+	StepTypeEcsRunTask                   = "EcsRunTask"
+
+	// CD / Azure Web App
+	StepTypeAzureSlotDeployment = "AzureSlotDeployment"
+	StepTypeAzureTrafficShift   = "AzureTrafficShift"
+	StepTypeAzureSwapSlot       = "AzureSwapSlot"
+	StepTypeAzureWebAppRollback = "AzureWebAppRollback"
+
+	// CD / Tanzu Application Service
+	StepTypeTasBasicAppSetup  = "BasicAppSetup"
+	StepTypeTasBGAppSetup     = "BGAppSetup"
+	StepTypeTasCanaryAppSetup = "CanaryAppSetup"
+	StepTypeTasAppResize      = "AppResize"
+	StepTypeTasSwapRoutes     = "BGAppSwapRoutes"
+	StepTypeTasSwapRollback   = "SwapRollback"
+	StepTypeTasAppRollback    = "AppRollback"
+
+	// CD / ASG
+	StepTypeAsgRollingDeploy        = "AsgRollingDeploy"
+	StepTypeAsgRollingRollback      = "AsgRollingRollback"
+	StepTypeAsgBlueGreenDeploy      = "AsgBlueGreenDeploy"
+	StepTypeAsgBlueGreenSwapService = "AsgBlueGreenSwapService"
+	StepTypeAsgBlueGreenRollback    = "AsgBlueGreenRollback"
+
+	// CD / Google Cloud Functions
+	StepTypeDeployCloudFunction              = "DeployCloudFunction"
+	StepTypeDeployCloudFunctionWithNoTraffic = "DeployCloudFunctionWithNoTraffic"
+	StepTypeCloudFunctionTrafficShift        = "CloudFunctionTrafficShift"
+	StepTypeCloudFunctionRollback            = "CloudFunctionRollback"
+
+	// CD / AWS Lambda
+	StepTypeAwsLambdaDeploy   = "AwsLambdaDeploy"
+	StepTypeAwsLambdaRollback = "AwsLambdaRollback"
+
+	// STO
+	StepTypeSnyk         = "Snyk"
+	StepTypeSonarqube    = "Sonarqube"
+	StepTypeCheckmarx    = "Checkmarx"
+	StepTypeGrype        = "Grype"
+	StepTypeWiz          = "Wiz"
+	StepTypeSemgrep      = "Semgrep"
+	StepTypeGitleaks     = "Gitleaks"
+	StepTypePrismaCloud  = "PrismaCloud"
+	StepTypeCustomIngest = "CustomIngest"
+
+	// CD / GitOps
+	StepTypeGitOpsUpdateReleaseRepo = "GitOpsUpdateReleaseRepo"
+	StepTypeGitOpsMergePR           = "MergePR"
+	StepTypeGitOpsFetchLinkedApps   = "GitOpsFetchLinkedApps"
+	StepTypeGitOpsSync              = "GitOpsSync"
+	StepTypeGitOpsRevertPR          = "RevertPR"
+
+	// Governance
+	StepTypePolicy = "Policy"
 )
@@ -31,6 +31,11 @@ type ConversionContext struct {
 	// resets this between expressions to attribute warnings per expression.
 	Warnings []string
 
+	// UnsupportedFunctions lists the functions and operators with no v1
+	// equivalent met during conversion (e.g. "function 'json.format'"). Each
+	// is also reported in Warnings.
+	UnsupportedFunctions []string
+
 	// UseFQN enables FQN mode: at step_node the v1Path becomes the step's v1
 	// FQN base path.
 	UseFQN bool
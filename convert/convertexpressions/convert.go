@@ -29,7 +29,7 @@ func ConvertExpressionWithTrie(expr string, context *ConversionContext, inner bo
 
 	// Apply trie-based matching to dotted path segments
 	// FQN building happens inside the trie when UseFQN is enabled
-	return replaceDottedPaths(expr, func(m string) string {
+	expr = replaceDottedPaths(expr, func(m string) string {
 		// Handle codebase path conversion: pipeline.properties.ci.codebase.* -> codebase.*
 		if strings.HasPrefix(m, codebasePrefix) {
 			m = "codebase." + m[len(codebasePrefix):]
@@ -40,4 +40,7 @@ func ConvertExpressionWithTrie(expr string, context *ConversionContext, inner bo
 		}
 		return m
 	})
+
+	// Rewrite JEXL functions, methods and operators to their v1 equivalents
+	return rewriteFunctions(expr, context)
 }
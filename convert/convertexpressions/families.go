@@ -77,6 +77,90 @@ var TemplateUsesToV0Type = map[string]string{
 	// IACM
 	"terraformStep": StepTypeIACMTerraformPlugin,
 	"openTofuStep":  StepTypeIACMOpenTofuPlugin,
+
+	// Terraform / Terragrunt / CloudFormation provisioners
+	"terraformPlanStep":               StepTypeTerraformPlan,
+	"terraformApplyStep":              StepTypeTerraformApply,
+	"terraformDestroyStep":            StepTypeTerraformDestroy,
+	"terraformRollbackStep":           StepTypeTerraformRollback,
+	"terragruntPlanStep":              StepTypeTerragruntPlan,
+	"terragruntApplyStep":             StepTypeTerragruntApply,
+	"terragruntDestroyStep":           StepTypeTerragruntDestroy,
+	"terragruntRollbackStep":          StepTypeTerragruntRollback,
+	"cloudformationCreateStackStep":   StepTypeCloudformationCreateStack,
+	"cloudformationDeleteStackStep":   StepTypeCloudformationDeleteStack,
+	"cloudformationRollbackStackStep": StepTypeCloudformationRollbackStack,
+
+	// ECS
+	"ecsRollingDeployStep":             StepTypeEcsRollingDeploy,
+	"ecsRollingRollbackStep":           StepTypeEcsRollingRollback,
+	"ecsCanaryDeployStep":              StepTypeEcsCanaryDeploy,
+	"ecsCanaryDeleteStep":              StepTypeEcsCanaryDelete,
+	"ecsBlueGreenCreateServiceStep":    StepTypeEcsBlueGreenCreateService,
+	"ecsBlueGreenSwapTargetGroupsStep": StepTypeEcsBlueGreenSwapTargetGroups,
+	"ecsBlueGreenRollbackStep":         StepTypeEcsBlueGreenRollback,
+	"ecsRunTaskStep":                   StepTypeEcsRunTask,
+
+	// Azure Web App
+	"azureWebAppSlotDeployStep":   StepTypeAzureSlotDeployment,
+	"azureWebAppTrafficShiftStep": StepTypeAzureTrafficShift,
+	"azureWebAppSwapSlotStep":     StepTypeAzureSwapSlot,
+	"azureWebAppRollbackStep":     StepTypeAzureWebAppRollback,
+
+	// Tanzu Application Service
+	"tasBasicAppSetupStep":     StepTypeTasBasicAppSetup,
+	"tasBlueGreenAppSetupStep": StepTypeTasBGAppSetup,
+	"tasCanaryAppSetupStep":    StepTypeTasCanaryAppSetup,
+	"tasAppResizeStep":         StepTypeTasAppResize,
+	"tasSwapRoutesStep":        StepTypeTasSwapRoutes,
+	"tasSwapRollbackStep":      StepTypeTasSwapRollback,
+	"tasAppRollbackStep":       StepTypeTasAppRollback,
+
+	// ASG
+	"asgRollingDeployStep":        StepTypeAsgRollingDeploy,
+	"asgRollingRollbackStep":      StepTypeAsgRollingRollback,
+	"asgBlueGreenDeployStep":      StepTypeAsgBlueGreenDeploy,
+	"asgBlueGreenSwapServiceStep": StepTypeAsgBlueGreenSwapService,
+	"asgBlueGreenRollbackStep":    StepTypeAsgBlueGreenRollback,
+
+	// Google Cloud Functions
+	"googleCloudFunctionDeployStep":               StepTypeDeployCloudFunction,
+	"googleCloudFunctionDeployWithoutTrafficStep": StepTypeDeployCloudFunctionWithNoTraffic,
+	"googleCloudFunctionTrafficShiftStep":         StepTypeCloudFunctionTrafficShift,
+	"googleCloudFunctionRollbackStep":             StepTypeCloudFunctionRollback,
+
+	// AWS Lambda
+	"awsLambdaDeployStep":   StepTypeAwsLambdaDeploy,
+	"awsLambdaRollbackStep": StepTypeAwsLambdaRollback,
+
+	// STO
+	"aquaTrivyStep":    StepTypeAquaTrivy,
+	"snykStep":         StepTypeSnyk,
+	"sonarqubeStep":    StepTypeSonarqube,
+	"checkmarxStep":    StepTypeCheckmarx,
+	"grypeStep":        StepTypeGrype,
+	"wizStep":          StepTypeWiz,
+	"semgrepStep":      StepTypeSemgrep,
+	"gitleaksStep":     StepTypeGitleaks,
+	"prismaCloudStep":  StepTypePrismaCloud,
+	"customIngestStep": StepTypeCustomIngest,
+
+	// Feature Flags
+	"flagConfigurationStep": StepTypeFlagConfiguration,
+
+	// Continuous Verification / Chaos
+	"verifyStep": StepTypeVerify,
+	"chaosStep":  StepTypeChaos,
+
+	// GitOps
+	"gitopsUpdateReleaseRepoStep": StepTypeGitOpsUpdateReleaseRepo,
+	"gitopsMergePrStep":           StepTypeGitOpsMergePR,
+	"gitopsFetchLinkedAppsStep":   StepTypeGitOpsFetchLinkedApps,
+	"gitopsSyncStep":              StepTypeGitOpsSync,
+	"gitopsRevertPrStep":          StepTypeGitOpsRevertPR,
+
+	// Governance
+	"policyStep": StepTypePolicy,
 }
 
 // ApprovalUsesToV0Type maps a v1 step.approval `uses` to the v0 step type.
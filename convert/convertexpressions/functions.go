@@ -0,0 +1,328 @@
+package convertexpressions
+
+import (
+	"sort"
+	"strings"
+)
+
+// functionRule describes how a v0 function or method that v1 does not
+// evaluate the same way maps to v1.
+type functionRule struct {
+	// rewrite builds the v1 form from the rendered receiver (nil for functors
+	// and global functions) and arguments. It returns false when the call
+	// shape is not one the rule understands, in which case the call is kept.
+	rewrite func(recv *renderedNode, args []*renderedNode) (string, bool)
+	// level is the binding power (see binaryLevels) of the rewritten form;
+	// zero for a postfix expression.
+	level int
+	// unsupported marks functions with no v1 equivalent: the call is kept
+	// and a warning is raised.
+	unsupported bool
+}
+
+// keptFunctions lists the v0 functors (by qualified name), JEXL built-in
+// functions and Java methods that v1 evaluates the same way. They are kept
+// unchanged; any other function without a rule raises a warning.
+var keptFunctions = map[string]bool{
+	// functors, called on a bare namespace, e.g.
+	// <+json.select("$.a", body)>
+	"secrets.getValue":           true,
+	"exportedVariables.getValue": true,
+	"json.select":                true,
+	"json.list":                  true,
+
+	// JEXL built-in functions.
+	"empty": true,
+	"size":  true,
+
+	// Java methods called on a value, e.g.
+	// <+pipeline.variables.env>.toLowerCase()
+	"charAt":      true,
+	"concat":      true,
+	"contains":    true,
+	"containsKey": true,
+	"endsWith":    true,
+	"get":         true,
+	"indexOf":     true,
+	"isEmpty":     true,
+	"lastIndexOf": true,
+	"length":      true,
+	"replace":     true,
+	"split":       true,
+	"startsWith":  true,
+	"substring":   true,
+	"toLowerCase": true,
+	"toString":    true,
+	"toUpperCase": true,
+	"trim":        true,
+}
+
+// functorRules maps the v0 expression functors with no v1 equivalent.
+var functorRules = map[string]functionRule{
+	"json.object":            {unsupported: true},
+	"json.format":            {unsupported: true},
+	"xml.select":             {unsupported: true},
+	"ngSecretManager.obtain": {unsupported: true},
+	"configFile.getAsString": {unsupported: true},
+	"configFile.getAsBase64": {unsupported: true},
+	"fileStore.getAsString":  {unsupported: true},
+	"fileStore.getAsBase64":  {unsupported: true},
+}
+
+// methodRules maps the Java methods called on a value that are rewritten
+// for v1, or have no v1 equivalent.
+var methodRules = map[string]functionRule{
+	// a.equals(b) -> a == b
+	"equals": {level: equalityLevel, rewrite: func(recv *renderedNode, args []*renderedNode) (string, bool) {
+		if len(args) != 1 {
+			return "", false
+		}
+		return recv.operand(equalityLevel) + " == " + args[0].operand(equalityLevel+1), true
+	}},
+	// a.equalsIgnoreCase(b) -> a.toLowerCase() == b.toLowerCase()
+	"equalsIgnoreCase": {level: equalityLevel, rewrite: func(recv *renderedNode, args []*renderedNode) (string, bool) {
+		if len(args) != 1 {
+			return "", false
+		}
+		return recv.receiver() + ".toLowerCase() == " + args[0].receiver() + ".toLowerCase()", true
+	}},
+
+	// Java regular-expression methods.
+	"matches":      {unsupported: true},
+	"replaceAll":   {unsupported: true},
+	"replaceFirst": {unsupported: true},
+}
+
+// operatorRule describes how a JEXL operator maps to v1. Operators without a
+// rule, including the match operators =~ and !~, are kept unchanged.
+type operatorRule struct {
+	op     string // replacement operator
+	method string // rewrite "a op b" to "a.method(b)"
+	negate bool   // prefix the method call with !
+}
+
+var operatorRules = map[string]operatorRule{
+	"and": {op: "&&"},
+	"or":  {op: "||"},
+	"not": {op: "!"},
+	"eq":  {op: "=="},
+	"ne":  {op: "!="},
+	"lt":  {op: "<"},
+	"le":  {op: "<="},
+	"gt":  {op: ">"},
+	"ge":  {op: ">="},
+	"div": {op: "/"},
+	"mod": {op: "%"},
+
+	"=^": {method: "startsWith"},
+	"!^": {method: "startsWith", negate: true},
+	"=$": {method: "endsWith"},
+	"!$": {method: "endsWith", negate: true},
+}
+
+// renderedNode is the v1 text of a node.
+type renderedNode struct {
+	node     *exprNode
+	text     string
+	level    int // binding power of text when a rewrite changed its shape
+	children []*renderedNode
+}
+
+// binding returns the binding power of the rendered text, or zero for a
+// postfix expression.
+func (r *renderedNode) binding() int {
+	if r.level > 0 {
+		return r.level
+	}
+	switch r.node.kind {
+	case nodeBinary:
+		return binaryLevels[r.node.op]
+	case nodeTernary:
+		return -1
+	}
+	return 0
+}
+
+// receiver returns the text ready to have a method called on it.
+func (r *renderedNode) receiver() string {
+	text := strings.TrimSpace(r.text)
+	if r.level == 0 && r.node.isPostfix() {
+		return text
+	}
+	return "(" + text + ")"
+}
+
+// operand returns the text ready to be the operand of an operator that needs
+// at least minLevel binding power.
+func (r *renderedNode) operand(minLevel int) string {
+	text := strings.TrimSpace(r.text)
+	if level := r.binding(); level == 0 || level >= minLevel {
+		return text
+	}
+	return "(" + text + ")"
+}
+
+// functionRewriter renders a parsed expression to v1 and reports functions
+// with no v1 equivalent on ctx.
+type functionRewriter struct {
+	src string
+	ctx *ConversionContext
+}
+
+// rewriteFunctions rewrites the functions, methods and operators in the inner
+// content of a single expression to their v1 equivalents. Content that does
+// not parse is returned unchanged.
+func rewriteFunctions(expr string, ctx *ConversionContext) string {
+	root, err := parseExpression(expr)
+	if err != nil {
+		return expr
+	}
+	r := &functionRewriter{src: expr, ctx: ctx}
+	return expr[:root.start] + r.render(root).text + expr[root.end:]
+}
+
+// splice replaces the source range [start,end).
+type splice struct {
+	start int
+	end   int
+	text  string
+}
+
+// spliceNode returns the source text of n with the splices applied.
+func (r *functionRewriter) spliceNode(n *exprNode, splices []splice) string {
+	sort.Slice(splices, func(i, j int) bool { return splices[i].start < splices[j].start })
+	var b strings.Builder
+	prev := n.start
+	for _, s := range splices {
+		b.WriteString(r.src[prev:s.start])
+		b.WriteString(s.text)
+		prev = s.end
+	}
+	b.WriteString(r.src[prev:n.end])
+	return b.String()
+}
+
+// render returns the v1 text of n. Unchanged nodes keep their source text
+// byte for byte.
+func (r *functionRewriter) render(n *exprNode) *renderedNode {
+	out := &renderedNode{node: n, children: make([]*renderedNode, len(n.args))}
+	for i, child := range n.args {
+		out.children[i] = r.render(child)
+	}
+
+	var splices []splice
+	switch n.kind {
+	case nodeCall:
+		if text, level, ok := r.rewriteCall(out); ok {
+			out.text, out.level = text, level
+			return out
+		}
+	case nodeUnary, nodeBinary:
+		rule, found := operatorRules[n.op]
+		switch {
+		case !found:
+		case rule.method != "":
+			out.text = out.children[0].receiver() + "." + rule.method + "(" + strings.TrimSpace(out.children[1].text) + ")"
+			if rule.negate {
+				out.text = "!" + out.text
+			}
+			return out
+		case n.kind == nodeUnary:
+			// "not x" -> "!x"
+			splices = append(splices, splice{n.opStart, n.args[0].start, rule.op})
+		default:
+			splices = append(splices, splice{n.opStart, n.opEnd, rule.op})
+		}
+	}
+
+	for i, child := range out.children {
+		text := child.text
+		if child.level > 0 && n.kind != nodeParen {
+			// A rewrite turned a postfix call into an operator expression.
+			text = child.operand(minOperandLevel(n, i))
+		}
+		splices = append(splices, splice{n.args[i].start, n.args[i].end, text})
+	}
+	out.text = r.spliceNode(n, splices)
+	return out
+}
+
+// minOperandLevel is the binding power child i of n needs to go without
+// parentheses.
+func minOperandLevel(n *exprNode, i int) int {
+	switch n.kind {
+	case nodeCall, nodeIndex:
+		if i > 0 {
+			return 0 // arguments and indexes
+		}
+	case nodeArray, nodeTernary:
+		return 0
+	case nodeBinary:
+		if i == 0 {
+			return binaryLevels[n.op]
+		}
+		return binaryLevels[n.op] + 1
+	}
+	return len(binaryLevels) + 1 // receivers and unary operands
+}
+
+// rewriteCall applies the function rules to a rendered call node.
+func (r *functionRewriter) rewriteCall(call *renderedNode) (string, int, bool) {
+	fun := call.node.args[0]
+	var (
+		name  string
+		rule  functionRule
+		found bool
+		recv  *renderedNode
+	)
+	switch fun.kind {
+	case nodeIdent:
+		name = fun.name
+	case nodeMember:
+		name = fun.qualifiedName()
+		if rule, found = functorRules[name]; !found && !keptFunctions[name] {
+			if namespace, _, ok := strings.Cut(name, "."); !ok || !isFunctorNamespace(namespace) {
+				name = fun.name
+				rule, found = methodRules[name]
+				recv = call.children[0].children[0]
+			}
+		}
+	}
+
+	if !found && keptFunctions[name] {
+		return "", 0, false
+	}
+	if !found || rule.unsupported {
+		r.reportUnsupported("function '" + name + "'")
+		return "", 0, false
+	}
+	if rule.rewrite == nil {
+		return "", 0, false
+	}
+	text, ok := rule.rewrite(recv, call.children[1:])
+	return text, rule.level, ok
+}
+
+// isFunctorNamespace reports whether name is the namespace of a v0 functor.
+func isFunctorNamespace(name string) bool {
+	for qualified := range functorRules {
+		if strings.HasPrefix(qualified, name+".") {
+			return true
+		}
+	}
+	for qualified := range keptFunctions {
+		if strings.HasPrefix(qualified, name+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// reportUnsupported records a function or operator with no v1 equivalent.
+func (r *functionRewriter) reportUnsupported(what string) {
+	if r.ctx == nil {
+		return
+	}
+	r.ctx.UnsupportedFunctions = append(r.ctx.UnsupportedFunctions, what)
+	r.ctx.addWarning(what + " in '" + strings.TrimSpace(r.src) + "' has no v1 equivalent; kept unchanged")
+}
@@ -0,0 +1,165 @@
+package convertexpressions
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRewriteFunctions(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    string
+		unsupported []string
+	}{
+		{
+			name:     "supported methods unchanged",
+			input:    `<+<+pipeline.variables.list>.split(",")[0].trim().toLowerCase()>`,
+			expected: `<+<+pipeline.variables.list>.split(",")[0].trim().toLowerCase()>`,
+		},
+		{
+			name:     "functors unchanged",
+			input:    `<+json.select("$.id", <+pipeline.variables.body>) + secrets.getValue("account.token")>`,
+			expected: `<+json.select("$.id", <+pipeline.variables.body>) + secrets.getValue("account.token")>`,
+		},
+		{
+			name:     "global functions unchanged",
+			input:    `<+empty(<+pipeline.variables.tags>) || size(<+pipeline.variables.tags>) > 2>`,
+			expected: `<+empty(<+pipeline.variables.tags>) || size(<+pipeline.variables.tags>) > 2>`,
+		},
+		{
+			name:        "unknown functor",
+			input:       `<+json.parse(<+pipeline.variables.body>)>`,
+			expected:    `<+json.parse(<+pipeline.variables.body>)>`,
+			unsupported: []string{"function 'json.parse'"},
+		},
+		{
+			name:     "equals",
+			input:    `<+<+pipeline.variables.env>.equals("prod")>`,
+			expected: `<+<+pipeline.variables.env> == "prod">`,
+		},
+		{
+			name:     "equals inside logical operator",
+			input:    `<+ <+pipeline.variables.env>.equals("prod") && <+pipeline.variables.deploy> >`,
+			expected: `<+ <+pipeline.variables.env> == "prod" && <+pipeline.variables.deploy> >`,
+		},
+		{
+			name:     "equals parenthesised under negation and as receiver",
+			input:    `<+!<+pipeline.variables.env>.equals("prod") || <+pipeline.variables.env>.equals("qa").toString() == "true">`,
+			expected: `<+!(<+pipeline.variables.env> == "prod") || (<+pipeline.variables.env> == "qa").toString() == "true">`,
+		},
+		{
+			name:     "equals with ternary argument",
+			input:    `<+"prod".equals(<+pipeline.variables.ci> ? "qa" : "prod")>`,
+			expected: `<+"prod" == (<+pipeline.variables.ci> ? "qa" : "prod")>`,
+		},
+		{
+			name:     "equalsIgnoreCase",
+			input:    `<+<+pipeline.variables.env>.equalsIgnoreCase("PROD")>`,
+			expected: `<+<+pipeline.variables.env>.toLowerCase() == "PROD".toLowerCase()>`,
+		},
+		{
+			name:     "word operators",
+			input:    `<+ <+pipeline.variables.a> eq "x" and not <+pipeline.variables.b> or <+pipeline.variables.n> ge 3 >`,
+			expected: `<+ <+pipeline.variables.a> == "x" && !<+pipeline.variables.b> || <+pipeline.variables.n> >= 3 >`,
+		},
+		{
+			name:     "starts and ends with operators",
+			input:    `<+<+codebase.branch> =^ "feature/" ? "dev" : <+codebase.branch> !$ "-rc">`,
+			expected: `<+<+codebase.branch>.startsWith("feature/") ? "dev" : !<+codebase.branch>.endsWith("-rc")>`,
+		},
+		{
+			name:     "paths converted alongside methods",
+			input:    `<+pipeline.stages.build.spec.execution.steps.step1.output.outputVariables.tag.equals("latest")>`,
+			expected: `<+pipeline.stages.build.steps.step1.output.outputVariables.tag == "latest">`,
+		},
+		{
+			name:     "dollar delimiter",
+			input:    `${{ pipeline.variables.a ne "b" }}`,
+			expected: `${{ pipeline.variables.a != "b" }}`,
+		},
+		{
+			name:        "unsupported functor",
+			input:       `<+json.format(<+pipeline.variables.body>)>`,
+			expected:    `<+json.format(<+pipeline.variables.body>)>`,
+			unsupported: []string{"function 'json.format'"},
+		},
+		{
+			name:        "unsupported method",
+			input:       `<+<+pipeline.variables.tag>.replaceAll("v", "")>`,
+			expected:    `<+<+pipeline.variables.tag>.replaceAll("v", "")>`,
+			unsupported: []string{"function 'replaceAll'"},
+		},
+		{
+			name:        "unknown function",
+			input:       `<+Integer.parseInt(<+pipeline.variables.count>) > 2>`,
+			expected:    `<+Integer.parseInt(<+pipeline.variables.count>) > 2>`,
+			unsupported: []string{"function 'parseInt'"},
+		},
+		{
+			name:     "match operators are kept",
+			input:    `<+<+pipeline.variables.tag> =~ "^v[0-9]+" and <+pipeline.variables.env> !~ "^dev">`,
+			expected: `<+<+pipeline.variables.tag> =~ "^v[0-9]+" && <+pipeline.variables.env> !~ "^dev">`,
+		},
+		{
+			name:        "nested expressions report their own functions",
+			input:       `<+<+json.object(<+pipeline.variables.body>)>.equals("x")>`,
+			expected:    `<+<+json.object(<+pipeline.variables.body>)> == "x">`,
+			unsupported: []string{"function 'json.object'"},
+		},
+		{
+			name:     "unparseable content unchanged",
+			input:    `<+pipeline.variables.a = "b">`,
+			expected: `<+pipeline.variables.a = "b">`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &ConversionContext{}
+			got := ConvertExpressionWithTrie(tt.input, ctx, false)
+			if got != tt.expected {
+				t.Errorf("ConvertExpressionWithTrie(%q)\n got  %q\n want %q", tt.input, got, tt.expected)
+			}
+			if diff := cmp.Diff(tt.unsupported, ctx.UnsupportedFunctions); diff != "" {
+				t.Errorf("unsupported functions mismatch (-want +got):\n%s", diff)
+			}
+			if len(ctx.Warnings) != len(tt.unsupported) {
+				t.Errorf("expected %d warnings, got %v", len(tt.unsupported), ctx.Warnings)
+			}
+		})
+	}
+}
+
+func TestParseExpression(t *testing.T) {
+	valid := []string{
+		`pipeline.variables.a`,
+		`<+a>.contains("x") ? <+b> : "c"`,
+		`a ?: "default"`,
+		`a ?? b`,
+		`steps.s1.output.outputVariables["my-var"]`,
+		`[1, 2.5, 'three'].size() > -1`,
+		`empty(a) || size(b) == 0`,
+		`!(a && b)`,
+	}
+	for _, src := range valid {
+		if _, err := parseExpression(src); err != nil {
+			t.Errorf("parseExpression(%q): unexpected error %v", src, err)
+		}
+	}
+
+	invalid := []string{
+		`a = b`,
+		`a.`,
+		`foo("unterminated`,
+		`(a`,
+		`a b`,
+		`x -> x`,
+	}
+	for _, src := range invalid {
+		if _, err := parseExpression(src); err == nil {
+			t.Errorf("parseExpression(%q): expected error", src)
+		}
+	}
+}
@@ -0,0 +1,421 @@
+package convertexpressions
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The v0 expression language is JEXL: dotted paths, Java method calls,
+// C-style and word operators (and, or, eq, ...), the JEXL string operators
+// (=^, =$, =~) and ternaries. parseExpression turns the inner content of a
+// single <+...> / ${{...}} expression into a small AST whose nodes keep their
+// byte offsets, so the rewriter can splice changed nodes back into the
+// original text without disturbing formatting. Nested expressions are opaque
+// nodes; they are converted on their own by ConvertExpressionWithTrie.
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokNested // nested <+...> or ${{...}} expression
+	tokOp
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	start int
+	end   int
+}
+
+// operators lists the multi-character operators before the single-character
+// ones so the lexer always takes the longest match.
+var operators = []string{
+	"?:", "??", "&&", "||", "==", "!=", "<=", ">=",
+	"=~", "!~", "=^", "!^", "=$", "!$", "->",
+	"(", ")", "[", "]", "{", "}", ",", ".", "?", ":",
+	"!", "<", ">", "+", "-", "*", "/", "%", "&", "|", "^", "~",
+}
+
+func isIdentStart(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_' || c == '$'
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+// lexExpression splits src into tokens.
+func lexExpression(src string) ([]token, error) {
+	var toks []token
+	n := len(src)
+	for i := 0; i < n; {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+			continue
+
+		case (c == '<' && i+1 < n && src[i+1] == '+') ||
+			(c == '$' && i+2 < n && src[i+1] == '{' && src[i+2] == '{'):
+			spans := findExprSpans(src[i:])
+			if len(spans) == 0 || spans[0].start != 0 {
+				return nil, fmt.Errorf("unterminated nested expression at %d", i)
+			}
+			end := i + spans[0].end
+			toks = append(toks, token{tokNested, src[i:end], i, end})
+			i = end
+
+		case c == '"' || c == '\'':
+			j := i + 1
+			for j < n && src[j] != c {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= n {
+				return nil, fmt.Errorf("unterminated string at %d", i)
+			}
+			toks = append(toks, token{tokString, src[i : j+1], i, j + 1})
+			i = j + 1
+
+		case c >= '0' && c <= '9':
+			j := i
+			for j < n && src[j] >= '0' && src[j] <= '9' {
+				j++
+			}
+			if j+1 < n && src[j] == '.' && src[j+1] >= '0' && src[j+1] <= '9' {
+				j++
+				for j < n && src[j] >= '0' && src[j] <= '9' {
+					j++
+				}
+			}
+			toks = append(toks, token{tokNumber, src[i:j], i, j})
+			i = j
+
+		case isIdentStart(c):
+			j := i
+			for j < n && isIdentChar(src[j]) {
+				j++
+			}
+			toks = append(toks, token{tokIdent, src[i:j], i, j})
+			i = j
+
+		default:
+			matched := false
+			for _, op := range operators {
+				if strings.HasPrefix(src[i:], op) {
+					toks = append(toks, token{tokOp, op, i, i + len(op)})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected character %q at %d", c, i)
+			}
+		}
+	}
+	return append(toks, token{kind: tokEOF, start: n, end: n}), nil
+}
+
+type nodeKind int
+
+const (
+	nodeLiteral nodeKind = iota // string, number, true, false, null
+	nodeIdent                   // bare identifier
+	nodeNested                  // nested <+...> / ${{...}} expression
+	nodeMember                  // args[0].name
+	nodeIndex                   // args[0][args[1]]
+	nodeCall                    // args[0](args[1:]...); args[0] is an ident or member
+	nodeUnary                   // op args[0]
+	nodeBinary                  // args[0] op args[1]
+	nodeTernary                 // args[0] ? args[1] : args[2], or args[0] ?: args[1] / args[0] ?? args[1]
+	nodeParen                   // (args[0])
+	nodeArray                   // [args...]
+)
+
+// exprNode is a parsed expression node. start/end is its byte range in the
+// source and opStart/opEnd the range of its operator token, if any.
+type exprNode struct {
+	kind    nodeKind
+	start   int
+	end     int
+	name    string // identifier or member name
+	op      string
+	opStart int
+	opEnd   int
+	args    []*exprNode
+}
+
+// binaryLevels is the binding power of each binary operator; higher binds
+// tighter.
+var binaryLevels = map[string]int{
+	"||": 1, "or": 1,
+	"&&": 2, "and": 2,
+	"|":  3,
+	"^":  4,
+	"&":  5,
+	"==": 6, "!=": 6, "eq": 6, "ne": 6, "=~": 6, "!~": 6, "=^": 6, "!^": 6, "=$": 6, "!$": 6,
+	"<": 7, "<=": 7, ">": 7, ">=": 7, "lt": 7, "le": 7, "gt": 7, "ge": 7,
+	"+": 8, "-": 8,
+	"*": 9, "/": 9, "%": 9, "div": 9, "mod": 9,
+}
+
+// equalityLevel is the binding power of == and friends.
+const equalityLevel = 6
+
+type exprParser struct {
+	toks []token
+	pos  int
+}
+
+// parseExpression parses the inner content of a Harness expression.
+func parseExpression(src string) (*exprNode, error) {
+	toks, err := lexExpression(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{toks: toks}
+	node, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at %d", tok.text, tok.start)
+	}
+	return node, nil
+}
+
+func (p *exprParser) peek() token {
+	return p.toks[p.pos]
+}
+
+func (p *exprParser) next() token {
+	tok := p.toks[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *exprParser) isOp(text string) bool {
+	tok := p.peek()
+	return tok.kind == tokOp && tok.text == text
+}
+
+func (p *exprParser) expect(text string) (token, error) {
+	tok := p.next()
+	if tok.kind != tokOp || tok.text != text {
+		return tok, fmt.Errorf("expected %q at %d", text, tok.start)
+	}
+	return tok, nil
+}
+
+func (p *exprParser) parseTernary() (*exprNode, error) {
+	cond, err := p.parseBinary(1)
+	if err != nil {
+		return nil, err
+	}
+	tok := p.peek()
+	if tok.kind != tokOp {
+		return cond, nil
+	}
+	switch tok.text {
+	case "?":
+		p.next()
+		then, err := p.parseTernary()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		els, err := p.parseTernary()
+		if err != nil {
+			return nil, err
+		}
+		return &exprNode{kind: nodeTernary, start: cond.start, end: els.end, op: "?", opStart: tok.start, opEnd: tok.end, args: []*exprNode{cond, then, els}}, nil
+	case "?:", "??":
+		p.next()
+		els, err := p.parseTernary()
+		if err != nil {
+			return nil, err
+		}
+		return &exprNode{kind: nodeTernary, start: cond.start, end: els.end, op: tok.text, opStart: tok.start, opEnd: tok.end, args: []*exprNode{cond, els}}, nil
+	}
+	return cond, nil
+}
+
+// binaryOp returns the binary operator at the current token, if any.
+func (p *exprParser) binaryOp() (token, int, bool) {
+	tok := p.peek()
+	if tok.kind != tokOp && tok.kind != tokIdent {
+		return tok, 0, false
+	}
+	level, ok := binaryLevels[tok.text]
+	return tok, level, ok
+}
+
+func (p *exprParser) parseBinary(minLevel int) (*exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, level, ok := p.binaryOp()
+		if !ok || level < minLevel {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &exprNode{kind: nodeBinary, start: left.start, end: right.end, op: tok.text, opStart: tok.start, opEnd: tok.end, args: []*exprNode{left, right}}
+	}
+}
+
+func (p *exprParser) parseUnary() (*exprNode, error) {
+	tok := p.peek()
+	if (tok.kind == tokOp && (tok.text == "!" || tok.text == "-" || tok.text == "~")) ||
+		(tok.kind == tokIdent && tok.text == "not") {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &exprNode{kind: nodeUnary, start: tok.start, end: operand.end, op: tok.text, opStart: tok.start, opEnd: tok.end, args: []*exprNode{operand}}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *exprParser) parsePostfix() (*exprNode, error) {
+	x, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch {
+		case p.isOp("."):
+			p.next()
+			name := p.next()
+			if name.kind != tokIdent && name.kind != tokNumber {
+				return nil, fmt.Errorf("expected member name at %d", name.start)
+			}
+			x = &exprNode{kind: nodeMember, start: x.start, end: name.end, name: name.text, args: []*exprNode{x}}
+
+		case p.isOp("["):
+			p.next()
+			index, err := p.parseTernary()
+			if err != nil {
+				return nil, err
+			}
+			closing, err := p.expect("]")
+			if err != nil {
+				return nil, err
+			}
+			x = &exprNode{kind: nodeIndex, start: x.start, end: closing.end, args: []*exprNode{x, index}}
+
+		case p.isOp("(") && (x.kind == nodeIdent || x.kind == nodeMember):
+			p.next()
+			args := []*exprNode{x}
+			for !p.isOp(")") {
+				arg, err := p.parseTernary()
+				if err != nil {
+					return nil, err
+				}
+				args = append(args, arg)
+				if !p.isOp(",") {
+					break
+				}
+				p.next()
+			}
+			closing, err := p.expect(")")
+			if err != nil {
+				return nil, err
+			}
+			x = &exprNode{kind: nodeCall, start: x.start, end: closing.end, args: args}
+
+		default:
+			return x, nil
+		}
+	}
+}
+
+func (p *exprParser) parsePrimary() (*exprNode, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokNumber, tokString:
+		return &exprNode{kind: nodeLiteral, start: tok.start, end: tok.end}, nil
+	case tokNested:
+		return &exprNode{kind: nodeNested, start: tok.start, end: tok.end}, nil
+	case tokIdent:
+		switch tok.text {
+		case "true", "false", "null":
+			return &exprNode{kind: nodeLiteral, start: tok.start, end: tok.end}, nil
+		}
+		return &exprNode{kind: nodeIdent, start: tok.start, end: tok.end, name: tok.text}, nil
+	case tokOp:
+		switch tok.text {
+		case "(":
+			inner, err := p.parseTernary()
+			if err != nil {
+				return nil, err
+			}
+			closing, err := p.expect(")")
+			if err != nil {
+				return nil, err
+			}
+			return &exprNode{kind: nodeParen, start: tok.start, end: closing.end, args: []*exprNode{inner}}, nil
+		case "[":
+			node := &exprNode{kind: nodeArray, start: tok.start}
+			for !p.isOp("]") {
+				elem, err := p.parseTernary()
+				if err != nil {
+					return nil, err
+				}
+				node.args = append(node.args, elem)
+				if !p.isOp(",") {
+					break
+				}
+				p.next()
+			}
+			closing, err := p.expect("]")
+			if err != nil {
+				return nil, err
+			}
+			node.end = closing.end
+			return node, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected %q at %d", tok.text, tok.start)
+}
+
+// isPostfix reports whether n can be used as a method receiver or operand
+// without parentheses.
+func (n *exprNode) isPostfix() bool {
+	switch n.kind {
+	case nodeLiteral, nodeIdent, nodeNested, nodeMember, nodeIndex, nodeCall, nodeParen, nodeArray:
+		return true
+	}
+	return false
+}
+
+// qualifiedName returns the dotted name of an identifier or a member chain
+// of identifiers (e.g. "json.select"), or "" for anything else.
+func (n *exprNode) qualifiedName() string {
+	switch n.kind {
+	case nodeIdent:
+		return n.name
+	case nodeMember:
+		if recv := n.args[0].qualifiedName(); recv != "" {
+			return recv + "." + n.name
+		}
+	}
+	return ""
+}
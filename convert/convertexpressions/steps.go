@@ -714,6 +714,136 @@ var StepSpecConversionRules = map[string][]ConversionRule{
 		{"subscriptionId", "steps.pushWithBuildx.spec.with.SUBSCRIPTION_ID"},
 		// runAsUser -> NO_TEMPLATE_MAPPING
 	},
+
+	// ============================================================
+	// ECS STEPS
+	// ============================================================
+
+	StepTypeEcsRollingDeploy: {
+		{"sameAsAlreadyRunningInstances", "steps.ecsRollingDeployAction.spec.env.PLUGIN_SAME_AS_RUNNING_INSTANCES"},
+		{"forceNewDeployment", "steps.ecsRollingDeployAction.spec.env.PLUGIN_FORCE_NEW_DEPLOYMENT"},
+	},
+
+	StepTypeEcsBlueGreenCreateService: {
+		{"loadBalancer", "steps.ecsBlueGreenCreateServiceAction.spec.env.PLUGIN_LOAD_BALANCER"},
+		{"prodListener", "steps.ecsBlueGreenCreateServiceAction.spec.env.PLUGIN_PROD_LISTENER"},
+		{"prodListenerRuleArn", "steps.ecsBlueGreenCreateServiceAction.spec.env.PLUGIN_PROD_LISTENER_RULE"},
+		{"stageListener", "steps.ecsBlueGreenCreateServiceAction.spec.env.PLUGIN_STAGE_LISTENER"},
+		{"stageListenerRuleArn", "steps.ecsBlueGreenCreateServiceAction.spec.env.PLUGIN_STAGE_LISTENER_RULE"},
+		{"sameAsAlreadyRunningInstances", "steps.ecsBlueGreenCreateServiceAction.spec.env.PLUGIN_SAME_AS_RUNNING_INSTANCES"},
+		{"updateGreenService", "steps.ecsBlueGreenCreateServiceAction.spec.env.PLUGIN_UPDATE_GREEN_SERVICE"},
+	},
+
+	StepTypeEcsBlueGreenSwapTargetGroups: {
+		{"doNotDownsizeOldService", "steps.ecsBlueGreenSwapTargetGroupsAction.spec.env.PLUGIN_SKIP_DOWNSIZE_OLD_SERVICE"},
+		{"downsizeOldServiceDelayInSecs", "steps.ecsBlueGreenSwapTargetGroupsAction.spec.env.PLUGIN_DOWNSIZE_DELAY"},
+	},
+
+	StepTypeEcsRunTask: {
+		{"taskDefinitionArn", "steps.ecsRunTaskAction.spec.env.PLUGIN_TASK_DEFINITION_ARN"},
+		{"skipSteadyStateCheck", "steps.ecsRunTaskAction.spec.env.PLUGIN_SKIP_STEADY_STATE_CHECK"},
+	},
+
+	// ============================================================
+	// AZURE WEB APP STEPS
+	// ============================================================
+
+	StepTypeAzureSlotDeployment: {
+		{"webApp", "steps.azureWebAppSlotDeployAction.spec.env.PLUGIN_WEB_APP"},
+		{"deploymentSlot", "steps.azureWebAppSlotDeployAction.spec.env.PLUGIN_DEPLOYMENT_SLOT"},
+		{"clean", "steps.azureWebAppSlotDeployAction.spec.env.PLUGIN_CLEAN"},
+	},
+
+	StepTypeAzureTrafficShift: {
+		{"traffic", "steps.azureWebAppTrafficShiftAction.spec.env.PLUGIN_TRAFFIC"},
+	},
+
+	StepTypeAzureSwapSlot: {
+		{"targetSlot", "steps.azureWebAppSwapSlotAction.spec.env.PLUGIN_TARGET_SLOT"},
+	},
+
+	// ============================================================
+	// TANZU APPLICATION SERVICE (TAS) STEPS
+	// ============================================================
+
+	StepTypeTasBasicAppSetup: {
+		{"tasInstanceCountType", "steps.tasBasicAppSetupAction.spec.env.PLUGIN_INSTANCE_COUNT"},
+		{"existingVersionToKeep", "steps.tasBasicAppSetupAction.spec.env.PLUGIN_EXISTING_VERSIONS_TO_KEEP"},
+		{"additionalRoutes", "steps.tasBasicAppSetupAction.spec.env.PLUGIN_ADDITIONAL_ROUTES"},
+	},
+
+	StepTypeTasBGAppSetup: {
+		{"tasInstanceCountType", "steps.tasBlueGreenAppSetupAction.spec.env.PLUGIN_INSTANCE_COUNT"},
+		{"existingVersionToKeep", "steps.tasBlueGreenAppSetupAction.spec.env.PLUGIN_EXISTING_VERSIONS_TO_KEEP"},
+		{"additionalRoutes", "steps.tasBlueGreenAppSetupAction.spec.env.PLUGIN_ADDITIONAL_ROUTES"},
+		{"tempRoutes", "steps.tasBlueGreenAppSetupAction.spec.env.PLUGIN_TEMP_ROUTES"},
+	},
+
+	StepTypeTasCanaryAppSetup: {
+		{"tasInstanceCountType", "steps.tasCanaryAppSetupAction.spec.env.PLUGIN_INSTANCE_COUNT"},
+		{"existingVersionToKeep", "steps.tasCanaryAppSetupAction.spec.env.PLUGIN_EXISTING_VERSIONS_TO_KEEP"},
+		{"additionalRoutes", "steps.tasCanaryAppSetupAction.spec.env.PLUGIN_ADDITIONAL_ROUTES"},
+		{"resizeStrategy", "steps.tasCanaryAppSetupAction.spec.env.PLUGIN_RESIZE_STRATEGY"},
+	},
+
+	StepTypeTasAppResize: {
+		{"newAppInstances.spec.value", "steps.tasAppResizeAction.spec.env.PLUGIN_NEW_APP_INSTANCES"},
+		{"oldAppInstances.spec.value", "steps.tasAppResizeAction.spec.env.PLUGIN_OLD_APP_INSTANCES"},
+	},
+
+	StepTypeTasSwapRoutes: {
+		{"downSizeOldApplication", "steps.tasSwapRoutesAction.spec.env.PLUGIN_DOWNSIZE_OLD_APP"},
+	},
+
+	StepTypeTasSwapRollback: {
+		{"upsizeInActiveApp", "steps.tasSwapRollbackAction.spec.env.PLUGIN_UPSIZE_INACTIVE_APP"},
+	},
+
+	// ============================================================
+	// ASG STEPS
+	// ============================================================
+
+	StepTypeAsgRollingDeploy: {
+		{"useAlreadyRunningInstances", "steps.asgRollingDeployAction.spec.env.PLUGIN_SAME_AS_RUNNING_INSTANCES"},
+		{"minimumHealthyPercentage", "steps.asgRollingDeployAction.spec.env.PLUGIN_MIN_HEALTHY_PERCENTAGE"},
+		{"instanceWarmup", "steps.asgRollingDeployAction.spec.env.PLUGIN_INSTANCE_WARMUP"},
+		{"skipMatching", "steps.asgRollingDeployAction.spec.env.PLUGIN_SKIP_MATCHING"},
+	},
+
+	StepTypeAsgBlueGreenDeploy: {
+		{"loadBalancers", "steps.asgBlueGreenDeployAction.spec.env.PLUGIN_LOAD_BALANCERS"},
+		{"useAlreadyRunningInstances", "steps.asgBlueGreenDeployAction.spec.env.PLUGIN_SAME_AS_RUNNING_INSTANCES"},
+	},
+
+	StepTypeAsgBlueGreenSwapService: {
+		{"downsizeOldAsg", "steps.asgBlueGreenSwapServiceAction.spec.env.PLUGIN_DOWNSIZE_OLD_ASG"},
+	},
+
+	// ============================================================
+	// GOOGLE CLOUD FUNCTIONS STEPS
+	// ============================================================
+
+	StepTypeDeployCloudFunction: {
+		{"updateFieldMask", "steps.googleCloudFunctionDeployAction.spec.env.PLUGIN_UPDATE_FIELD_MASK"},
+	},
+
+	StepTypeDeployCloudFunctionWithNoTraffic: {
+		{"updateFieldMask", "steps.googleCloudFunctionDeployAction.spec.env.PLUGIN_UPDATE_FIELD_MASK"},
+	},
+
+	StepTypeCloudFunctionTrafficShift: {
+		{"trafficPercent", "steps.googleCloudFunctionTrafficShiftAction.spec.env.PLUGIN_TRAFFIC_PERCENT"},
+	},
+
+	// ============================================================
+	// FEATURE FLAG STEPS
+	// ============================================================
+
+	// FlagConfiguration (sub-steps: flagConfigurationAction)
+	StepTypeFlagConfiguration: {
+		{"feature", "steps.flagConfigurationAction.spec.env.PLUGIN_FLAG"},
+		{"environment", "steps.flagConfigurationAction.spec.env.PLUGIN_ENVIRONMENT"},
+	},
 }
 
 // inside step.output
@@ -915,4 +1045,154 @@ var StepOutputConversionRules = map[string][]ConversionRule{
  
 	// HelmCanaryDelete -> EMPTY_OUTCOME
 	// HelmDelete -> EMPTY_OUTCOME
+
+	// ============================================================
+	// TERRAFORM / TERRAGRUNT / CLOUDFORMATION STEPS
+	// ============================================================
+
+	// TerraformPlan (sub-steps: terraformPlanAction)
+	StepTypeTerraformPlan: {
+		{"jsonFilePath", "steps.terraformPlanAction.output.outputVariables.PLUGIN_PLAN_JSON_FILE_PATH"},
+		{"humanReadableFilePath", "steps.terraformPlanAction.output.outputVariables.PLUGIN_PLAN_HUMAN_READABLE_FILE_PATH"},
+		{"detailedExitCode", "steps.terraformPlanAction.output.outputVariables.PLUGIN_DETAILED_EXIT_CODE"},
+	},
+
+	// TerraformApply (sub-steps: terraformApplyAction)
+	// The outcome holds the terraform outputs keyed by output name.
+	StepTypeTerraformApply: {
+		{"*", "steps.terraformApplyAction.output.outputVariables.*"},
+	},
+
+	// TerraformDestroy / TerraformRollback -> EMPTY_OUTCOME
+
+	// TerragruntPlan (sub-steps: terragruntPlanAction)
+	StepTypeTerragruntPlan: {
+		{"jsonFilePath", "steps.terragruntPlanAction.output.outputVariables.PLUGIN_PLAN_JSON_FILE_PATH"},
+	},
+
+	// TerragruntApply (sub-steps: terragruntApplyAction)
+	// The outcome holds the terraform outputs keyed by output name.
+	StepTypeTerragruntApply: {
+		{"*", "steps.terragruntApplyAction.output.outputVariables.*"},
+	},
+
+	// TerragruntDestroy / TerragruntRollback -> EMPTY_OUTCOME
+
+	// CreateStack (sub-steps: cloudformationCreateStackAction)
+	// The outcome holds the stack outputs keyed by output key.
+	StepTypeCloudformationCreateStack: {
+		{"*", "steps.cloudformationCreateStackAction.output.outputVariables.*"},
+	},
+
+	// RollbackStack (sub-steps: cloudformationRollbackStackAction)
+	StepTypeCloudformationRollbackStack: {
+		{"*", "steps.cloudformationRollbackStackAction.output.outputVariables.*"},
+	},
+
+	// DeleteStack -> EMPTY_OUTCOME
+
+	// ============================================================
+	// GOOGLE CLOUD FUNCTIONS / AWS LAMBDA STEPS
+	// ============================================================
+
+	// DeployCloudFunction (sub-steps: googleCloudFunctionDeployAction)
+	StepTypeDeployCloudFunction: {
+		{"functionName", "steps.googleCloudFunctionDeployAction.output.outputVariables.PLUGIN_FUNCTION_NAME"},
+		{"runtime", "steps.googleCloudFunctionDeployAction.output.outputVariables.PLUGIN_RUNTIME"},
+		{"url", "steps.googleCloudFunctionDeployAction.output.outputVariables.PLUGIN_FUNCTION_URL"},
+	},
+
+	// DeployCloudFunctionWithNoTraffic (sub-steps: googleCloudFunctionDeployAction)
+	StepTypeDeployCloudFunctionWithNoTraffic: {
+		{"functionName", "steps.googleCloudFunctionDeployAction.output.outputVariables.PLUGIN_FUNCTION_NAME"},
+		{"runtime", "steps.googleCloudFunctionDeployAction.output.outputVariables.PLUGIN_RUNTIME"},
+		{"url", "steps.googleCloudFunctionDeployAction.output.outputVariables.PLUGIN_FUNCTION_URL"},
+	},
+
+	// AwsLambdaDeploy (sub-steps: awsLambdaDeployAction)
+	StepTypeAwsLambdaDeploy: {
+		{"functionName", "steps.awsLambdaDeployAction.output.outputVariables.PLUGIN_FUNCTION_NAME"},
+		{"functionArn", "steps.awsLambdaDeployAction.output.outputVariables.PLUGIN_FUNCTION_ARN"},
+		{"version", "steps.awsLambdaDeployAction.output.outputVariables.PLUGIN_FUNCTION_VERSION"},
+		{"runtime", "steps.awsLambdaDeployAction.output.outputVariables.PLUGIN_RUNTIME"},
+	},
+
+	// ECS, Azure Web App, TAS and ASG steps -> NO_PLUGIN_OUTPUT
+
+	// ============================================================
+	// STO SCANNER STEPS
+	// ============================================================
+
+	// Every scanner publishes the same issue counters (sub-steps: <scanner>Action).
+	StepTypeAquaTrivy:    stoOutputRules("aquaTrivyAction"),
+	StepTypeSnyk:         stoOutputRules("snykAction"),
+	StepTypeSonarqube:    stoOutputRules("sonarqubeAction"),
+	StepTypeCheckmarx:    stoOutputRules("checkmarxAction"),
+	StepTypeGrype:        stoOutputRules("grypeAction"),
+	StepTypeWiz:          stoOutputRules("wizAction"),
+	StepTypeSemgrep:      stoOutputRules("semgrepAction"),
+	StepTypeGitleaks:     stoOutputRules("gitleaksAction"),
+	StepTypePrismaCloud:  stoOutputRules("prismaCloudAction"),
+	StepTypeCustomIngest: stoOutputRules("customIngestAction"),
+
+	// ============================================================
+	// GITOPS STEPS
+	// ============================================================
+
+	// GitOpsUpdateReleaseRepo (sub-steps: gitopsUpdateReleaseRepoAction)
+	StepTypeGitOpsUpdateReleaseRepo: {
+		{"prNumber", "steps.gitopsUpdateReleaseRepoAction.output.outputVariables.PLUGIN_PR_NUMBER"},
+		{"prlink", "steps.gitopsUpdateReleaseRepoAction.output.outputVariables.PLUGIN_PR_LINK"},
+		{"commitId", "steps.gitopsUpdateReleaseRepoAction.output.outputVariables.PLUGIN_COMMIT_ID"},
+		{"ref", "steps.gitopsUpdateReleaseRepoAction.output.outputVariables.PLUGIN_REF"},
+	},
+
+	// MergePR (sub-steps: gitopsMergePrAction)
+	StepTypeGitOpsMergePR: {
+		{"commitId", "steps.gitopsMergePrAction.output.outputVariables.PLUGIN_COMMIT_ID"},
+	},
+
+	// RevertPR (sub-steps: gitopsRevertPrAction)
+	StepTypeGitOpsRevertPR: {
+		{"prNumber", "steps.gitopsRevertPrAction.output.outputVariables.PLUGIN_PR_NUMBER"},
+		{"prlink", "steps.gitopsRevertPrAction.output.outputVariables.PLUGIN_PR_LINK"},
+		{"commitId", "steps.gitopsRevertPrAction.output.outputVariables.PLUGIN_COMMIT_ID"},
+	},
+
+	// GitOpsFetchLinkedApps (sub-steps: gitopsFetchLinkedAppsAction)
+	StepTypeGitOpsFetchLinkedApps: {
+		{"apps", "steps.gitopsFetchLinkedAppsAction.output.outputVariables.PLUGIN_APPS"},
+	},
+
+	// GitOpsSync -> EMPTY_OUTCOME
+
+	// ============================================================
+	// POLICY STEP
+	// ============================================================
+
+	// Policy (sub-steps: policyAction)
+	StepTypePolicy: {
+		{"status", "steps.policyAction.output.outputVariables.PLUGIN_POLICY_STATUS"},
+		{"evaluationId", "steps.policyAction.output.outputVariables.PLUGIN_EVALUATION_ID"},
+	},
+}
+
+// stoOutputVariables lists the output variables published by STO scanner steps.
+var stoOutputVariables = []string{
+	"CRITICAL", "HIGH", "MEDIUM", "LOW", "INFO", "UNASSIGNED", "TOTAL",
+	"NEW_CRITICAL", "NEW_HIGH", "NEW_MEDIUM", "NEW_LOW", "NEW_INFO", "NEW_UNASSIGNED", "NEW_TOTAL",
+	"EXTERNAL_POLICY_FAILURES", "JOB_ID",
+}
+
+// stoOutputRules returns the output rules of an STO scanner step whose
+// template runs the scan in the given sub-step.
+func stoOutputRules(actionID string) []ConversionRule {
+	rules := make([]ConversionRule, 0, len(stoOutputVariables))
+	for _, name := range stoOutputVariables {
+		rules = append(rules, ConversionRule{
+			From: "outputVariables." + name,
+			To:   "steps." + actionID + ".output.outputVariables." + name,
+		})
+	}
+	return rules
 }
\ No newline at end of file
@@ -868,7 +868,7 @@ func (t *Trie) tryContextMatchCandidates(node *TrieNode, parts []pathPart, index
 	if len(keys) == 0 {
 		return "", false
 	}
-	return t.bestContextMatchAmong(node, keys, parts, index, ctx, convContext)
+	return t.bestContextMatchAmong(node, keys, parts, index, ctx, convContext, 0)
 }
 
 // tryContextMatchAny is the deterministic fallback: try every available context
@@ -892,7 +892,10 @@ func (t *Trie) tryContextMatchAny(node *TrieNode, parts []pathPart, index int, c
 	if len(contextKeys) == 0 {
 		return "", false
 	}
-	result, matched := t.bestContextMatchAmong(node, contextKeys, parts, index, ctx, convContext)
+	// Require at least one named node match: a rule set whose only match is a
+	// root wildcard (e.g. TerraformApply outputs) would otherwise claim every
+	// path under the node.
+	result, matched := t.bestContextMatchAmong(node, contextKeys, parts, index, ctx, convContext, 1)
 	// Warn only when some step context was expected (FQN map or an explicit
 	// step type) but the type couldn't be pinned down — i.e. an unmapped
 	// template/approval `uses` or an unresolved candidate. Pure no-context
@@ -914,7 +917,8 @@ func contextExpected(cc *ConversionContext) bool {
 
 // bestContextMatchAmong tries each given context key's sub-trie and returns the
 // match with the highest node-match score; ties are broken alphabetically.
-func (t *Trie) bestContextMatchAmong(node *TrieNode, keys []string, parts []pathPart, index int, ctx *matchContext, convContext *ConversionContext) (string, bool) {
+// Matches scoring below minScore are discarded.
+func (t *Trie) bestContextMatchAmong(node *TrieNode, keys []string, parts []pathPart, index int, ctx *matchContext, convContext *ConversionContext, minScore int) (string, bool) {
 	sortStrings(keys)
 
 	var bestResult string
@@ -929,6 +933,9 @@ func (t *Trie) bestContextMatchAmong(node *TrieNode, keys []string, parts []path
 		result, matched := t.tryContextSubtree(contextRoot, parts, index, ctx, convContext)
 		if matched {
 			score := t.countContextNodeMatches(contextRoot, parts, index)
+			if score < minScore {
+				continue
+			}
 			if score > bestScore || (score == bestScore && (bestKey == "" || contextKey < bestKey)) {
 				bestScore = score
 				bestResult = result
@@ -965,10 +972,13 @@ func (t *Trie) isSkippedNodePassthrough(result string, ctx *matchContext, parts
 }
 
 // countContextNodeMatches counts the number of exact (non-wildcard) node matches
-// in a context subtree against the remaining input path parts.
+// in a context subtree against the remaining input path parts, up to the deepest
+// rule endpoint reached. A prefix that stops short of any endpoint (e.g. the
+// "outputVariables" segment of a multi-segment rule) does not count.
 // This is used to determine the best-match context when no context is provided.
 func (t *Trie) countContextNodeMatches(contextRoot *TrieNode, parts []pathPart, startIndex int) int {
 	score := 0
+	endScore := 0
 	currentNode := contextRoot
 
 	for i := startIndex; i < len(parts); i++ {
@@ -982,9 +992,12 @@ func (t *Trie) countContextNodeMatches(contextRoot *TrieNode, parts []pathPart,
 		} else {
 			break
 		}
+		if currentNode.isEnd {
+			endScore = score
+		}
 	}
 
-	return score
+	return endScore
 }
 
 // tryContextSubtree attempts to match through a specific context sub-trie
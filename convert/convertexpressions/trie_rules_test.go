@@ -691,6 +691,204 @@ func TestTrieRules_HelmSteps(t *testing.T) {
 	}
 }
 
+func TestTrieRules_ProvisionerSteps(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		context  *ConversionContext
+		expected string
+	}{
+		{
+			name:     "TerraformPlan jsonFilePath FQN",
+			path:     "pipeline.stages.deploy.spec.execution.steps.tfPlan.output.jsonFilePath",
+			context:  &ConversionContext{StepType: StepTypeTerraformPlan},
+			expected: "pipeline.stages.deploy.steps.tfPlan.steps.terraformPlanAction.output.outputVariables.PLUGIN_PLAN_JSON_FILE_PATH",
+		},
+		{
+			name:     "TerraformPlan humanReadableFilePath relative",
+			path:     "execution.steps.tfPlan.output.humanReadableFilePath",
+			context:  &ConversionContext{StepType: StepTypeTerraformPlan},
+			expected: "stage.steps.tfPlan.steps.terraformPlanAction.output.outputVariables.PLUGIN_PLAN_HUMAN_READABLE_FILE_PATH",
+		},
+		{
+			name:     "TerraformApply output FQN",
+			path:     "pipeline.stages.deploy.spec.execution.steps.tfApply.output.vpc_id",
+			context:  &ConversionContext{StepType: StepTypeTerraformApply},
+			expected: "pipeline.stages.deploy.steps.tfApply.steps.terraformApplyAction.output.outputVariables.vpc_id",
+		},
+		{
+			name:     "TerragruntApply output FQN",
+			path:     "pipeline.stages.deploy.spec.execution.steps.tgApply.output.cluster_name",
+			context:  &ConversionContext{StepType: StepTypeTerragruntApply},
+			expected: "pipeline.stages.deploy.steps.tgApply.steps.terragruntApplyAction.output.outputVariables.cluster_name",
+		},
+		{
+			name:     "CreateStack output FQN",
+			path:     "pipeline.stages.deploy.spec.execution.steps.createStack.output.BucketArn",
+			context:  &ConversionContext{StepType: StepTypeCloudformationCreateStack},
+			expected: "pipeline.stages.deploy.steps.createStack.steps.cloudformationCreateStackAction.output.outputVariables.BucketArn",
+		},
+	}
+
+	trie := buildPipelineTrie()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _ := trie.Match(tt.path, tt.context)
+			if result != tt.expected {
+				t.Errorf("Match() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTrieRules_DeploymentFamilySteps(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		context  *ConversionContext
+		expected string
+	}{
+		{
+			name:     "EcsBlueGreenCreateService prodListener FQN",
+			path:     "pipeline.stages.deploy.spec.execution.steps.bg.spec.prodListener",
+			context:  &ConversionContext{StepType: StepTypeEcsBlueGreenCreateService},
+			expected: "pipeline.stages.deploy.steps.bg.steps.ecsBlueGreenCreateServiceAction.spec.env.PLUGIN_PROD_LISTENER",
+		},
+		{
+			name:     "AzureSlotDeployment webApp relative",
+			path:     "execution.steps.slot.spec.webApp",
+			context:  &ConversionContext{StepType: StepTypeAzureSlotDeployment},
+			expected: "stage.steps.slot.steps.azureWebAppSlotDeployAction.spec.env.PLUGIN_WEB_APP",
+		},
+		{
+			name:     "AppResize newAppInstances FQN",
+			path:     "pipeline.stages.deploy.spec.execution.steps.resize.spec.newAppInstances.spec.value",
+			context:  &ConversionContext{StepType: StepTypeTasAppResize},
+			expected: "pipeline.stages.deploy.steps.resize.steps.tasAppResizeAction.spec.env.PLUGIN_NEW_APP_INSTANCES",
+		},
+		{
+			name:     "AsgRollingDeploy instanceWarmup FQN",
+			path:     "pipeline.stages.deploy.spec.execution.steps.rolling.spec.instanceWarmup",
+			context:  &ConversionContext{StepType: StepTypeAsgRollingDeploy},
+			expected: "pipeline.stages.deploy.steps.rolling.steps.asgRollingDeployAction.spec.env.PLUGIN_INSTANCE_WARMUP",
+		},
+		{
+			name:     "DeployCloudFunction url output FQN",
+			path:     "pipeline.stages.deploy.spec.execution.steps.gcf.output.url",
+			context:  &ConversionContext{StepType: StepTypeDeployCloudFunction},
+			expected: "pipeline.stages.deploy.steps.gcf.steps.googleCloudFunctionDeployAction.output.outputVariables.PLUGIN_FUNCTION_URL",
+		},
+		{
+			name:     "AwsLambdaDeploy functionArn output FQN",
+			path:     "pipeline.stages.deploy.spec.execution.steps.lambda.output.functionArn",
+			context:  &ConversionContext{StepType: StepTypeAwsLambdaDeploy},
+			expected: "pipeline.stages.deploy.steps.lambda.steps.awsLambdaDeployAction.output.outputVariables.PLUGIN_FUNCTION_ARN",
+		},
+	}
+
+	trie := buildPipelineTrie()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _ := trie.Match(tt.path, tt.context)
+			if result != tt.expected {
+				t.Errorf("Match() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTrieRules_STOSteps(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		context  *ConversionContext
+		expected string
+	}{
+		{
+			name:     "AquaTrivy CRITICAL output FQN",
+			path:     "pipeline.stages.build.spec.execution.steps.trivy.output.outputVariables.CRITICAL",
+			context:  &ConversionContext{StepType: StepTypeAquaTrivy},
+			expected: "pipeline.stages.build.steps.trivy.steps.aquaTrivyAction.output.outputVariables.CRITICAL",
+		},
+		{
+			name:     "Snyk NEW_HIGH output FQN",
+			path:     "pipeline.stages.build.spec.execution.steps.snyk.output.outputVariables.NEW_HIGH",
+			context:  &ConversionContext{StepType: StepTypeSnyk},
+			expected: "pipeline.stages.build.steps.snyk.steps.snykAction.output.outputVariables.NEW_HIGH",
+		},
+		{
+			name:     "Sonarqube TOTAL output FQN",
+			path:     "pipeline.stages.build.spec.execution.steps.sonar.output.outputVariables.TOTAL",
+			context:  &ConversionContext{StepType: StepTypeSonarqube},
+			expected: "pipeline.stages.build.steps.sonar.steps.sonarqubeAction.output.outputVariables.TOTAL",
+		},
+		{
+			name:     "CustomIngest LOW output FQN",
+			path:     "pipeline.stages.build.spec.execution.steps.ingest.output.outputVariables.LOW",
+			context:  &ConversionContext{StepType: StepTypeCustomIngest},
+			expected: "pipeline.stages.build.steps.ingest.steps.customIngestAction.output.outputVariables.LOW",
+		},
+	}
+
+	trie := buildPipelineTrie()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _ := trie.Match(tt.path, tt.context)
+			if result != tt.expected {
+				t.Errorf("Match() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTrieRules_GitOpsPolicySteps(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		context  *ConversionContext
+		expected string
+	}{
+		{
+			name:     "GitOpsUpdateReleaseRepo prNumber output FQN",
+			path:     "pipeline.stages.gitops.spec.execution.steps.updateRepo.output.prNumber",
+			context:  &ConversionContext{StepType: StepTypeGitOpsUpdateReleaseRepo},
+			expected: "pipeline.stages.gitops.steps.updateRepo.steps.gitopsUpdateReleaseRepoAction.output.outputVariables.PLUGIN_PR_NUMBER",
+		},
+		{
+			name:     "MergePR commitId output FQN",
+			path:     "pipeline.stages.gitops.spec.execution.steps.merge.output.commitId",
+			context:  &ConversionContext{StepType: StepTypeGitOpsMergePR},
+			expected: "pipeline.stages.gitops.steps.merge.steps.gitopsMergePrAction.output.outputVariables.PLUGIN_COMMIT_ID",
+		},
+		{
+			name:     "RevertPR prlink output FQN",
+			path:     "pipeline.stages.gitops.spec.execution.steps.revert.output.prlink",
+			context:  &ConversionContext{StepType: StepTypeGitOpsRevertPR},
+			expected: "pipeline.stages.gitops.steps.revert.steps.gitopsRevertPrAction.output.outputVariables.PLUGIN_PR_LINK",
+		},
+		{
+			name:     "Policy status output FQN",
+			path:     "pipeline.stages.gitops.spec.execution.steps.opa.output.status",
+			context:  &ConversionContext{StepType: StepTypePolicy},
+			expected: "pipeline.stages.gitops.steps.opa.steps.policyAction.output.outputVariables.PLUGIN_POLICY_STATUS",
+		},
+	}
+
+	trie := buildPipelineTrie()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _ := trie.Match(tt.path, tt.context)
+			if result != tt.expected {
+				t.Errorf("Match() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestTrieRules_BuildAndPushSteps(t *testing.T) {
 	tests := []struct {
 		name     string
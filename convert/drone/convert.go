@@ -21,8 +21,10 @@ import (
 	"io"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
 
+	"github.com/drone/go-convert/convert/drone/render"
 	v1 "github.com/drone/go-convert/convert/drone/yaml"
 	v2 "github.com/drone/spec/dist/go"
 
@@ -44,6 +46,10 @@ type Converter struct {
 	dockerhubConn string
 	identifiers   *store.Identifiers
 	orgSecrets    []string
+	secretManager string
+	secretScheme  string
+	format        Format
+	renderArgs    render.Args
 }
 
 var variableMap = map[string]string{
@@ -95,13 +101,7 @@ func New(options ...Option) *Converter {
 
 // Convert downgrades a v1 pipeline.
 func (d *Converter) Convert(r io.Reader) ([]byte, error) {
-	src, err := v1.Parse(r)
-	if err != nil {
-		return nil, err
-	}
-	return d.convert(&context{
-		pipeline: src,
-	})
+	return d.convertReader(r, d.format, "")
 }
 
 // ConvertString downgrades a v1 pipeline.
@@ -125,7 +125,25 @@ func (d *Converter) ConvertFile(p string) ([]byte, error) {
 		return nil, err
 	}
 	defer f.Close()
-	return d.Convert(f)
+
+	// if the format is not configured, the format is
+	// determined by the file extension.
+	format := d.format
+	if format == "" {
+		format = formatFromPath(p)
+	}
+	return d.convertReader(f, format, p)
+}
+
+// convertReader parses and converts a Drone pipeline.
+func (d *Converter) convertReader(r io.Reader, format Format, filename string) ([]byte, error) {
+	src, err := parse(r, format, filename, d.renderArgs)
+	if err != nil {
+		return nil, err
+	}
+	return d.convert(&context{
+		pipeline: src,
+	})
 }
 
 // converts converts a Drone pipeline to a Harness pipeline.
@@ -140,32 +158,73 @@ func (d *Converter) convert(ctx *context) ([]byte, error) {
 	//
 
 	// create the pipeline spec
-	pipeline := &v2.PipelineV1{}
+	pipeline := &pipelineV1{}
 
 	// create the harness pipeline resource
-	config := &v2.ConfigV1{
+	config := &configV1{
 		Pipeline: pipeline,
 	}
 
+	// create the secret store used to resolve secret
+	// names, including secrets sourced from an external
+	// secret manager using kind: secret documents.
+	secrets := newSecretStore(d.orgSecrets, d.secretManager, d.secretScheme, ctx.pipeline)
+	if err := secrets.err(); err != nil {
+		return nil, err
+	}
+
+	var stages []*stageV1
+	var froms []*v1.Pipeline
 	for _, from := range ctx.pipeline {
 		if from == nil {
 			continue
 		}
 
 		switch from.Kind {
-		case v1.KindSecret: // TODO
-		case v1.KindSignature: // TODO
+		case v1.KindSecret: // handled by the secret store
+		case v1.KindSignature: // not applicable
 		case v1.KindPipeline:
 			// TODO pipeline.name removed from spec
 			// pipeline.Name = from.Name
 			runtime := determineRuntime(from)
-			pipeline.Stages = append(pipeline.Stages, &v2.StageV1{
-				Name:    from.Name,
-				Clone:   convertCloneV1(&from.Clone),
-				Runtime: runtime,
-				Steps:   convertSteps(from, d.orgSecrets),
+			stages = append(stages, &stageV1{
+				Name:        from.Name,
+				If:          convertTrigger(from.Trigger),
+				Delegate:    convertNodeSorted(from.Node),
+				Concurrency: convertConcurrency(from),
+				Clone:       convertCloneV1(&from.Clone),
+				Runtime:     runtime,
+				Steps:       convertSteps(from, secrets),
+			})
+			froms = append(froms, from)
+		}
+	}
+
+	// if the pipelines define dependencies, group the
+	// stages such that independent stages execute in
+	// parallel. Otherwise stages execute sequentially.
+	if hasPipelineDeps(froms) {
+		names := make([]string, len(froms))
+		deps := make([][]string, len(froms))
+		for i, from := range froms {
+			names[i] = from.Name
+			deps[i] = from.Deps
+		}
+		for _, group := range groupByDependencies(names, deps) {
+			if len(group) == 1 {
+				pipeline.Stages = append(pipeline.Stages, stages[group[0]])
+				continue
+			}
+			parallel := &stageGroupV1{}
+			for _, i := range group {
+				parallel.Stages = append(parallel.Stages, stages[i])
+			}
+			pipeline.Stages = append(pipeline.Stages, &stageV1{
+				Parallel: parallel,
 			})
 		}
+	} else {
+		pipeline.Stages = stages
 	}
 
 	// marshal the harness yaml
@@ -225,15 +284,26 @@ func convertRegistry(src []*v1.Pipeline) *v2.Registry {
 	return dst
 }
 
-func convertSteps(src *v1.Pipeline, orgSecrets []string) []*v2.StepV1 {
-	var dst []*v2.StepV1
+func convertSteps(src *v1.Pipeline, secrets *secretStore) []*stepV1 {
+	var dst []*stepV1
+
+	// services are converted to background steps,
+	// which are started before all other steps.
+	for _, v := range src.Services {
+		if v != nil {
+			dst = append(dst, convertBackgroundV1(v, secrets))
+		}
+	}
+
+	var steps []*stepV1
+	var froms []*v1.Step
 	for _, v := range src.Steps {
 		if v != nil {
 			switch {
 			case v.Detach:
-				continue
+				steps = append(steps, convertBackgroundV1(v, secrets))
 			case isPlugin(v):
-				stepV1 := &v2.StepV1{
+				stepV1 := &stepV1{
 					Name: v.Name,
 				}
 				stepV1.RunSpec = v2.RunSpec{
@@ -241,35 +311,101 @@ func convertSteps(src *v1.Pipeline, orgSecrets []string) []*v2.StepV1 {
 						Image:     v.Image,
 						Connector: v.Connector,
 					},
-					With: convertSettings(v.Settings, orgSecrets),
-					Env:  convertVariables(v.Environment, orgSecrets),
+					With: convertSettings(v.Settings, secrets),
+					Env:  convertVariables(v.Environment, secrets),
 				}
-				dst = append(dst, stepV1)
+				steps = append(steps, stepV1)
 			default:
-				stepV1 := &v2.StepV1{
+				stepV1 := &stepV1{
 					Name: v.Name,
 					Run: &v2.RunSpec{
 						Container: &v2.ContainerSpec{
 							Image:     v.Image,
 							Connector: v.Connector,
 						},
-						Env:    convertVariables(v.Environment, orgSecrets),
+						Env:    convertVariables(v.Environment, secrets),
 						Script: joinCommands(v.Commands),
 					},
 				}
-				dst = append(dst, convertRun(stepV1, orgSecrets))
+				steps = append(steps, convertRun(stepV1))
 			}
+			froms = append(froms, v)
 		}
 	}
 
+	// if the steps do not define dependencies the steps
+	// execute sequentially.
+	if !hasStepDeps(froms) {
+		return append(dst, steps...)
+	}
+
+	// else group the steps such that independent steps
+	// execute in parallel.
+	names := make([]string, len(froms))
+	deps := make([][]string, len(froms))
+	for i, from := range froms {
+		names[i] = from.Name
+		deps[i] = from.DependsOn
+	}
+	for _, group := range groupByDependencies(names, deps) {
+		if len(group) == 1 {
+			dst = append(dst, steps[group[0]])
+			continue
+		}
+		parallel := &stepGroupV1{}
+		for _, i := range group {
+			parallel.Steps = append(parallel.Steps, steps[i])
+		}
+		dst = append(dst, &stepV1{
+			Parallel: parallel,
+		})
+	}
 	return dst
 }
 
+// helper function converts a Drone service or detached
+// step to a background step.
+func convertBackgroundV1(src *v1.Step, secrets *secretStore) *stepV1 {
+	return &stepV1{
+		Name: src.Name,
+		Background: &v2.RunSpec{
+			Container: &v2.ContainerSpec{
+				Image:     src.Image,
+				Connector: src.Connector,
+			},
+			Env:    convertVariables(src.Environment, secrets),
+			Script: convertScript(src.Commands),
+		},
+	}
+}
+
+// helper function returns true if any pipeline defines
+// pipeline dependencies.
+func hasPipelineDeps(src []*v1.Pipeline) bool {
+	for _, v := range src {
+		if len(v.Deps) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// helper function returns true if any step defines
+// step dependencies.
+func hasStepDeps(src []*v1.Step) bool {
+	for _, v := range src {
+		if len(v.DependsOn) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func joinCommands(commands []string) string {
 	return strings.Join(commands, "\n")
 }
 
-func convertPlugin(src *v1.Step, orgSecrets []string) *v2.Step {
+func convertPlugin(src *v1.Step, secrets *secretStore) *v2.Step {
 	return &v2.Step{
 		Name: src.Name,
 		Type: "plugin",
@@ -280,15 +416,15 @@ func convertPlugin(src *v1.Step, orgSecrets []string) *v2.Step {
 			Privileged: src.Privileged,
 			Pull:       convertPull(src.Pull),
 			User:       src.User,
-			Envs:       convertVariables(src.Environment, orgSecrets),
-			With:       convertSettings(src.Settings, orgSecrets),
+			Envs:       convertVariables(src.Environment, secrets),
+			With:       convertSettings(src.Settings, secrets),
 			Resources:  convertResourceLimits(&src.Resource),
 			// Volumes       // FIX
 		},
 	}
 }
 
-func convertBackground(src *v1.Step, orgSecrets []string) *v2.Step {
+func convertBackground(src *v1.Step, secrets *secretStore) *v2.Step {
 	return &v2.Step{
 		Name: src.Name,
 		Type: "background",
@@ -303,14 +439,14 @@ func convertBackground(src *v1.Step, orgSecrets []string) *v2.Step {
 			Entrypoint: convertEntrypoint(src.Entrypoint),
 			Args:       convertArgs(src.Entrypoint, src.Command),
 			Run:        convertScript(src.Commands),
-			Envs:       convertVariables(src.Environment, orgSecrets),
+			Envs:       convertVariables(src.Environment, secrets),
 			Resources:  convertResourceLimits(&src.Resource),
 			// Volumes       // FIX
 		},
 	}
 }
 
-func convertRun(src *v2.StepV1, orgSecrets []string) *v2.StepV1 {
+func convertRun(src *stepV1) *stepV1 {
 	runSpec := &v2.RunSpec{
 		With: src.Run.With,
 		Container: &v2.ContainerSpec{
@@ -321,7 +457,7 @@ func convertRun(src *v2.StepV1, orgSecrets []string) *v2.StepV1 {
 		Script: src.Run.Script,
 	}
 
-	return &v2.StepV1{
+	return &stepV1{
 		Name: src.Name,
 		Run:  runSpec,
 	}
@@ -359,24 +495,15 @@ func convertEntrypoint(src []string) string {
 	}
 }
 
-func convertVariables(src map[string]*v1.Variable, orgSecrets []string) map[string]string {
+func convertVariables(src map[string]*v1.Variable, secrets *secretStore) map[string]string {
 	dst := map[string]string{}
 
-	orgSecretsMap := make(map[string]bool, len(orgSecrets))
-	for _, secret := range orgSecrets {
-		orgSecretsMap[secret] = true
-	}
-
 	for k, v := range src {
 		switch {
 		case v.Value != "":
 			dst[sanitizeString(k)] = replaceVars(v.Value)
 		case v.Secret != "":
-			secretID := sanitizeString(v.Secret)
-			if _, exists := orgSecretsMap[secretID]; exists {
-				secretID = "org." + secretID
-			}
-			dst[k] = fmt.Sprintf("<+secrets.getValue(%q)>", secretID) // TODO figure out secret syntax
+			dst[k] = secrets.expr(v.Secret)
 		}
 	}
 	return dst
@@ -437,22 +564,13 @@ func convertMounts(src []*v1.VolumeMount) []*v2.Mount {
 	return dst
 }
 
-func convertSettings(src map[string]*v1.Parameter, orgSecrets []string) map[string]interface{} {
+func convertSettings(src map[string]*v1.Parameter, secrets *secretStore) map[string]interface{} {
 	dst := map[string]interface{}{}
 
-	orgSecretsMap := make(map[string]bool, len(orgSecrets))
-	for _, secret := range orgSecrets {
-		orgSecretsMap[secret] = true
-	}
-
 	for k, v := range src {
 		switch {
 		case v.Secret != "":
-			secretID := sanitizeString(v.Secret)
-			if _, exists := orgSecretsMap[secretID]; exists {
-				secretID = "org." + secretID
-			}
-			dst[k] = fmt.Sprintf("<+secrets.getValue(%q)>", secretID)
+			dst[k] = secrets.expr(v.Secret)
 		case v.Value != nil:
 			dst[k] = convertInterface(v.Value)
 		}
@@ -720,6 +838,174 @@ func convertCond(src v1.Conditions) *v2.When {
 	return dst
 }
 
+// helper function converts the Drone pipeline trigger
+// conditions to a Harness stage expression.
+//
+// TODO convert paths conditions
+// TODO convert status conditions
+func convertTrigger(src v1.Conditions) string {
+	var exprs []string
+	if expr := convertTriggerCond(src.Branch, "<+codebase.branch>"); expr != "" {
+		exprs = append(exprs, expr)
+	}
+	if expr := convertTriggerEvent(src.Event); expr != "" {
+		exprs = append(exprs, expr)
+	}
+	if expr := convertTriggerCond(src.Ref, "<+trigger.payload.ref>"); expr != "" {
+		exprs = append(exprs, expr)
+	}
+	if expr := convertTriggerCond(src.Repo, "<+trigger.payload.repository.full_name>"); expr != "" {
+		exprs = append(exprs, expr)
+	}
+	if expr := convertTriggerCond(src.Cron, "<+trigger.triggerName>"); expr != "" {
+		exprs = append(exprs, expr)
+	}
+	return strings.Join(exprs, " && ")
+}
+
+// helper function converts a Drone trigger condition to
+// an expression that compares the variable against the
+// included and excluded patterns.
+func convertTriggerCond(src v1.Condition, variable string) string {
+	var exprs []string
+	if len(src.Include) != 0 {
+		var include []string
+		for _, pattern := range src.Include {
+			include = append(include, convertPattern(variable, pattern, false))
+		}
+		exprs = append(exprs, wrapExprs(include, " || "))
+	}
+	for _, pattern := range src.Exclude {
+		exprs = append(exprs, convertPattern(variable, pattern, true))
+	}
+	return strings.Join(exprs, " && ")
+}
+
+// helper function converts a Drone glob pattern to an
+// equality or regular expression comparison.
+func convertPattern(variable, pattern string, negate bool) string {
+	if strings.ContainsAny(pattern, "*?[") {
+		op := "=~"
+		if negate {
+			op = "!~"
+		}
+		return fmt.Sprintf("%s %s %q", variable, op, globToRegexp(pattern))
+	}
+	op := "=="
+	if negate {
+		op = "!="
+	}
+	return fmt.Sprintf("%s %s %q", variable, op, pattern)
+}
+
+// helper function converts the Drone event trigger to an
+// expression that compares the Harness trigger event.
+func convertTriggerEvent(src v1.Condition) string {
+	var exprs []string
+	if len(src.Include) != 0 {
+		var include []string
+		for _, event := range src.Include {
+			if expr := convertEvent(event, false); expr != "" {
+				include = append(include, expr)
+			}
+		}
+		if len(include) != 0 {
+			exprs = append(exprs, wrapExprs(include, " || "))
+		}
+	}
+	for _, event := range src.Exclude {
+		if expr := convertEvent(event, true); expr != "" {
+			exprs = append(exprs, expr)
+		}
+	}
+	return strings.Join(exprs, " && ")
+}
+
+// helper function converts a Drone event to a Harness
+// trigger expression.
+func convertEvent(event string, negate bool) string {
+	eq := "=="
+	if negate {
+		eq = "!="
+	}
+	switch event {
+	case "push":
+		return fmt.Sprintf("<+trigger.event> %s %q", eq, "PUSH")
+	case "pull_request":
+		return fmt.Sprintf("<+trigger.event> %s %q", eq, "PR")
+	case "tag":
+		if negate {
+			return fmt.Sprintf("!<+trigger.payload.ref>.startsWith(%q)", "refs/tags/")
+		}
+		return fmt.Sprintf("<+trigger.payload.ref>.startsWith(%q)", "refs/tags/")
+	case "cron":
+		return fmt.Sprintf("<+trigger.type> %s %q", eq, "Scheduled")
+	case "custom", "promote", "rollback":
+		return fmt.Sprintf("<+trigger.type> %s %q", eq, "Manual")
+	default:
+		return ""
+	}
+}
+
+// helper function joins the expressions, wrapping in
+// parentheses if there is more than one expression.
+func wrapExprs(exprs []string, sep string) string {
+	if len(exprs) == 1 {
+		return exprs[0]
+	}
+	return "(" + strings.Join(exprs, sep) + ")"
+}
+
+// helper function converts a glob pattern to a regular
+// expression.
+func globToRegexp(pattern string) string {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			// a double star matches across path
+			// separators.
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				b.WriteString(".*")
+				i++
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[', ']':
+			b.WriteByte(c)
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return b.String()
+}
+
+// helper function converts the Drone node labels to a
+// sorted list of Harness delegate selectors.
+func convertNodeSorted(src map[string]string) []string {
+	dst := convertNode(src)
+	sort.Strings(dst)
+	return dst
+}
+
+// helper function converts the Drone concurrency limit
+// to a Harness concurrency group. Harness concurrency
+// groups limit execution to a single pipeline at a time.
+//
+// TODO support concurrency limits greater than one
+func convertConcurrency(src *v1.Pipeline) *concurrencyV1 {
+	if src.Concurrency.Limit != 1 {
+		return nil
+	}
+	return &concurrencyV1{
+		Group: src.Name,
+	}
+}
+
 func convertExpr(src v1.Condition) *v2.Expr {
 	if len(src.Include) != 0 {
 		return &v2.Expr{In: src.Include}
@@ -17,17 +17,27 @@ package drone
 import (
 	"io/ioutil"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/drone/go-convert/convert/drone/render"
 	"github.com/google/go-cmp/cmp"
 	"gopkg.in/yaml.v3"
 )
 
 func TestConvert(t *testing.T) {
-	tests, err := filepath.Glob("testdatav1/examples/*.yaml")
-	if err != nil {
-		t.Error(err)
-		return
+	var tests []string
+	for _, pattern := range []string{
+		"testdatav1/examples/*.yaml",
+		"testdatav1/examples/*.jsonnet",
+		"testdatav1/examples/*.star",
+	} {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		tests = append(tests, matches...)
 	}
 
 	for _, test := range tests {
@@ -39,6 +49,11 @@ func TestConvert(t *testing.T) {
 			// convert the yaml file from drone to harness
 			converter := New(
 				WithOrgSecrets(orgSecrets...),
+				WithSecretManager("vault"),
+				WithBuild(render.Build{
+					Branch: "main",
+					Event:  "push",
+				}),
 			)
 			tmp1, err := converter.ConvertFile(test)
 			if err != nil {
@@ -180,3 +195,65 @@ func TestReplaceVars(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertSecrets(t *testing.T) {
+	const pipeline = `
+kind: pipeline
+name: default
+steps:
+- name: test
+  image: golang
+  environment:
+    TOKEN:
+      from_secret: token
+  commands:
+  - go test ./...
+---
+kind: secret
+name: token
+`
+	tests := []struct {
+		doc     string
+		options []Option
+		want    string
+		err     bool
+	}{
+		{
+			doc:     "get:\n  path: secret/data/ci\n  name: token\n",
+			options: []Option{WithSecretManager("ci_vault")},
+			want:    `<+secrets.getValue("hashicorpvault://ci_vault/secret/data/ci#token")>`,
+		},
+		{
+			doc:     "get:\n  path: ci/token\n",
+			options: []Option{WithSecretManager("ci_secrets"), WithSecretScheme("customsecretmanager")},
+			want:    `<+secrets.getValue("customsecretmanager://ci_secrets/ci/token")>`,
+		},
+		{
+			// the secret manager is not configured.
+			doc: "get:\n  path: secret/data/ci\n  name: token\n",
+			err: true,
+		},
+		{
+			// encrypted secrets cannot be converted.
+			doc:     "data: Zm9vYmFy\n",
+			options: []Option{WithSecretManager("ci_vault")},
+			err:     true,
+		},
+	}
+	for i, test := range tests {
+		out, err := New(test.options...).ConvertString(pipeline + test.doc)
+		if test.err {
+			if err == nil {
+				t.Errorf("Want error at index %d", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error at index %d: %s", i, err)
+			continue
+		}
+		if got := string(out); !strings.Contains(got, test.want) {
+			t.Errorf("Want secret %s at index %d, got\n%s", test.want, i, got)
+		}
+	}
+}
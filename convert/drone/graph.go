@@ -0,0 +1,72 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drone
+
+// helper function groups the nodes of a dependency graph
+// into ordered groups, where each node in a group depends
+// only on nodes in preceding groups. Nodes within a group
+// can execute in parallel. The nodes are identified by
+// their index, and the order of nodes within a group
+// matches the source order. Dependencies on unknown nodes
+// are ignored, and cyclical dependencies are broken.
+func groupByDependencies(names []string, deps [][]string) [][]int {
+	if len(names) == 0 {
+		return nil
+	}
+
+	index := map[string]int{}
+	for i, name := range names {
+		if _, ok := index[name]; !ok {
+			index[name] = i
+		}
+	}
+
+	// compute the depth of each node, where the depth is
+	// the length of the longest path to a root node.
+	depth := make([]int, len(names))
+	state := make([]int, len(names)) // 0=unvisited, 1=visiting, 2=visited
+	var visit func(i int) int
+	visit = func(i int) int {
+		switch state[i] {
+		case 1:
+			return -1 // break the cycle
+		case 2:
+			return depth[i]
+		}
+		state[i] = 1
+		for _, dep := range deps[i] {
+			if j, ok := index[dep]; ok && j != i {
+				if d := visit(j) + 1; d > depth[i] {
+					depth[i] = d
+				}
+			}
+		}
+		state[i] = 2
+		return depth[i]
+	}
+
+	max := 0
+	for i := range names {
+		if d := visit(i); d > max {
+			max = d
+		}
+	}
+
+	groups := make([][]int, max+1)
+	for i := range names {
+		groups[depth[i]] = append(groups[depth[i]], i)
+	}
+	return groups
+}
@@ -0,0 +1,76 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drone
+
+import (
+	v2 "github.com/drone/spec/dist/go"
+)
+
+//
+// this file defines the harness v1 yaml structures emitted
+// by the converter. The structures mirror the v1 structures
+// in the spec package, with additional support for stage
+// and step conditions, parallel groups and background steps.
+//
+
+type (
+	// configV1 defines the harness v1 configuration.
+	configV1 struct {
+		Pipeline *pipelineV1 `json:"pipeline,omitempty"`
+	}
+
+	// pipelineV1 defines the harness v1 pipeline.
+	pipelineV1 struct {
+		Stages []*stageV1 `json:"stages,omitempty"`
+	}
+
+	// stageV1 defines a harness v1 stage.
+	stageV1 struct {
+		Name        string           `json:"name,omitempty"`
+		If          string           `json:"if,omitempty"`
+		Delegate    []string         `json:"delegate,omitempty"`
+		Concurrency *concurrencyV1   `json:"concurrency,omitempty"`
+		Clone       *v2.CloneStageV1 `json:"clone,omitempty"`
+		Runtime     string           `json:"runtime,omitempty"`
+		Steps       []*stepV1        `json:"steps,omitempty"`
+		Parallel    *stageGroupV1    `json:"parallel,omitempty"`
+	}
+
+	// stageGroupV1 defines a harness v1 group of stages.
+	stageGroupV1 struct {
+		Stages []*stageV1 `json:"stages"`
+	}
+
+	// concurrencyV1 defines the harness v1 concurrency
+	// group.
+	concurrencyV1 struct {
+		Group string `json:"group,omitempty"`
+	}
+
+	// stepV1 defines a harness v1 step. Plugin steps
+	// inline the run specification.
+	stepV1 struct {
+		Name       string       `json:"name,omitempty"`
+		Run        *v2.RunSpec  `json:"run,omitempty"`
+		Background *v2.RunSpec  `json:"background,omitempty"`
+		Parallel   *stepGroupV1 `json:"parallel,omitempty"`
+		v2.RunSpec
+	}
+
+	// stepGroupV1 defines a harness v1 group of steps.
+	stepGroupV1 struct {
+		Steps []*stepV1 `json:"steps"`
+	}
+)
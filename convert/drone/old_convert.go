@@ -23,8 +23,8 @@ import (
 	"os"
 	"regexp"
 	"strings"
-	"unsafe"
 
+	"github.com/drone/go-convert/convert/drone/render"
 	v1 "github.com/drone/go-convert/convert/drone/yaml"
 	v2 "github.com/drone/spec/dist/go"
 
@@ -47,6 +47,8 @@ type OldConverter struct {
 	dockerhubConn string
 	identifiers   *store.Identifiers
 	orgSecrets    []string
+	format        Format
+	renderArgs    render.Args
 }
 
 var oldVariableMap = map[string]string{
@@ -77,12 +79,18 @@ func NewOld(options ...func(*Converter)) *OldConverter {
 	// prevent duplicate names, unique index violations.
 	d.identifiers = store.New()
 
-	// loop through and apply the options.
-	// Convert *OldConverter to *Converter temporarily to use same options
-	converter := (*Converter)(unsafe.Pointer(d))
+	// loop through and apply the options to a Converter,
+	// and copy the options used by the old converter.
+	converter := new(Converter)
 	for _, option := range options {
 		option(converter)
 	}
+	d.kubeNamespace = converter.kubeNamespace
+	d.kubeConnector = converter.kubeConnector
+	d.dockerhubConn = converter.dockerhubConn
+	d.orgSecrets = converter.orgSecrets
+	d.format = converter.format
+	d.renderArgs = converter.renderArgs
 
 	// set the default kubernetes namespace.
 	if d.kubeNamespace == "" {
@@ -100,13 +108,7 @@ func NewOld(options ...func(*Converter)) *OldConverter {
 
 // ConvertOld downgrades a v1 pipeline.
 func (d *OldConverter) ConvertOld(r io.Reader) ([]byte, error) {
-	src, err := v1.Parse(r)
-	if err != nil {
-		return nil, err
-	}
-	return d.convertOld(&oldContext{
-		pipeline: src,
-	})
+	return d.convertReaderOld(r, d.format, "")
 }
 
 // ConvertBytesOld downgrades a v1 pipeline.
@@ -130,7 +132,25 @@ func (d *OldConverter) ConvertFileOld(p string) ([]byte, error) {
 		return nil, err
 	}
 	defer f.Close()
-	return d.ConvertOld(f)
+
+	// if the format is not configured, the format is
+	// determined by the file extension.
+	format := d.format
+	if format == "" {
+		format = formatFromPath(p)
+	}
+	return d.convertReaderOld(f, format, p)
+}
+
+// convertReaderOld parses and converts a Drone pipeline.
+func (d *OldConverter) convertReaderOld(r io.Reader, format Format, filename string) ([]byte, error) {
+	src, err := parse(r, format, filename, d.renderArgs)
+	if err != nil {
+		return nil, err
+	}
+	return d.convertOld(&oldContext{
+		pipeline: src,
+	})
 }
 
 // convertOld converts a Drone pipeline to a Harness pipeline.
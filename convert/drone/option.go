@@ -14,6 +14,8 @@
 
 package drone
 
+import "github.com/drone/go-convert/convert/drone/render"
+
 // Option configures a Converter option.
 type Option func(*Converter)
 
@@ -40,3 +42,58 @@ func WithOrgSecrets(secrets ...string) Option {
 		d.orgSecrets = secrets
 	}
 }
+
+// WithSecretManager returns an option to set the secret
+// manager connector used to resolve external secrets,
+// defined by kind: secret documents. External secrets
+// cannot be converted if the connector is not set.
+func WithSecretManager(connector string) Option {
+	return func(d *Converter) {
+		d.secretManager = connector
+	}
+}
+
+// WithSecretScheme returns an option to set the secret
+// reference scheme of the secret manager, for example
+// hashicorpvault. The default scheme is hashicorpvault.
+func WithSecretScheme(scheme string) Option {
+	return func(d *Converter) {
+		d.secretScheme = scheme
+	}
+}
+
+// WithFormat returns an option to set the configuration
+// file format. If unset, the format is determined by the
+// file extension, and defaults to yaml.
+func WithFormat(format Format) Option {
+	return func(d *Converter) {
+		d.format = format
+	}
+}
+
+// WithBuild returns an option to set the build context
+// used to evaluate Jsonnet and Starlark configuration
+// files.
+func WithBuild(build render.Build) Option {
+	return func(d *Converter) {
+		d.renderArgs.Build = build
+	}
+}
+
+// WithRepo returns an option to set the repository
+// context used to evaluate Jsonnet and Starlark
+// configuration files.
+func WithRepo(repo render.Repo) Option {
+	return func(d *Converter) {
+		d.renderArgs.Repo = repo
+	}
+}
+
+// WithRoot returns an option to set the repository root
+// directory. Jsonnet and Starlark configuration files can
+// only import files inside the root directory.
+func WithRoot(root string) Option {
+	return func(d *Converter) {
+		d.renderArgs.Root = root
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drone
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/drone/go-convert/convert/drone/render"
+	v1 "github.com/drone/go-convert/convert/drone/yaml"
+)
+
+// Format defines the Drone configuration file format.
+type Format string
+
+// Format enumeration.
+const (
+	FormatYAML     Format = "yaml"
+	FormatJsonnet  Format = "jsonnet"
+	FormatStarlark Format = "starlark"
+)
+
+// helper function returns the configuration file format
+// based on the file extension.
+func formatFromPath(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jsonnet", ".libsonnet":
+		return FormatJsonnet
+	case ".star", ".starlark", ".script":
+		return FormatStarlark
+	default:
+		return FormatYAML
+	}
+}
+
+// helper function parses the Drone configuration file. If
+// the configuration file is a Jsonnet or Starlark script,
+// the script is evaluated and the resulting documents are
+// parsed.
+func parse(r io.Reader, format Format, filename string, args render.Args) ([]*v1.Pipeline, error) {
+	if format == "" || format == FormatYAML {
+		return v1.Parse(r)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// if the repository root is not configured, imports
+	// are restricted to the configuration file directory.
+	if args.Root == "" && filename != "" {
+		args.Root = filepath.Dir(filename)
+	}
+
+	var out []byte
+	switch format {
+	case FormatJsonnet:
+		out, err = render.Jsonnet(filename, data, &args)
+	case FormatStarlark:
+		out, err = render.Starlark(filename, data, &args)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return v1.ParseBytes(out)
+}
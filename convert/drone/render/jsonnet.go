@@ -0,0 +1,71 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"os"
+
+	"github.com/google/go-jsonnet"
+)
+
+// Jsonnet evaluates the Jsonnet configuration file and
+// returns the pipeline documents in yaml format. The build
+// context is exposed to the configuration using external
+// variables (e.g. std.extVar("build.branch")).
+func Jsonnet(filename string, data []byte, args *Args) ([]byte, error) {
+	if args == nil {
+		args = new(Args)
+	}
+	vm := jsonnet.MakeVM()
+	vm.MaxStack = 500
+	vm.ErrorFormatter.SetMaxStackTraceSize(20)
+	vm.Importer(&importer{
+		root:  args.Root,
+		cache: map[string]jsonnet.Contents{},
+	})
+	for key, val := range args.vars() {
+		vm.ExtVar(key, val)
+	}
+	out, err := vm.EvaluateAnonymousSnippet(filename, string(data))
+	if err != nil {
+		return nil, err
+	}
+	return toDocuments([]byte(out))
+}
+
+// importer is a Jsonnet importer that restricts imports
+// to files inside the repository root.
+type importer struct {
+	root  string
+	cache map[string]jsonnet.Contents
+}
+
+// Import fetches the imported file.
+func (i *importer) Import(importedFrom, importedPath string) (jsonnet.Contents, string, error) {
+	path, err := resolve(i.root, importedFrom, importedPath)
+	if err != nil {
+		return jsonnet.Contents{}, "", err
+	}
+	if contents, ok := i.cache[path]; ok {
+		return contents, path, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return jsonnet.Contents{}, "", err
+	}
+	contents := jsonnet.MakeContentsRaw(data)
+	i.cache[path] = contents
+	return contents, path, nil
+}
@@ -0,0 +1,172 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package render evaluates Drone Jsonnet and Starlark
+// configuration files, and renders the resulting pipeline
+// documents to yaml.
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+)
+
+// ErrAccessDenied is returned when a configuration file
+// attempts to load a file outside the repository root.
+var ErrAccessDenied = errors.New("render: access denied outside the repository root")
+
+type (
+	// Args provides the build context used to evaluate
+	// the configuration file.
+	Args struct {
+		// Build defines the build being executed.
+		Build Build
+
+		// Repo defines the repository being built.
+		Repo Repo
+
+		// Root defines the repository root directory.
+		// Files outside the root directory cannot be
+		// imported or loaded. If empty, imports and
+		// loads are disabled.
+		Root string
+	}
+
+	// Build defines the build context.
+	Build struct {
+		Event       string `json:"event"`
+		Action      string `json:"action"`
+		Environment string `json:"environment"`
+		Link        string `json:"link"`
+		Branch      string `json:"branch"`
+		Source      string `json:"source"`
+		Target      string `json:"target"`
+		Ref         string `json:"ref"`
+		Commit      string `json:"commit"`
+		Title       string `json:"title"`
+		Message     string `json:"message"`
+		Author      string `json:"author_login"`
+		Sender      string `json:"sender"`
+	}
+
+	// Repo defines the repository context.
+	Repo struct {
+		Namespace string `json:"namespace"`
+		Name      string `json:"name"`
+		Slug      string `json:"slug"`
+		Link      string `json:"link"`
+		Branch    string `json:"branch"`
+		HTTPURL   string `json:"git_http_url"`
+		SSHURL    string `json:"git_ssh_url"`
+		Private   bool   `json:"private"`
+	}
+)
+
+// helper function returns the build context as a flat
+// map of string values, keyed by the Drone variable name
+// (e.g. build.branch, repo.slug).
+func (a *Args) vars() map[string]string {
+	repo := a.Repo
+	if repo.Slug == "" && repo.Name != "" {
+		repo.Slug = strings.TrimPrefix(repo.Namespace+"/"+repo.Name, "/")
+	}
+	private := "false"
+	if repo.Private {
+		private = "true"
+	}
+	return map[string]string{
+		"build.event":        a.Build.Event,
+		"build.action":       a.Build.Action,
+		"build.environment":  a.Build.Environment,
+		"build.link":         a.Build.Link,
+		"build.branch":       a.Build.Branch,
+		"build.source":       a.Build.Source,
+		"build.target":       a.Build.Target,
+		"build.ref":          a.Build.Ref,
+		"build.commit":       a.Build.Commit,
+		"build.title":        a.Build.Title,
+		"build.message":      a.Build.Message,
+		"build.author_login": a.Build.Author,
+		"build.sender":       a.Build.Sender,
+		"repo.namespace":     repo.Namespace,
+		"repo.name":          repo.Name,
+		"repo.slug":          repo.Slug,
+		"repo.link":          repo.Link,
+		"repo.branch":        repo.Branch,
+		"repo.git_http_url":  repo.HTTPURL,
+		"repo.git_ssh_url":   repo.SSHURL,
+		"repo.private":       private,
+	}
+}
+
+// helper function resolves the path relative to the
+// importing file, and returns an error if the resolved
+// path is outside the repository root.
+func resolve(root, from, path string) (string, error) {
+	if root == "" {
+		return "", ErrAccessDenied
+	}
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(from), path)
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+	path = filepath.Clean(path)
+
+	// resolve symbolic links to prevent links inside
+	// the repository from escaping the root.
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", ErrAccessDenied
+	}
+	return path, nil
+}
+
+// helper function converts the json output to a stream
+// of yaml documents. If the output is an array, each
+// element in the array is a separate document.
+func toDocuments(data []byte) ([]byte, error) {
+	var docs []json.RawMessage
+	if trimmed := bytes.TrimSpace(data); len(trimmed) != 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &docs); err != nil {
+			return nil, err
+		}
+	} else {
+		docs = append(docs, json.RawMessage(trimmed))
+	}
+
+	// json is a subset of yaml, so each document is
+	// written to the stream as-is.
+	buf := new(bytes.Buffer)
+	for _, doc := range docs {
+		buf.WriteString("---\n")
+		buf.Write(doc)
+		buf.WriteString("\n")
+	}
+	return buf.Bytes(), nil
+}
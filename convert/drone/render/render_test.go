@@ -0,0 +1,141 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestJsonnet(t *testing.T) {
+	src := `
+local branch = std.extVar('build.branch');
+{
+  kind: 'pipeline',
+  name: branch + '/' + std.extVar('repo.slug'),
+}
+`
+	args := &Args{
+		Build: Build{Branch: "main"},
+		Repo:  Repo{Namespace: "octocat", Name: "hello-world"},
+	}
+	got, err := Jsonnet(".drone.jsonnet", []byte(src), args)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	want := "---\n{\n   \"kind\": \"pipeline\",\n   \"name\": \"main/octocat/hello-world\"\n}\n"
+	if string(got) != want {
+		t.Errorf("Want rendered jsonnet %q, got %q", want, got)
+	}
+}
+
+func TestJsonnet_Stream(t *testing.T) {
+	src := `[{kind: 'pipeline', name: 'a'}, {kind: 'pipeline', name: 'b'}]`
+	got, err := Jsonnet(".drone.jsonnet", []byte(src), nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if n := strings.Count(string(got), "---\n"); n != 2 {
+		t.Errorf("Want 2 documents, got %d", n)
+	}
+}
+
+func TestJsonnet_ImportOutsideRoot(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "repo")
+	if err := os.Mkdir(root, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "secret.libsonnet"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "lib.libsonnet"), []byte("{name: 'lib'}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	filename := filepath.Join(root, ".drone.jsonnet")
+	args := &Args{Root: root}
+
+	if _, err := Jsonnet(filename, []byte(`import 'lib.libsonnet'`), args); err != nil {
+		t.Errorf("Want import inside the root to succeed, got %s", err)
+	}
+	if _, err := Jsonnet(filename, []byte(`import '../secret.libsonnet'`), args); err == nil {
+		t.Errorf("Want import outside the root to fail")
+	}
+	if _, err := Jsonnet(filename, []byte(`import 'lib.libsonnet'`), nil); err == nil {
+		t.Errorf("Want import without a root to fail")
+	}
+}
+
+func TestStarlark(t *testing.T) {
+	src := `
+def main(ctx):
+    return {
+        "kind": "pipeline",
+        "name": ctx.build.event + "/" + ctx.repo.name,
+    }
+`
+	args := &Args{
+		Build: Build{Event: "push"},
+		Repo:  Repo{Name: "hello-world"},
+	}
+	got, err := Starlark(".drone.star", []byte(src), args)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	want := "---\n{\"kind\":\"pipeline\",\"name\":\"push/hello-world\"}\n"
+	if string(got) != want {
+		t.Errorf("Want rendered starlark %q, got %q", want, got)
+	}
+}
+
+func TestStarlark_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{"missing main", `x = 1`},
+		{"invalid result", "def main(ctx):\n    return 1"},
+		{"infinite loop", "def main(ctx):\n    while True:\n        pass"},
+		{"load without root", `load("lib.star", "x")`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := Starlark(".drone.star", []byte(test.src), nil); err == nil {
+				t.Errorf("Want error")
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	root := t.TempDir()
+	from := filepath.Join(root, ".drone.star")
+
+	if _, err := resolve(root, from, "lib/util.star"); err != nil {
+		t.Errorf("Want path inside the root to resolve, got %s", err)
+	}
+	for _, path := range []string{"../util.star", "/etc/passwd", "lib/../../util.star"} {
+		if _, err := resolve(root, from, path); !errors.Is(err, ErrAccessDenied) {
+			t.Errorf("Want access denied for path %q, got %v", path, err)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.starlark.net/lib/json"
+	"go.starlark.net/lib/math"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+)
+
+// maxExecutionSteps limits the number of execution steps
+// to prevent infinite loops from blocking the converter.
+const maxExecutionSteps = 50000
+
+// Starlark evaluates the Starlark configuration file and
+// returns the pipeline documents in yaml format. The file
+// must define a main function that accepts the build
+// context (e.g. ctx.build.branch) and returns a pipeline
+// dictionary, or a list of pipeline dictionaries.
+func Starlark(filename string, data []byte, args *Args) ([]byte, error) {
+	if args == nil {
+		args = new(Args)
+	}
+	loader := &loader{
+		root:  args.Root,
+		cache: map[string]*loadEntry{},
+	}
+	thread := &starlark.Thread{
+		Name: "drone",
+		Load: loader.load,
+	}
+	thread.SetMaxExecutionSteps(maxExecutionSteps)
+
+	globals, err := starlark.ExecFileOptions(fileOptions, thread, filename, data, predeclared())
+	if err != nil {
+		return nil, err
+	}
+	main, ok := globals["main"]
+	if !ok {
+		return nil, errors.New("render: starlark main function not found")
+	}
+	ctx := starlark.Tuple{convertArgs(args)}
+	res, err := starlark.Call(thread, main, ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	switch res.(type) {
+	case *starlark.List, *starlark.Dict:
+	default:
+		return nil, fmt.Errorf("render: starlark main function returned %s, want dict or list", res.Type())
+	}
+	out, err := starlark.Call(thread, json.Module.Members["encode"], starlark.Tuple{res}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return toDocuments([]byte(out.(starlark.String).GoString()))
+}
+
+// fileOptions defines the starlark dialect, which matches
+// the dialect supported by Drone.
+var fileOptions = &syntax.FileOptions{
+	Set:             true,
+	While:           true,
+	TopLevelControl: true,
+	GlobalReassign:  true,
+	Recursion:       true,
+}
+
+// helper function returns the predeclared starlark
+// modules and functions.
+func predeclared() starlark.StringDict {
+	return starlark.StringDict{
+		"json":   json.Module,
+		"math":   math.Module,
+		"struct": starlark.NewBuiltin("struct", starlarkstruct.Make),
+	}
+}
+
+// helper function converts the build context to a
+// starlark struct.
+func convertArgs(args *Args) starlark.Value {
+	build := starlark.StringDict{}
+	repo := starlark.StringDict{}
+	for key, val := range args.vars() {
+		if name, ok := strings.CutPrefix(key, "build."); ok {
+			build[name] = starlark.String(val)
+		} else if name, ok := strings.CutPrefix(key, "repo."); ok {
+			repo[name] = starlark.String(val)
+		}
+	}
+	repo["private"] = starlark.Bool(args.Repo.Private)
+	return starlarkstruct.FromStringDict(starlark.String("context"), starlark.StringDict{
+		"build": starlarkstruct.FromStringDict(starlark.String("build"), build),
+		"repo":  starlarkstruct.FromStringDict(starlark.String("repo"), repo),
+	})
+}
+
+// loader loads starlark modules from files inside the
+// repository root.
+type loader struct {
+	root  string
+	cache map[string]*loadEntry
+}
+
+// loadEntry caches the result of loading a module. A nil
+// entry indicates the module is being loaded, and is used
+// to detect cycles.
+type loadEntry struct {
+	globals starlark.StringDict
+	err     error
+}
+
+func (l *loader) load(thread *starlark.Thread, module string) (starlark.StringDict, error) {
+	from := thread.CallFrame(0).Pos.Filename()
+	path, err := resolve(l.root, from, module)
+	if err != nil {
+		return nil, err
+	}
+	if e, ok := l.cache[path]; ok {
+		if e == nil {
+			return nil, fmt.Errorf("render: cycle in load graph: %s", module)
+		}
+		return e.globals, e.err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	l.cache[path] = nil
+	globals, err := starlark.ExecFileOptions(fileOptions, thread, path, data, predeclared())
+	l.cache[path] = &loadEntry{globals, err}
+	return globals, err
+}
@@ -0,0 +1,107 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drone
+
+import (
+	"errors"
+	"fmt"
+
+	v1 "github.com/drone/go-convert/convert/drone/yaml"
+)
+
+// defaultSecretScheme is the default secret reference
+// scheme used to resolve external secrets.
+const defaultSecretScheme = "hashicorpvault"
+
+// secretStore resolves Drone secret names to Harness
+// secret expressions.
+type secretStore struct {
+	// org defines secrets stored at the organization
+	// level in Harness.
+	org map[string]bool
+
+	// external defines secrets sourced from an external
+	// secret manager, using the kind: secret document.
+	external map[string]*v1.Secret
+
+	// manager defines the external secret manager
+	// connector identifier, and scheme defines the
+	// secret reference scheme of the secret manager.
+	manager string
+	scheme  string
+
+	// errs lists the kind: secret documents that cannot
+	// be converted.
+	errs []error
+}
+
+// helper function creates a secret store from the
+// organization secrets and kind: secret documents.
+func newSecretStore(orgSecrets []string, manager, scheme string, docs []*v1.Pipeline) *secretStore {
+	s := &secretStore{
+		org:      map[string]bool{},
+		external: map[string]*v1.Secret{},
+		manager:  manager,
+		scheme:   scheme,
+	}
+	if s.scheme == "" {
+		s.scheme = defaultSecretScheme
+	}
+	for _, secret := range orgSecrets {
+		s.org[secret] = true
+	}
+	for _, doc := range docs {
+		if doc == nil || doc.Kind != v1.KindSecret {
+			continue
+		}
+		switch {
+		case doc.Data != "":
+			// encrypted secrets can only be decrypted by
+			// the drone server.
+			s.errs = append(s.errs, fmt.Errorf("drone: secret %q is encrypted and cannot be converted, create the secret in harness and remove the secret document", doc.Name))
+		case doc.Get.Path == "":
+		case s.manager == "":
+			s.errs = append(s.errs, fmt.Errorf("drone: secret %q is sourced from %s, configure the secret manager connector to convert the secret", doc.Name, doc.Get.Path))
+		case doc.Name != "":
+			s.external[doc.Name] = &doc.Get
+		}
+	}
+	return s
+}
+
+// err returns an error listing the kind: secret documents
+// that cannot be converted, if any.
+func (s *secretStore) err() error {
+	return errors.Join(s.errs...)
+}
+
+// expr returns the Harness secret expression for the
+// named Drone secret. Secrets defined by a kind: secret
+// document are referenced directly in the external
+// secret manager.
+func (s *secretStore) expr(name string) string {
+	if get, ok := s.external[name]; ok {
+		ref := get.Path
+		if get.Name != "" {
+			ref = ref + "#" + get.Name
+		}
+		return fmt.Sprintf("<+secrets.getValue(%q)>", s.scheme+"://"+s.manager+"/"+ref)
+	}
+	id := sanitizeString(name)
+	if s.org[id] {
+		id = "org." + id
+	}
+	return fmt.Sprintf("<+secrets.getValue(%q)>", id)
+}
@@ -0,0 +1,16 @@
+{
+  pipeline(name, image):: {
+    kind: 'pipeline',
+    type: 'docker',
+    name: name,
+    steps: [
+      {
+        name: 'test',
+        image: image,
+        commands: [
+          'make test',
+        ],
+      },
+    ],
+  },
+}
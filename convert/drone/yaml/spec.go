@@ -25,7 +25,8 @@ type (
 		Node        map[string]string `json:"node,omitempty"`
 		Concurrency Concurrency       `json:"concurrency,omitempty"`
 		Platform    Platform          `json:"platform,omitempty"`
-		Data        Secret            `json:"secret,omitempty"`
+		Data        string            `json:"data,omitempty"`
+		Get         Secret            `json:"get,omitempty"`
 		Clone       Clone             `json:"clone,omitempty"`
 		Trigger     Conditions        `json:"conditions,omitempty"`
 		Environment map[string]string `json:"environment,omitempty"`
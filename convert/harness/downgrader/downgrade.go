@@ -578,6 +578,7 @@ func (d *Downgrader) convertStepGroup(src *v1.Step) *v0.StepGroup {
 		ID:      src.Id,
 		Name:    convertName(src.Name),
 		Timeout: convertTimeout(src.Timeout),
+		When:    convertStepWhen(src.When, src.Id),
 		Steps:   steps,
 	}
 }
@@ -957,7 +958,7 @@ func (d *Downgrader) convertStepPluginToTrivy(src *v1.Step) *v0.Step {
 	stepTrivy := &v0.StepTrivy{
 		Mode:       "orchestration",
 		Config:     "default",
-		Privileged: true,
+		Privileged: &flexible.Field[bool]{Value: true},
 		Target:     target,
 		Advanced:   advanced,
 		Image:      image,
@@ -1195,6 +1196,9 @@ func convertStepWhen(when *v1.When, stepId string) *flexible.Field[v0.StepWhen]
 		StageStatus: "Success", // default
 	}
 	var conditions []string
+	if when.Eval != "" {
+		conditions = append(conditions, when.Eval)
+	}
 
 	for _, cond := range when.Cond {
 		for k, v := range cond {
@@ -54,4 +54,18 @@ type (
 		Tags      *flexible.Field[map[string]string] `json:"tags,omitempty"      yaml:"tags,omitempty"`
 		MatchType string                             `json:"matchType,omitempty" yaml:"matchType,omitempty"`
 	}
-)
\ No newline at end of file
+
+	// EnvironmentEntity defines a standalone environment entity (the
+	// top-level environment: document).
+	EnvironmentEntity struct {
+		ID        string                             `json:"identifier,omitempty"        yaml:"identifier,omitempty"`
+		Name      string                             `json:"name,omitempty"              yaml:"name,omitempty"`
+		Desc      string                             `json:"description,omitempty"       yaml:"description,omitempty"`
+		Org       string                             `json:"orgIdentifier,omitempty"     yaml:"orgIdentifier,omitempty"`
+		Project   string                             `json:"projectIdentifier,omitempty" yaml:"projectIdentifier,omitempty"`
+		Tags      *flexible.Field[map[string]string] `json:"tags,omitempty"              yaml:"tags,omitempty"`
+		Type      string                             `json:"type,omitempty"              yaml:"type,omitempty"` // Production|PreProduction
+		Variables []*Variable                        `json:"variables,omitempty"         yaml:"variables,omitempty"`
+		Overrides *OverridesSpec                     `json:"overrides,omitempty"         yaml:"overrides,omitempty"`
+	}
+)
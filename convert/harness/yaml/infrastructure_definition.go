@@ -0,0 +1,22 @@
+package yaml
+
+import "github.com/drone/go-convert/internal/flexible"
+
+type (
+	// InfrastructureEntity defines a standalone infrastructure definition
+	// (the top-level infrastructureDefinition: document). The spec depends on
+	// the infrastructure type and keeps its v0 shape.
+	InfrastructureEntity struct {
+		ID                           string                             `json:"identifier,omitempty"                   yaml:"identifier,omitempty"`
+		Name                         string                             `json:"name,omitempty"                         yaml:"name,omitempty"`
+		Desc                         string                             `json:"description,omitempty"                  yaml:"description,omitempty"`
+		Org                          string                             `json:"orgIdentifier,omitempty"                yaml:"orgIdentifier,omitempty"`
+		Project                      string                             `json:"projectIdentifier,omitempty"            yaml:"projectIdentifier,omitempty"`
+		Tags                         *flexible.Field[map[string]string] `json:"tags,omitempty"                         yaml:"tags,omitempty"`
+		EnvironmentRef               string                             `json:"environmentRef,omitempty"               yaml:"environmentRef,omitempty"`
+		DeploymentType               string                             `json:"deploymentType,omitempty"               yaml:"deploymentType,omitempty"`
+		Type                         string                             `json:"type,omitempty"                         yaml:"type,omitempty"`
+		Spec                         interface{}                        `json:"spec,omitempty"                         yaml:"spec,omitempty"`
+		AllowSimultaneousDeployments *flexible.Field[bool]              `json:"allowSimultaneousDeployments,omitempty" yaml:"allowSimultaneousDeployments,omitempty"`
+	}
+)
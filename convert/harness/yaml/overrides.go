@@ -0,0 +1,35 @@
+package yaml
+
+// Override types.
+const (
+	OverrideTypeEnvGlobal    = "ENV_GLOBAL_OVERRIDE"
+	OverrideTypeEnvService   = "ENV_SERVICE_OVERRIDE"
+	OverrideTypeInfraGlobal  = "INFRA_GLOBAL_OVERRIDE"
+	OverrideTypeInfraService = "INFRA_SERVICE_OVERRIDE"
+)
+
+type (
+	// OverridesEntity defines a standalone service/environment override (the
+	// top-level overrides: document). The type selects which of the
+	// environment, service and infrastructure refs scope the override.
+	OverridesEntity struct {
+		ID              string         `json:"identifier,omitempty"        yaml:"identifier,omitempty"`
+		Org             string         `json:"orgIdentifier,omitempty"     yaml:"orgIdentifier,omitempty"`
+		Project         string         `json:"projectIdentifier,omitempty" yaml:"projectIdentifier,omitempty"`
+		Type            string         `json:"type,omitempty"              yaml:"type,omitempty"`
+		EnvironmentRef  string         `json:"environmentRef,omitempty"    yaml:"environmentRef,omitempty"`
+		ServiceRef      string         `json:"serviceRef,omitempty"        yaml:"serviceRef,omitempty"`
+		InfraIdentifier string         `json:"infraIdentifier,omitempty"   yaml:"infraIdentifier,omitempty"`
+		Spec            *OverridesSpec `json:"spec,omitempty"              yaml:"spec,omitempty"`
+	}
+
+	// OverridesSpec defines the values an override replaces. Manifests,
+	// config files and the Azure settings keep their v0 shape.
+	OverridesSpec struct {
+		Variables           []*Variable   `json:"variables,omitempty"           yaml:"variables,omitempty"`
+		Manifests           []interface{} `json:"manifests,omitempty"           yaml:"manifests,omitempty"`
+		ConfigFiles         []interface{} `json:"configFiles,omitempty"         yaml:"configFiles,omitempty"`
+		ApplicationSettings interface{}   `json:"applicationSettings,omitempty" yaml:"applicationSettings,omitempty"`
+		ConnectionStrings   interface{}   `json:"connectionStrings,omitempty"   yaml:"connectionStrings,omitempty"`
+	}
+)
@@ -19,10 +19,14 @@ import "github.com/drone/go-convert/internal/flexible"
 type (
 	// Config defines resource configuration.
 	Config struct {
-		Pipeline Pipeline  `json:"pipeline,omitempty" yaml:"pipeline,omitempty"`
-		Template *Template `json:"template,omitempty" yaml:"template,omitempty"`
-		InputSet *InputSet `json:"inputSet,omitempty" yaml:"inputSet,omitempty"`
-		Trigger  *Trigger  `json:"trigger,omitempty"  yaml:"trigger,omitempty"`
+		Pipeline       Pipeline              `json:"pipeline,omitempty"                 yaml:"pipeline,omitempty"`
+		Template       *Template             `json:"template,omitempty"                 yaml:"template,omitempty"`
+		InputSet       *InputSet             `json:"inputSet,omitempty"                 yaml:"inputSet,omitempty"`
+		Trigger        *Trigger              `json:"trigger,omitempty"                  yaml:"trigger,omitempty"`
+		Service        *ServiceEntity        `json:"service,omitempty"                  yaml:"service,omitempty"`
+		Environment    *EnvironmentEntity    `json:"environment,omitempty"              yaml:"environment,omitempty"`
+		Infrastructure *InfrastructureEntity `json:"infrastructureDefinition,omitempty" yaml:"infrastructureDefinition,omitempty"`
+		Overrides      *OverridesEntity      `json:"overrides,omitempty"                yaml:"overrides,omitempty"`
 	}
 
 	// Pipeline defines a pipeline.
@@ -156,20 +160,36 @@ type (
 	STOTarget struct {
 		Type      string `json:"type" yaml:"type,omitempty"`
 		Detection string `json:"detection" yaml:"detection,omitempty"`
+		Name      string `json:"name,omitempty"      yaml:"name,omitempty"`
+		Variant   string `json:"variant,omitempty"   yaml:"variant,omitempty"`
+		Workspace string `json:"workspace,omitempty" yaml:"workspace,omitempty"`
 	}
 
 	STOAdvanced struct {
-		Log *STOAdvancedLog `json:"log" yaml:"log,omitempty"`
+		Log            *STOAdvancedLog       `json:"log" yaml:"log,omitempty"`
+		Args           *STOAdvancedArgs      `json:"args,omitempty"             yaml:"args,omitempty"`
+		FailOnSeverity string                `json:"fail_on_severity,omitempty" yaml:"fail_on_severity,omitempty"`
+		IncludeRaw     *flexible.Field[bool] `json:"include_raw,omitempty"      yaml:"include_raw,omitempty"`
 	}
 
 	STOAdvancedLog struct {
-		Level string `json:"level" yaml:"level,omitempty"`
+		Level string                `json:"level" yaml:"level,omitempty"`
+		Cli   *flexible.Field[bool] `json:"cli,omitempty" yaml:"cli,omitempty"`
+	}
+
+	STOAdvancedArgs struct {
+		Cli         string `json:"cli,omitempty"         yaml:"cli,omitempty"`
+		Passthrough string `json:"passthrough,omitempty" yaml:"passthrough,omitempty"`
 	}
 
 	STOImage struct {
-		Tag  string `json:"tag,omitempty"   yaml:"tag,omitempty"`
-		Name string `json:"name,omitempty"         yaml:"name,omitempty"`
-		Type string `json:"type,omitempty"         yaml:"type,omitempty"`
+		Tag         string `json:"tag,omitempty"   yaml:"tag,omitempty"`
+		Name        string `json:"name,omitempty"         yaml:"name,omitempty"`
+		Type        string `json:"type,omitempty"         yaml:"type,omitempty"`
+		Domain      string `json:"domain,omitempty"       yaml:"domain,omitempty"`
+		AccessId    string `json:"access_id,omitempty"    yaml:"access_id,omitempty"`
+		AccessToken string `json:"access_token,omitempty" yaml:"access_token,omitempty"`
+		Region      string `json:"region,omitempty"       yaml:"region,omitempty"`
 	}
 
 	ReportJunit struct {
@@ -126,4 +126,38 @@ type (
 		Identifier string `json:"identifier,omitempty" yaml:"identifier,omitempty"`
 		Spec       *Store `json:"spec,omitempty"       yaml:"spec,omitempty"`
 	}
-)
\ No newline at end of file
+
+	// ServiceEntity defines a standalone service entity (the top-level
+	// service: document), as opposed to a service reference in a stage.
+	ServiceEntity struct {
+		ID                string                             `json:"identifier,omitempty"        yaml:"identifier,omitempty"`
+		Name              string                             `json:"name,omitempty"              yaml:"name,omitempty"`
+		Desc              string                             `json:"description,omitempty"       yaml:"description,omitempty"`
+		Org               string                             `json:"orgIdentifier,omitempty"     yaml:"orgIdentifier,omitempty"`
+		Project           string                             `json:"projectIdentifier,omitempty" yaml:"projectIdentifier,omitempty"`
+		Tags              *flexible.Field[map[string]string] `json:"tags,omitempty"              yaml:"tags,omitempty"`
+		GitOpsEnabled     *flexible.Field[bool]              `json:"gitOpsEnabled,omitempty"     yaml:"gitOpsEnabled,omitempty"`
+		ServiceDefinition *ServiceEntityDefinition           `json:"serviceDefinition,omitempty" yaml:"serviceDefinition,omitempty"`
+	}
+
+	// ServiceEntityDefinition defines the deployment type and spec of a
+	// standalone service.
+	ServiceEntityDefinition struct {
+		Type string             `json:"type,omitempty" yaml:"type,omitempty"`
+		Spec *ServiceEntitySpec `json:"spec,omitempty" yaml:"spec,omitempty"`
+	}
+
+	// ServiceEntitySpec defines the service variables and deployable sources.
+	// Manifests, artifacts and config files keep their v0 shape.
+	ServiceEntitySpec struct {
+		Variables              []*Variable             `json:"variables,omitempty"              yaml:"variables,omitempty"`
+		Manifests              []interface{}           `json:"manifests,omitempty"              yaml:"manifests,omitempty"`
+		Artifacts              interface{}             `json:"artifacts,omitempty"              yaml:"artifacts,omitempty"`
+		ConfigFiles            []interface{}           `json:"configFiles,omitempty"            yaml:"configFiles,omitempty"`
+		ManifestConfigurations *ManifestConfigurations `json:"manifestConfigurations,omitempty" yaml:"manifestConfigurations,omitempty"`
+		Hooks                  []interface{}           `json:"hooks,omitempty"                  yaml:"hooks,omitempty"`
+		ApplicationSettings    interface{}             `json:"applicationSettings,omitempty"    yaml:"applicationSettings,omitempty"`
+		ConnectionStrings      interface{}             `json:"connectionStrings,omitempty"      yaml:"connectionStrings,omitempty"`
+		StartupCommand         interface{}             `json:"startupCommand,omitempty"         yaml:"startupCommand,omitempty"`
+	}
+)
@@ -200,10 +200,6 @@ type (
 		RemoteCacheImage       string                             `json:"remoteCacheImage,omitempty"        yaml:"remoteCacheImage,omitempty"`
 	}
 
-	StepFlagConfiguration struct {
-		// TODO
-	}
-
 	StepGCSUpload struct {
 		CommonStepSpec
 		ConnectorRef string               `json:"connectorRef,omitempty" yaml:"connectorRef,omitempty"`
@@ -374,16 +370,6 @@ type (
 		Resources        *Resources                `json:"resources,omitempty"     yaml:"resources,omitempty"`
 	}
 
-	StepTrivy struct {
-		CommonStepSpec
-		Mode       string       `json:"mode,omitempty"    yaml:"mode,omitempty"`
-		Config     string       `json:"config,omitempty"         yaml:"config,omitempty"`
-		Target     *STOTarget   `json:"target,omitempty"         yaml:"target,omitempty"`
-		Advanced   *STOAdvanced `json:"advanced,omitempty"         yaml:"advanced,omitempty"`
-		Privileged bool         `json:"privileged,omitempty"      yaml:"privileged,omitempty"`
-		Image      *STOImage    `json:"image,omitempty"      yaml:"image,omitempty"`
-	}
-
 	StepDocker struct {
 		CommonStepSpec
 		BuildsArgs      map[string]string `json:"buildArgs,omitempty"       yaml:"buildArgs,omitempty"`
@@ -861,6 +847,24 @@ func (s *Step) UnmarshalJSON(data []byte) error {
 		s.Spec = new(StepHelmRollback)
 	case StepTypeWait:
 		s.Spec = new(StepWait)
+	case StepTypeFlagConfiguration:
+		s.Spec = new(StepFlagConfiguration)
+	case StepTypeVerify:
+		s.Spec = new(StepVerify)
+	case StepTypeChaos:
+		s.Spec = new(StepChaos)
+	case StepTypeGitOpsUpdateReleaseRepo:
+		s.Spec = new(StepGitOpsUpdateReleaseRepo)
+	case StepTypeGitOpsMergePR:
+		s.Spec = new(StepGitOpsMergePR)
+	case StepTypeGitOpsFetchLinkedApps:
+		s.Spec = new(StepGitOpsFetchLinkedApps)
+	case StepTypeGitOpsSync:
+		s.Spec = new(StepGitOpsSync)
+	case StepTypeGitOpsRevertPR:
+		s.Spec = new(StepGitOpsRevertPR)
+	case StepTypePolicy:
+		s.Spec = new(StepPolicy)
 	case StepTypeEmail:
 		s.Spec = new(StepEmail)
 	case StepTypeSaveCacheS3:
@@ -889,6 +893,91 @@ func (s *Step) UnmarshalJSON(data []byte) error {
 		s.Spec = new(StepServiceNowCreate)
 	case StepTypeServiceNowUpdate:
 		s.Spec = new(StepServiceNowUpdate)
+	case StepTypeTerraformPlan:
+		s.Spec = new(StepTerraformPlan)
+	case StepTypeTerraformApply:
+		s.Spec = new(StepTerraformApply)
+	case StepTypeTerraformDestroy:
+		s.Spec = new(StepTerraformDestroy)
+	case StepTypeTerraformRollback:
+		s.Spec = new(StepTerraformRollback)
+	case StepTypeTerragruntPlan:
+		s.Spec = new(StepTerragruntPlan)
+	case StepTypeTerragruntApply:
+		s.Spec = new(StepTerragruntApply)
+	case StepTypeTerragruntDestroy:
+		s.Spec = new(StepTerragruntDestroy)
+	case StepTypeTerragruntRollback:
+		s.Spec = new(StepTerragruntRollback)
+	case StepTypeCloudformationCreateStack:
+		s.Spec = new(StepCloudformationCreateStack)
+	case StepTypeCloudformationDeleteStack:
+		s.Spec = new(StepCloudformationDeleteStack)
+	case StepTypeCloudformationRollbackStack:
+		s.Spec = new(StepCloudformationRollbackStack)
+	case StepTypeEcsRollingDeploy:
+		s.Spec = new(StepEcsRollingDeploy)
+	case StepTypeEcsRollingRollback:
+		s.Spec = new(StepEcsRollingRollback)
+	case StepTypeEcsCanaryDeploy:
+		s.Spec = new(StepEcsCanaryDeploy)
+	case StepTypeEcsCanaryDelete:
+		s.Spec = new(StepEcsCanaryDelete)
+	case StepTypeEcsBlueGreenCreateService:
+		s.Spec = new(StepEcsBlueGreenCreateService)
+	case StepTypeEcsBlueGreenSwapTargetGroups:
+		s.Spec = new(StepEcsBlueGreenSwapTargetGroups)
+	case StepTypeEcsBlueGreenRollback:
+		s.Spec = new(StepEcsBlueGreenRollback)
+	case StepTypeEcsRunTask:
+		s.Spec = new(StepEcsRunTask)
+	case StepTypeAzureSlotDeployment:
+		s.Spec = new(StepAzureSlotDeployment)
+	case StepTypeAzureTrafficShift:
+		s.Spec = new(StepAzureTrafficShift)
+	case StepTypeAzureSwapSlot:
+		s.Spec = new(StepAzureSwapSlot)
+	case StepTypeAzureWebAppRollback:
+		s.Spec = new(StepAzureWebAppRollback)
+	case StepTypeTasBasicAppSetup:
+		s.Spec = new(StepTasBasicAppSetup)
+	case StepTypeTasBGAppSetup:
+		s.Spec = new(StepTasBGAppSetup)
+	case StepTypeTasCanaryAppSetup:
+		s.Spec = new(StepTasCanaryAppSetup)
+	case StepTypeTasAppResize:
+		s.Spec = new(StepTasAppResize)
+	case StepTypeTasSwapRoutes:
+		s.Spec = new(StepTasSwapRoutes)
+	case StepTypeTasSwapRollback:
+		s.Spec = new(StepTasSwapRollback)
+	case StepTypeTasAppRollback:
+		s.Spec = new(StepTasAppRollback)
+	case StepTypeAsgRollingDeploy:
+		s.Spec = new(StepAsgRollingDeploy)
+	case StepTypeAsgRollingRollback:
+		s.Spec = new(StepAsgRollingRollback)
+	case StepTypeAsgBlueGreenDeploy:
+		s.Spec = new(StepAsgBlueGreenDeploy)
+	case StepTypeAsgBlueGreenSwapService:
+		s.Spec = new(StepAsgBlueGreenSwapService)
+	case StepTypeAsgBlueGreenRollback:
+		s.Spec = new(StepAsgBlueGreenRollback)
+	case StepTypeDeployCloudFunction:
+		s.Spec = new(StepDeployCloudFunction)
+	case StepTypeDeployCloudFunctionWithNoTraffic:
+		s.Spec = new(StepDeployCloudFunctionWithNoTraffic)
+	case StepTypeCloudFunctionTrafficShift:
+		s.Spec = new(StepCloudFunctionTrafficShift)
+	case StepTypeCloudFunctionRollback:
+		s.Spec = new(StepCloudFunctionRollback)
+	case StepTypeAwsLambdaDeploy:
+		s.Spec = new(StepAwsLambdaDeploy)
+	case StepTypeAwsLambdaRollback:
+		s.Spec = new(StepAwsLambdaRollback)
+	case StepTypeAquaTrivy, StepTypeSnyk, StepTypeSonarqube, StepTypeCheckmarx, StepTypeGrype, StepTypeWiz,
+		StepTypeSemgrep, StepTypeGitleaks, StepTypePrismaCloud, StepTypeCustomIngest:
+		s.Spec = new(StepSTOScan)
 	case StepTypeIACMTerraformPlugin:
 		s.Spec = new(StepIACMTerraformPlugin)
 	case StepTypeIACMOpenTofuPlugin:
@@ -0,0 +1,48 @@
+package yaml
+
+import "github.com/drone/go-convert/internal/flexible"
+
+// v0 struct ports of the harness-core AWS Auto Scaling Group (ASG) CD step
+// specs. Field yaml tags match the *StepInfo.java JSON keys.
+type (
+	// CD: ASG Rolling Deploy (harness-core AsgRollingDeployStepInfo)
+	StepAsgRollingDeploy struct {
+		CommonStepSpec
+		UseAlreadyRunningInstances *flexible.Field[bool] `json:"useAlreadyRunningInstances,omitempty" yaml:"useAlreadyRunningInstances,omitempty"`
+		MinimumHealthyPercentage   *flexible.Field[int]  `json:"minimumHealthyPercentage,omitempty"   yaml:"minimumHealthyPercentage,omitempty"`
+		InstanceWarmup             *flexible.Field[int]  `json:"instanceWarmup,omitempty"             yaml:"instanceWarmup,omitempty"`
+		SkipMatching               *flexible.Field[bool] `json:"skipMatching,omitempty"               yaml:"skipMatching,omitempty"`
+	}
+
+	// CD: ASG Rolling Rollback (harness-core AsgRollingRollbackStepInfo)
+	StepAsgRollingRollback struct {
+		CommonStepSpec
+	}
+
+	// CD: ASG Blue Green Deploy (harness-core AsgBlueGreenDeployStepInfo)
+	StepAsgBlueGreenDeploy struct {
+		CommonStepSpec
+		LoadBalancers              []*AsgLoadBalancer    `json:"loadBalancers,omitempty"              yaml:"loadBalancers,omitempty"`
+		UseAlreadyRunningInstances *flexible.Field[bool] `json:"useAlreadyRunningInstances,omitempty" yaml:"useAlreadyRunningInstances,omitempty"`
+	}
+
+	// AsgLoadBalancer is a load balancer with its prod and stage listeners.
+	AsgLoadBalancer struct {
+		LoadBalancer         string `json:"loadBalancer,omitempty"         yaml:"loadBalancer,omitempty"`
+		ProdListener         string `json:"prodListener,omitempty"         yaml:"prodListener,omitempty"`
+		ProdListenerRuleArn  string `json:"prodListenerRuleArn,omitempty"  yaml:"prodListenerRuleArn,omitempty"`
+		StageListener        string `json:"stageListener,omitempty"        yaml:"stageListener,omitempty"`
+		StageListenerRuleArn string `json:"stageListenerRuleArn,omitempty" yaml:"stageListenerRuleArn,omitempty"`
+	}
+
+	// CD: ASG Blue Green Swap Service (harness-core AsgBlueGreenSwapServiceStepInfo)
+	StepAsgBlueGreenSwapService struct {
+		CommonStepSpec
+		DownsizeOldAsg *flexible.Field[bool] `json:"downsizeOldAsg,omitempty" yaml:"downsizeOldAsg,omitempty"`
+	}
+
+	// CD: ASG Blue Green Rollback (harness-core AsgBlueGreenRollbackStepInfo)
+	StepAsgBlueGreenRollback struct {
+		CommonStepSpec
+	}
+)
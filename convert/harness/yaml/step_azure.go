@@ -0,0 +1,32 @@
+package yaml
+
+import "github.com/drone/go-convert/internal/flexible"
+
+// v0 struct ports of the harness-core Azure Web App CD step specs. Field yaml
+// tags match the *StepInfo.java JSON keys.
+type (
+	// CD: Azure Web App Slot Deployment (harness-core AzureWebAppSlotDeploymentStepInfo)
+	StepAzureSlotDeployment struct {
+		CommonStepSpec
+		WebApp         string                `json:"webApp,omitempty"         yaml:"webApp,omitempty"`
+		DeploymentSlot string                `json:"deploymentSlot,omitempty" yaml:"deploymentSlot,omitempty"`
+		Clean          *flexible.Field[bool] `json:"clean,omitempty"          yaml:"clean,omitempty"`
+	}
+
+	// CD: Azure Web App Traffic Shift (harness-core AzureWebAppTrafficShiftStepInfo)
+	StepAzureTrafficShift struct {
+		CommonStepSpec
+		Traffic string `json:"traffic,omitempty" yaml:"traffic,omitempty"`
+	}
+
+	// CD: Azure Web App Swap Slot (harness-core AzureWebAppSwapSlotStepInfo)
+	StepAzureSwapSlot struct {
+		CommonStepSpec
+		TargetSlot string `json:"targetSlot,omitempty" yaml:"targetSlot,omitempty"`
+	}
+
+	// CD: Azure Web App Rollback (harness-core AzureWebAppRollbackStepInfo)
+	StepAzureWebAppRollback struct {
+		CommonStepSpec
+	}
+)
@@ -0,0 +1,63 @@
+package yaml
+
+import "github.com/drone/go-convert/internal/flexible"
+
+// v0 struct ports of the harness-core ECS CD step specs. Field yaml tags match
+// the *StepInfo.java JSON keys.
+type (
+	// CD: ECS Rolling Deploy (harness-core EcsRollingDeployStepInfo)
+	StepEcsRollingDeploy struct {
+		CommonStepSpec
+		SameAsAlreadyRunningInstances *flexible.Field[bool] `json:"sameAsAlreadyRunningInstances,omitempty" yaml:"sameAsAlreadyRunningInstances,omitempty"`
+		ForceNewDeployment            *flexible.Field[bool] `json:"forceNewDeployment,omitempty"            yaml:"forceNewDeployment,omitempty"`
+	}
+
+	// CD: ECS Rolling Rollback (harness-core EcsRollingRollbackStepInfo)
+	StepEcsRollingRollback struct {
+		CommonStepSpec
+	}
+
+	// CD: ECS Canary Deploy (harness-core EcsCanaryDeployStepInfo)
+	StepEcsCanaryDeploy struct {
+		CommonStepSpec
+	}
+
+	// CD: ECS Canary Delete (harness-core EcsCanaryDeleteStepInfo)
+	StepEcsCanaryDelete struct {
+		CommonStepSpec
+	}
+
+	// CD: ECS Blue Green Create Service (harness-core EcsBlueGreenCreateServiceStepInfo)
+	StepEcsBlueGreenCreateService struct {
+		CommonStepSpec
+		LoadBalancer                  string                `json:"loadBalancer,omitempty"                  yaml:"loadBalancer,omitempty"`
+		ProdListener                  string                `json:"prodListener,omitempty"                  yaml:"prodListener,omitempty"`
+		ProdListenerRuleArn           string                `json:"prodListenerRuleArn,omitempty"           yaml:"prodListenerRuleArn,omitempty"`
+		StageListener                 string                `json:"stageListener,omitempty"                 yaml:"stageListener,omitempty"`
+		StageListenerRuleArn          string                `json:"stageListenerRuleArn,omitempty"          yaml:"stageListenerRuleArn,omitempty"`
+		SameAsAlreadyRunningInstances *flexible.Field[bool] `json:"sameAsAlreadyRunningInstances,omitempty" yaml:"sameAsAlreadyRunningInstances,omitempty"`
+		UpdateGreenService            *flexible.Field[bool] `json:"updateGreenService,omitempty"            yaml:"updateGreenService,omitempty"`
+	}
+
+	// CD: ECS Blue Green Swap Target Groups (harness-core EcsBlueGreenSwapTargetGroupsStepInfo)
+	StepEcsBlueGreenSwapTargetGroups struct {
+		CommonStepSpec
+		DoNotDownsizeOldService       *flexible.Field[bool] `json:"doNotDownsizeOldService,omitempty"       yaml:"doNotDownsizeOldService,omitempty"`
+		DownsizeOldServiceDelayInSecs *flexible.Field[int]  `json:"downsizeOldServiceDelayInSecs,omitempty" yaml:"downsizeOldServiceDelayInSecs,omitempty"`
+	}
+
+	// CD: ECS Blue Green Rollback (harness-core EcsBlueGreenRollbackStepInfo)
+	StepEcsBlueGreenRollback struct {
+		CommonStepSpec
+		SwitchTrafficAlsoAsRollback *flexible.Field[bool] `json:"switchTrafficAlsoAsRollback,omitempty" yaml:"switchTrafficAlsoAsRollback,omitempty"`
+	}
+
+	// CD: ECS Run Task (harness-core EcsRunTaskStepInfo)
+	StepEcsRunTask struct {
+		CommonStepSpec
+		TaskDefinition           *ProvisionerStore     `json:"taskDefinition,omitempty"           yaml:"taskDefinition,omitempty"`
+		TaskDefinitionArn        string                `json:"taskDefinitionArn,omitempty"        yaml:"taskDefinitionArn,omitempty"`
+		RunTaskRequestDefinition *ProvisionerStore     `json:"runTaskRequestDefinition,omitempty" yaml:"runTaskRequestDefinition,omitempty"`
+		SkipSteadyStateCheck     *flexible.Field[bool] `json:"skipSteadyStateCheck,omitempty"     yaml:"skipSteadyStateCheck,omitempty"`
+	}
+)
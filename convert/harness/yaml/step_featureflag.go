@@ -0,0 +1,78 @@
+package yaml
+
+import "github.com/drone/go-convert/internal/flexible"
+
+// FlagConfiguration instruction types.
+const (
+	FlagInstructionSetFeatureFlagState               = "SetFeatureFlagState"
+	FlagInstructionSetOnVariation                    = "SetOnVariation"
+	FlagInstructionSetOffVariation                   = "SetOffVariation"
+	FlagInstructionSetDefaultVariations              = "SetDefaultVariations"
+	FlagInstructionAddRule                           = "AddRule"
+	FlagInstructionAddTargetsToVariationTargetMap    = "AddTargetsToVariationTargetMap"
+	FlagInstructionRemoveTargetsToVariationTargetMap = "RemoveTargetsToVariationTargetMap"
+	FlagInstructionAddSegmentToVariationTargetMap    = "AddSegmentToVariationTargetMap"
+	FlagInstructionRemoveSegmentToVariationTargetMap = "RemoveSegmentToVariationTargetMap"
+)
+
+// v0 struct ports of the Feature Flag stage Flag Configuration step. The
+// instruction spec is a union of the fields used by all instruction types.
+type (
+	// FF: Flag Configuration
+	StepFlagConfiguration struct {
+		CommonStepSpec
+		Feature      string             `json:"feature,omitempty"      yaml:"feature,omitempty"`
+		Environment  string             `json:"environment,omitempty"  yaml:"environment,omitempty"`
+		Instructions []*FlagInstruction `json:"instructions,omitempty" yaml:"instructions,omitempty"`
+	}
+
+	FlagInstruction struct {
+		ID   string               `json:"identifier,omitempty" yaml:"identifier,omitempty"`
+		Type string               `json:"type,omitempty"       yaml:"type,omitempty"`
+		Spec *FlagInstructionSpec `json:"spec,omitempty"       yaml:"spec,omitempty"`
+	}
+
+	FlagInstructionSpec struct {
+		// SetFeatureFlagState
+		State string `json:"state,omitempty" yaml:"state,omitempty"`
+
+		// SetOnVariation, SetOffVariation and the target map instructions
+		Variation string `json:"variation,omitempty" yaml:"variation,omitempty"`
+
+		// SetDefaultVariations
+		On  string `json:"on,omitempty"  yaml:"on,omitempty"`
+		Off string `json:"off,omitempty" yaml:"off,omitempty"`
+
+		// AddRule
+		Priority     *flexible.Field[int] `json:"priority,omitempty"     yaml:"priority,omitempty"`
+		Serve        *FlagServe           `json:"serve,omitempty"        yaml:"serve,omitempty"`
+		Clauses      []*FlagClause        `json:"clauses,omitempty"      yaml:"clauses,omitempty"`
+		Distribution *FlagDistribution    `json:"distribution,omitempty" yaml:"distribution,omitempty"`
+
+		// Add/Remove Targets and Segments
+		Targets  *flexible.Field[[]string] `json:"targets,omitempty"  yaml:"targets,omitempty"`
+		Segments *flexible.Field[[]string] `json:"segments,omitempty" yaml:"segments,omitempty"`
+	}
+
+	FlagServe struct {
+		Variation string `json:"variation,omitempty" yaml:"variation,omitempty"`
+	}
+
+	FlagClause struct {
+		Attribute string   `json:"attribute,omitempty" yaml:"attribute,omitempty"`
+		Op        string   `json:"op,omitempty"        yaml:"op,omitempty"`
+		Values    []string `json:"values,omitempty"    yaml:"values,omitempty"`
+	}
+
+	// FlagDistribution defines a percentage rollout across variations.
+	FlagDistribution struct {
+		BucketBy   string                   `json:"bucketBy,omitempty"   yaml:"bucketBy,omitempty"`
+		Clauses    []*FlagClause            `json:"clauses,omitempty"    yaml:"clauses,omitempty"`
+		Variations []*FlagWeightedVariation `json:"variations,omitempty" yaml:"variations,omitempty"`
+	}
+
+	FlagWeightedVariation struct {
+		Variation string               `json:"variation,omitempty" yaml:"variation,omitempty"`
+		Weight    *flexible.Field[int] `json:"weight,omitempty"    yaml:"weight,omitempty"`
+	}
+)
@@ -0,0 +1,30 @@
+package yaml
+
+import "github.com/drone/go-convert/internal/flexible"
+
+// v0 struct ports of the harness-core Google Cloud Functions (Gen 2) CD step
+// specs. Field yaml tags match the *StepInfo.java JSON keys.
+type (
+	// CD: Deploy Cloud Function (harness-core GoogleFunctionsDeployStepInfo)
+	StepDeployCloudFunction struct {
+		CommonStepSpec
+		UpdateFieldMask string `json:"updateFieldMask,omitempty" yaml:"updateFieldMask,omitempty"`
+	}
+
+	// CD: Deploy Cloud Function With No Traffic (harness-core GoogleFunctionsDeployWithoutTrafficStepInfo)
+	StepDeployCloudFunctionWithNoTraffic struct {
+		CommonStepSpec
+		UpdateFieldMask string `json:"updateFieldMask,omitempty" yaml:"updateFieldMask,omitempty"`
+	}
+
+	// CD: Cloud Function Traffic Shift (harness-core GoogleFunctionsTrafficShiftStepInfo)
+	StepCloudFunctionTrafficShift struct {
+		CommonStepSpec
+		TrafficPercent *flexible.Field[int] `json:"trafficPercent,omitempty" yaml:"trafficPercent,omitempty"`
+	}
+
+	// CD: Cloud Function Rollback (harness-core GoogleFunctionsRollbackStepInfo)
+	StepCloudFunctionRollback struct {
+		CommonStepSpec
+	}
+)
@@ -0,0 +1,67 @@
+package yaml
+
+import "github.com/drone/go-convert/internal/flexible"
+
+// v0 struct ports of the harness-core GitOps CD step specs. Field yaml tags
+// match the *StepInfo.java JSON keys.
+type (
+	// CD: Update Release Repo (harness-core UpdateReleaseRepoStepInfo)
+	StepGitOpsUpdateReleaseRepo struct {
+		CommonStepSpec
+		Variables        []*Variable           `json:"variables,omitempty"        yaml:"variables,omitempty"`
+		PrTitle          string                `json:"prTitle,omitempty"          yaml:"prTitle,omitempty"`
+		AllowEmptyCommit *flexible.Field[bool] `json:"allowEmptyCommit,omitempty" yaml:"allowEmptyCommit,omitempty"`
+	}
+
+	// CD: Merge PR (harness-core MergePRStepInfo)
+	StepGitOpsMergePR struct {
+		CommonStepSpec
+		DeleteSourceBranch *flexible.Field[bool] `json:"deleteSourceBranch,omitempty" yaml:"deleteSourceBranch,omitempty"`
+		Variables          []*Variable           `json:"variables,omitempty"          yaml:"variables,omitempty"`
+	}
+
+	// CD: Fetch Linked Apps (harness-core FetchLinkedAppsStepInfo)
+	StepGitOpsFetchLinkedApps struct {
+		CommonStepSpec
+	}
+
+	// CD: GitOps Sync (harness-core SyncStepInfo)
+	StepGitOpsSync struct {
+		CommonStepSpec
+		Prune            *flexible.Field[bool] `json:"prune,omitempty"            yaml:"prune,omitempty"`
+		DryRun           *flexible.Field[bool] `json:"dryRun,omitempty"           yaml:"dryRun,omitempty"`
+		ApplyOnly        *flexible.Field[bool] `json:"applyOnly,omitempty"        yaml:"applyOnly,omitempty"`
+		ForceApply       *flexible.Field[bool] `json:"forceApply,omitempty"       yaml:"forceApply,omitempty"`
+		ApplicationsList []*GitOpsApplication  `json:"applicationsList,omitempty" yaml:"applicationsList,omitempty"`
+		RetryStrategy    *GitOpsRetryStrategy  `json:"retryStrategy,omitempty"    yaml:"retryStrategy,omitempty"`
+		SyncOptions      *GitOpsSyncOptions    `json:"syncOptions,omitempty"      yaml:"syncOptions,omitempty"`
+	}
+
+	GitOpsApplication struct {
+		ApplicationName string `json:"applicationName,omitempty" yaml:"applicationName,omitempty"`
+		AgentID         string `json:"agentId,omitempty"         yaml:"agentId,omitempty"`
+	}
+
+	GitOpsRetryStrategy struct {
+		Limit                   *flexible.Field[int] `json:"limit,omitempty"                   yaml:"limit,omitempty"`
+		BaseBackoffDuration     string               `json:"baseBackoffDuration,omitempty"     yaml:"baseBackoffDuration,omitempty"`
+		IncreaseBackoffByFactor *flexible.Field[int] `json:"increaseBackoffByFactor,omitempty" yaml:"increaseBackoffByFactor,omitempty"`
+		MaxBackoffDuration      string               `json:"maxBackoffDuration,omitempty"      yaml:"maxBackoffDuration,omitempty"`
+	}
+
+	GitOpsSyncOptions struct {
+		SkipSchemaValidation   *flexible.Field[bool] `json:"skipSchemaValidation,omitempty"   yaml:"skipSchemaValidation,omitempty"`
+		AutoCreateNamespace    *flexible.Field[bool] `json:"autoCreateNamespace,omitempty"    yaml:"autoCreateNamespace,omitempty"`
+		PruneResourcesAtLast   *flexible.Field[bool] `json:"pruneResourcesAtLast,omitempty"   yaml:"pruneResourcesAtLast,omitempty"`
+		ApplyOutOfSyncOnly     *flexible.Field[bool] `json:"applyOutOfSyncOnly,omitempty"     yaml:"applyOutOfSyncOnly,omitempty"`
+		ReplaceResources       *flexible.Field[bool] `json:"replaceResources,omitempty"       yaml:"replaceResources,omitempty"`
+		PrunePropagationPolicy string                `json:"prunePropagationPolicy,omitempty" yaml:"prunePropagationPolicy,omitempty"`
+	}
+
+	// CD: Revert PR (harness-core RevertPRStepInfo)
+	StepGitOpsRevertPR struct {
+		CommonStepSpec
+		CommitID string `json:"commitId,omitempty" yaml:"commitId,omitempty"`
+		PrTitle  string `json:"prTitle,omitempty"  yaml:"prTitle,omitempty"`
+	}
+)
@@ -0,0 +1,16 @@
+package yaml
+
+// v0 struct ports of the harness-core AWS Lambda CD step specs. The steps
+// deploy the function defined by the service; the spec only carries the common
+// step fields.
+type (
+	// CD: AWS Lambda Deploy (harness-core AwsLambdaDeployStepInfo)
+	StepAwsLambdaDeploy struct {
+		CommonStepSpec
+	}
+
+	// CD: AWS Lambda Rollback (harness-core AwsLambdaRollbackStepInfo)
+	StepAwsLambdaRollback struct {
+		CommonStepSpec
+	}
+)
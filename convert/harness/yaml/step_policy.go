@@ -0,0 +1,18 @@
+package yaml
+
+import "github.com/drone/go-convert/internal/flexible"
+
+// v0 struct port of the harness-core Policy step (PolicyStepInfo), which
+// evaluates OPA policy sets against a custom JSON payload.
+type (
+	StepPolicy struct {
+		CommonStepSpec
+		PolicySets *flexible.Field[[]string] `json:"policySets,omitempty" yaml:"policySets,omitempty"`
+		Type       string                    `json:"type,omitempty"       yaml:"type,omitempty"`
+		PolicySpec *PolicySpec               `json:"policySpec,omitempty" yaml:"policySpec,omitempty"`
+	}
+
+	PolicySpec struct {
+		Payload string `json:"payload,omitempty" yaml:"payload,omitempty"`
+	}
+)
@@ -0,0 +1,333 @@
+package yaml
+
+import "github.com/drone/go-convert/internal/flexible"
+
+// Provisioner configuration types. Terraform plan/apply/destroy configuration
+// is either declared inline, or inherited from a previous plan/apply step with
+// the same provisionerIdentifier.
+const (
+	ProvisionerConfigurationInline           = "Inline"
+	ProvisionerConfigurationInheritFromPlan  = "InheritFromPlan"
+	ProvisionerConfigurationInheritFromApply = "InheritFromApply"
+	ProvisionerConfigurationInherited        = "Inherited"
+)
+
+type (
+
+	// ProvisionerStore is the remote store of provisioner config, var
+	// and template files ({type, spec}). The type is the store kind, for
+	// example Github, Git, GitLab, Bitbucket, Harness, Artifactory or S3.
+	ProvisionerStore struct {
+		Type string                `json:"type,omitempty" yaml:"type,omitempty"`
+		Spec *ProvisionerStoreSpec `json:"spec,omitempty" yaml:"spec,omitempty"`
+	}
+
+	// ProvisionerStoreSpec is the union of the git, Harness file store,
+	// Artifactory and S3 store specs used by the provisioner steps.
+	ProvisionerStoreSpec struct {
+		ConnectorRef   string                    `json:"connectorRef,omitempty" yaml:"connectorRef,omitempty"`
+		RepoName       string                    `json:"repoName,omitempty"     yaml:"repoName,omitempty"`
+		GitFetchType   string                    `json:"gitFetchType,omitempty" yaml:"gitFetchType,omitempty"`
+		Branch         string                    `json:"branch,omitempty"       yaml:"branch,omitempty"`
+		CommitId       string                    `json:"commitId,omitempty"     yaml:"commitId,omitempty"`
+		FolderPath     string                    `json:"folderPath,omitempty"   yaml:"folderPath,omitempty"`
+		Paths          *flexible.Field[[]string] `json:"paths,omitempty"        yaml:"paths,omitempty"`
+		Files          *flexible.Field[[]string] `json:"files,omitempty"        yaml:"files,omitempty"`
+		RepositoryName string                    `json:"repositoryName,omitempty" yaml:"repositoryName,omitempty"`
+		ArtifactPaths  *flexible.Field[[]string] `json:"artifactPaths,omitempty" yaml:"artifactPaths,omitempty"`
+		Region         string                    `json:"region,omitempty"       yaml:"region,omitempty"`
+		Bucket         string                    `json:"bucketName,omitempty"   yaml:"bucketName,omitempty"`
+		URLs           *flexible.Field[[]string] `json:"urls,omitempty"         yaml:"urls,omitempty"`
+	}
+
+	// ProvisionerVariable is a single environment variable entry of the
+	// provisioner steps ({name, value, type}).
+	ProvisionerVariable struct {
+		Name  string `json:"name,omitempty"  yaml:"name,omitempty"`
+		Value string `json:"value,omitempty" yaml:"value,omitempty"`
+		Type  string `json:"type,omitempty"  yaml:"type,omitempty"`
+	}
+
+	//
+	// Terraform
+	//
+
+	// CD: Terraform Plan
+	StepTerraformPlan struct {
+		CommonStepSpec
+		ProvisionerIdentifier string                      `json:"provisionerIdentifier,omitempty" yaml:"provisionerIdentifier,omitempty"`
+		Configuration         *TerraformPlanConfiguration `json:"configuration,omitempty"         yaml:"configuration,omitempty"`
+	}
+
+	// TerraformPlanConfiguration is the inline configuration of the
+	// Terraform plan step. The command is Apply or Destroy.
+	TerraformPlanConfiguration struct {
+		Command          string `json:"command,omitempty"          yaml:"command,omitempty"`
+		SecretManagerRef string `json:"secretManagerRef,omitempty" yaml:"secretManagerRef,omitempty"`
+		TerraformExecutionData
+		ExportTerraformPlanJson          *flexible.Field[bool] `json:"exportTerraformPlanJson,omitempty"          yaml:"exportTerraformPlanJson,omitempty"`
+		ExportTerraformHumanReadablePlan *flexible.Field[bool] `json:"exportTerraformHumanReadablePlan,omitempty" yaml:"exportTerraformHumanReadablePlan,omitempty"`
+	}
+
+	// CD: Terraform Apply
+	StepTerraformApply struct {
+		CommonStepSpec
+		ProvisionerIdentifier string                      `json:"provisionerIdentifier,omitempty" yaml:"provisionerIdentifier,omitempty"`
+		Configuration         *TerraformStepConfiguration `json:"configuration,omitempty"         yaml:"configuration,omitempty"`
+	}
+
+	// CD: Terraform Destroy
+	StepTerraformDestroy struct {
+		CommonStepSpec
+		ProvisionerIdentifier string                      `json:"provisionerIdentifier,omitempty" yaml:"provisionerIdentifier,omitempty"`
+		Configuration         *TerraformStepConfiguration `json:"configuration,omitempty"         yaml:"configuration,omitempty"`
+	}
+
+	// CD: Terraform Rollback
+	StepTerraformRollback struct {
+		CommonStepSpec
+		ProvisionerIdentifier string                    `json:"provisionerIdentifier,omitempty" yaml:"provisionerIdentifier,omitempty"`
+		SkipRefreshCommand    *flexible.Field[bool]     `json:"skipRefreshCommand,omitempty"    yaml:"skipRefreshCommand,omitempty"`
+		CommandFlags          []*ProvisionerCommandFlag `json:"commandFlags,omitempty"      yaml:"commandFlags,omitempty"`
+	}
+
+	// TerraformStepConfiguration is the configuration of the Terraform
+	// apply and destroy steps. The spec is only set for the Inline type.
+	TerraformStepConfiguration struct {
+		Type             string                    `json:"type,omitempty" yaml:"type,omitempty"`
+		Spec             *TerraformExecutionData   `json:"spec,omitempty" yaml:"spec,omitempty"`
+		SkipStateStorage *flexible.Field[bool]     `json:"skipStateStorage,omitempty" yaml:"skipStateStorage,omitempty"`
+		EncryptOutput    *TerraformEncryptOutput   `json:"encryptOutput,omitempty" yaml:"encryptOutput,omitempty"`
+		CommandFlags     []*ProvisionerCommandFlag `json:"commandFlags,omitempty" yaml:"commandFlags,omitempty"`
+	}
+
+	// TerraformExecutionData is the inline Terraform configuration shared
+	// by the plan, apply and destroy steps.
+	TerraformExecutionData struct {
+		Workspace            string                     `json:"workspace,omitempty"            yaml:"workspace,omitempty"`
+		ConfigFiles          *TerraformConfigFiles      `json:"configFiles,omitempty"          yaml:"configFiles,omitempty"`
+		VarFiles             []*TerraformVarFileWrapper `json:"varFiles,omitempty"             yaml:"varFiles,omitempty"`
+		BackendConfig        *TerraformBackendConfig    `json:"backendConfig,omitempty"        yaml:"backendConfig,omitempty"`
+		Targets              *flexible.Field[[]string]  `json:"targets,omitempty"              yaml:"targets,omitempty"`
+		EnvironmentVariables []*ProvisionerVariable     `json:"environmentVariables,omitempty" yaml:"environmentVariables,omitempty"`
+		SkipRefreshCommand   *flexible.Field[bool]      `json:"skipRefreshCommand,omitempty"   yaml:"skipRefreshCommand,omitempty"`
+		CommandFlags         []*ProvisionerCommandFlag  `json:"commandFlags,omitempty"         yaml:"commandFlags,omitempty"`
+	}
+
+	// TerraformConfigFiles is the store of the Terraform configuration
+	// (root module).
+	TerraformConfigFiles struct {
+		Store        *ProvisionerStore      `json:"store,omitempty"        yaml:"store,omitempty"`
+		ModuleSource *TerraformModuleSource `json:"moduleSource,omitempty" yaml:"moduleSource,omitempty"`
+	}
+
+	// TerraformModuleSource configures the connector used to download
+	// the Terraform modules over ssh.
+	TerraformModuleSource struct {
+		UseConnectorCredentials *flexible.Field[bool] `json:"useConnectorCredentials,omitempty" yaml:"useConnectorCredentials,omitempty"`
+	}
+
+	// TerraformVarFileWrapper wraps a single var file entry ({varFile}).
+	TerraformVarFileWrapper struct {
+		VarFile *TerraformVarFile `json:"varFile,omitempty" yaml:"varFile,omitempty"`
+	}
+
+	// TerraformVarFile is an Inline or Remote var file.
+	TerraformVarFile struct {
+		Identifier string                `json:"identifier,omitempty" yaml:"identifier,omitempty"`
+		Type       string                `json:"type,omitempty"       yaml:"type,omitempty"`
+		Spec       *TerraformVarFileSpec `json:"spec,omitempty"       yaml:"spec,omitempty"`
+	}
+
+	// TerraformVarFileSpec holds the inline content or the remote store
+	// of a var file.
+	TerraformVarFileSpec struct {
+		Content  string                `json:"content,omitempty"  yaml:"content,omitempty"`
+		Store    *ProvisionerStore     `json:"store,omitempty"    yaml:"store,omitempty"`
+		Optional *flexible.Field[bool] `json:"optional,omitempty" yaml:"optional,omitempty"`
+	}
+
+	// TerraformBackendConfig is an Inline or Remote backend config.
+	TerraformBackendConfig struct {
+		Type string                      `json:"type,omitempty" yaml:"type,omitempty"`
+		Spec *TerraformBackendConfigSpec `json:"spec,omitempty" yaml:"spec,omitempty"`
+	}
+
+	// TerraformBackendConfigSpec holds the inline content or the remote
+	// store of the backend config.
+	TerraformBackendConfigSpec struct {
+		Content string            `json:"content,omitempty" yaml:"content,omitempty"`
+		Store   *ProvisionerStore `json:"store,omitempty"   yaml:"store,omitempty"`
+	}
+
+	// TerraformEncryptOutput configures the secret manager used to
+	// encrypt the Terraform outputs.
+	TerraformEncryptOutput struct {
+		OutputSecretManagerRef string `json:"outputSecretManagerRef,omitempty" yaml:"outputSecretManagerRef,omitempty"`
+	}
+
+	// ProvisionerCommandFlag is a single command flag entry of the
+	// provisioner steps ({commandType, flag}).
+	ProvisionerCommandFlag struct {
+		CommandType string `json:"commandType,omitempty" yaml:"commandType,omitempty"`
+		Flag        string `json:"flag,omitempty"        yaml:"flag,omitempty"`
+	}
+
+	//
+	// Terragrunt
+	//
+
+	// CD: Terragrunt Plan
+	StepTerragruntPlan struct {
+		CommonStepSpec
+		ProvisionerIdentifier string                       `json:"provisionerIdentifier,omitempty" yaml:"provisionerIdentifier,omitempty"`
+		Configuration         *TerragruntPlanConfiguration `json:"configuration,omitempty"         yaml:"configuration,omitempty"`
+	}
+
+	// TerragruntPlanConfiguration is the inline configuration of the
+	// Terragrunt plan step. The command is Apply or Destroy.
+	TerragruntPlanConfiguration struct {
+		Command          string `json:"command,omitempty"          yaml:"command,omitempty"`
+		SecretManagerRef string `json:"secretManagerRef,omitempty" yaml:"secretManagerRef,omitempty"`
+		TerragruntExecutionData
+		ExportTerragruntPlanJson *flexible.Field[bool] `json:"exportTerragruntPlanJson,omitempty" yaml:"exportTerragruntPlanJson,omitempty"`
+	}
+
+	// CD: Terragrunt Apply
+	StepTerragruntApply struct {
+		CommonStepSpec
+		ProvisionerIdentifier string                       `json:"provisionerIdentifier,omitempty" yaml:"provisionerIdentifier,omitempty"`
+		Configuration         *TerragruntStepConfiguration `json:"configuration,omitempty"         yaml:"configuration,omitempty"`
+	}
+
+	// CD: Terragrunt Destroy
+	StepTerragruntDestroy struct {
+		CommonStepSpec
+		ProvisionerIdentifier string                       `json:"provisionerIdentifier,omitempty" yaml:"provisionerIdentifier,omitempty"`
+		Configuration         *TerragruntStepConfiguration `json:"configuration,omitempty"         yaml:"configuration,omitempty"`
+	}
+
+	// CD: Terragrunt Rollback
+	StepTerragruntRollback struct {
+		CommonStepSpec
+		ProvisionerIdentifier string `json:"provisionerIdentifier,omitempty" yaml:"provisionerIdentifier,omitempty"`
+	}
+
+	// TerragruntStepConfiguration is the configuration of the Terragrunt
+	// apply and destroy steps. The spec is only set for the Inline type.
+	TerragruntStepConfiguration struct {
+		Type string                   `json:"type,omitempty" yaml:"type,omitempty"`
+		Spec *TerragruntExecutionData `json:"spec,omitempty" yaml:"spec,omitempty"`
+	}
+
+	// TerragruntExecutionData is the inline Terragrunt configuration
+	// shared by the plan, apply and destroy steps.
+	TerragruntExecutionData struct {
+		TerraformExecutionData
+		ModuleConfig *TerragruntModuleConfig `json:"moduleConfig,omitempty" yaml:"moduleConfig,omitempty"`
+	}
+
+	// TerragruntModuleConfig selects a single module or all modules of
+	// the Terragrunt configuration.
+	TerragruntModuleConfig struct {
+		TerragruntRunType string `json:"terragruntRunType,omitempty" yaml:"terragruntRunType,omitempty"`
+		Path              string `json:"path,omitempty"              yaml:"path,omitempty"`
+	}
+
+	//
+	// CloudFormation
+	//
+
+	// CD: CloudFormation Create Stack
+	StepCloudformationCreateStack struct {
+		CommonStepSpec
+		ProvisionerIdentifier string                           `json:"provisionerIdentifier,omitempty" yaml:"provisionerIdentifier,omitempty"`
+		Configuration         *CloudformationCreateStackConfig `json:"configuration,omitempty"         yaml:"configuration,omitempty"`
+	}
+
+	// CloudformationCreateStackConfig is the configuration of the
+	// CloudFormation create stack step.
+	CloudformationCreateStackConfig struct {
+		StackName            string                          `json:"stackName,omitempty"            yaml:"stackName,omitempty"`
+		ConnectorRef         string                          `json:"connectorRef,omitempty"         yaml:"connectorRef,omitempty"`
+		Region               string                          `json:"region,omitempty"               yaml:"region,omitempty"`
+		RoleArn              string                          `json:"roleArn,omitempty"              yaml:"roleArn,omitempty"`
+		TemplateFile         *CloudformationTemplateFile     `json:"templateFile,omitempty"         yaml:"templateFile,omitempty"`
+		Parameters           []*CloudformationParametersFile `json:"parameters,omitempty"           yaml:"parameters,omitempty"`
+		ParameterOverrides   []*ProvisionerVariable          `json:"parameterOverrides,omitempty"   yaml:"parameterOverrides,omitempty"`
+		Capabilities         *flexible.Field[[]string]       `json:"capabilities,omitempty"         yaml:"capabilities,omitempty"`
+		Tags                 *CloudformationTags             `json:"tags,omitempty"                 yaml:"tags,omitempty"`
+		SkipOnStackStatuses  *flexible.Field[[]string]       `json:"skipOnStackStatuses,omitempty"  yaml:"skipOnStackStatuses,omitempty"`
+		SkipWaitForResources *flexible.Field[bool]           `json:"skipWaitForResources,omitempty" yaml:"skipWaitForResources,omitempty"`
+	}
+
+	// CloudformationTemplateFile is an Inline, Remote or S3URL template.
+	CloudformationTemplateFile struct {
+		Type string                          `json:"type,omitempty" yaml:"type,omitempty"`
+		Spec *CloudformationTemplateFileSpec `json:"spec,omitempty" yaml:"spec,omitempty"`
+	}
+
+	// CloudformationTemplateFileSpec holds the inline body, the remote
+	// store or the S3 url of the template.
+	CloudformationTemplateFileSpec struct {
+		TemplateBody string            `json:"templateBody,omitempty" yaml:"templateBody,omitempty"`
+		TemplateUrl  string            `json:"templateUrl,omitempty"  yaml:"templateUrl,omitempty"`
+		Store        *ProvisionerStore `json:"store,omitempty"        yaml:"store,omitempty"`
+	}
+
+	// CloudformationParametersFile is a remote parameters file.
+	CloudformationParametersFile struct {
+		Identifier string            `json:"identifier,omitempty" yaml:"identifier,omitempty"`
+		Store      *ProvisionerStore `json:"store,omitempty"      yaml:"store,omitempty"`
+	}
+
+	// CloudformationTags is an Inline or Remote tags file.
+	CloudformationTags struct {
+		Type string                  `json:"type,omitempty" yaml:"type,omitempty"`
+		Spec *CloudformationTagsSpec `json:"spec,omitempty" yaml:"spec,omitempty"`
+	}
+
+	// CloudformationTagsSpec holds the inline content or the remote store
+	// of the tags.
+	CloudformationTagsSpec struct {
+		Content string            `json:"content,omitempty" yaml:"content,omitempty"`
+		Store   *ProvisionerStore `json:"store,omitempty"   yaml:"store,omitempty"`
+	}
+
+	// CD: CloudFormation Delete Stack
+	StepCloudformationDeleteStack struct {
+		CommonStepSpec
+		Configuration *CloudformationDeleteStackConfig `json:"configuration,omitempty" yaml:"configuration,omitempty"`
+	}
+
+	// CloudformationDeleteStackConfig is the configuration of the
+	// CloudFormation delete stack step. The stack is declared Inline, or
+	// is Inherited from the create stack step with the same provisioner
+	// identifier.
+	CloudformationDeleteStackConfig struct {
+		Type string                               `json:"type,omitempty" yaml:"type,omitempty"`
+		Spec *CloudformationDeleteStackConfigSpec `json:"spec,omitempty" yaml:"spec,omitempty"`
+	}
+
+	// CloudformationDeleteStackConfigSpec is the inline or inherited
+	// delete stack configuration.
+	CloudformationDeleteStackConfigSpec struct {
+		ProvisionerIdentifier string `json:"provisionerIdentifier,omitempty" yaml:"provisionerIdentifier,omitempty"`
+		StackName             string `json:"stackName,omitempty"             yaml:"stackName,omitempty"`
+		ConnectorRef          string `json:"connectorRef,omitempty"          yaml:"connectorRef,omitempty"`
+		Region                string `json:"region,omitempty"                yaml:"region,omitempty"`
+		RoleArn               string `json:"roleArn,omitempty"               yaml:"roleArn,omitempty"`
+	}
+
+	// CD: CloudFormation Rollback Stack
+	StepCloudformationRollbackStack struct {
+		CommonStepSpec
+		Configuration *CloudformationRollbackStackConfig `json:"configuration,omitempty" yaml:"configuration,omitempty"`
+	}
+
+	// CloudformationRollbackStackConfig is the configuration of the
+	// CloudFormation rollback stack step.
+	CloudformationRollbackStackConfig struct {
+		ProvisionerIdentifier string `json:"provisionerIdentifier,omitempty" yaml:"provisionerIdentifier,omitempty"`
+	}
+)
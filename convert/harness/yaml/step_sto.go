@@ -0,0 +1,80 @@
+package yaml
+
+import "github.com/drone/go-convert/internal/flexible"
+
+// v0 struct ports of the Security Testing Orchestration (STO) scanner step
+// specs. Every scanner (AquaTrivy, Snyk, Sonarqube, ...) shares the same spec
+// layout: the scan mode and config, the target, ingestion and advanced blocks,
+// and the per-tool auth, tool and instance sections. Unlike most v0 steps the
+// STO blocks use snake_case keys (fail_on_severity, access_token, ...).
+type (
+	// STO: scanner step (AquaTrivy, Snyk, Sonarqube, Checkmarx, Grype, Wiz,
+	// Semgrep, Gitleaks, PrismaCloud, CustomIngest)
+	StepSTOScan struct {
+		CommonStepSpec
+		Mode            string                             `json:"mode,omitempty"            yaml:"mode,omitempty"`
+		Config          string                             `json:"config,omitempty"          yaml:"config,omitempty"`
+		Target          *STOTarget                         `json:"target,omitempty"          yaml:"target,omitempty"`
+		Ingestion       *STOIngestion                      `json:"ingestion,omitempty"       yaml:"ingestion,omitempty"`
+		Advanced        *STOAdvanced                       `json:"advanced,omitempty"        yaml:"advanced,omitempty"`
+		Image           *STOImage                          `json:"image,omitempty"           yaml:"image,omitempty"`
+		Auth            *STOAuth                           `json:"auth,omitempty"            yaml:"auth,omitempty"`
+		Tool            *STOTool                           `json:"tool,omitempty"            yaml:"tool,omitempty"`
+		Instance        *STOInstance                       `json:"instance,omitempty"        yaml:"instance,omitempty"`
+		Settings        *flexible.Field[map[string]string] `json:"settings,omitempty"        yaml:"settings,omitempty"`
+		Privileged      *flexible.Field[bool]              `json:"privileged,omitempty"      yaml:"privileged,omitempty"`
+		RunAsUser       *flexible.Field[int]               `json:"runAsUser,omitempty"       yaml:"runAsUser,omitempty"`
+		ImagePullPolicy string                             `json:"imagePullPolicy,omitempty" yaml:"imagePullPolicy,omitempty"`
+		Resources       *Resources                         `json:"resources,omitempty"       yaml:"resources,omitempty"`
+	}
+
+	// StepTrivy is the AquaTrivy scanner step spec.
+	StepTrivy = StepSTOScan
+
+	// STOIngestion is the results file ingested in ingestion mode.
+	STOIngestion struct {
+		File string `json:"file,omitempty" yaml:"file,omitempty"`
+	}
+
+	// STOAuth is the scanner authentication section.
+	STOAuth struct {
+		Domain      string                `json:"domain,omitempty"       yaml:"domain,omitempty"`
+		AccessId    string                `json:"access_id,omitempty"    yaml:"access_id,omitempty"`
+		AccessToken string                `json:"access_token,omitempty" yaml:"access_token,omitempty"`
+		Region      string                `json:"region,omitempty"       yaml:"region,omitempty"`
+		Type        string                `json:"type,omitempty"         yaml:"type,omitempty"`
+		Version     string                `json:"version,omitempty"      yaml:"version,omitempty"`
+		Ssl         *flexible.Field[bool] `json:"ssl,omitempty"          yaml:"ssl,omitempty"`
+	}
+
+	// STOTool is the union of the per-scanner tool sections.
+	STOTool struct {
+		ProjectName       string                `json:"project_name,omitempty"       yaml:"project_name,omitempty"`
+		ProjectKey        string                `json:"project_key,omitempty"        yaml:"project_key,omitempty"`
+		ProjectVersion    string                `json:"project_version,omitempty"    yaml:"project_version,omitempty"`
+		TeamName          string                `json:"team_name,omitempty"          yaml:"team_name,omitempty"`
+		Include           string                `json:"include,omitempty"            yaml:"include,omitempty"`
+		Exclude           string                `json:"exclude,omitempty"            yaml:"exclude,omitempty"`
+		Context           string                `json:"context,omitempty"            yaml:"context,omitempty"`
+		ImageName         string                `json:"image_name,omitempty"         yaml:"image_name,omitempty"`
+		PullRequestNumber string                `json:"pull_request_number,omitempty" yaml:"pull_request_number,omitempty"`
+		Java              *STOToolJava          `json:"java,omitempty"               yaml:"java,omitempty"`
+		DetectSecrets     *flexible.Field[bool] `json:"detect_secrets,omitempty"     yaml:"detect_secrets,omitempty"`
+	}
+
+	// STOToolJava is the java section of the SonarQube tool section.
+	STOToolJava struct {
+		Libraries string `json:"libraries,omitempty" yaml:"libraries,omitempty"`
+		Binaries  string `json:"binaries,omitempty"  yaml:"binaries,omitempty"`
+	}
+
+	// STOInstance is the scanned instance of the DAST scanners.
+	STOInstance struct {
+		Domain   string `json:"domain,omitempty"   yaml:"domain,omitempty"`
+		Protocol string `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+		Port     string `json:"port,omitempty"     yaml:"port,omitempty"`
+		Path     string `json:"path,omitempty"     yaml:"path,omitempty"`
+		Username string `json:"username,omitempty" yaml:"username,omitempty"`
+		Password string `json:"password,omitempty" yaml:"password,omitempty"`
+	}
+)
@@ -0,0 +1,67 @@
+package yaml
+
+import "github.com/drone/go-convert/internal/flexible"
+
+// v0 struct ports of the harness-core Tanzu Application Service (TAS) CD step
+// specs. Field yaml tags match the *StepInfo.java JSON keys.
+type (
+	// CD: TAS Basic App Setup (harness-core TasBasicAppSetupStepInfo)
+	StepTasBasicAppSetup struct {
+		CommonStepSpec
+		TasInstanceCountType  string                    `json:"tasInstanceCountType,omitempty"  yaml:"tasInstanceCountType,omitempty"`
+		ExistingVersionToKeep *flexible.Field[int]      `json:"existingVersionToKeep,omitempty" yaml:"existingVersionToKeep,omitempty"`
+		AdditionalRoutes      *flexible.Field[[]string] `json:"additionalRoutes,omitempty"      yaml:"additionalRoutes,omitempty"`
+	}
+
+	// CD: TAS Blue Green App Setup (harness-core TasBGAppSetupStepInfo)
+	StepTasBGAppSetup struct {
+		CommonStepSpec
+		TasInstanceCountType  string                    `json:"tasInstanceCountType,omitempty"  yaml:"tasInstanceCountType,omitempty"`
+		ExistingVersionToKeep *flexible.Field[int]      `json:"existingVersionToKeep,omitempty" yaml:"existingVersionToKeep,omitempty"`
+		AdditionalRoutes      *flexible.Field[[]string] `json:"additionalRoutes,omitempty"      yaml:"additionalRoutes,omitempty"`
+		TempRoutes            *flexible.Field[[]string] `json:"tempRoutes,omitempty"            yaml:"tempRoutes,omitempty"`
+	}
+
+	// CD: TAS Canary App Setup (harness-core TasCanaryAppSetupStepInfo)
+	StepTasCanaryAppSetup struct {
+		CommonStepSpec
+		TasInstanceCountType  string                    `json:"tasInstanceCountType,omitempty"  yaml:"tasInstanceCountType,omitempty"`
+		ExistingVersionToKeep *flexible.Field[int]      `json:"existingVersionToKeep,omitempty" yaml:"existingVersionToKeep,omitempty"`
+		AdditionalRoutes      *flexible.Field[[]string] `json:"additionalRoutes,omitempty"      yaml:"additionalRoutes,omitempty"`
+		ResizeStrategy        string                    `json:"resizeStrategy,omitempty"        yaml:"resizeStrategy,omitempty"`
+	}
+
+	// CD: TAS App Resize (harness-core TasAppResizeStepInfo)
+	StepTasAppResize struct {
+		CommonStepSpec
+		NewAppInstances *TasInstanceSelection `json:"newAppInstances,omitempty" yaml:"newAppInstances,omitempty"`
+		OldAppInstances *TasInstanceSelection `json:"oldAppInstances,omitempty" yaml:"oldAppInstances,omitempty"`
+	}
+
+	// TasInstanceSelection is a Count or Percentage instance selection.
+	TasInstanceSelection struct {
+		Type string                    `json:"type,omitempty" yaml:"type,omitempty"`
+		Spec *TasInstanceSelectionSpec `json:"spec,omitempty" yaml:"spec,omitempty"`
+	}
+
+	TasInstanceSelectionSpec struct {
+		Value *flexible.Field[int] `json:"value,omitempty" yaml:"value,omitempty"`
+	}
+
+	// CD: TAS Swap Routes (harness-core TasSwapRoutesStepInfo)
+	StepTasSwapRoutes struct {
+		CommonStepSpec
+		DownSizeOldApplication *flexible.Field[bool] `json:"downSizeOldApplication,omitempty" yaml:"downSizeOldApplication,omitempty"`
+	}
+
+	// CD: TAS Swap Rollback (harness-core TasSwapRollbackStepInfo)
+	StepTasSwapRollback struct {
+		CommonStepSpec
+		UpsizeInActiveApp *flexible.Field[bool] `json:"upsizeInActiveApp,omitempty" yaml:"upsizeInActiveApp,omitempty"`
+	}
+
+	// CD: TAS App Rollback (harness-core TasAppRollbackStepInfo)
+	StepTasAppRollback struct {
+		CommonStepSpec
+	}
+)
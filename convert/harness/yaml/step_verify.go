@@ -0,0 +1,51 @@
+package yaml
+
+import "github.com/drone/go-convert/internal/flexible"
+
+// Verify step verification types.
+const (
+	VerifyTypeCanary    = "Canary"
+	VerifyTypeBlueGreen = "Bluegreen"
+	VerifyTypeRolling   = "Rolling"
+	VerifyTypeAuto      = "Auto"
+	VerifyTypeLoadTest  = "LoadTest"
+)
+
+// v0 struct ports of the Continuous Verification and Chaos step specs.
+type (
+	// CV: Verify (harness-core VerifyStepInfo)
+	StepVerify struct {
+		CommonStepSpec
+		Type                  string                  `json:"type,omitempty"                  yaml:"type,omitempty"`
+		MonitoredService      *VerifyMonitoredService `json:"monitoredService,omitempty"      yaml:"monitoredService,omitempty"`
+		Spec                  *VerifySpec             `json:"spec,omitempty"                  yaml:"spec,omitempty"`
+		IsMultiServicesOrEnvs *flexible.Field[bool]   `json:"isMultiServicesOrEnvs,omitempty" yaml:"isMultiServicesOrEnvs,omitempty"`
+	}
+
+	// VerifyMonitoredService selects the monitored service to analyse. The
+	// Default type uses the monitored service of the stage service and
+	// environment; Configured references one explicitly.
+	VerifyMonitoredService struct {
+		Type string                      `json:"type,omitempty" yaml:"type,omitempty"`
+		Spec *VerifyMonitoredServiceSpec `json:"spec,omitempty" yaml:"spec,omitempty"`
+	}
+
+	VerifyMonitoredServiceSpec struct {
+		MonitoredServiceRef string `json:"monitoredServiceRef,omitempty" yaml:"monitoredServiceRef,omitempty"`
+	}
+
+	VerifySpec struct {
+		Sensitivity      string                `json:"sensitivity,omitempty"      yaml:"sensitivity,omitempty"`
+		Duration         string                `json:"duration,omitempty"         yaml:"duration,omitempty"`
+		DeploymentTag    string                `json:"deploymentTag,omitempty"    yaml:"deploymentTag,omitempty"`
+		FailOnNoAnalysis *flexible.Field[bool] `json:"failOnNoAnalysis,omitempty" yaml:"failOnNoAnalysis,omitempty"`
+	}
+
+	// Chaos: run a chaos experiment and assert on its resilience score.
+	StepChaos struct {
+		CommonStepSpec
+		ExperimentRef           string                   `json:"experimentRef,omitempty"           yaml:"experimentRef,omitempty"`
+		ExpectedResilienceScore *flexible.Field[float64] `json:"expectedResilienceScore,omitempty" yaml:"expectedResilienceScore,omitempty"`
+		Assertion               string                   `json:"assertion,omitempty"               yaml:"assertion,omitempty"`
+	}
+)
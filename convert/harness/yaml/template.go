@@ -7,6 +7,18 @@ import (
 	"github.com/drone/go-convert/internal/flexible"
 )
 
+// Template types.
+const (
+	TemplateTypeStep             = "Step"
+	TemplateTypeStage            = "Stage"
+	TemplateTypeStepGroup        = "StepGroup"
+	TemplateTypePipeline         = "Pipeline"
+	TemplateTypeCustomDeployment = "CustomDeployment"
+	TemplateTypeArtifactSource   = "ArtifactSource"
+	TemplateTypeMonitoredService = "MonitoredService"
+	TemplateTypeSecretManager    = "SecretManager"
+)
+
 type (
 	Template struct {
 		ID           string      `json:"identifier,omitempty"        yaml:"identifier,omitempty"`
@@ -19,6 +31,44 @@ type (
 		Description  string      `json:"description,omitempty"       yaml:"description,omitempty"`
 		Tags         *flexible.Field[map[string]string] `json:"tags,omitempty"                 yaml:"tags,omitempty"`
 	}
+
+	// TemplateCustomDeployment defines a deployment template: the
+	// infrastructure variables and the script that fetches the instances.
+	TemplateCustomDeployment struct {
+		Infrastructure *CustomDeploymentInfrastructure `json:"infrastructure,omitempty" yaml:"infrastructure,omitempty"`
+		Execution      *CustomDeploymentExecution      `json:"execution,omitempty"      yaml:"execution,omitempty"`
+	}
+
+	CustomDeploymentInfrastructure struct {
+		Variables            []*Variable                          `json:"variables,omitempty"            yaml:"variables,omitempty"`
+		FetchInstancesScript *CustomDeploymentFetchInstancesScript `json:"fetchInstancesScript,omitempty" yaml:"fetchInstancesScript,omitempty"`
+		InstanceAttributes   []*CustomDeploymentInstanceAttribute  `json:"instanceAttributes,omitempty"   yaml:"instanceAttributes,omitempty"`
+		InstancesListPath    string                                `json:"instancesListPath,omitempty"    yaml:"instancesListPath,omitempty"`
+	}
+
+	CustomDeploymentFetchInstancesScript struct {
+		Store *CustomDeploymentScriptStore `json:"store,omitempty" yaml:"store,omitempty"`
+	}
+
+	CustomDeploymentScriptStore struct {
+		Type string                           `json:"type,omitempty" yaml:"type,omitempty"` // Inline|Harness
+		Spec *CustomDeploymentScriptStoreSpec `json:"spec,omitempty" yaml:"spec,omitempty"`
+	}
+
+	CustomDeploymentScriptStoreSpec struct {
+		Content string                    `json:"content,omitempty" yaml:"content,omitempty"`
+		Files   *flexible.Field[[]string] `json:"files,omitempty"   yaml:"files,omitempty"`
+	}
+
+	CustomDeploymentInstanceAttribute struct {
+		Name        string `json:"name,omitempty"        yaml:"name,omitempty"`
+		JsonPath    string `json:"jsonPath,omitempty"    yaml:"jsonPath,omitempty"`
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	}
+
+	CustomDeploymentExecution struct {
+		StepTemplateRefs []string `json:"stepTemplateRefs,omitempty" yaml:"stepTemplateRefs,omitempty"`
+	}
 )
 
 func (t *Template) UnmarshalJSON(data []byte) error {
@@ -61,8 +111,21 @@ func (t *Template) UnmarshalJSON(data []byte) error {
 			return fmt.Errorf("failed to unmarshal Pipeline template spec: %w", err)
 		}
 		t.Spec = &spec
+	case "CustomDeployment":
+		var spec TemplateCustomDeployment
+		if err := json.Unmarshal(aux.Spec, &spec); err != nil {
+			return fmt.Errorf("failed to unmarshal CustomDeployment template spec: %w", err)
+		}
+		t.Spec = &spec
+	case "ArtifactSource", "MonitoredService", "SecretManager":
+		// Not modelled; the spec is kept as-is so it can be passed through.
+		var spec map[string]interface{}
+		if err := json.Unmarshal(aux.Spec, &spec); err != nil {
+			return fmt.Errorf("failed to unmarshal %s template spec: %w", t.Type, err)
+		}
+		t.Spec = spec
 	default:
-		return fmt.Errorf("unsupported template type: %s (only Step, Stage, StepGroup, and Pipeline are supported)", t.Type)
+		return fmt.Errorf("unsupported template type: %s (only Step, Stage, StepGroup, Pipeline, CustomDeployment, ArtifactSource, MonitoredService, and SecretManager are supported)", t.Type)
 	}
 
 	return nil
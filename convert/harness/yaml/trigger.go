@@ -17,20 +17,20 @@ package yaml
 type (
 	// Trigger defines a v0 trigger configuration.
 	Trigger struct {
-		Name               string                 `json:"name,omitempty"               yaml:"name,omitempty"`
-		ID                 string                 `json:"identifier,omitempty"         yaml:"identifier,omitempty"`
-		Enabled            bool                   `json:"enabled,omitempty"            yaml:"enabled,omitempty"`
-		StagesToExecute    []string               `json:"stagesToExecute,omitempty"    yaml:"stagesToExecute,omitempty"`
-		Description        string                 `json:"description,omitempty"        yaml:"description,omitempty"`
-		Tags               map[string]string      `json:"tags,omitempty"               yaml:"tags,omitempty"`
-		Org                string                 `json:"orgIdentifier,omitempty"      yaml:"orgIdentifier,omitempty"`
-		Project            string                 `json:"projectIdentifier,omitempty"  yaml:"projectIdentifier,omitempty"`
-		PipelineIdentifier string                 `json:"pipelineIdentifier,omitempty" yaml:"pipelineIdentifier,omitempty"`
-		PipelineBranchName string                 `json:"pipelineBranchName,omitempty" yaml:"pipelineBranchName,omitempty"`
-		Source             *TriggerSource         `json:"source,omitempty"             yaml:"source,omitempty"`
-		InputSetBranchName string                 `json:"inputSetBranchName,omitempty" yaml:"inputSetBranchName,omitempty"`
-		InputYaml          string                 `json:"inputYaml,omitempty"          yaml:"inputYaml,omitempty"`
-		InputSetReferences []string               `json:"inputSetRefs,omitempty" yaml:"inputSetRefs,omitempty"`
+		Name               string            `json:"name,omitempty"               yaml:"name,omitempty"`
+		ID                 string            `json:"identifier,omitempty"         yaml:"identifier,omitempty"`
+		Enabled            bool              `json:"enabled,omitempty"            yaml:"enabled,omitempty"`
+		StagesToExecute    []string          `json:"stagesToExecute,omitempty"    yaml:"stagesToExecute,omitempty"`
+		Description        string            `json:"description,omitempty"        yaml:"description,omitempty"`
+		Tags               map[string]string `json:"tags,omitempty"               yaml:"tags,omitempty"`
+		Org                string            `json:"orgIdentifier,omitempty"      yaml:"orgIdentifier,omitempty"`
+		Project            string            `json:"projectIdentifier,omitempty"  yaml:"projectIdentifier,omitempty"`
+		PipelineIdentifier string            `json:"pipelineIdentifier,omitempty" yaml:"pipelineIdentifier,omitempty"`
+		PipelineBranchName string            `json:"pipelineBranchName,omitempty" yaml:"pipelineBranchName,omitempty"`
+		Source             *TriggerSource    `json:"source,omitempty"             yaml:"source,omitempty"`
+		InputSetBranchName string            `json:"inputSetBranchName,omitempty" yaml:"inputSetBranchName,omitempty"`
+		InputYaml          string            `json:"inputYaml,omitempty"          yaml:"inputYaml,omitempty"`
+		InputSetReferences []string          `json:"inputSetRefs,omitempty" yaml:"inputSetRefs,omitempty"`
 	}
 
 	// TriggerSource defines the trigger source configuration.
@@ -44,4 +44,43 @@ type (
 		Type string      `json:"type,omitempty" yaml:"type,omitempty"`
 		Spec interface{} `json:"spec,omitempty" yaml:"spec,omitempty"`
 	}
+
+	// TriggerWebhook defines the webhook trigger event.
+	TriggerWebhook struct {
+		Type string      `json:"type,omitempty" yaml:"type,omitempty"`
+		Spec interface{} `json:"spec,omitempty" yaml:"spec,omitempty"`
+	}
+
+	// TriggerGit defines the git provider webhook trigger
+	// spec (e.g. Github, Gitlab, Bitbucket).
+	TriggerGit struct {
+		ConnectorRef      string              `json:"connectorRef,omitempty"      yaml:"connectorRef,omitempty"`
+		RepoName          string              `json:"repoName,omitempty"          yaml:"repoName,omitempty"`
+		AutoAbortPrev     bool                `json:"autoAbortPreviousExecutions" yaml:"autoAbortPreviousExecutions"`
+		PayloadConditions []*TriggerCondition `json:"payloadConditions"           yaml:"payloadConditions"`
+		HeaderConditions  []*TriggerCondition `json:"headerConditions"            yaml:"headerConditions"`
+		JexlCondition     string              `json:"jexlCondition,omitempty"     yaml:"jexlCondition,omitempty"`
+		Actions           []string            `json:"actions"                     yaml:"actions"`
+	}
+
+	// TriggerCustom defines the custom webhook trigger spec.
+	TriggerCustom struct {
+		PayloadConditions []*TriggerCondition `json:"payloadConditions" yaml:"payloadConditions"`
+		HeaderConditions  []*TriggerCondition `json:"headerConditions"  yaml:"headerConditions"`
+		JexlCondition     string              `json:"jexlCondition,omitempty" yaml:"jexlCondition,omitempty"`
+	}
+
+	// TriggerCron defines the scheduled trigger cron spec.
+	TriggerCron struct {
+		Expression string `json:"expression" yaml:"expression"`
+		Type       string `json:"type,omitempty" yaml:"type,omitempty"`
+	}
+
+	// TriggerCondition defines a trigger payload or header
+	// condition.
+	TriggerCondition struct {
+		Key      string `json:"key"      yaml:"key"`
+		Operator string `json:"operator" yaml:"operator"`
+		Value    string `json:"value"    yaml:"value"`
+	}
 )
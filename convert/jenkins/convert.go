@@ -17,24 +17,20 @@ package jenkins
 
 import (
 	"bytes"
-	"encoding/json"
-	"errors"
-	"fmt"
 	"io"
 	"io/ioutil"
-	"net/http"
-	"net/url"
 	"os"
-	"strconv"
-	"strings"
 	"time"
 
-	"github.com/drone/go-convert/convert/drone"
-	"github.com/drone/go-convert/convert/github"
-	"github.com/drone/go-convert/convert/gitlab"
+	"github.com/drone/go-convert/convert/jenkins/groovy"
+	"github.com/drone/go-convert/convert/jenkins/jenkinsfile"
+	"github.com/drone/go-convert/internal/store"
+	harness "github.com/drone/spec/dist/go"
+
+	"github.com/ghodss/yaml"
 )
 
-// Converter converts a Drone pipeline to a Harness
+// Converter converts a Jenkinsfile to a Harness
 // v1 pipeline.
 type Converter struct {
 	format        Format
@@ -45,10 +41,26 @@ type Converter struct {
 	debug         bool
 	token         string
 	attempts      int
+	llm           LLMClient
+	promptTmpl    string
+	backoff       time.Duration
+	libraryPaths  []string
+	libraryTmpl   bool
+
+	// the below fields are reset for each conversion.
+	library     *jenkinsfile.Library
+	identifiers *store.Identifiers
+	report      *Report
+	script      *groovy.Script
+	methods     map[string]*groovy.MethodDecl
+	file        string
+	inputs      map[string]*harness.Input
+	stage       string
+	spans       int
 }
 
-// New creates a new Converter that converts a Drone
-// pipeline to a Harness v1 pipeline.
+// New creates a new Converter that converts a Jenkinsfile
+// to a Harness v1 pipeline.
 func New(options ...Option) *Converter {
 	d := new(Converter)
 
@@ -73,6 +85,18 @@ func New(options ...Option) *Converter {
 		d.attempts = 1
 	}
 
+	// use the openai client if the token is configured
+	// and no client is configured.
+	if d.llm == nil && d.token != "" {
+		d.llm = NewOpenAIClient(LLMConfig{Token: d.token})
+	}
+
+	// set the default pause before retrying a failed
+	// request.
+	if d.backoff == 0 {
+		d.backoff = time.Second
+	}
+
 	return d
 }
 
@@ -85,9 +109,15 @@ func (d *Converter) Convert(r io.Reader) ([]byte, error) {
 	return d.ConvertBytes(b)
 }
 
-// ConvertString downgrades a v1 pipeline.
+// ConvertBytes converts the Jenkinsfile. The Jenkinsfile
+// is parsed and converted offline, unless a large language
+// model client is configured.
 func (d *Converter) ConvertBytes(b []byte) ([]byte, error) {
-	return d.retry(b)
+	if d.llm != nil {
+		return d.retry(b)
+	}
+	out, _, err := d.ConvertWithReport(bytes.NewBuffer(b))
+	return out, err
 }
 
 // ConvertString downgrades a v1 pipeline.
@@ -105,229 +135,310 @@ func (d *Converter) ConvertFile(p string) ([]byte, error) {
 	return d.Convert(f)
 }
 
-// retry attempts the conversion with a backoff
-func (d *Converter) retry(src []byte) ([]byte, error) {
-	var out []byte
-	var err error
-	for i := 0; i < d.attempts; i++ {
-		// puase before retry
-		if i != 0 {
-			// print status for debug purposes
-			fmt.Fprintln(os.Stderr, "attempt failed")
-			fmt.Fprintln(os.Stderr, err)
-			// 10 seconds before retry
-			time.Sleep(time.Second * 10)
-		}
-		// attempt the conversion
-		if out, err = d.convert(src); err == nil {
-			break
-		}
+// ConvertWithReport parses and converts the Jenkinsfile
+// offline, and returns the converted pipeline with a
+// report of the constructs that could not be converted.
+func (d *Converter) ConvertWithReport(r io.Reader) ([]byte, *Report, error) {
+	src, err := jenkinsfile.Parse(r)
+	if err != nil {
+		return nil, nil, err
 	}
-	return out, err
+	c, err := d.begin()
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.convert(src)
 }
 
-// convert converts a Drone pipeline to a Harness pipeline.
-func (d *Converter) convert(src []byte) ([]byte, error) {
+// helper function returns a copy of the converter with
+// the per-conversion state reset, so that the converter
+// can be reused for multiple, concurrent conversions.
+func (d *Converter) begin() (*Converter, error) {
+	c := *d
+	// create the unique identifier store. this store
+	// is used for registering unique identifiers to
+	// prevent duplicate names, unique index violations.
+	c.identifiers = store.New()
+	c.report = new(Report)
+	c.library = nil
+	c.script = nil
+	c.methods = nil
+	c.file = ""
+	c.inputs = nil
+	c.stage = ""
+	c.spans = 0
+	if err := c.loadLibrary(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
 
-	// gpt input
-	req := &request{
-		Model: "gpt-3.5-turbo",
-		Messages: []*message{
-			{
-				Role:    "user",
-				Content: fmt.Sprintf("Convert this Jenkinsfile to a %s Yaml.\n\n```\n%s\n```\n", d.format.String(), []byte(src)),
-			},
-		},
+// convert converts a Jenkinsfile to a Harness pipeline.
+func (d *Converter) convert(src *jenkinsfile.Pipeline) ([]byte, *Report, error) {
+	d.script = src.Script
+	d.methods = src.Methods
+
+	// create the root scope, which is inherited by
+	// all stages and steps.
+	root := new(scope)
+
+	// if the Jenkinsfile calls a shared library step
+	// that declares the pipeline, the library pipeline
+	// is converted with the call arguments bound to the
+	// step parameters.
+	if v, call := d.libraryPipeline(src); v != nil {
+		root = d.bindParams(v.Call(), call, root)
+		root.depth = 0
+		src = v.Pipeline()
+		d.enterVar(v)
 	}
 
-	// gpt output
-	res := new(response)
+	// create the harness pipeline spec
+	pipeline := &harness.Pipeline{
+		Options: new(harness.Default),
+	}
 
-	// marshal the input to json
-	err := d.do("https://api.openai.com/v1/chat/completions", "POST", req, res)
-	if err != nil {
-		return nil, err
+	// create the harness pipeline
+	config := &harness.Config{
+		Version: 1,
+		Kind:    "pipeline",
+		Spec:    pipeline,
 	}
 
-	if len(res.Choices) == 0 {
-		return nil, errors.New("chat gpt returned a response with zero choices. conversion not possible.")
-	}
-
-	// extract the message
-	code := extractCodeFence(res.Choices[0].Message.Content)
-
-	if d.format == FromDrone {
-		// convert the pipeline yaml from the drone
-		// format to the harness yaml format.
-		converter := drone.New(
-			drone.WithDockerhub(d.dockerhubConn),
-			drone.WithKubernetes(d.kubeConnector, d.kubeNamespace),
-		)
-		pipeline, err := converter.ConvertString(code)
-		if err != nil && d.debug {
-			// dump data for debug mode
-			os.Stdout.WriteString("\n")
-			os.Stdout.WriteString("---")
-			os.Stdout.WriteString("\n")
-			os.Stdout.WriteString(res.Choices[0].Message.Content)
-			os.Stdout.WriteString("\n")
-			os.Stdout.WriteString("---")
-			os.Stdout.WriteString("\n")
-			os.Stdout.Write(pipeline)
-			os.Stdout.WriteString("\n")
-			os.Stdout.WriteString("---")
-			os.Stdout.WriteString("\n")
-		}
-		return pipeline, err
-	}
-
-	if d.format == FromGitlab {
-		// convert the pipeline yaml from the gitlab
-		// format to the harness yaml format.
-		converter := gitlab.New(
-			gitlab.WithDockerhub(d.dockerhubConn),
-			gitlab.WithKubernetes(d.kubeConnector, d.kubeNamespace),
-		)
-		pipeline, err := converter.ConvertString(code)
-		if err != nil {
-			// dump data for debug mode
-			if err != nil && d.debug {
-				os.Stdout.WriteString("\n")
-				os.Stdout.WriteString("---")
-				os.Stdout.WriteString("\n")
-				os.Stdout.WriteString(res.Choices[0].Message.Content)
-				os.Stdout.WriteString("\n")
-				os.Stdout.WriteString("---")
-				os.Stdout.WriteString("\n")
-				os.Stdout.Write(pipeline)
-				os.Stdout.WriteString("\n")
-				os.Stdout.WriteString("---")
-				os.Stdout.WriteString("\n")
-			}
+	// convert parameters to inputs
+	pipeline.Inputs = d.convertParameters(src.Parameters)
+
+	// map the jenkins environment variables used by the
+	// pipeline to harness expressions, and append the
+	// pipeline environment variables.
+	envs := map[string]string{}
+	for name, expr := range jenkinsEnv {
+		if usesVariable(src.Script.Source, name) {
+			envs[name] = expr
 		}
-		return pipeline, err
+	}
+	for k, v := range d.convertEnvironment(src.Environment, root) {
+		envs[k] = v
+	}
+	if len(envs) != 0 {
+		pipeline.Options.Envs = envs
 	}
 
-	// convert the pipeline yaml from the github
-	// format to the harness yaml format.
-	converter := github.New(
-		github.WithDockerhub(d.dockerhubConn),
-		github.WithKubernetes(d.kubeConnector, d.kubeNamespace),
-	)
-	pipeline, err := converter.ConvertString(code)
-	if err != nil {
-		// dump data for debug mode
-		if err != nil && d.debug {
-			os.Stdout.WriteString("\n")
-			os.Stdout.WriteString("---")
-			os.Stdout.WriteString("\n")
-			os.Stdout.WriteString(res.Choices[0].Message.Content)
-			os.Stdout.WriteString("\n")
-			os.Stdout.WriteString("---")
-			os.Stdout.WriteString("\n")
-			os.Stdout.Write(pipeline)
-			os.Stdout.WriteString("\n")
-			os.Stdout.WriteString("---")
-			os.Stdout.WriteString("\n")
+	d.applyAgent(src.Agent, root)
+	d.applyTools(src.Tools, root)
+	d.applyOptions(src.Options, root, pipeline)
+
+	for _, trigger := range src.Triggers {
+		d.unmapped(unmappedTrigger, trigger.Name, trigger, "configure a harness trigger")
+	}
+	// shared library steps are converted if a local
+	// checkout of the library is configured.
+	if d.library == nil {
+		for _, library := range src.Libraries {
+			d.unmapped(unmappedLibrary, library, nil, "shared library steps are not converted")
 		}
 	}
+	for _, node := range src.Unknown {
+		d.unmapped(unmappedSection, nodeName(node), node, "")
+	}
 
-	return pipeline, err
-}
+	// convert the pipeline stages
+	pipeline.Stages = d.convertStages(src.Stages, root)
 
-func extractCodeFence(s string) string {
-	// trim space
-	s = strings.TrimSpace(s)
-	s = strings.TrimSuffix(s, "```")
-	// find and trim the code fence prefix
-	if _, c, ok := strings.Cut(s, "```"); ok {
-		s = c
-		// find and trim the code fence suffix
-		if c, _, ok := strings.Cut(s, "```"); ok {
-			s = c
+	// convert the pipeline post section to stages that
+	// execute conditionally, based on the pipeline status.
+	d.stage = ""
+	for _, post := range src.Post {
+		if stage := d.convertPostStage(post, root); stage != nil {
+			pipeline.Stages = append(pipeline.Stages, stage)
 		}
 	}
-	return strings.TrimPrefix(s, "yaml")
-}
 
-//
-// Chat GPT Client
-// TODO move to separate package
-//
+	// remove the pipeline options if empty
+	if isDefaultEmpty(pipeline.Options) {
+		pipeline.Options = nil
+	}
 
-type request struct {
-	Model    string     `json:"model"`
-	Messages []*message `json:"messages"`
+	// marshal the harness yaml
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, d.report, nil
 }
 
-type message struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+// helper function converts the pipeline parameters to
+// pipeline inputs.
+func (d *Converter) convertParameters(params []*jenkinsfile.Parameter) map[string]*harness.Input {
+	if len(params) == 0 {
+		return nil
+	}
+	inputs := map[string]*harness.Input{}
+	for _, param := range params {
+		if param.Name == "" {
+			d.unmapped(unmappedSection, param.Type, param.Node, "parameter name is not a literal")
+			continue
+		}
+		input := &harness.Input{
+			Type:        "string",
+			Description: param.Description,
+			Default:     param.Default,
+		}
+		switch param.Type {
+		case "string", "text":
+		case "booleanParam":
+			input.Type = "boolean"
+		case "choice":
+			input.Enum = param.Choices
+			if len(param.Choices) != 0 {
+				input.Default = param.Choices[0]
+			}
+		case "password":
+			input.Mask = true
+		default:
+			d.unmapped(unmappedSection, param.Type, param.Node, "converted to a string input")
+		}
+		inputs[param.Name] = input
+	}
+	return inputs
 }
 
-type response struct {
-	ID      string `json:"id"`
-	Object  string `json:"object"`
-	Created int    `json:"created"`
-	Model   string `json:"model"`
-	Usage   struct {
-		PromptTokens     int `json:"prompt_tokens"`
-		CompletionTokens int `json:"completion_tokens"`
-		TotalTokens      int `json:"total_tokens"`
-	} `json:"usage"`
-	Choices []struct {
-		Message struct {
-			Role    string `json:"role"`
-			Content string `json:"content"`
-		} `json:"message"`
-		FinishReason string `json:"finish_reason"`
-		Index        int    `json:"index"`
+// helper function converts the environment section to
+// environment variables.
+func (d *Converter) convertEnvironment(vars []*jenkinsfile.Variable, sc *scope) map[string]string {
+	if len(vars) == 0 {
+		return nil
+	}
+	envs := map[string]string{}
+	for _, v := range vars {
+		// the credentials helper binds a secret to the
+		// environment variable.
+		if call, ok := v.Value.(*groovy.Call); ok && call.Name == "credentials" && call.Receiver == nil {
+			if id := stringArg(call); id != "" {
+				envs[v.Name] = secretExpr(id)
+				continue
+			}
+		}
+		envs[v.Name] = d.shellExpr(v.Value, sc)
 	}
+	return envs
 }
 
-// helper function to make an http request
-func (d *Converter) do(rawurl, method string, in, out interface{}) error {
-	body, err := d.open(rawurl, method, in, out)
-	if err != nil {
-		return err
+// helper function applies the agent to the scope.
+func (d *Converter) applyAgent(agent *jenkinsfile.Agent, sc *scope) {
+	if agent == nil {
+		return
 	}
-	defer body.Close()
-	if out != nil {
-		return json.NewDecoder(body).Decode(out)
+	switch agent.Type {
+	case "any", "none", "":
+	case "label":
+		if isWindowsLabel(agent.Label) {
+			sc.os = harness.OSWindows.String()
+		} else {
+			d.unmapped(unmappedAgent, "label", agent.Node, "agent label "+agent.Label+" is not converted")
+		}
+	case "docker":
+		sc.image = agent.Image
+		if agent.Args != "" {
+			d.unmapped(unmappedAgent, "docker", agent.Node, "docker args are not converted")
+		}
+		if agent.Registry != "" || agent.Credentials != "" {
+			d.unmapped(unmappedAgent, "docker", agent.Node, "configure a docker registry connector")
+		}
+	case "kubernetes":
+		if image := podImage(agent.Yaml, agent.Container); image != "" {
+			sc.image = image
+		} else {
+			d.unmapped(unmappedAgent, "kubernetes", agent.Node, "pod template is not converted")
+		}
+	case "dockerfile":
+		d.unmapped(unmappedAgent, "dockerfile", agent.Node, "build the image in a separate step")
+	default:
+		d.unmapped(unmappedAgent, agent.Type, agent.Node, "")
 	}
-	return nil
 }
 
-// helper function to open an http request
-func (d *Converter) open(rawurl, method string, in, out interface{}) (io.ReadCloser, error) {
-	uri, err := url.Parse(rawurl)
-	if err != nil {
-		return nil, err
+// helper function applies the tools to the scope. The
+// tool is converted to a container image.
+func (d *Converter) applyTools(tools []*jenkinsfile.Tool, sc *scope) {
+	var jdk string
+	for _, tool := range tools {
+		image := toolImage(tool.Type, tool.Name)
+		switch {
+		case image == "":
+			d.unmapped(unmappedTool, tool.Type, nil, "tool "+tool.Name+" is not converted")
+		case tool.Type == "jdk":
+			jdk = image
+		default:
+			// build tool images bundle a jdk and are
+			// preferred over the jdk image.
+			sc.image = image
+		}
 	}
-	req, err := http.NewRequest(method, uri.String(), nil)
-	if err != nil {
-		return nil, err
+	if jdk != "" && sc.image == "" {
+		sc.image = jdk
 	}
-	if in != nil {
-		decoded, derr := json.Marshal(in)
-		if derr != nil {
-			return nil, derr
+}
+
+// helper function applies the options to the scope. The
+// pipeline is non-nil for pipeline options.
+func (d *Converter) applyOptions(options []*groovy.Call, sc *scope, pipeline *harness.Pipeline) {
+	for _, option := range options {
+		switch option.Name {
+		case "timeout":
+			timeout := convertTimeout(groovy.Args(option.Args))
+			if pipeline != nil {
+				pipeline.Options.Timeout = timeout
+			} else {
+				sc.timeout = timeout
+			}
+		case "retry":
+			if n, ok := numberArg(option); ok {
+				sc.retry = int64(n)
+			}
+		case "skipDefaultCheckout":
+			if pipeline != nil {
+				pipeline.Options.Clone = &harness.Clone{Disabled: true}
+			} else {
+				sc.skipClone = true
+			}
+		// options that do not affect the converted
+		// pipeline are silently ignored.
+		case "timestamps", "ansiColor", "disableResume",
+			"durabilityHint", "preserveStashes", "buildDiscarder",
+			"parallelsAlwaysFailFast", "quietPeriod", "checkoutToSubdirectory",
+			"skipStagesAfterUnstable", "newContainerPerStage":
+		default:
+			d.unmapped(unmappedOption, option.Name, option, "")
 		}
-		buf := bytes.NewBuffer(decoded)
-		req.Body = ioutil.NopCloser(buf)
-		req.ContentLength = int64(len(decoded))
-		req.Header.Set("Content-Length", strconv.Itoa(len(decoded)))
-		req.Header.Set("Content-Type", "application/json")
-	}
-	req.Header.Set("Authorization", "Bearer "+d.token)
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
 	}
-	if resp.StatusCode > 299 {
-		defer resp.Body.Close()
-		out, _ := ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("client error %d: %s", resp.StatusCode, string(out))
+}
+
+// helper function returns the runtime.
+func (d *Converter) convertRuntime() *harness.Runtime {
+	if d.kubeEnabled {
+		return &harness.Runtime{
+			Type: "kubernetes",
+			Spec: &harness.RuntimeKube{
+				Namespace: d.kubeNamespace,
+				Connector: d.kubeConnector,
+			},
+		}
+	}
+	return &harness.Runtime{
+		Type: "cloud",
+		Spec: &harness.RuntimeCloud{},
+	}
+}
+
+// helper function returns the platform.
+func (d *Converter) convertPlatform(sc *scope) *harness.Platform {
+	os := harness.OSLinux.String()
+	if sc.os != "" {
+		os = sc.os
+	}
+	return &harness.Platform{
+		Os:   os,
+		Arch: harness.ArchAmd64.String(),
 	}
-	return resp.Body, nil
 }
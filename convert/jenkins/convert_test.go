@@ -13,10 +13,379 @@
 // limitations under the License.
 package jenkins
 
-import "testing"
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+)
 
 func TestConvert(t *testing.T) {
-	t.Skip()
+	tests, err := filepath.Glob("testdata/declarative/*.Jenkinsfile")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			// convert the jenkinsfile to harness
+			converter := New()
+			tmp1, err := converter.ConvertFile(test)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			// unmarshal the converted yaml file to a map
+			got := map[string]interface{}{}
+			if err := yaml.Unmarshal(tmp1, &got); err != nil {
+				t.Error(err)
+				return
+			}
+
+			// parse the golden yaml file
+			data, err := ioutil.ReadFile(test + ".golden")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			// unmarshal the golden yaml file to a map
+			want := map[string]interface{}{}
+			if err := yaml.Unmarshal(data, &want); err != nil {
+				t.Error(err)
+				return
+			}
+
+			// compare the converted yaml to the golden file
+			if diff := cmp.Diff(got, want); diff != "" {
+				t.Errorf("Unexpected conversion result")
+				t.Log(diff)
+			}
+		})
+	}
+}
+
+// this test verifies the legacy jenkinsfiles, which
+// include scripted pipelines, convert without error.
+func TestConvertFiles(t *testing.T) {
+	tests, err := filepath.Glob("testdata/Jenkinsfile*")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			out, err := New().ConvertFile(test)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if !strings.Contains(string(out), "stages:") {
+				t.Errorf("Expect converted stages")
+			}
+		})
+	}
+}
+
+// TestConvertTraceJenkinsfiles converts the Jenkinsfiles of
+// the jenkins json test files offline. The conversion may
+// fail, but must not panic.
+func TestConvertTraceJenkinsfiles(t *testing.T) {
+	var files []string
+	err := filepath.Walk("../jenkinsjson/convertTestFiles", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && strings.HasPrefix(info.Name(), "Jenkinsfile") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(files) == 0 {
+		t.Errorf("Want Jenkinsfiles in the jenkins json test files")
+	}
+	for _, file := range files {
+		t.Run(file, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("Unexpected panic: %v", r)
+				}
+			}()
+			data, err := ioutil.ReadFile(file)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			New().ConvertWithReport(bytes.NewReader(data))
+		})
+	}
+}
+
+func TestConvertReport(t *testing.T) {
+	src := `
+pipeline {
+    agent { label 'linux' }
+    triggers { cron('H * * * *') }
+    stages {
+        stage('Build') {
+            steps {
+                sh 'make'
+                customStep name: 'foo'
+            }
+        }
+    }
+}
+`
+	_, report, err := New().ConvertWithReport(strings.NewReader(src))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	var got []string
+	for _, item := range report.Unmapped {
+		got = append(got, item.Kind+"/"+item.Name+"/"+item.Stage)
+	}
+	want := []string{
+		"agent/label/",
+		"trigger/cron/",
+		"step/customStep/Build",
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected report")
+		t.Log(diff)
+	}
+	if got, want := report.Unmapped[2].Line, 9; got != want {
+		t.Errorf("Want line %d, got %d", want, got)
+	}
+}
+
+func TestConvertReportFailFast(t *testing.T) {
+	src := `
+pipeline {
+    agent any
+    stages {
+        stage('Test') {
+            failFast true
+            parallel {
+                stage('Unit') {
+                    steps { sh 'make test' }
+                }
+                stage('Lint') {
+                    steps {
+                        parallel failFast: true, vet: { sh 'go vet' }, fmt: { sh 'gofmt -l .' }
+                    }
+                }
+            }
+        }
+    }
+}
+`
+	_, report, err := New().ConvertWithReport(strings.NewReader(src))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	var got []string
+	for _, item := range report.Unmapped {
+		got = append(got, item.Kind+"/"+item.Name+"/"+item.Stage)
+	}
+	want := []string{
+		"option/failFast/Lint",
+		"option/failFast/Test",
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected report")
+		t.Log(diff)
+	}
+}
+
+func TestConvertReuse(t *testing.T) {
+	src := `
+pipeline {
+    agent any
+    stages {
+        stage('Build') {
+            steps {
+                customStep name: 'foo'
+            }
+        }
+    }
+}
+`
+	converter := New()
+	first, _, err := converter.ConvertWithReport(strings.NewReader(src))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// the converter is shared by concurrent conversions,
+	// and the identifiers and report are not carried over
+	// between conversions.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			out, report, err := converter.ConvertWithReport(strings.NewReader(src))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if diff := cmp.Diff(string(out), string(first)); diff != "" {
+				t.Errorf("Unexpected conversion result")
+				t.Log(diff)
+			}
+			if got, want := len(report.Unmapped), 1; got != want {
+				t.Errorf("Want %d unmapped constructs, got %d", want, got)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestConvertLibrary(t *testing.T) {
+	tests := []struct {
+		file    string
+		golden  string
+		options []Option
+	}{
+		{
+			file:    "testdata/library/Jenkinsfile.inline",
+			golden:  "testdata/library/Jenkinsfile.inline.golden",
+			options: []Option{WithLibrary("testdata/library")},
+		},
+		{
+			file:    "testdata/library/Jenkinsfile.pipeline",
+			golden:  "testdata/library/Jenkinsfile.pipeline.golden",
+			options: []Option{WithLibrary("testdata/library")},
+		},
+		{
+			file:    "testdata/library/Jenkinsfile.inline",
+			golden:  "testdata/library/templates/Jenkinsfile.inline.golden",
+			options: []Option{WithLibrary("testdata/library"), WithLibraryTemplates()},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.golden, func(t *testing.T) {
+			out, err := New(test.options...).ConvertFile(test.file)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			compareGolden(t, out, test.golden)
+		})
+	}
+}
+
+func TestConvertLibraryTemplates(t *testing.T) {
+	templates, _, err := New(WithLibrary("testdata/library")).ConvertLibrary()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	// the maven var does not declare a call method, and
+	// the javaPipeline var declares the pipeline.
+	if got, want := len(templates), 1; got != want {
+		t.Errorf("Want %d templates, got %d", want, got)
+	}
+	compareGolden(t, templates["buildJavaService"], "testdata/library/templates/buildJavaService.yaml.golden")
+}
+
+func TestConvertLibraryReport(t *testing.T) {
+	f, err := os.Open("testdata/library/Jenkinsfile.inline")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer f.Close()
+
+	_, report, err := New(WithLibrary("testdata/library")).ConvertWithReport(f)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	// the library annotation is not reported, and the
+	// unmapped condition is reported with the library
+	// file and line.
+	want := []*Unmapped{
+		{
+			Kind:   "condition",
+			Name:   "if",
+			Stage:  "Build",
+			File:   "testdata/library/vars/buildJavaService.groovy",
+			Line:   6,
+			Detail: "config.publish",
+		},
+	}
+	if diff := cmp.Diff(report.Unmapped, want); diff != "" {
+		t.Errorf("Unexpected report")
+		t.Log(diff)
+	}
+
+	_, report, err = New().ConvertWithReport(strings.NewReader("@Library('ci-lib') _\nbuildJavaService()"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := report.Unmapped[0].Kind, "library"; got != want {
+		t.Errorf("Want unmapped %s without a library checkout, got %s", want, got)
+	}
+}
+
+// helper function compares the converted yaml to the
+// golden file.
+func compareGolden(t *testing.T, out []byte, golden string) {
+	got := map[string]interface{}{}
+	if err := yaml.Unmarshal(out, &got); err != nil {
+		t.Error(err)
+		return
+	}
+	data, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	want := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &want); err != nil {
+		t.Error(err)
+		return
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected conversion result")
+		t.Log(diff)
+	}
+}
+
+func TestGlobToRegexp(t *testing.T) {
+	tests := []struct {
+		glob string
+		want string
+	}{
+		{glob: "main", want: "^main$"},
+		{glob: "release/*", want: "^release/[^/]*$"},
+		{glob: "feature/**", want: "^feature/.*$"},
+		{glob: "v1.?", want: "^v1\\.[^/]$"},
+	}
+	for _, test := range tests {
+		if got := globToRegexp(test.glob); got != test.want {
+			t.Errorf("Want regexp %q for glob %q, got %q", test.want, test.glob, got)
+		}
+	}
 }
 
 func TestExtractCodeFence(t *testing.T) {
@@ -0,0 +1,378 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jenkins
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/drone/go-convert/convert/jenkins/groovy"
+)
+
+// jenkinsEnv maps the jenkins environment variables to
+// harness expressions.
+var jenkinsEnv = map[string]string{
+	"BRANCH_NAME":   "<+codebase.branch>",
+	"GIT_BRANCH":    "<+codebase.branch>",
+	"GIT_COMMIT":    "<+codebase.commitSha>",
+	"GIT_URL":       "<+codebase.repoUrl>",
+	"TAG_NAME":      "<+codebase.tag>",
+	"CHANGE_ID":     "<+codebase.prNumber>",
+	"CHANGE_TITLE":  "<+codebase.prTitle>",
+	"CHANGE_TARGET": "<+codebase.targetBranch>",
+	"CHANGE_BRANCH": "<+codebase.sourceBranch>",
+	"CHANGE_AUTHOR": "<+codebase.gitUserId>",
+	"BUILD_NUMBER":  "<+pipeline.sequenceId>",
+	"BUILD_ID":      "<+pipeline.sequenceId>",
+	"BUILD_URL":     "<+pipeline.executionUrl>",
+	"JOB_NAME":      "<+pipeline.name>",
+}
+
+// jexl operator precedence, from lowest to highest.
+var jexlPrecedence = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"==": 3,
+	"!=": 3,
+	"=~": 3,
+	"<":  4,
+	">":  4,
+	"<=": 4,
+	">=": 4,
+	"+":  5,
+	"-":  5,
+	"*":  6,
+	"/":  6,
+	"%":  6,
+}
+
+const (
+	precTernary = 0
+	precUnary   = 7
+	precPrimary = 8
+)
+
+// jexlMethods lists the string methods that are supported
+// by jexl expressions.
+var jexlMethods = map[string]bool{
+	"contains":         true,
+	"endsWith":         true,
+	"equals":           true,
+	"equalsIgnoreCase": true,
+	"isEmpty":          true,
+	"matches":          true,
+	"startsWith":       true,
+	"toLowerCase":      true,
+	"toUpperCase":      true,
+	"trim":             true,
+}
+
+// binding defines a method parameter bound to the
+// converted argument value.
+type binding struct {
+	shell string
+	jexl  string
+}
+
+var upperName = regexp.MustCompile(`^[A-Z_][A-Z0-9_]*$`)
+
+// helper function converts the expression to a jexl
+// expression. Returns false if the expression cannot be
+// converted.
+func (d *Converter) jexlExpr(expr groovy.Expr, sc *scope) (string, bool) {
+	s, _, ok := d.jexl(expr, sc)
+	return s, ok
+}
+
+// helper function converts the expression to a jexl
+// expression, and returns the expression precedence.
+func (d *Converter) jexl(expr groovy.Expr, sc *scope) (string, int, bool) {
+	switch v := expr.(type) {
+	case *groovy.String:
+		if len(v.Parts) != 0 {
+			return "", 0, false
+		}
+		return fmt.Sprintf("%q", v.Value), precPrimary, true
+	case *groovy.Number:
+		return v.Value, precPrimary, true
+	case *groovy.Bool:
+		return fmt.Sprint(v.Value), precPrimary, true
+	case *groovy.Null:
+		return "null", precPrimary, true
+	case *groovy.Ident, *groovy.Property:
+		s, ok := d.jexlVar(expr, sc)
+		return s, precPrimary, ok
+	case *groovy.List:
+		var items []string
+		for _, item := range v.Items {
+			s, ok := d.jexlExpr(item, sc)
+			if !ok {
+				return "", 0, false
+			}
+			items = append(items, s)
+		}
+		return "[" + strings.Join(items, ", ") + "]", precPrimary, true
+	case *groovy.Cast:
+		return d.jexl(v.X, sc)
+	case *groovy.Unary:
+		if v.Postfix || (v.Op != "!" && v.Op != "-") {
+			return "", 0, false
+		}
+		x, prec, ok := d.jexl(v.X, sc)
+		if !ok {
+			return "", 0, false
+		}
+		if prec < precUnary {
+			x = "(" + x + ")"
+		}
+		return v.Op + x, precUnary, true
+	case *groovy.Binary:
+		op := v.Op
+		switch op {
+		case "==~":
+			op = "=~"
+		case "in":
+			// jexl uses the match operator to test
+			// membership of a list.
+			op = "=~"
+		}
+		prec, ok := jexlPrecedence[op]
+		if !ok {
+			return "", 0, false
+		}
+		x, xprec, ok := d.jexl(v.X, sc)
+		if !ok {
+			return "", 0, false
+		}
+		y, yprec, ok := d.jexl(v.Y, sc)
+		if !ok {
+			return "", 0, false
+		}
+		if xprec < prec {
+			x = "(" + x + ")"
+		}
+		if yprec <= prec {
+			y = "(" + y + ")"
+		}
+		return x + " " + op + " " + y, prec, true
+	case *groovy.Ternary:
+		cond, prec, ok := d.jexl(v.Cond, sc)
+		if !ok {
+			return "", 0, false
+		}
+		if prec <= precTernary {
+			cond = "(" + cond + ")"
+		}
+		y, _, ok := d.jexl(v.Else, sc)
+		if !ok {
+			return "", 0, false
+		}
+		if v.Then == nil {
+			return cond + " ?: " + y, precTernary, true
+		}
+		x, _, ok := d.jexl(v.Then, sc)
+		if !ok {
+			return "", 0, false
+		}
+		return cond + " ? " + x + " : " + y, precTernary, true
+	case *groovy.Call:
+		if v.Receiver == nil || v.Closure != nil {
+			return "", 0, false
+		}
+		recv, prec, ok := d.jexl(v.Receiver, sc)
+		if !ok {
+			return "", 0, false
+		}
+		if prec < precPrimary {
+			recv = "(" + recv + ")"
+		}
+		if v.Name == "toBoolean" && len(v.Args) == 0 {
+			return recv + ` == "true"`, jexlPrecedence["=="], true
+		}
+		if !jexlMethods[v.Name] {
+			return "", 0, false
+		}
+		var args []string
+		for _, arg := range v.Args {
+			if arg.Name != "" {
+				return "", 0, false
+			}
+			s, ok := d.jexlExpr(arg.Value, sc)
+			if !ok {
+				return "", 0, false
+			}
+			args = append(args, s)
+		}
+		return recv + "." + v.Name + "(" + strings.Join(args, ", ") + ")", precPrimary, true
+	}
+	return "", 0, false
+}
+
+// helper function converts the variable to a jexl
+// expression.
+func (d *Converter) jexlVar(expr groovy.Expr, sc *scope) (string, bool) {
+	name := groovy.Name(expr)
+	if sc != nil {
+		if b, ok := sc.vars[name]; ok {
+			return b.jexl, b.jexl != ""
+		}
+		if input, ok := d.templateInput(name, sc); ok {
+			return input, true
+		}
+	}
+	switch {
+	case strings.HasPrefix(name, "params."):
+		return "<+inputs." + strings.TrimPrefix(name, "params.") + ">", true
+	case strings.HasPrefix(name, "env."):
+		name = strings.TrimPrefix(name, "env.")
+	case !upperName.MatchString(name):
+		return "", false
+	}
+	if expr, ok := jenkinsEnv[name]; ok {
+		return expr, true
+	}
+	return "<+pipeline.variables." + name + ">", true
+}
+
+// helper function converts the expression to shell text,
+// for example a script argument. Interpolated variables
+// are converted to shell variables or harness expressions.
+func (d *Converter) shellExpr(expr groovy.Expr, sc *scope) string {
+	switch v := expr.(type) {
+	case *groovy.String:
+		if len(v.Parts) == 0 {
+			return v.Value
+		}
+		var sb strings.Builder
+		for _, part := range v.Parts {
+			if part.Expr == nil {
+				sb.WriteString(part.Text)
+			} else {
+				sb.WriteString(d.shellExpr(part.Expr, sc))
+			}
+		}
+		return sb.String()
+	case *groovy.Binary:
+		if v.Op == "+" {
+			return d.shellExpr(v.X, sc) + d.shellExpr(v.Y, sc)
+		}
+	case *groovy.Number:
+		return v.Value
+	case *groovy.Bool:
+		return fmt.Sprint(v.Value)
+	case *groovy.Null:
+		return ""
+	}
+	return d.shellVar(expr, sc)
+}
+
+// helper function converts the variable expression to a
+// shell variable or harness expression.
+func (d *Converter) shellVar(expr groovy.Expr, sc *scope) string {
+	name := groovy.Name(expr)
+	if sc != nil {
+		if b, ok := sc.vars[name]; ok {
+			return b.shell
+		}
+		if input, ok := d.templateInput(name, sc); ok {
+			return input
+		}
+	}
+	switch {
+	case strings.HasPrefix(name, "params."):
+		return "<+inputs." + strings.TrimPrefix(name, "params.") + ">"
+	case strings.HasPrefix(name, "env."):
+		return "${" + strings.TrimPrefix(name, "env.") + "}"
+	case upperName.MatchString(name):
+		return "${" + name + "}"
+	case name != "":
+		// groovy variables are not available to the
+		// converted steps.
+		d.unmapped(unmappedVariable, name, expr, "converted to an environment variable reference")
+		return "${" + name + "}"
+	}
+	text := d.script.Text(expr)
+	d.unmapped(unmappedVariable, text, expr, "groovy expression is not converted")
+	return "${" + text + "}"
+}
+
+// helper function converts the expression to a step
+// parameter value.
+func (d *Converter) paramValue(expr groovy.Expr, sc *scope) interface{} {
+	switch v := expr.(type) {
+	case *groovy.Number, *groovy.Bool:
+		return groovy.Value(v)
+	case *groovy.Null:
+		return nil
+	case *groovy.List:
+		out := []interface{}{}
+		for _, item := range v.Items {
+			out = append(out, d.paramValue(item, sc))
+		}
+		return out
+	case *groovy.Map:
+		return d.paramArgs(v.Entries, sc)
+	case *groovy.Call:
+		if v.Receiver == nil {
+			return map[string]interface{}{
+				"symbol":    v.Name,
+				"arguments": d.paramArgs(v.Args, sc),
+			}
+		}
+	}
+	return d.shellExpr(expr, sc)
+}
+
+// helper function converts the call arguments to step
+// parameter values. Named arguments are returned as a map.
+// If all arguments are positional, a single argument is
+// returned as its value and multiple arguments are
+// returned as a slice.
+func (d *Converter) paramArgs(args []*groovy.Arg, sc *scope) interface{} {
+	named := map[string]interface{}{}
+	var positional []interface{}
+	for _, arg := range args {
+		switch {
+		case arg.Name != "":
+			named[arg.Name] = d.paramValue(arg.Value, sc)
+		case arg.Key != nil:
+			if key, ok := groovy.Value(arg.Key).(string); ok {
+				named[key] = d.paramValue(arg.Value, sc)
+			}
+		default:
+			positional = append(positional, d.paramValue(arg.Value, sc))
+		}
+	}
+	switch {
+	case len(named) != 0 || len(positional) == 0:
+		return named
+	case len(positional) == 1:
+		return positional[0]
+	default:
+		return positional
+	}
+}
+
+// helper function returns true if the source references
+// the named variable.
+func usesVariable(src, name string) bool {
+	return regexp.MustCompile(`\b` + name + `\b`).MatchString(src)
+}
+
+// helper function returns the harness secret expression.
+func secretExpr(id string) string {
+	return fmt.Sprintf("<+secrets.getValue(%q)>", id)
+}
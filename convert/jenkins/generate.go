@@ -0,0 +1,169 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jenkins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/drone/go-convert/convert/drone"
+	droneyaml "github.com/drone/go-convert/convert/drone/yaml"
+	"github.com/drone/go-convert/convert/github"
+	githubyaml "github.com/drone/go-convert/convert/github/yaml"
+	"github.com/drone/go-convert/convert/gitlab"
+	gitlabyaml "github.com/drone/go-convert/convert/gitlab/yaml"
+)
+
+// retry attempts the conversion using the large language
+// model. If the returned yaml cannot be parsed, the parse
+// error is sent back to the model and the conversion is
+// retried. If the request fails, the conversion is retried
+// with a backoff.
+func (d *Converter) retry(src []byte) ([]byte, error) {
+	messages, err := d.prompt(src)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < d.attempts; i++ {
+		// print status before retry
+		if i != 0 {
+			// print status for debug purposes
+			fmt.Fprintln(os.Stderr, "attempt failed")
+			fmt.Fprintln(os.Stderr, err)
+		}
+
+		// attempt the conversion
+		var content string
+		content, err = d.llm.Complete(context.Background(), messages)
+		if err != nil {
+			// pause before retrying the failed request
+			if i+1 < d.attempts {
+				time.Sleep(d.backoff)
+			}
+			continue
+		}
+
+		// validate and convert the yaml. If invalid, the
+		// response and the error are appended to the chat
+		// so that the model can correct the yaml.
+		var out []byte
+		code := extractCodeFence(content)
+		if err = d.validate(code); err == nil {
+			if out, err = d.convertIntermediate(code); err == nil {
+				return out, nil
+			}
+		}
+		if d.debug {
+			// dump data for debug mode
+			os.Stdout.WriteString("\n---\n")
+			os.Stdout.WriteString(content)
+			os.Stdout.WriteString("\n---\n")
+		}
+		messages = append(messages,
+			&LLMMessage{Role: "assistant", Content: content},
+			&LLMMessage{Role: "user", Content: fmt.Sprintf(feedbackPrompt, d.format, err)},
+		)
+	}
+	return nil, err
+}
+
+// validate parses the yaml with the intermediate format
+// parser, and returns an error if the yaml is invalid or
+// does not define a pipeline.
+func (d *Converter) validate(code string) error {
+	if strings.TrimSpace(code) == "" {
+		return errors.New("the yaml is empty")
+	}
+	switch d.format {
+	case FromDrone:
+		pipelines, err := droneyaml.ParseString(code)
+		if err != nil {
+			return err
+		}
+		for _, pipeline := range pipelines {
+			if len(pipeline.Steps) != 0 {
+				return nil
+			}
+		}
+		return errors.New("the yaml does not define a pipeline with steps")
+	case FromGitlab:
+		pipeline, err := gitlabyaml.ParseString(code)
+		if err != nil {
+			return err
+		}
+		if len(pipeline.Jobs) == 0 {
+			return errors.New("the yaml does not define any jobs")
+		}
+	default:
+		pipeline, err := githubyaml.ParseString(code)
+		if err != nil {
+			return err
+		}
+		if len(pipeline.Jobs) == 0 {
+			return errors.New("the yaml does not define any jobs")
+		}
+	}
+	return nil
+}
+
+// convertIntermediate converts the intermediate format
+// yaml to a Harness pipeline.
+func (d *Converter) convertIntermediate(code string) ([]byte, error) {
+	switch d.format {
+	case FromDrone:
+		// convert the pipeline yaml from the drone
+		// format to the harness yaml format.
+		converter := drone.New(
+			drone.WithDockerhub(d.dockerhubConn),
+			drone.WithKubernetes(d.kubeConnector, d.kubeNamespace),
+		)
+		return converter.ConvertString(code)
+	case FromGitlab:
+		// convert the pipeline yaml from the gitlab
+		// format to the harness yaml format.
+		converter := gitlab.New(
+			gitlab.WithDockerhub(d.dockerhubConn),
+			gitlab.WithKubernetes(d.kubeConnector, d.kubeNamespace),
+		)
+		return converter.ConvertString(code)
+	default:
+		// convert the pipeline yaml from the github
+		// format to the harness yaml format.
+		converter := github.New(
+			github.WithDockerhub(d.dockerhubConn),
+			github.WithKubernetes(d.kubeConnector, d.kubeNamespace),
+		)
+		return converter.ConvertString(code)
+	}
+}
+
+func extractCodeFence(s string) string {
+	// trim space
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(s, "```")
+	// find and trim the code fence prefix
+	if _, c, ok := strings.Cut(s, "```"); ok {
+		s = c
+		// find and trim the code fence suffix
+		if c, _, ok := strings.Cut(s, "```"); ok {
+			s = c
+		}
+	}
+	return strings.TrimPrefix(s, "yaml")
+}
@@ -0,0 +1,395 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groovy
+
+type (
+	// Node is implemented by all nodes in the syntax tree.
+	Node interface {
+		Span() (pos, end int)
+	}
+
+	// Expr is implemented by all expression nodes.
+	Expr interface {
+		Node
+		expr()
+	}
+
+	// Stmt is implemented by all statement nodes.
+	Stmt interface {
+		Node
+		stmt()
+	}
+
+	// Pos defines the start and end byte offsets of a node
+	// in the source.
+	Pos struct {
+		Start int
+		Stop  int
+	}
+)
+
+// Span returns the start and end byte offsets of the node.
+func (p Pos) Span() (int, int) { return p.Start, p.Stop }
+
+//
+// Expressions
+//
+
+type (
+	// Ident defines an identifier, such as a variable name.
+	Ident struct {
+		Pos
+		Name string
+	}
+
+	// String defines a string literal. Parts is non-empty
+	// if the string is a GString with interpolated
+	// expressions.
+	String struct {
+		Pos
+		Value string
+		Parts []*Part
+	}
+
+	// Part defines a part of an interpolated string. Either
+	// Text or Expr is set.
+	Part struct {
+		Text string
+		Expr Expr
+	}
+
+	// Number defines a numeric literal.
+	Number struct {
+		Pos
+		Value string
+	}
+
+	// Bool defines a boolean literal.
+	Bool struct {
+		Pos
+		Value bool
+	}
+
+	// Null defines the null literal.
+	Null struct {
+		Pos
+	}
+
+	// List defines a list literal.
+	List struct {
+		Pos
+		Items []Expr
+	}
+
+	// Map defines a map literal.
+	Map struct {
+		Pos
+		Entries []*Arg
+	}
+
+	// Closure defines a closure.
+	Closure struct {
+		Pos
+		Params []*Param
+		Body   *Block
+	}
+
+	// Call defines a method call. Receiver is nil for
+	// unqualified calls, such as pipeline steps.
+	Call struct {
+		Pos
+		Receiver Expr
+		Name     string
+		Args     []*Arg
+		Closure  *Closure
+		Safe     bool
+	}
+
+	// Arg defines a call argument or map entry. Name is
+	// empty for positional arguments.
+	Arg struct {
+		Name  string
+		Key   Expr
+		Value Expr
+	}
+
+	// Property defines a property access expression.
+	Property struct {
+		Pos
+		X    Expr
+		Name string
+		Safe bool
+	}
+
+	// Index defines an index expression.
+	Index struct {
+		Pos
+		X     Expr
+		Index Expr
+	}
+
+	// Unary defines a unary expression.
+	Unary struct {
+		Pos
+		Op      string
+		X       Expr
+		Postfix bool
+	}
+
+	// Binary defines a binary expression.
+	Binary struct {
+		Pos
+		Op string
+		X  Expr
+		Y  Expr
+	}
+
+	// Ternary defines a conditional expression. Then is
+	// nil for the elvis operator.
+	Ternary struct {
+		Pos
+		Cond Expr
+		Then Expr
+		Else Expr
+	}
+
+	// Cast defines a type cast expression, for example
+	// "x as String".
+	Cast struct {
+		Pos
+		X    Expr
+		Type string
+	}
+
+	// New defines an object creation expression.
+	New struct {
+		Pos
+		Type string
+		Args []*Arg
+	}
+)
+
+func (*Ident) expr()    {}
+func (*String) expr()   {}
+func (*Number) expr()   {}
+func (*Bool) expr()     {}
+func (*Null) expr()     {}
+func (*List) expr()     {}
+func (*Map) expr()      {}
+func (*Closure) expr()  {}
+func (*Call) expr()     {}
+func (*Property) expr() {}
+func (*Index) expr()    {}
+func (*Unary) expr()    {}
+func (*Binary) expr()   {}
+func (*Ternary) expr()  {}
+func (*Cast) expr()     {}
+func (*New) expr()      {}
+
+//
+// Statements
+//
+
+type (
+	// Block defines a block of statements.
+	Block struct {
+		Pos
+		Stmts []Stmt
+	}
+
+	// ExprStmt defines an expression statement, such as
+	// a pipeline step.
+	ExprStmt struct {
+		Pos
+		X Expr
+	}
+
+	// Assign defines an assignment or variable declaration.
+	Assign struct {
+		Pos
+		Target Expr
+		Op     string
+		Value  Expr
+		Decl   bool
+		Type   string
+	}
+
+	// MethodDecl defines a method declaration.
+	MethodDecl struct {
+		Pos
+		Name   string
+		Type   string
+		Params []*Param
+		Body   *Block
+	}
+
+	// Param defines a method or closure parameter.
+	Param struct {
+		Name    string
+		Type    string
+		Default Expr
+	}
+
+	// If defines an if statement.
+	If struct {
+		Pos
+		Cond Expr
+		Then Stmt
+		Else Stmt
+	}
+
+	// For defines a for loop. The loop header is stored
+	// as source text.
+	For struct {
+		Pos
+		Header string
+		Body   Stmt
+	}
+
+	// While defines a while loop.
+	While struct {
+		Pos
+		Cond Expr
+		Body Stmt
+	}
+
+	// Try defines a try, catch and finally statement.
+	Try struct {
+		Pos
+		Body    *Block
+		Catches []*Catch
+		Finally *Block
+	}
+
+	// Catch defines a catch clause.
+	Catch struct {
+		Param *Param
+		Body  *Block
+	}
+
+	// Jump defines a return, throw, break or continue
+	// statement.
+	Jump struct {
+		Pos
+		Keyword string
+		X       Expr
+	}
+
+	// Annotation defines an annotation, for example
+	// @Library('my-library').
+	Annotation struct {
+		Pos
+		Name string
+		Args []*Arg
+	}
+
+	// Import defines an import statement.
+	Import struct {
+		Pos
+		Path string
+	}
+
+	// Raw defines a statement the parser does not model,
+	// such as a switch statement or class declaration. The
+	// statement is stored as source text.
+	Raw struct {
+		Pos
+		Keyword string
+	}
+)
+
+func (*Block) stmt()      {}
+func (*ExprStmt) stmt()   {}
+func (*Assign) stmt()     {}
+func (*MethodDecl) stmt() {}
+func (*If) stmt()         {}
+func (*For) stmt()        {}
+func (*While) stmt()      {}
+func (*Try) stmt()        {}
+func (*Jump) stmt()       {}
+func (*Annotation) stmt() {}
+func (*Import) stmt()     {}
+func (*Raw) stmt()        {}
+
+// Script defines a parsed groovy script.
+type Script struct {
+	Source string
+	Body   *Block
+
+	lines []int
+}
+
+// Text returns the source text of the node.
+func (s *Script) Text(n Node) string {
+	pos, end := n.Span()
+	if pos < 0 || end > len(s.Source) || pos > end {
+		return ""
+	}
+	return s.Source[pos:end]
+}
+
+// Line returns the line number of the node, starting at 1.
+func (s *Script) Line(n Node) int {
+	pos, _ := n.Span()
+	if s.lines == nil {
+		s.lines = []int{0}
+		for i := 0; i < len(s.Source); i++ {
+			if s.Source[i] == '\n' {
+				s.lines = append(s.lines, i+1)
+			}
+		}
+	}
+	// binary search for the last line that starts at or
+	// before the position.
+	lo, hi := 0, len(s.lines)
+	for lo+1 < hi {
+		mid := (lo + hi) / 2
+		if s.lines[mid] <= pos {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo + 1
+}
+
+// Arg returns the named argument, or nil.
+func (c *Call) Arg(name string) Expr {
+	for _, arg := range c.Args {
+		if arg.Name == name {
+			return arg.Value
+		}
+	}
+	return nil
+}
+
+// Positional returns the positional arguments.
+func (c *Call) Positional() []Expr {
+	var out []Expr
+	for _, arg := range c.Args {
+		if arg.Name == "" {
+			out = append(out, arg.Value)
+		}
+	}
+	return out
+}
+
+// Body returns the closure body statements, or nil.
+func (c *Call) Body() []Stmt {
+	if c.Closure == nil || c.Closure.Body == nil {
+		return nil
+	}
+	return c.Closure.Body.Stmts
+}
@@ -0,0 +1,403 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groovy
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// lexer tokenizes groovy source code.
+type lexer struct {
+	src     string
+	pos     int
+	line    int
+	newline bool
+	tokens  []Token
+}
+
+// lex returns the tokens for the groovy source code.
+func lex(src string) ([]Token, error) {
+	l := &lexer{
+		src:     src,
+		line:    1,
+		newline: true,
+	}
+	// skip the shebang line
+	if strings.HasPrefix(src, "#!") {
+		l.skipLine()
+	}
+	for {
+		if err := l.skipSpace(); err != nil {
+			return nil, err
+		}
+		if l.pos >= len(l.src) {
+			break
+		}
+		tok, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		tok.Newline = l.newline
+		l.newline = false
+		l.tokens = append(l.tokens, tok)
+	}
+	l.tokens = append(l.tokens, Token{
+		Kind:    EOF,
+		Pos:     len(src),
+		End:     len(src),
+		Line:    l.line,
+		Newline: true,
+	})
+	return l.tokens, nil
+}
+
+// skipLine skips to the end of the current line.
+func (l *lexer) skipLine() {
+	for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+		l.pos++
+	}
+}
+
+// skipSpace skips whitespace and comments.
+func (l *lexer) skipSpace() error {
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '\n':
+			l.line++
+			l.pos++
+			l.newline = true
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f':
+			l.pos++
+		case c == '\\' && l.peek(1) == '\n':
+			// line continuation
+			l.line++
+			l.pos += 2
+		case c == '/' && l.peek(1) == '/':
+			l.skipLine()
+		case c == '/' && l.peek(1) == '*':
+			end := strings.Index(l.src[l.pos+2:], "*/")
+			if end == -1 {
+				return l.errorf("unterminated comment")
+			}
+			comment := l.src[l.pos : l.pos+2+end+2]
+			l.line += strings.Count(comment, "\n")
+			if strings.Contains(comment, "\n") {
+				l.newline = true
+			}
+			l.pos += len(comment)
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// peek returns the byte at offset n from the current
+// position, or zero if out of range.
+func (l *lexer) peek(n int) byte {
+	if l.pos+n < len(l.src) {
+		return l.src[l.pos+n]
+	}
+	return 0
+}
+
+// next returns the next token.
+func (l *lexer) next() (Token, error) {
+	start := l.pos
+	line := l.line
+	c := l.src[l.pos]
+
+	switch {
+	case c == '\'' || c == '"':
+		return l.lexString(c)
+	case c == '/' && l.regexpAllowed():
+		return l.lexSlashy()
+	case c == '$' && l.peek(1) == '/':
+		return l.lexDollarSlashy()
+	case isDigit(c) || (c == '.' && isDigit(l.peek(1)) && !l.valueBefore()):
+		return l.lexNumber()
+	case isIdentStart(l.src[l.pos:]):
+		for l.pos < len(l.src) && isIdentPart(l.src[l.pos:]) {
+			_, size := utf8.DecodeRuneInString(l.src[l.pos:])
+			l.pos += size
+		}
+		return Token{Kind: IDENT, Text: l.src[start:l.pos], Pos: start, End: l.pos, Line: line}, nil
+	}
+
+	for _, op := range operators {
+		if strings.HasPrefix(l.src[l.pos:], op) {
+			l.pos += len(op)
+			return Token{Kind: OP, Text: op, Pos: start, End: l.pos, Line: line}, nil
+		}
+	}
+	return Token{}, l.errorf("unexpected character %q", c)
+}
+
+// valueBefore returns true if the previous token ends a
+// value, such as an identifier, literal or closing bracket.
+func (l *lexer) valueBefore() bool {
+	if len(l.tokens) == 0 {
+		return false
+	}
+	prev := l.tokens[len(l.tokens)-1]
+	switch prev.Kind {
+	case IDENT:
+		switch prev.Text {
+		case "return", "case", "in", "assert", "throw":
+			return false
+		}
+		return true
+	case STRING, NUMBER:
+		return true
+	case OP:
+		return prev.Text == ")" || prev.Text == "]" || prev.Text == "}"
+	}
+	return false
+}
+
+// regexpAllowed returns true if a slash starts a slashy
+// string rather than a division operator.
+func (l *lexer) regexpAllowed() bool {
+	if l.peek(1) == '=' || l.peek(1) == ' ' {
+		return false
+	}
+	return !l.valueBefore()
+}
+
+// lexString lexes a single, double or triple quoted string.
+func (l *lexer) lexString(quote byte) (Token, error) {
+	start := l.pos
+	line := l.line
+	delim := string(quote)
+	if strings.HasPrefix(l.src[l.pos:], strings.Repeat(delim, 3)) {
+		delim = strings.Repeat(delim, 3)
+	}
+	l.pos += len(delim)
+	body := l.pos
+	for {
+		if l.pos >= len(l.src) {
+			return Token{}, fmt.Errorf("line %d: unterminated string", line)
+		}
+		c := l.src[l.pos]
+		switch {
+		case c == '\\':
+			if l.peek(1) == '\n' {
+				l.line++
+			}
+			l.pos += 2
+			continue
+		case c == '\n':
+			if len(delim) == 1 {
+				return Token{}, fmt.Errorf("line %d: unterminated string", line)
+			}
+			l.line++
+		case quote == '"' && c == '$' && l.peek(1) == '{':
+			if err := l.skipBraces(); err != nil {
+				return Token{}, err
+			}
+			continue
+		case strings.HasPrefix(l.src[l.pos:], delim):
+			raw := l.src[body:l.pos]
+			l.pos += len(delim)
+			tok := Token{
+				Kind: STRING,
+				Pos:  start,
+				End:  l.pos,
+				Line: line,
+			}
+			if quote == '"' {
+				// double quoted strings are interpolated
+				// by the parser, so the raw text is kept.
+				tok.Text = raw
+				tok.Interpolated = true
+			} else {
+				tok.Text = unescape(raw)
+			}
+			return tok, nil
+		}
+		l.pos++
+	}
+}
+
+// skipBraces skips an interpolated ${...} expression in a
+// double quoted string, including nested strings.
+func (l *lexer) skipBraces() error {
+	line := l.line
+	l.pos += 2
+	depth := 1
+	for depth > 0 {
+		if l.pos >= len(l.src) {
+			return fmt.Errorf("line %d: unterminated string expression", line)
+		}
+		switch c := l.src[l.pos]; c {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case '\n':
+			l.line++
+		case '\'', '"':
+			if _, err := l.lexString(c); err != nil {
+				return err
+			}
+			continue
+		}
+		l.pos++
+	}
+	return nil
+}
+
+// lexSlashy lexes a slashy string, commonly used for
+// regular expressions.
+func (l *lexer) lexSlashy() (Token, error) {
+	start := l.pos
+	line := l.line
+	l.pos++
+	var sb strings.Builder
+	for {
+		if l.pos >= len(l.src) {
+			return Token{}, fmt.Errorf("line %d: unterminated slashy string", line)
+		}
+		c := l.src[l.pos]
+		switch {
+		case c == '\\' && l.peek(1) == '/':
+			sb.WriteByte('/')
+			l.pos += 2
+			continue
+		case c == '\n':
+			l.line++
+		case c == '/':
+			l.pos++
+			return Token{Kind: STRING, Text: sb.String(), Pos: start, End: l.pos, Line: line}, nil
+		}
+		sb.WriteByte(c)
+		l.pos++
+	}
+}
+
+// lexDollarSlashy lexes a dollar slashy string.
+func (l *lexer) lexDollarSlashy() (Token, error) {
+	start := l.pos
+	line := l.line
+	end := strings.Index(l.src[l.pos+2:], "/$")
+	if end == -1 {
+		return Token{}, fmt.Errorf("line %d: unterminated dollar slashy string", line)
+	}
+	text := l.src[l.pos+2 : l.pos+2+end]
+	l.line += strings.Count(text, "\n")
+	l.pos += 2 + end + 2
+	return Token{Kind: STRING, Text: text, Pos: start, End: l.pos, Line: line}, nil
+}
+
+// lexNumber lexes an integer or decimal number, including
+// hexadecimal and type suffixes.
+func (l *lexer) lexNumber() (Token, error) {
+	start := l.pos
+	if l.src[l.pos] == '0' && (l.peek(1) == 'x' || l.peek(1) == 'X') {
+		l.pos += 2
+		for l.pos < len(l.src) && isHex(l.src[l.pos]) {
+			l.pos++
+		}
+	} else {
+		for l.pos < len(l.src) && (isDigit(l.src[l.pos]) || l.src[l.pos] == '_') {
+			l.pos++
+		}
+		// decimal fraction, excluding the range operator
+		if l.peek(0) == '.' && isDigit(l.peek(1)) {
+			l.pos++
+			for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+				l.pos++
+			}
+		}
+		// exponent
+		if c := l.peek(0); c == 'e' || c == 'E' {
+			n := 1
+			if s := l.peek(1); s == '+' || s == '-' {
+				n++
+			}
+			if isDigit(l.peek(n)) {
+				l.pos += n
+				for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+					l.pos++
+				}
+			}
+		}
+	}
+	// type suffix
+	if c := l.peek(0); strings.IndexByte("lLiIgGfFdD", c) != -1 {
+		l.pos++
+	}
+	return Token{Kind: NUMBER, Text: l.src[start:l.pos], Pos: start, End: l.pos, Line: l.line}, nil
+}
+
+// errorf returns an error annotated with the line number.
+func (l *lexer) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", l.line, fmt.Sprintf(format, args...))
+}
+
+// unescape replaces escape sequences in a string.
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' || i+1 == len(s) {
+			sb.WriteByte(c)
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			sb.WriteByte('\n')
+		case 't':
+			sb.WriteByte('\t')
+		case 'r':
+			sb.WriteByte('\r')
+		case 'b':
+			sb.WriteByte('\b')
+		case 'f':
+			sb.WriteByte('\f')
+		case '\n':
+			// line continuation
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String()
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isHex(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func isIdentStart(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r == '_' || r == '$' || unicode.IsLetter(r)
+}
+
+func isIdentPart(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,1483 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package groovy provides a parser for the subset of the
+// groovy language used by Jenkins pipelines.
+package groovy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// binary operator precedence, from lowest to highest.
+var precedence = map[string]int{
+	"||":         1,
+	"&&":         2,
+	"|":          3,
+	"^":          4,
+	"&":          5,
+	"==":         6,
+	"!=":         6,
+	"<=>":        6,
+	"=~":         6,
+	"==~":        6,
+	"<":          7,
+	">":          7,
+	"<=":         7,
+	">=":         7,
+	"in":         7,
+	"instanceof": 7,
+	"as":         7,
+	"<<":         8,
+	">>":         8,
+	">>>":        8,
+	"..":         8,
+	"..<":        8,
+	"+":          9,
+	"-":          9,
+	"*":          10,
+	"/":          10,
+	"%":          10,
+	"**":         11,
+}
+
+// assignment operators.
+var assignments = map[string]bool{
+	"=":    true,
+	"+=":   true,
+	"-=":   true,
+	"*=":   true,
+	"/=":   true,
+	"%=":   true,
+	"**=":  true,
+	"<<=":  true,
+	">>=":  true,
+	">>>=": true,
+	"&=":   true,
+	"|=":   true,
+	"^=":   true,
+	"?=":   true,
+}
+
+// declaration modifiers.
+var modifiers = map[string]bool{
+	"def":       true,
+	"final":     true,
+	"static":    true,
+	"private":   true,
+	"public":    true,
+	"protected": true,
+	"abstract":  true,
+}
+
+// primitive and common lowercase type names.
+var primitives = map[string]bool{
+	"void":    true,
+	"boolean": true,
+	"byte":    true,
+	"char":    true,
+	"short":   true,
+	"int":     true,
+	"long":    true,
+	"float":   true,
+	"double":  true,
+	"var":     true,
+}
+
+// keywords that cannot be used as command arguments.
+var keywords = map[string]bool{
+	"in":         true,
+	"instanceof": true,
+	"as":         true,
+	"else":       true,
+	"catch":      true,
+	"finally":    true,
+	"case":       true,
+	"default":    true,
+}
+
+// Parse parses the groovy script.
+func Parse(src string) (*Script, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{src: src, tokens: tokens}
+	body, err := p.parseScript()
+	if err != nil {
+		return nil, err
+	}
+	return &Script{Source: src, Body: body}, nil
+}
+
+// ParseExpr parses a single groovy expression.
+func ParseExpr(src string) (Expr, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{src: src, tokens: tokens}
+	return p.parseFull(func() Expr {
+		return p.parseExpr()
+	})
+}
+
+// bailout is used to unwind the parser on error.
+type bailout struct {
+	err error
+}
+
+// parser parses groovy tokens into a syntax tree.
+type parser struct {
+	src    string
+	tokens []Token
+	pos    int
+	// nest is greater than zero when parsing inside
+	// parentheses or brackets, where newlines are not
+	// significant.
+	nest int
+}
+
+// parseScript parses the top-level statements.
+func (p *parser) parseScript() (*Block, error) {
+	var block *Block
+	_, err := p.parseFull(func() Expr {
+		block = &Block{Pos: Pos{0, len(p.src)}}
+		block.Stmts = p.parseStmts(true)
+		return nil
+	})
+	return block, err
+}
+
+// parseFull invokes the parse function and recovers from
+// parse errors.
+func (p *parser) parseFull(fn func() Expr) (expr Expr, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			b, ok := r.(bailout)
+			if !ok {
+				panic(r)
+			}
+			err = b.err
+		}
+	}()
+	expr = fn()
+	if !p.at(EOF) {
+		p.fail("unexpected %s", p.describe())
+	}
+	return expr, nil
+}
+
+//
+// token helpers
+//
+
+func (p *parser) tok() Token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) peekAt(n int) Token {
+	if p.pos+n < len(p.tokens) {
+		return p.tokens[p.pos+n]
+	}
+	return p.tokens[len(p.tokens)-1]
+}
+
+func (p *parser) advance() Token {
+	tok := p.tokens[p.pos]
+	if tok.Kind != EOF {
+		p.pos++
+	}
+	return tok
+}
+
+// end returns the end offset of the previous token.
+func (p *parser) end() int {
+	if p.pos == 0 {
+		return 0
+	}
+	return p.tokens[p.pos-1].End
+}
+
+func (p *parser) at(kind Kind) bool {
+	return p.tok().Kind == kind
+}
+
+func (p *parser) isOp(text string) bool {
+	tok := p.tok()
+	return tok.Kind == OP && tok.Text == text
+}
+
+func (p *parser) isIdent(text string) bool {
+	tok := p.tok()
+	return tok.Kind == IDENT && tok.Text == text
+}
+
+func (p *parser) accept(text string) bool {
+	if p.isOp(text) {
+		p.advance()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(text string) Token {
+	if !p.isOp(text) {
+		p.fail("expected %q, found %s", text, p.describe())
+	}
+	return p.advance()
+}
+
+func (p *parser) expectIdent() Token {
+	if !p.at(IDENT) {
+		p.fail("expected identifier, found %s", p.describe())
+	}
+	return p.advance()
+}
+
+// newline returns true if the current token starts a new
+// line and newlines are significant.
+func (p *parser) newline() bool {
+	return p.nest == 0 && p.tok().Newline
+}
+
+// endOfStmt returns true if the current token ends the
+// current statement.
+func (p *parser) endOfStmt() bool {
+	return p.at(EOF) || p.newline() || p.isOp(";") || p.isOp("}")
+}
+
+func (p *parser) describe() string {
+	tok := p.tok()
+	if tok.Kind == EOF {
+		return "end of file"
+	}
+	return fmt.Sprintf("%s %q", tok.Kind, tok.Text)
+}
+
+func (p *parser) fail(format string, args ...interface{}) {
+	panic(bailout{
+		err: fmt.Errorf("line %d: %s", p.tok().Line, fmt.Sprintf(format, args...)),
+	})
+}
+
+// nested invokes the function with newlines significant,
+// for example inside a closure body.
+func (p *parser) nested(fn func()) {
+	nest := p.nest
+	p.nest = 0
+	fn()
+	p.nest = nest
+}
+
+//
+// statements
+//
+
+// parseStmts parses statements until the closing brace or
+// end of file.
+func (p *parser) parseStmts(top bool) []Stmt {
+	var stmts []Stmt
+	for {
+		for p.accept(";") {
+		}
+		if p.at(EOF) {
+			if !top {
+				p.fail("unexpected end of file, expected \"}\"")
+			}
+			return stmts
+		}
+		if p.isOp("}") {
+			if top {
+				p.fail("unexpected \"}\"")
+			}
+			return stmts
+		}
+		stmt := p.parseStmt()
+		if stmt != nil {
+			stmts = append(stmts, stmt)
+		}
+		if !p.endOfStmt() && !p.tok().Newline {
+			p.fail("unexpected %s", p.describe())
+		}
+	}
+}
+
+// parseBlock parses a brace delimited block of statements.
+func (p *parser) parseBlock() *Block {
+	start := p.expect("{").Pos
+	block := &Block{}
+	p.nested(func() {
+		block.Stmts = p.parseStmts(false)
+	})
+	p.expect("}")
+	block.Pos = Pos{start, p.end()}
+	return block
+}
+
+// parseBody parses the body of a control statement, which
+// is either a block or a single statement.
+func (p *parser) parseBody() Stmt {
+	if p.isOp("{") {
+		return p.parseBlock()
+	}
+	return p.parseStmt()
+}
+
+func (p *parser) parseStmt() Stmt {
+	tok := p.tok()
+	if tok.Kind == OP {
+		switch tok.Text {
+		case "@":
+			return p.parseAnnotation()
+		case "{":
+			return p.parseBlock()
+		}
+	}
+	if tok.Kind == IDENT {
+		switch tok.Text {
+		case "import", "package":
+			return p.parseImport()
+		case "if":
+			return p.parseIf()
+		case "for":
+			return p.parseFor()
+		case "while":
+			return p.parseWhile()
+		case "try":
+			return p.parseTry()
+		case "return", "throw", "assert":
+			return p.parseJump(true)
+		case "break", "continue":
+			return p.parseJump(false)
+		case "switch", "do", "synchronized":
+			return p.parseRaw(false)
+		case "class", "interface", "enum", "trait":
+			return p.parseRaw(true)
+		}
+		if p.isDecl() {
+			return p.parseDecl()
+		}
+	}
+	return p.parseExprStmt()
+}
+
+func (p *parser) parseAnnotation() Stmt {
+	start := p.expect("@").Pos
+	name := p.parseQualifiedName()
+	annotation := &Annotation{Name: name}
+	if p.isOp("(") && !p.tok().Newline {
+		annotation.Args = p.parseArgs()
+	}
+	// the @Library annotation is commonly followed by an
+	// underscore placeholder.
+	if p.isIdent("_") {
+		p.advance()
+	}
+	annotation.Pos = Pos{start, p.end()}
+	return annotation
+}
+
+// parseQualifiedName parses a dot separated name.
+func (p *parser) parseQualifiedName() string {
+	start := p.pos
+	p.expectIdent()
+	for p.isOp(".") && p.peekAt(1).Kind == IDENT {
+		p.advance()
+		p.advance()
+	}
+	return p.text(start, p.pos)
+}
+
+func (p *parser) parseImport() Stmt {
+	start := p.advance().Pos
+	for !p.endOfStmt() {
+		p.advance()
+	}
+	path := strings.TrimSpace(p.src[start:p.end()])
+	path = strings.TrimPrefix(path, "import")
+	path = strings.TrimPrefix(path, "package")
+	return &Import{
+		Pos:  Pos{start, p.end()},
+		Path: strings.TrimSpace(path),
+	}
+}
+
+func (p *parser) parseIf() Stmt {
+	start := p.advance().Pos
+	p.expect("(")
+	p.nest++
+	cond := p.parseExpr()
+	p.nest--
+	p.expect(")")
+	stmt := &If{Cond: cond, Then: p.parseBody()}
+	// the else keyword may be on the next line, after
+	// optional semicolons.
+	save := p.pos
+	for p.accept(";") {
+	}
+	if p.isIdent("else") {
+		p.advance()
+		stmt.Else = p.parseBody()
+	} else {
+		p.pos = save
+	}
+	stmt.Pos = Pos{start, p.end()}
+	return stmt
+}
+
+func (p *parser) parseFor() Stmt {
+	start := p.advance().Pos
+	open := p.expect("(").End
+	p.skipBalanced("(", ")")
+	header := strings.TrimSpace(p.src[open : p.end()-1])
+	body := p.parseBody()
+	return &For{
+		Pos:    Pos{start, p.end()},
+		Header: header,
+		Body:   body,
+	}
+}
+
+func (p *parser) parseWhile() Stmt {
+	start := p.advance().Pos
+	p.expect("(")
+	p.nest++
+	cond := p.parseExpr()
+	p.nest--
+	p.expect(")")
+	body := p.parseBody()
+	return &While{
+		Pos:  Pos{start, p.end()},
+		Cond: cond,
+		Body: body,
+	}
+}
+
+func (p *parser) parseTry() Stmt {
+	start := p.advance().Pos
+	stmt := &Try{Body: p.parseBlock()}
+	for p.isIdent("catch") {
+		p.advance()
+		p.expect("(")
+		param := &Param{}
+		// the exception type is optional and may be a
+		// multi-catch type union.
+		var names []string
+		for !p.isOp(")") {
+			tok := p.advance()
+			if tok.Kind == EOF {
+				p.fail("unexpected end of file")
+			}
+			if tok.Kind == IDENT {
+				names = append(names, tok.Text)
+			}
+		}
+		p.expect(")")
+		if len(names) != 0 {
+			param.Name = names[len(names)-1]
+			param.Type = strings.Join(names[:len(names)-1], "|")
+		}
+		stmt.Catches = append(stmt.Catches, &Catch{
+			Param: param,
+			Body:  p.parseBlock(),
+		})
+	}
+	if p.isIdent("finally") {
+		p.advance()
+		stmt.Finally = p.parseBlock()
+	}
+	stmt.Pos = Pos{start, p.end()}
+	return stmt
+}
+
+func (p *parser) parseJump(expr bool) Stmt {
+	tok := p.advance()
+	stmt := &Jump{Keyword: tok.Text}
+	if expr && !p.endOfStmt() {
+		stmt.X = p.parseCommandExpr()
+		// assert statements have an optional message.
+		if tok.Text == "assert" && (p.accept(":") || p.accept(",")) {
+			p.parseExpr()
+		}
+	}
+	stmt.Pos = Pos{tok.Pos, p.end()}
+	return stmt
+}
+
+// parseRaw skips a statement that is not modeled by the
+// parser, such as a switch statement or class declaration.
+func (p *parser) parseRaw(decl bool) Stmt {
+	tok := p.advance()
+	for !p.isOp("{") {
+		if p.at(EOF) {
+			p.fail("unexpected end of file")
+		}
+		if p.isOp("(") {
+			p.advance()
+			p.skipBalanced("(", ")")
+			continue
+		}
+		p.advance()
+	}
+	p.advance()
+	p.skipBalanced("{", "}")
+	// a do block is followed by a while condition.
+	if tok.Text == "do" && p.isIdent("while") {
+		p.advance()
+		p.expect("(")
+		p.skipBalanced("(", ")")
+	}
+	return &Raw{
+		Pos:     Pos{tok.Pos, p.end()},
+		Keyword: tok.Text,
+	}
+}
+
+// skipBalanced skips tokens until the closing token that
+// balances an opening token that was already consumed.
+func (p *parser) skipBalanced(open, close string) {
+	depth := 1
+	for depth > 0 {
+		tok := p.advance()
+		switch {
+		case tok.Kind == EOF:
+			p.fail("unexpected end of file, expected %q", close)
+		case tok.Kind != OP:
+		case tok.Text == open:
+			depth++
+		case tok.Text == close:
+			depth--
+		}
+	}
+}
+
+// isDecl returns true if the current token starts a
+// variable or method declaration.
+func (p *parser) isDecl() bool {
+	tok := p.tok()
+	if modifiers[tok.Text] {
+		next := p.peekAt(1)
+		// def used as a method name or map key.
+		return !(next.Kind == OP && (next.Text == "." || next.Text == ":" || next.Text == "="))
+	}
+	i := p.skipType(p.pos)
+	if i == -1 {
+		return false
+	}
+	name := p.tokens[i]
+	if name.Kind != IDENT || keywords[name.Text] || name.Newline {
+		return false
+	}
+	next := p.tokens[i+1]
+	switch {
+	case next.Kind == EOF, next.Newline:
+		return true
+	case next.Kind == OP:
+		switch next.Text {
+		case "=", "(", ";", "}", ",":
+			return true
+		}
+	}
+	return false
+}
+
+// skipType returns the index of the token following the
+// type at index i, or -1 if the token is not a type.
+func (p *parser) skipType(i int) int {
+	tok := p.tokens[i]
+	if tok.Kind != IDENT {
+		return -1
+	}
+	if !primitives[tok.Text] && !startsUpper(tok.Text) {
+		// lowercase qualified type names are permitted,
+		// for example java.util.Date.
+		j := i
+		for j+2 < len(p.tokens) && isOpToken(p.tokens[j+1], ".") && p.tokens[j+2].Kind == IDENT {
+			j += 2
+		}
+		if j == i || !startsUpper(p.tokens[j].Text) {
+			return -1
+		}
+		i = j
+	}
+	i++
+	// qualified type name
+	for isOpToken(p.tokens[i], ".") && p.tokens[i+1].Kind == IDENT {
+		i += 2
+	}
+	// generic type arguments
+	if isOpToken(p.tokens[i], "<") {
+		depth := 0
+		for ; i < len(p.tokens); i++ {
+			tok := p.tokens[i]
+			if tok.Kind != OP && tok.Kind != IDENT {
+				return -1
+			}
+			if tok.Kind == OP {
+				switch tok.Text {
+				case "<":
+					depth++
+				case ">":
+					depth--
+				case ">>":
+					depth -= 2
+				case ">>>":
+					depth -= 3
+				case ",", "?", ".", "[", "]", "&":
+				default:
+					return -1
+				}
+			}
+			if depth <= 0 {
+				break
+			}
+		}
+		if depth != 0 {
+			return -1
+		}
+		i++
+	}
+	// array and varargs types
+	for {
+		if isOpToken(p.tokens[i], "[") && isOpToken(p.tokens[i+1], "]") {
+			i += 2
+		} else if isOpToken(p.tokens[i], "..") && isOpToken(p.tokens[i+1], ".") {
+			i += 2
+		} else {
+			break
+		}
+	}
+	return i
+}
+
+// parseDecl parses a variable or method declaration.
+func (p *parser) parseDecl() Stmt {
+	start := p.tok().Pos
+	var typ string
+	for modifiers[p.tok().Text] && p.at(IDENT) {
+		if tok := p.advance(); tok.Text == "def" {
+			typ = "def"
+		}
+	}
+	// the type is optional when a modifier is used.
+	if i := p.skipType(p.pos); i != -1 && p.tokens[i].Kind == IDENT && !p.tokens[i].Newline {
+		typ = p.text(p.pos, i)
+		p.pos = i
+	}
+	// multiple assignment, for example def (a, b) = [1, 2]
+	if p.isOp("(") {
+		target := p.parsePrimary()
+		p.expect("=")
+		value := p.parseCommandExpr()
+		return &Assign{
+			Pos:    Pos{start, p.end()},
+			Target: target,
+			Op:     "=",
+			Value:  value,
+			Decl:   true,
+			Type:   typ,
+		}
+	}
+	nameTok := p.tok()
+	if nameTok.Kind != IDENT && nameTok.Kind != STRING {
+		p.fail("expected identifier, found %s", p.describe())
+	}
+	p.advance()
+	if p.isOp("(") {
+		return p.parseMethod(start, typ, nameTok.Text)
+	}
+	target := &Ident{Pos: Pos{nameTok.Pos, nameTok.End}, Name: nameTok.Text}
+	stmt := &Assign{
+		Target: target,
+		Decl:   true,
+		Type:   typ,
+	}
+	if p.accept("=") {
+		stmt.Op = "="
+		stmt.Value = p.parseCommandExpr()
+	}
+	// additional declarations, for example def a = 1, b = 2
+	for p.isOp(",") && p.nest == 0 {
+		p.advance()
+		p.expectIdent()
+		if p.accept("=") {
+			p.parseExpr()
+		}
+	}
+	stmt.Pos = Pos{start, p.end()}
+	return stmt
+}
+
+func (p *parser) parseMethod(start int, typ, name string) Stmt {
+	p.expect("(")
+	p.nest++
+	var params []*Param
+	for !p.isOp(")") {
+		params = append(params, p.parseParam())
+		if !p.accept(",") {
+			break
+		}
+	}
+	p.nest--
+	p.expect(")")
+	if p.isIdent("throws") {
+		p.advance()
+		for !p.isOp("{") && !p.at(EOF) {
+			p.advance()
+		}
+	}
+	method := &MethodDecl{
+		Name:   name,
+		Type:   typ,
+		Params: params,
+	}
+	// abstract methods do not have a body.
+	if p.isOp("{") {
+		method.Body = p.parseBlock()
+	}
+	method.Pos = Pos{start, p.end()}
+	return method
+}
+
+// parseParam parses a method or closure parameter.
+func (p *parser) parseParam() *Param {
+	param := &Param{}
+	for modifiers[p.tok().Text] && p.at(IDENT) && p.peekAt(1).Kind == IDENT {
+		p.advance()
+	}
+	if i := p.skipType(p.pos); i != -1 && p.tokens[i].Kind == IDENT {
+		param.Type = p.text(p.pos, i)
+		p.pos = i
+	}
+	param.Name = p.expectIdent().Text
+	if p.accept("=") {
+		param.Default = p.parseExpr()
+	}
+	return param
+}
+
+// parseExprStmt parses an expression statement, which may
+// be an assignment or a command expression.
+func (p *parser) parseExprStmt() Stmt {
+	start := p.tok().Pos
+	x := p.parseCommandExpr()
+	if tok := p.tok(); tok.Kind == OP && assignments[tok.Text] && !p.newline() {
+		p.advance()
+		value := p.parseCommandExpr()
+		return &Assign{
+			Pos:    Pos{start, p.end()},
+			Target: x,
+			Op:     tok.Text,
+			Value:  value,
+		}
+	}
+	return &ExprStmt{Pos: Pos{start, p.end()}, X: x}
+}
+
+//
+// expressions
+//
+
+// parseCommandExpr parses an expression that may be a
+// command expression, which is a method call without
+// parentheses, for example: sh 'make build'
+func (p *parser) parseCommandExpr() Expr {
+	x := p.parseExpr()
+	if !p.startsCommandArg() {
+		return x
+	}
+	call := &Call{}
+	switch v := x.(type) {
+	case *Ident:
+		call.Name = v.Name
+	case *Property:
+		call.Receiver = v.X
+		call.Name = v.Name
+		call.Safe = v.Safe
+	default:
+		return x
+	}
+	start, _ := x.Span()
+	for {
+		call.Args = append(call.Args, p.parseArg())
+		if !p.isOp(",") {
+			break
+		}
+		p.advance()
+	}
+	call.Pos = Pos{start, p.end()}
+	// a command expression can be the receiver of a method
+	// call, for example: sh(script: 'x').trim()
+	return p.parsePostfix(call)
+}
+
+// startsCommandArg returns true if the current token is on
+// the same line and can start a command expression
+// argument.
+func (p *parser) startsCommandArg() bool {
+	if p.newline() || p.tok().Newline {
+		return false
+	}
+	tok := p.tok()
+	switch tok.Kind {
+	case IDENT:
+		return !keywords[tok.Text]
+	case STRING, NUMBER:
+		return true
+	case OP:
+		switch tok.Text {
+		case "!", "~":
+			return true
+		}
+	}
+	return false
+}
+
+// parseExpr parses an expression, including the ternary,
+// elvis and assignment operators.
+func (p *parser) parseExpr() Expr {
+	x := p.parseBinary(1)
+	start, _ := x.Span()
+	for {
+		tok := p.tok()
+		if tok.Kind != OP || (p.newline() && tok.Text != "?" && tok.Text != "?:") {
+			return x
+		}
+		switch {
+		case tok.Text == "?":
+			p.advance()
+			then := p.parseExpr()
+			p.expect(":")
+			els := p.parseExpr()
+			x = &Ternary{Pos: Pos{start, p.end()}, Cond: x, Then: then, Else: els}
+		case tok.Text == "?:":
+			p.advance()
+			els := p.parseExpr()
+			x = &Ternary{Pos: Pos{start, p.end()}, Cond: x, Else: els}
+		case assignments[tok.Text] && p.nest > 0:
+			// assignment expressions inside parentheses,
+			// for example: if ((x = y) != null)
+			p.advance()
+			y := p.parseExpr()
+			x = &Binary{Pos: Pos{start, p.end()}, Op: tok.Text, X: x, Y: y}
+		default:
+			return x
+		}
+	}
+}
+
+// parseBinary parses a binary expression using precedence
+// climbing.
+func (p *parser) parseBinary(min int) Expr {
+	x := p.parseUnary()
+	start, _ := x.Span()
+	for {
+		tok := p.tok()
+		if tok.Kind != OP && tok.Kind != IDENT {
+			return x
+		}
+		prec, ok := precedence[tok.Text]
+		if !ok || prec < min {
+			return x
+		}
+		// newlines terminate the expression, except for
+		// leading logical operators.
+		if p.newline() && tok.Text != "&&" && tok.Text != "||" {
+			return x
+		}
+		p.advance()
+		if tok.Text == "as" || tok.Text == "instanceof" {
+			i := p.skipType(p.pos)
+			if i == -1 {
+				p.fail("expected type, found %s", p.describe())
+			}
+			typ := &Ident{Pos: Pos{p.tok().Pos, p.tokens[i-1].End}, Name: p.text(p.pos, i)}
+			p.pos = i
+			if tok.Text == "as" {
+				x = &Cast{Pos: Pos{start, p.end()}, X: x, Type: typ.Name}
+			} else {
+				x = &Binary{Pos: Pos{start, p.end()}, Op: tok.Text, X: x, Y: typ}
+			}
+			continue
+		}
+		next := prec + 1
+		if tok.Text == "**" {
+			// right associative
+			next = prec
+		}
+		y := p.parseBinary(next)
+		x = &Binary{Pos: Pos{start, p.end()}, Op: tok.Text, X: x, Y: y}
+	}
+}
+
+func (p *parser) parseUnary() Expr {
+	tok := p.tok()
+	if tok.Kind == OP {
+		switch tok.Text {
+		case "!", "-", "+", "~", "++", "--":
+			p.advance()
+			x := p.parseUnary()
+			return &Unary{Pos: Pos{tok.Pos, p.end()}, Op: tok.Text, X: x}
+		case "(":
+			// type cast, for example (String) x
+			if i := p.skipType(p.pos + 1); i != -1 && isOpToken(p.tokens[i], ")") {
+				if next := p.tokens[i+1]; !next.Newline && startsOperand(next) {
+					typ := p.text(p.pos+1, i)
+					p.pos = i + 1
+					x := p.parseUnary()
+					return &Cast{Pos: Pos{tok.Pos, p.end()}, X: x, Type: typ}
+				}
+			}
+		}
+	}
+	return p.parsePostfix(p.parsePrimary())
+}
+
+// parsePostfix parses property access, method calls, index
+// expressions and trailing closures.
+func (p *parser) parsePostfix(x Expr) Expr {
+	start, _ := x.Span()
+	for {
+		tok := p.tok()
+		if tok.Kind != OP {
+			return x
+		}
+		switch tok.Text {
+		case ".", "?.", "*.", ".&":
+			// method chains may continue on the next line.
+			p.advance()
+			var name string
+			switch next := p.tok(); next.Kind {
+			case IDENT, STRING:
+				p.advance()
+				name = next.Text
+			case OP:
+				if next.Text == "@" {
+					// direct field access, for example x.@y
+					p.advance()
+					name = p.expectIdent().Text
+					break
+				}
+				fallthrough
+			default:
+				p.fail("expected property name, found %s", p.describe())
+			}
+			if p.isOp("(") && !p.tok().Newline {
+				call := &Call{Receiver: x, Name: name, Safe: tok.Text == "?."}
+				call.Args = p.parseArgs()
+				p.parseClosures(call)
+				call.Pos = Pos{start, p.end()}
+				x = call
+			} else if p.isOp("{") && (!p.tok().Newline || p.nest > 0) {
+				call := &Call{Receiver: x, Name: name, Safe: tok.Text == "?."}
+				p.parseClosures(call)
+				call.Pos = Pos{start, p.end()}
+				x = call
+			} else {
+				x = &Property{Pos: Pos{start, p.end()}, X: x, Name: name, Safe: tok.Text == "?."}
+			}
+		case "[":
+			if tok.Newline && p.nest == 0 {
+				return x
+			}
+			p.advance()
+			p.nest++
+			index := p.parseExpr()
+			// multi-dimensional and slice indexes
+			for p.accept(",") {
+				p.parseExpr()
+			}
+			p.nest--
+			p.expect("]")
+			x = &Index{Pos: Pos{start, p.end()}, X: x, Index: index}
+		case "(":
+			if tok.Newline {
+				return x
+			}
+			// call expression, for example closure() or
+			// the result of a method call.
+			call := &Call{Receiver: x, Name: "call"}
+			call.Args = p.parseArgs()
+			p.parseClosures(call)
+			call.Pos = Pos{start, p.end()}
+			x = call
+		case "++", "--":
+			if p.newline() {
+				return x
+			}
+			p.advance()
+			x = &Unary{Pos: Pos{start, p.end()}, Op: tok.Text, X: x, Postfix: true}
+		case "{":
+			// trailing closure, for example: node { ... }
+			ident, ok := x.(*Ident)
+			if !ok || (tok.Newline && p.nest > 0) {
+				return x
+			}
+			call := &Call{Name: ident.Name}
+			p.parseClosures(call)
+			call.Pos = Pos{start, p.end()}
+			x = call
+		default:
+			return x
+		}
+	}
+}
+
+// parseClosures parses the trailing closure arguments of
+// a method call.
+func (p *parser) parseClosures(call *Call) {
+	for p.isOp("{") {
+		// the closure may be on the next line after a
+		// method call with parentheses.
+		closure := p.parseClosure()
+		if call.Closure == nil {
+			call.Closure = closure
+		} else {
+			call.Args = append(call.Args, &Arg{Value: call.Closure})
+			call.Closure = closure
+		}
+		if p.tok().Newline {
+			break
+		}
+	}
+}
+
+func (p *parser) parsePrimary() Expr {
+	tok := p.tok()
+	switch tok.Kind {
+	case EOF:
+		p.fail("unexpected end of file")
+	case NUMBER:
+		p.advance()
+		return &Number{Pos: Pos{tok.Pos, tok.End}, Value: tok.Text}
+	case STRING:
+		p.advance()
+		return p.parseString(tok)
+	case IDENT:
+		switch tok.Text {
+		case "true", "false":
+			p.advance()
+			return &Bool{Pos: Pos{tok.Pos, tok.End}, Value: tok.Text == "true"}
+		case "null":
+			p.advance()
+			return &Null{Pos: Pos{tok.Pos, tok.End}}
+		case "new":
+			return p.parseNew()
+		}
+		p.advance()
+		if p.isOp("(") && !p.tok().Newline {
+			call := &Call{Name: tok.Text}
+			call.Args = p.parseArgs()
+			if p.isOp("{") && (!p.tok().Newline || p.nest == 0) {
+				p.parseClosures(call)
+			}
+			call.Pos = Pos{tok.Pos, p.end()}
+			return call
+		}
+		return &Ident{Pos: Pos{tok.Pos, tok.End}, Name: tok.Text}
+	case OP:
+		switch tok.Text {
+		case "(":
+			p.advance()
+			p.nest++
+			x := p.parseExpr()
+			// multiple assignment targets
+			var items []Expr
+			for p.accept(",") {
+				items = append(items, p.parseExpr())
+			}
+			p.nest--
+			p.expect(")")
+			if len(items) != 0 {
+				return &List{Pos: Pos{tok.Pos, p.end()}, Items: append([]Expr{x}, items...)}
+			}
+			return x
+		case "[":
+			return p.parseListOrMap()
+		case "{":
+			return p.parseClosure()
+		}
+	}
+	p.fail("unexpected %s", p.describe())
+	return nil
+}
+
+func (p *parser) parseNew() Expr {
+	start := p.advance().Pos
+	i := p.skipType(p.pos)
+	if i == -1 {
+		// lowercase type names, for example new java.io.File
+		i = p.pos + 1
+		for isOpToken(p.tokens[i], ".") && p.tokens[i+1].Kind == IDENT {
+			i += 2
+		}
+	}
+	x := &New{Type: p.text(p.pos, i)}
+	p.pos = i
+	if p.isOp("(") {
+		x.Args = p.parseArgs()
+	} else if p.isOp("[") {
+		// array creation
+		p.advance()
+		p.skipBalanced("[", "]")
+	}
+	// anonymous inner class
+	if p.isOp("{") && !p.tok().Newline {
+		p.advance()
+		p.skipBalanced("{", "}")
+	}
+	x.Pos = Pos{start, p.end()}
+	return x
+}
+
+// parseArgs parses parenthesized call arguments.
+func (p *parser) parseArgs() []*Arg {
+	p.expect("(")
+	p.nest++
+	var args []*Arg
+	for !p.isOp(")") {
+		args = append(args, p.parseArg())
+		if !p.accept(",") {
+			break
+		}
+	}
+	p.nest--
+	p.expect(")")
+	return args
+}
+
+// parseArg parses a positional or named argument.
+func (p *parser) parseArg() *Arg {
+	if name, ok := p.parseLabel(); ok {
+		return &Arg{Name: name, Value: p.parseExpr()}
+	}
+	// spread map arguments, for example *:map
+	if p.isOp("*") && isOpToken(p.peekAt(1), ":") {
+		p.advance()
+		p.advance()
+		return &Arg{Name: "*", Value: p.parseExpr()}
+	}
+	return &Arg{Value: p.parseExpr()}
+}
+
+// parseLabel parses a named argument or map key followed
+// by a colon.
+func (p *parser) parseLabel() (string, bool) {
+	tok := p.tok()
+	if !isOpToken(p.peekAt(1), ":") {
+		return "", false
+	}
+	switch tok.Kind {
+	case IDENT, NUMBER:
+		p.advance()
+		p.advance()
+		return tok.Text, true
+	case STRING:
+		if tok.Interpolated && strings.Contains(tok.Text, "$") {
+			return "", false
+		}
+		p.advance()
+		p.advance()
+		return unescape(tok.Text), true
+	}
+	return "", false
+}
+
+func (p *parser) parseListOrMap() Expr {
+	start := p.expect("[").Pos
+	p.nest++
+	defer func() { p.nest-- }()
+
+	// empty map
+	if p.isOp(":") && isOpToken(p.peekAt(1), "]") {
+		p.advance()
+		p.advance()
+		return &Map{Pos: Pos{start, p.end()}}
+	}
+	var list *List
+	var dict *Map
+	for !p.isOp("]") {
+		switch {
+		case dict == nil && list == nil:
+			if name, ok := p.parseLabel(); ok {
+				dict = &Map{}
+				dict.Entries = append(dict.Entries, &Arg{Name: name, Value: p.parseExpr()})
+			} else if key, ok := p.parseKeyExpr(); ok {
+				dict = &Map{}
+				dict.Entries = append(dict.Entries, &Arg{Key: key, Value: p.parseExpr()})
+			} else {
+				list = &List{}
+				list.Items = append(list.Items, p.parseExpr())
+			}
+		case dict != nil:
+			if name, ok := p.parseLabel(); ok {
+				dict.Entries = append(dict.Entries, &Arg{Name: name, Value: p.parseExpr()})
+			} else if key, ok := p.parseKeyExpr(); ok {
+				dict.Entries = append(dict.Entries, &Arg{Key: key, Value: p.parseExpr()})
+			} else if p.isOp("*") && isOpToken(p.peekAt(1), ":") {
+				p.advance()
+				p.advance()
+				dict.Entries = append(dict.Entries, &Arg{Name: "*", Value: p.parseExpr()})
+			} else {
+				p.fail("expected map entry, found %s", p.describe())
+			}
+		default:
+			list.Items = append(list.Items, p.parseExpr())
+		}
+		if !p.accept(",") {
+			break
+		}
+	}
+	p.expect("]")
+	if dict != nil {
+		dict.Pos = Pos{start, p.end()}
+		return dict
+	}
+	if list == nil {
+		list = &List{}
+	}
+	list.Pos = Pos{start, p.end()}
+	return list
+}
+
+// parseKeyExpr parses a map key expression, for example
+// (key): value or "${key}": value.
+func (p *parser) parseKeyExpr() (Expr, bool) {
+	save := p.pos
+	if !p.isOp("(") && !p.at(STRING) {
+		return nil, false
+	}
+	key := p.parsePrimary()
+	if !p.accept(":") {
+		p.pos = save
+		return nil, false
+	}
+	return key, true
+}
+
+func (p *parser) parseClosure() *Closure {
+	start := p.expect("{").Pos
+	closure := &Closure{}
+	if p.hasClosureParams() {
+		for !p.isOp("->") {
+			closure.Params = append(closure.Params, p.parseParam())
+			if !p.accept(",") {
+				break
+			}
+		}
+		p.expect("->")
+	}
+	body := &Block{Pos: Pos{start, 0}}
+	p.nested(func() {
+		body.Stmts = p.parseStmts(false)
+	})
+	p.expect("}")
+	body.Pos.Stop = p.end()
+	closure.Body = body
+	closure.Pos = Pos{start, p.end()}
+	return closure
+}
+
+// hasClosureParams returns true if the closure declares
+// parameters, for example: { a, b -> ... }
+func (p *parser) hasClosureParams() bool {
+	for i := p.pos; i < len(p.tokens); i++ {
+		tok := p.tokens[i]
+		switch tok.Kind {
+		case IDENT:
+			continue
+		case OP:
+			switch tok.Text {
+			case "->":
+				return true
+			case ",", ".", "<", ">", "[", "]":
+				continue
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// parseString parses a string literal, including the
+// interpolated expressions of a GString.
+func (p *parser) parseString(tok Token) Expr {
+	str := &String{Pos: Pos{tok.Pos, tok.End}}
+	if !tok.Interpolated {
+		str.Value = tok.Text
+		return str
+	}
+	// offset of the raw string body in the source.
+	offset := tok.Pos + 1
+	if strings.HasPrefix(p.src[tok.Pos:], `"""`) {
+		offset = tok.Pos + 3
+	}
+	raw := tok.Text
+	var value, text strings.Builder
+	flush := func() {
+		if text.Len() != 0 {
+			str.Parts = append(str.Parts, &Part{Text: text.String()})
+			text.Reset()
+		}
+	}
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+		switch {
+		case c == '\\' && i+1 < len(raw):
+			s := unescape(raw[i : i+2])
+			value.WriteString(s)
+			text.WriteString(s)
+			i++
+		case c == '$' && i+1 < len(raw) && raw[i+1] == '{':
+			end := matchBrace(raw, i+1)
+			if end == -1 {
+				value.WriteString(raw[i:])
+				text.WriteString(raw[i:])
+				i = len(raw)
+				break
+			}
+			value.WriteString(raw[i : end+1])
+			flush()
+			inner := raw[i+2 : end]
+			if expr := parseEmbedded(p.src, offset+i+2, offset+end); expr != nil {
+				str.Parts = append(str.Parts, &Part{Expr: expr})
+			} else if strings.TrimSpace(inner) != "" {
+				str.Parts = append(str.Parts, &Part{Text: raw[i : end+1]})
+			}
+			i = end
+		case c == '$' && i+1 < len(raw) && isIdentStart(raw[i+1:]) && raw[i+1] != '$':
+			// simple property path, for example $env.FOO
+			j := i + 1
+			for j < len(raw) {
+				k := j
+				for k < len(raw) && isIdentPart(raw[k:]) && raw[k] != '$' {
+					k++
+				}
+				j = k
+				if j+1 < len(raw) && raw[j] == '.' && isIdentStart(raw[j+1:]) && raw[j+1] != '$' {
+					j++
+					continue
+				}
+				break
+			}
+			value.WriteString(raw[i:j])
+			flush()
+			if expr := parseEmbedded(p.src, offset+i+1, offset+j); expr != nil {
+				str.Parts = append(str.Parts, &Part{Expr: expr})
+			} else {
+				str.Parts = append(str.Parts, &Part{Text: raw[i:j]})
+			}
+			i = j - 1
+		default:
+			value.WriteByte(c)
+			text.WriteByte(c)
+		}
+	}
+	flush()
+	str.Value = value.String()
+	// a GString without expressions is a plain string.
+	hasExpr := false
+	for _, part := range str.Parts {
+		if part.Expr != nil {
+			hasExpr = true
+		}
+	}
+	if !hasExpr {
+		str.Parts = nil
+	}
+	return str
+}
+
+// parseEmbedded parses an expression embedded in a GString
+// at the given source offsets. Positions in the returned
+// expression are relative to the full source.
+func parseEmbedded(src string, pos, end int) Expr {
+	tokens, err := lex(src[pos:end])
+	if err != nil {
+		return nil
+	}
+	for i := range tokens {
+		tokens[i].Pos += pos
+		tokens[i].End += pos
+	}
+	p := &parser{src: src, tokens: tokens, nest: 1}
+	expr, err := p.parseFull(func() Expr {
+		if p.at(EOF) {
+			return nil
+		}
+		return p.parseCommandExpr()
+	})
+	if err != nil {
+		return nil
+	}
+	return expr
+}
+
+// matchBrace returns the index of the closing brace that
+// matches the opening brace at index i, skipping nested
+// strings, or -1 if not found.
+func matchBrace(s string, i int) int {
+	depth := 0
+	for ; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		case '\\':
+			i++
+		case '\'', '"':
+			for i++; i < len(s) && s[i] != c; i++ {
+				if s[i] == '\\' {
+					i++
+				}
+			}
+		}
+	}
+	return -1
+}
+
+// text returns the source text between the tokens at
+// index i and j, excluding the token at index j.
+func (p *parser) text(i, j int) string {
+	if j <= i {
+		return ""
+	}
+	return p.src[p.tokens[i].Pos:p.tokens[j-1].End]
+}
+
+func isOpToken(tok Token, text string) bool {
+	return tok.Kind == OP && tok.Text == text
+}
+
+// startsOperand returns true if the token can start an
+// operand following a type cast.
+func startsOperand(tok Token) bool {
+	switch tok.Kind {
+	case IDENT, STRING, NUMBER:
+		return !keywords[tok.Text] || tok.Kind != IDENT
+	case OP:
+		return tok.Text == "(" || tok.Text == "["
+	}
+	return false
+}
+
+func startsUpper(s string) bool {
+	return s != "" && s[0] >= 'A' && s[0] <= 'Z'
+}
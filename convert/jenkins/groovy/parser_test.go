@@ -0,0 +1,187 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groovy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseFiles(t *testing.T) {
+	tests, err := filepath.Glob("../testdata/Jenkinsfile*")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			data, err := os.ReadFile(test)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if _, err := Parse(string(data)); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	src := `
+pipeline {
+    agent { docker { image 'golang:1.20' } }
+    environment {
+        GOOS = 'linux'
+    }
+    stages {
+        stage('Build') {
+            steps {
+                sh 'go build'
+                junit allowEmptyResults: true,
+                    testResults: '**/*.xml'
+            }
+        }
+    }
+}
+`
+	script, err := Parse(src)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	calls := Calls(script.Body.Stmts)
+	if len(calls) != 1 || calls[0].Name != "pipeline" {
+		t.Errorf("Expect pipeline call")
+		return
+	}
+	var names []string
+	for _, call := range Calls(calls[0].Body()) {
+		names = append(names, call.Name)
+	}
+	if diff := cmp.Diff(names, []string{"agent", "environment", "stages"}); diff != "" {
+		t.Errorf("Unexpected pipeline sections")
+		t.Log(diff)
+	}
+
+	stages := Calls(calls[0].Body())[2]
+	stage := Calls(stages.Body())[0]
+	if got, want := Value(stage.Positional()[0]), "Build"; got != want {
+		t.Errorf("Want stage name %v, got %v", want, got)
+	}
+	steps := Calls(Calls(stage.Body())[0].Body())
+	if len(steps) != 2 {
+		t.Errorf("Want 2 steps, got %d", len(steps))
+		return
+	}
+	want := map[string]interface{}{
+		"allowEmptyResults": true,
+		"testResults":       "**/*.xml",
+	}
+	if diff := cmp.Diff(Args(steps[1].Args), want); diff != "" {
+		t.Errorf("Unexpected junit arguments")
+		t.Log(diff)
+	}
+	if got, want := script.Line(steps[1]), 11; got != want {
+		t.Errorf("Want line %d, got %d", want, got)
+	}
+}
+
+func TestParseExpr(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{
+			src:  `env.BRANCH_NAME == 'main' && params.DEPLOY`,
+			want: "&&",
+		},
+		{
+			src:  `sh(returnStdout: true, script: 'git rev-parse HEAD')` + "\n" + `  .trim()`,
+			want: "trim",
+		},
+		{
+			src:  `a ? b : c`,
+			want: "?",
+		},
+		{
+			src:  `!(x =~ /^release-.*/)`,
+			want: "!",
+		},
+	}
+	for _, test := range tests {
+		expr, err := ParseExpr(test.src)
+		if err != nil {
+			t.Errorf("%s: %s", test.src, err)
+			continue
+		}
+		var got string
+		switch v := expr.(type) {
+		case *Binary:
+			got = v.Op
+		case *Call:
+			got = v.Name
+		case *Ternary:
+			got = "?"
+		case *Unary:
+			got = v.Op
+		}
+		if got != test.want {
+			t.Errorf("%s: want %s, got %s", test.src, test.want, got)
+		}
+	}
+}
+
+func TestParseString(t *testing.T) {
+	expr, err := ParseExpr(`"mvn ${args} -Drevision=${env.VERSION} \$HOME"`)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	str, ok := expr.(*String)
+	if !ok {
+		t.Errorf("Expect string expression")
+		return
+	}
+	if got, want := str.Value, "mvn ${args} -Drevision=${env.VERSION} $HOME"; got != want {
+		t.Errorf("Want value %q, got %q", want, got)
+	}
+	var names []string
+	for _, part := range str.Parts {
+		if part.Expr != nil {
+			names = append(names, Name(part.Expr))
+		}
+	}
+	if diff := cmp.Diff(names, []string{"args", "env.VERSION"}); diff != "" {
+		t.Errorf("Unexpected string expressions")
+		t.Log(diff)
+	}
+}
+
+func TestParseError(t *testing.T) {
+	tests := []string{
+		"pipeline {",
+		"sh 'unterminated",
+		"foo(",
+	}
+	for _, test := range tests {
+		if _, err := Parse(test); err == nil {
+			t.Errorf("Expect error parsing %q", test)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groovy
+
+// Kind defines the token kind.
+type Kind int
+
+// Token kinds.
+const (
+	EOF Kind = iota
+	IDENT
+	STRING
+	NUMBER
+	OP
+)
+
+// String returns the token kind as a string.
+func (k Kind) String() string {
+	switch k {
+	case IDENT:
+		return "identifier"
+	case STRING:
+		return "string"
+	case NUMBER:
+		return "number"
+	case OP:
+		return "operator"
+	default:
+		return "end of file"
+	}
+}
+
+// Token defines a lexical token.
+type Token struct {
+	Kind Kind
+	// Text is the token text. For string tokens, Text is
+	// the unquoted and unescaped string value.
+	Text string
+	// Pos and End are the byte offsets of the token in
+	// the source.
+	Pos int
+	End int
+	// Line is the line number of the token, starting at 1.
+	Line int
+	// Newline is true if the token is the first token
+	// on a line.
+	Newline bool
+	// Interpolated is true if the token is a double
+	// quoted (GString) string.
+	Interpolated bool
+}
+
+// operators sorted by length, longest first, so that the
+// lexer matches the longest operator.
+var operators = []string{
+	">>>=",
+	"<=>", "==~", "..<", ">>>", "<<=", ">>=", "**=",
+	"=~", "?.", "?:", "*.", ".&", "..", "->", "==", "!=", "<=", ">=",
+	"&&", "||", "++", "--", "+=", "-=", "*=", "/=", "%=", "**",
+	"<<", ">>", "::", "&=", "|=", "^=",
+	"{", "}", "(", ")", "[", "]", ",", ";", ":", ".", "=", "+",
+	"-", "*", "/", "%", "!", "<", ">", "?", "&", "|", "^", "~", "@",
+}
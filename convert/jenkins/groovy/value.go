@@ -0,0 +1,125 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groovy
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Value returns the Go value of a literal expression.
+// Numbers are returned as float64, lists as slices and
+// maps as string keyed maps. Nested method calls, such as
+// Jenkins step symbols, are returned as a map with the
+// symbol name and arguments. Other expressions are
+// returned as nil.
+func Value(expr Expr) interface{} {
+	switch v := expr.(type) {
+	case *String:
+		return v.Value
+	case *Number:
+		s := strings.TrimRight(strings.ReplaceAll(v.Value, "_", ""), "lLiIgGfFdD")
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+		if i, err := strconv.ParseInt(s, 0, 64); err == nil {
+			return float64(i)
+		}
+		return v.Value
+	case *Bool:
+		return v.Value
+	case *List:
+		out := []interface{}{}
+		for _, item := range v.Items {
+			out = append(out, Value(item))
+		}
+		return out
+	case *Map:
+		return Args(v.Entries)
+	case *Call:
+		if v.Receiver != nil {
+			return nil
+		}
+		return map[string]interface{}{
+			"symbol":    v.Name,
+			"arguments": Args(v.Args),
+		}
+	case *Unary:
+		if v.Op == "-" {
+			if f, ok := Value(v.X).(float64); ok {
+				return -f
+			}
+		}
+	}
+	return nil
+}
+
+// Args returns the Go value of the call arguments. Named
+// arguments are returned as a map. If all arguments are
+// positional, a single argument is returned as its value
+// and multiple arguments are returned as a slice.
+func Args(args []*Arg) interface{} {
+	named := map[string]interface{}{}
+	var positional []interface{}
+	for _, arg := range args {
+		switch {
+		case arg.Name != "":
+			named[arg.Name] = Value(arg.Value)
+		case arg.Key != nil:
+			if key, ok := Value(arg.Key).(string); ok {
+				named[key] = Value(arg.Value)
+			}
+		default:
+			positional = append(positional, Value(arg.Value))
+		}
+	}
+	switch {
+	case len(named) != 0 || len(positional) == 0:
+		return named
+	case len(positional) == 1:
+		return positional[0]
+	default:
+		return positional
+	}
+}
+
+// Name returns the dotted name of an identifier or
+// property expression, for example env.BRANCH_NAME, or
+// an empty string.
+func Name(expr Expr) string {
+	switch v := expr.(type) {
+	case *Ident:
+		return v.Name
+	case *Property:
+		if x := Name(v.X); x != "" {
+			return x + "." + v.Name
+		}
+	}
+	return ""
+}
+
+// Calls returns the method calls in the statements, for
+// example the steps in a closure body.
+func Calls(stmts []Stmt) []*Call {
+	var out []*Call
+	for _, stmt := range stmts {
+		if s, ok := stmt.(*ExprStmt); ok {
+			if call, ok := s.X.(*Call); ok {
+				out = append(out, call)
+			}
+		}
+	}
+	return out
+}
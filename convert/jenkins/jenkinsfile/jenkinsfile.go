@@ -0,0 +1,180 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jenkinsfile provides the Jenkins declarative
+// pipeline structure parsed from a Jenkinsfile.
+package jenkinsfile
+
+import "github.com/drone/go-convert/convert/jenkins/groovy"
+
+type (
+	// Pipeline defines a declarative pipeline.
+	// https://www.jenkins.io/doc/book/pipeline/syntax/
+	Pipeline struct {
+		Agent       *Agent
+		Environment []*Variable
+		Options     []*groovy.Call
+		Parameters  []*Parameter
+		Triggers    []*groovy.Call
+		Tools       []*Tool
+		Libraries   []string
+		Stages      []*Stage
+		Post        []*Post
+
+		// Methods defines the methods declared in the
+		// Jenkinsfile, indexed by name.
+		Methods map[string]*groovy.MethodDecl
+
+		// Unknown defines sections that are not
+		// recognized by the parser.
+		Unknown []groovy.Node
+
+		// Scripted is true if the Jenkinsfile defines a
+		// scripted pipeline instead of a declarative
+		// pipeline.
+		Scripted bool
+
+		// Script is the parsed groovy script.
+		Script *groovy.Script
+	}
+
+	// Agent defines the pipeline or stage agent.
+	// https://www.jenkins.io/doc/book/pipeline/syntax/#agent
+	Agent struct {
+		Type        string // any, none, label, node, docker, dockerfile, kubernetes
+		Label       string
+		Image       string
+		Args        string
+		Registry    string
+		Credentials string
+		Filename    string
+		Dir         string
+		BuildArgs   string
+		Yaml        string
+		YamlFile    string
+		Container   string
+		Workspace   string
+		ReuseNode   bool
+		Node        groovy.Node
+	}
+
+	// Variable defines an environment variable.
+	Variable struct {
+		Name  string
+		Value groovy.Expr
+	}
+
+	// Parameter defines a pipeline parameter.
+	// https://www.jenkins.io/doc/book/pipeline/syntax/#parameters
+	Parameter struct {
+		Type        string // string, text, booleanParam, choice, password
+		Name        string
+		Default     interface{}
+		Choices     []string
+		Description string
+		Node        *groovy.Call
+	}
+
+	// Tool defines a tool installation.
+	// https://www.jenkins.io/doc/book/pipeline/syntax/#tools
+	Tool struct {
+		Type string // maven, jdk, gradle, nodejs, go
+		Name string
+	}
+
+	// Stage defines a pipeline stage.
+	// https://www.jenkins.io/doc/book/pipeline/syntax/#stage
+	Stage struct {
+		Name        string
+		Agent       *Agent
+		Environment []*Variable
+		Options     []*groovy.Call
+		When        *When
+		Tools       []*Tool
+		Input       *groovy.Call
+		FailFast    bool
+		Steps       []groovy.Stmt
+		Parallel    []*Stage
+		Stages      []*Stage
+		Matrix      *Matrix
+		Post        []*Post
+		Unknown     []groovy.Node
+		Node        groovy.Node
+	}
+
+	// When defines the stage conditions.
+	// https://www.jenkins.io/doc/book/pipeline/syntax/#when
+	When struct {
+		BeforeAgent   bool
+		BeforeInput   bool
+		BeforeOptions bool
+		Conditions    []*Condition
+	}
+
+	// Condition defines a when condition.
+	Condition struct {
+		// Type is the condition name, for example branch,
+		// tag, environment, expression, not, allOf or anyOf.
+		Type string
+
+		// Pattern and Comparator are set for the branch,
+		// tag, changeset, changelog and changeRequest
+		// conditions.
+		Pattern    string
+		Comparator string
+
+		// Name and Value are set for the environment
+		// condition.
+		Name  string
+		Value string
+
+		// Expected and Actual are set for the equals
+		// condition.
+		Expected groovy.Expr
+		Actual   groovy.Expr
+
+		// Expr is set for the expression condition.
+		Expr []groovy.Stmt
+
+		// Conditions is set for the not, allOf and anyOf
+		// conditions.
+		Conditions []*Condition
+
+		Node *groovy.Call
+	}
+
+	// Matrix defines a stage matrix.
+	// https://www.jenkins.io/doc/book/pipeline/syntax/#declarative-matrix
+	Matrix struct {
+		Axes     []*Axis
+		Excludes [][]*Axis
+		Stages   []*Stage
+	}
+
+	// Axis defines a matrix axis.
+	Axis struct {
+		Name    string
+		Values  []string
+		Exclude bool // notValues
+	}
+
+	// Post defines post build steps that execute on the
+	// named condition, for example always or failure.
+	// https://www.jenkins.io/doc/book/pipeline/syntax/#post
+	Post struct {
+		Type  string
+		Steps []groovy.Stmt
+		Node  *groovy.Call
+	}
+)
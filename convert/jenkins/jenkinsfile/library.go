@@ -0,0 +1,125 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jenkinsfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/drone/go-convert/convert/jenkins/groovy"
+)
+
+type (
+	// Library defines a Jenkins shared library.
+	// https://www.jenkins.io/doc/book/pipeline/shared-libraries/
+	Library struct {
+		// Vars defines the global variables declared in
+		// the vars directory, indexed by name.
+		Vars map[string]*Var
+	}
+
+	// Var defines a global variable declared in the vars
+	// directory of the shared library, for example
+	// vars/buildJavaService.groovy.
+	Var struct {
+		Name string
+		Path string
+
+		// Methods defines the methods declared in the
+		// file, indexed by name. The call method is
+		// invoked when the variable is called as a step.
+		Methods map[string]*groovy.MethodDecl
+
+		// Script is the parsed groovy script.
+		Script *groovy.Script
+	}
+)
+
+// ParseLibrary parses the global variables declared in
+// the vars directory of the shared library checkout. The
+// variables of multiple libraries are merged, and the
+// variables of the first library take precedence.
+func ParseLibrary(dirs ...string) (*Library, error) {
+	library := &Library{
+		Vars: map[string]*Var{},
+	}
+	for _, dir := range dirs {
+		paths, err := filepath.Glob(filepath.Join(dir, "vars", "*.groovy"))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			v, err := parseVar(path)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+			if _, ok := library.Vars[v.Name]; !ok {
+				library.Vars[v.Name] = v
+			}
+		}
+	}
+	return library, nil
+}
+
+// Call returns the call method, or nil if the variable
+// cannot be called as a step.
+func (v *Var) Call() *groovy.MethodDecl {
+	return v.Methods["call"]
+}
+
+// Pipeline returns the declarative pipeline declared by
+// the call method, for shared library steps that define
+// the complete pipeline. Returns nil if the call method
+// does not declare a pipeline block.
+func (v *Var) Pipeline() *Pipeline {
+	call := v.Call()
+	if call == nil {
+		return nil
+	}
+	for _, stmt := range call.Body.Stmts {
+		if c := stmtCall(stmt); c != nil && c.Name == "pipeline" && c.Receiver == nil && c.Closure != nil {
+			return parseStmts(v.Script, call.Body.Stmts)
+		}
+	}
+	return nil
+}
+
+// helper function parses the global variable file.
+func parseVar(path string) (*Var, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	script, err := groovy.Parse(string(b))
+	if err != nil {
+		return nil, err
+	}
+	v := &Var{
+		Name:    strings.TrimSuffix(filepath.Base(path), ".groovy"),
+		Path:    path,
+		Methods: map[string]*groovy.MethodDecl{},
+		Script:  script,
+	}
+	for _, stmt := range script.Body.Stmts {
+		if method, ok := stmt.(*groovy.MethodDecl); ok {
+			v.Methods[method.Name] = method
+		}
+	}
+	return v, nil
+}
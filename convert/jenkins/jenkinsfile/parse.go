@@ -0,0 +1,591 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jenkinsfile
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/drone/go-convert/convert/jenkins/groovy"
+)
+
+// Parse parses the Jenkinsfile from the io.Reader.
+func Parse(r io.Reader) (*Pipeline, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	script, err := groovy.Parse(string(b))
+	if err != nil {
+		return nil, err
+	}
+	return parse(script), nil
+}
+
+// ParseBytes parses the Jenkinsfile from bytes.
+func ParseBytes(b []byte) (*Pipeline, error) {
+	return Parse(
+		bytes.NewBuffer(b),
+	)
+}
+
+// ParseString parses the Jenkinsfile from a string.
+func ParseString(s string) (*Pipeline, error) {
+	return ParseBytes(
+		[]byte(s),
+	)
+}
+
+// ParseFile parses the Jenkinsfile from a file.
+func ParseFile(p string) (*Pipeline, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// parse returns the pipeline structure of the groovy
+// script. If the script does not declare a pipeline
+// block, it is parsed as a scripted pipeline.
+func parse(script *groovy.Script) *Pipeline {
+	return parseStmts(script, script.Body.Stmts)
+}
+
+// parseStmts returns the pipeline structure of the
+// statements, which are the top-level statements of the
+// script, or the body of a method that declares the
+// pipeline.
+func parseStmts(script *groovy.Script, stmts []groovy.Stmt) *Pipeline {
+	pipeline := &Pipeline{
+		Methods: map[string]*groovy.MethodDecl{},
+		Script:  script,
+	}
+
+	var body []groovy.Stmt
+	var declarative *groovy.Call
+	for _, stmt := range stmts {
+		switch v := stmt.(type) {
+		case *groovy.MethodDecl:
+			pipeline.Methods[v.Name] = v
+			continue
+		case *groovy.Annotation:
+			if v.Name == "Library" {
+				pipeline.Libraries = append(pipeline.Libraries, libraries(v.Args)...)
+			}
+			continue
+		case *groovy.Import:
+			continue
+		}
+		if call := stmtCall(stmt); call != nil && call.Name == "pipeline" && call.Receiver == nil && call.Closure != nil {
+			declarative = call
+			continue
+		}
+		body = append(body, stmt)
+	}
+
+	if declarative == nil {
+		pipeline.Scripted = true
+		parseScripted(pipeline, body)
+		return pipeline
+	}
+
+	// statements outside of the pipeline block, such as
+	// global variables, are not converted.
+	for _, stmt := range body {
+		pipeline.Unknown = append(pipeline.Unknown, stmt)
+	}
+
+	for _, stmt := range declarative.Body() {
+		call := stmtCall(stmt)
+		if call == nil {
+			pipeline.Unknown = append(pipeline.Unknown, stmt)
+			continue
+		}
+		switch call.Name {
+		case "agent":
+			pipeline.Agent = parseAgent(call)
+		case "environment":
+			pipeline.Environment = parseEnvironment(call)
+		case "options":
+			pipeline.Options = append(pipeline.Options, groovy.Calls(call.Body())...)
+		case "parameters":
+			pipeline.Parameters = append(pipeline.Parameters, parseParameters(call.Body())...)
+		case "triggers":
+			pipeline.Triggers = append(pipeline.Triggers, groovy.Calls(call.Body())...)
+		case "tools":
+			pipeline.Tools = append(pipeline.Tools, parseTools(call)...)
+		case "libraries":
+			for _, lib := range groovy.Calls(call.Body()) {
+				pipeline.Libraries = append(pipeline.Libraries, libraries(lib.Args)...)
+			}
+		case "stages":
+			var unknown []groovy.Node
+			pipeline.Stages, unknown = parseStages(call)
+			pipeline.Unknown = append(pipeline.Unknown, unknown...)
+		case "post":
+			pipeline.Post = parsePost(call)
+		default:
+			pipeline.Unknown = append(pipeline.Unknown, call)
+		}
+	}
+	return pipeline
+}
+
+// helper function parses the agent section.
+func parseAgent(call *groovy.Call) *Agent {
+	agent := &Agent{Node: call}
+
+	// agent any, agent none or agent label: 'x'
+	if args := call.Positional(); len(args) != 0 {
+		switch v := args[0].(type) {
+		case *groovy.Ident:
+			agent.Type = v.Name
+		case *groovy.String:
+			agent.Type = "label"
+			agent.Label = v.Value
+		}
+	}
+	if label := stringArg(call, "label"); label != "" {
+		agent.Type = "label"
+		agent.Label = label
+	}
+
+	for _, inner := range groovy.Calls(call.Body()) {
+		switch inner.Name {
+		case "any", "none":
+			agent.Type = inner.Name
+		case "label":
+			agent.Type = "label"
+			agent.Label = stringValue(inner)
+		case "node":
+			agent.Type = "label"
+			for _, arg := range groovy.Calls(inner.Body()) {
+				switch arg.Name {
+				case "label":
+					agent.Label = stringValue(arg)
+				case "customWorkspace":
+					agent.Workspace = stringValue(arg)
+				}
+			}
+			if label := stringArg(inner, "label"); label != "" {
+				agent.Label = label
+			}
+		case "docker":
+			agent.Type = "docker"
+			agent.Image = stringValue(inner)
+			if image := stringArg(inner, "image"); image != "" {
+				agent.Image = image
+			}
+			for _, arg := range groovy.Calls(inner.Body()) {
+				switch arg.Name {
+				case "image":
+					agent.Image = stringValue(arg)
+				case "args":
+					agent.Args = stringValue(arg)
+				case "label":
+					agent.Label = stringValue(arg)
+				case "registryUrl":
+					agent.Registry = stringValue(arg)
+				case "registryCredentialsId":
+					agent.Credentials = stringValue(arg)
+				case "customWorkspace":
+					agent.Workspace = stringValue(arg)
+				case "reuseNode":
+					agent.ReuseNode = boolValue(arg)
+				}
+			}
+		case "dockerfile":
+			agent.Type = "dockerfile"
+			agent.Filename = "Dockerfile"
+			for _, arg := range groovy.Calls(inner.Body()) {
+				switch arg.Name {
+				case "filename":
+					agent.Filename = stringValue(arg)
+				case "dir":
+					agent.Dir = stringValue(arg)
+				case "additionalBuildArgs":
+					agent.BuildArgs = stringValue(arg)
+				case "args":
+					agent.Args = stringValue(arg)
+				case "label":
+					agent.Label = stringValue(arg)
+				case "registryUrl":
+					agent.Registry = stringValue(arg)
+				case "registryCredentialsId":
+					agent.Credentials = stringValue(arg)
+				case "reuseNode":
+					agent.ReuseNode = boolValue(arg)
+				}
+			}
+		case "kubernetes":
+			agent.Type = "kubernetes"
+			for _, arg := range groovy.Calls(inner.Body()) {
+				switch arg.Name {
+				case "yaml":
+					agent.Yaml = stringValue(arg)
+				case "yamlFile":
+					agent.YamlFile = stringValue(arg)
+				case "label":
+					agent.Label = stringValue(arg)
+				case "defaultContainer":
+					agent.Container = stringValue(arg)
+				}
+			}
+		}
+	}
+	return agent
+}
+
+// helper function parses the environment section.
+func parseEnvironment(call *groovy.Call) []*Variable {
+	var vars []*Variable
+	for _, stmt := range call.Body() {
+		assign, ok := stmt.(*groovy.Assign)
+		if !ok {
+			continue
+		}
+		if name := groovy.Name(assign.Target); name != "" {
+			vars = append(vars, &Variable{
+				Name:  name,
+				Value: assign.Value,
+			})
+		}
+	}
+	return vars
+}
+
+// helper function parses the parameters section.
+func parseParameters(stmts []groovy.Stmt) []*Parameter {
+	var params []*Parameter
+	for _, call := range groovy.Calls(stmts) {
+		param := &Parameter{
+			Type:        call.Name,
+			Name:        stringArg(call, "name"),
+			Description: stringArg(call, "description"),
+			Node:        call,
+		}
+		if v := call.Arg("defaultValue"); v != nil {
+			param.Default = groovy.Value(v)
+		}
+		switch v := groovy.Value(call.Arg("choices")).(type) {
+		case string:
+			param.Choices = strings.Split(v, "\n")
+		case []interface{}:
+			for _, choice := range v {
+				if s, ok := choice.(string); ok {
+					param.Choices = append(param.Choices, s)
+				}
+			}
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// helper function parses the tools section.
+func parseTools(call *groovy.Call) []*Tool {
+	var tools []*Tool
+	for _, inner := range groovy.Calls(call.Body()) {
+		tool := &Tool{
+			Type: inner.Name,
+			Name: stringValue(inner),
+		}
+		// tool name: 'x', type: 'maven'
+		if typ := stringArg(inner, "type"); typ != "" {
+			tool.Type = typ
+			tool.Name = stringArg(inner, "name")
+		}
+		tools = append(tools, tool)
+	}
+	return tools
+}
+
+// helper function parses the stages section.
+func parseStages(call *groovy.Call) ([]*Stage, []groovy.Node) {
+	var stages []*Stage
+	var unknown []groovy.Node
+	for _, stmt := range call.Body() {
+		inner := stmtCall(stmt)
+		if inner == nil || inner.Name != "stage" {
+			unknown = append(unknown, stmt)
+			continue
+		}
+		stages = append(stages, parseStage(inner))
+	}
+	return stages, unknown
+}
+
+// helper function parses the stage.
+func parseStage(call *groovy.Call) *Stage {
+	stage := &Stage{
+		Name: stringValue(call),
+		Node: call,
+	}
+	if name := stringArg(call, "name"); name != "" {
+		stage.Name = name
+	}
+	for _, stmt := range call.Body() {
+		inner := stmtCall(stmt)
+		if inner == nil {
+			stage.Unknown = append(stage.Unknown, stmt)
+			continue
+		}
+		var unknown []groovy.Node
+		switch inner.Name {
+		case "agent":
+			stage.Agent = parseAgent(inner)
+		case "environment":
+			stage.Environment = parseEnvironment(inner)
+		case "options":
+			stage.Options = append(stage.Options, groovy.Calls(inner.Body())...)
+		case "when":
+			stage.When = parseWhen(inner)
+		case "tools":
+			stage.Tools = append(stage.Tools, parseTools(inner)...)
+		case "input":
+			stage.Input = inner
+		case "failFast":
+			stage.FailFast = boolValue(inner)
+		case "steps":
+			stage.Steps = inner.Body()
+		case "parallel":
+			stage.Parallel, unknown = parseStages(inner)
+		case "stages":
+			stage.Stages, unknown = parseStages(inner)
+		case "matrix":
+			stage.Matrix, unknown = parseMatrix(stage, inner)
+		case "post":
+			stage.Post = parsePost(inner)
+		default:
+			unknown = append(unknown, inner)
+		}
+		stage.Unknown = append(stage.Unknown, unknown...)
+	}
+	return stage
+}
+
+// helper function parses the when section.
+func parseWhen(call *groovy.Call) *When {
+	when := new(When)
+	for _, inner := range groovy.Calls(call.Body()) {
+		switch inner.Name {
+		case "beforeAgent":
+			when.BeforeAgent = boolValue(inner)
+		case "beforeInput":
+			when.BeforeInput = boolValue(inner)
+		case "beforeOptions":
+			when.BeforeOptions = boolValue(inner)
+		default:
+			when.Conditions = append(when.Conditions, parseCondition(inner))
+		}
+	}
+	return when
+}
+
+// helper function parses the when condition.
+func parseCondition(call *groovy.Call) *Condition {
+	cond := &Condition{
+		Type: call.Name,
+		Node: call,
+	}
+	switch call.Name {
+	case "not", "allOf", "anyOf":
+		for _, inner := range groovy.Calls(call.Body()) {
+			cond.Conditions = append(cond.Conditions, parseCondition(inner))
+		}
+	case "expression":
+		cond.Expr = call.Body()
+	case "environment":
+		cond.Name = stringArg(call, "name")
+		cond.Value = stringArg(call, "value")
+	case "equals":
+		cond.Expected = call.Arg("expected")
+		cond.Actual = call.Arg("actual")
+	case "changeRequest":
+		// changeRequest target: 'main', comparator: 'GLOB'
+		for _, arg := range call.Args {
+			switch arg.Name {
+			case "", "comparator":
+			default:
+				cond.Name = arg.Name
+				cond.Pattern, _ = groovy.Value(arg.Value).(string)
+			}
+		}
+		cond.Comparator = stringArg(call, "comparator")
+	case "triggeredBy":
+		cond.Pattern = stringValue(call)
+		if cause := stringArg(call, "cause"); cause != "" {
+			cond.Pattern = cause
+		}
+	default:
+		cond.Pattern = stringValue(call)
+		if pattern := stringArg(call, "pattern"); pattern != "" {
+			cond.Pattern = pattern
+		}
+		cond.Comparator = stringArg(call, "comparator")
+	}
+	return cond
+}
+
+// helper function parses the matrix section.
+func parseMatrix(stage *Stage, call *groovy.Call) (*Matrix, []groovy.Node) {
+	matrix := new(Matrix)
+	var unknown []groovy.Node
+	for _, inner := range groovy.Calls(call.Body()) {
+		switch inner.Name {
+		case "axes":
+			for _, axis := range groovy.Calls(inner.Body()) {
+				if axis.Name == "axis" {
+					matrix.Axes = append(matrix.Axes, parseAxis(axis))
+				}
+			}
+		case "excludes":
+			for _, exclude := range groovy.Calls(inner.Body()) {
+				var axes []*Axis
+				for _, axis := range groovy.Calls(exclude.Body()) {
+					if axis.Name == "axis" {
+						axes = append(axes, parseAxis(axis))
+					}
+				}
+				matrix.Excludes = append(matrix.Excludes, axes)
+			}
+		case "stages":
+			var nodes []groovy.Node
+			matrix.Stages, nodes = parseStages(inner)
+			unknown = append(unknown, nodes...)
+		// the matrix directive supports a subset of the
+		// stage directives, which are applied to the
+		// parent stage.
+		case "agent":
+			stage.Agent = parseAgent(inner)
+		case "environment":
+			stage.Environment = append(stage.Environment, parseEnvironment(inner)...)
+		case "options":
+			stage.Options = append(stage.Options, groovy.Calls(inner.Body())...)
+		case "when":
+			stage.When = parseWhen(inner)
+		case "tools":
+			stage.Tools = append(stage.Tools, parseTools(inner)...)
+		case "post":
+			stage.Post = append(stage.Post, parsePost(inner)...)
+		default:
+			unknown = append(unknown, inner)
+		}
+	}
+	return matrix, unknown
+}
+
+// helper function parses the matrix axis.
+func parseAxis(call *groovy.Call) *Axis {
+	axis := new(Axis)
+	for _, inner := range groovy.Calls(call.Body()) {
+		switch inner.Name {
+		case "name":
+			axis.Name = stringValue(inner)
+		case "values", "notValues":
+			axis.Exclude = inner.Name == "notValues"
+			for _, arg := range inner.Positional() {
+				switch v := groovy.Value(arg).(type) {
+				case string:
+					axis.Values = append(axis.Values, v)
+				case []interface{}:
+					for _, item := range v {
+						if s, ok := item.(string); ok {
+							axis.Values = append(axis.Values, s)
+						}
+					}
+				}
+			}
+		}
+	}
+	return axis
+}
+
+// helper function parses the post section.
+func parsePost(call *groovy.Call) []*Post {
+	var post []*Post
+	for _, inner := range groovy.Calls(call.Body()) {
+		post = append(post, &Post{
+			Type:  inner.Name,
+			Steps: inner.Body(),
+			Node:  inner,
+		})
+	}
+	return post
+}
+
+// helper function returns the library names from the
+// @Library annotation or library step arguments.
+func libraries(args []*groovy.Arg) []string {
+	var out []string
+	for _, arg := range args {
+		if arg.Name != "" && arg.Name != "value" {
+			continue
+		}
+		switch v := groovy.Value(arg.Value).(type) {
+		case string:
+			out = append(out, v)
+		case []interface{}:
+			for _, item := range v {
+				if s, ok := item.(string); ok {
+					out = append(out, s)
+				}
+			}
+		}
+	}
+	return out
+}
+
+// helper function returns the method call if the
+// statement is a method call expression.
+func stmtCall(stmt groovy.Stmt) *groovy.Call {
+	if s, ok := stmt.(*groovy.ExprStmt); ok {
+		if call, ok := s.X.(*groovy.Call); ok {
+			return call
+		}
+	}
+	return nil
+}
+
+// helper function returns the first positional argument
+// as a string.
+func stringValue(call *groovy.Call) string {
+	if args := call.Positional(); len(args) != 0 {
+		if s, ok := groovy.Value(args[0]).(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+// helper function returns the named argument as a string.
+func stringArg(call *groovy.Call, name string) string {
+	s, _ := groovy.Value(call.Arg(name)).(string)
+	return s
+}
+
+// helper function returns the first positional argument
+// as a boolean.
+func boolValue(call *groovy.Call) bool {
+	if args := call.Positional(); len(args) != 0 {
+		b, _ := groovy.Value(args[0]).(bool)
+		return b
+	}
+	return false
+}
@@ -0,0 +1,190 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jenkinsfile
+
+import (
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseFiles(t *testing.T) {
+	tests, err := filepath.Glob("../testdata/Jenkinsfile*")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			if _, err := ParseFile(test); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	src := `
+pipeline {
+    agent { docker { image 'golang:1.21'; args '-u root' } }
+    parameters {
+        choice(name: 'REGION', choices: ['us-east-1', 'eu-west-1'], description: 'aws region')
+    }
+    tools { maven 'Maven 3.9' }
+    stages {
+        stage('Test') {
+            when { branch 'main' }
+            matrix {
+                axes {
+                    axis { name 'OS'; values 'linux', 'windows' }
+                }
+                stages {
+                    stage('Unit') { steps { sh 'make test' } }
+                }
+            }
+        }
+    }
+    post { always { deleteDir() } }
+}
+`
+	pipeline, err := ParseString(src)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if pipeline.Scripted {
+		t.Errorf("Expect declarative pipeline")
+	}
+	if got, want := pipeline.Agent.Type, "docker"; got != want {
+		t.Errorf("Want agent type %q, got %q", want, got)
+	}
+	if got, want := pipeline.Agent.Image, "golang:1.21"; got != want {
+		t.Errorf("Want agent image %q, got %q", want, got)
+	}
+	if got, want := pipeline.Agent.Args, "-u root"; got != want {
+		t.Errorf("Want agent args %q, got %q", want, got)
+	}
+
+	if len(pipeline.Parameters) != 1 {
+		t.Errorf("Want 1 parameter, got %d", len(pipeline.Parameters))
+		return
+	}
+	param := pipeline.Parameters[0]
+	if got, want := param.Type, "choice"; got != want {
+		t.Errorf("Want parameter type %q, got %q", want, got)
+	}
+	if diff := cmp.Diff(param.Choices, []string{"us-east-1", "eu-west-1"}); diff != "" {
+		t.Errorf("Unexpected parameter choices")
+		t.Log(diff)
+	}
+
+	if diff := cmp.Diff(pipeline.Tools, []*Tool{{Type: "maven", Name: "Maven 3.9"}}); diff != "" {
+		t.Errorf("Unexpected tools")
+		t.Log(diff)
+	}
+
+	if len(pipeline.Stages) != 1 {
+		t.Errorf("Want 1 stage, got %d", len(pipeline.Stages))
+		return
+	}
+	stage := pipeline.Stages[0]
+	if stage.When == nil || len(stage.When.Conditions) != 1 {
+		t.Errorf("Want 1 stage condition")
+	} else if got, want := stage.When.Conditions[0].Pattern, "main"; got != want {
+		t.Errorf("Want branch pattern %q, got %q", want, got)
+	}
+	if stage.Matrix == nil {
+		t.Errorf("Want stage matrix")
+		return
+	}
+	if diff := cmp.Diff(stage.Matrix.Axes, []*Axis{{Name: "OS", Values: []string{"linux", "windows"}}}); diff != "" {
+		t.Errorf("Unexpected matrix axes")
+		t.Log(diff)
+	}
+	if got, want := len(stage.Matrix.Stages), 1; got != want {
+		t.Errorf("Want %d matrix stages, got %d", want, got)
+	}
+
+	if len(pipeline.Post) != 1 || pipeline.Post[0].Type != "always" {
+		t.Errorf("Want always post section")
+	}
+}
+
+func TestParseScripted(t *testing.T) {
+	src := `
+node('linux') {
+    stage('Build') {
+        sh 'make'
+    }
+    stage('Test') {
+        sh 'make test'
+    }
+}
+`
+	pipeline, err := ParseString(src)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !pipeline.Scripted {
+		t.Errorf("Expect scripted pipeline")
+	}
+	var names []string
+	for _, stage := range pipeline.Stages {
+		names = append(names, stage.Name)
+	}
+	if diff := cmp.Diff(names, []string{"Build", "Test"}); diff != "" {
+		t.Errorf("Unexpected stages")
+		t.Log(diff)
+	}
+}
+
+func TestParseLibrary(t *testing.T) {
+	library, err := ParseLibrary("../testdata/library")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	var names []string
+	for name := range library.Vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if diff := cmp.Diff(names, []string{"buildJavaService", "javaPipeline", "maven"}); diff != "" {
+		t.Errorf("Unexpected library vars")
+		t.Log(diff)
+	}
+
+	if v := library.Vars["maven"]; v.Call() != nil || v.Methods["verify"] == nil {
+		t.Errorf("Expect maven var with a verify method and no call method")
+	}
+	if v := library.Vars["buildJavaService"]; v.Call() == nil || v.Pipeline() != nil {
+		t.Errorf("Expect buildJavaService var with a call method and no pipeline")
+	}
+	pipeline := library.Vars["javaPipeline"].Pipeline()
+	if pipeline == nil {
+		t.Errorf("Expect javaPipeline var to declare a pipeline")
+		return
+	}
+	if got, want := len(pipeline.Stages), 2; got != want {
+		t.Errorf("Want %d stages, got %d", want, got)
+	}
+	if pipeline.Scripted {
+		t.Errorf("Expect declarative pipeline")
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jenkinsfile
+
+import (
+	"github.com/drone/go-convert/convert/jenkins/groovy"
+)
+
+// parseScripted parses a scripted pipeline on a best-effort
+// basis. Stage blocks are converted to stages, and
+// statements outside of a stage are grouped into implicit
+// stages. Wrapper blocks that enclose stages, such as
+// catchError or withEnv, are pushed down into each
+// enclosed stage.
+func parseScripted(pipeline *Pipeline, stmts []groovy.Stmt) {
+	w := &walker{pipeline: pipeline}
+	w.walk(stmts, nil)
+}
+
+type walker struct {
+	pipeline *Pipeline
+	pending  []groovy.Stmt
+}
+
+// walk walks the statements at the current nesting level,
+// where wrappers are the enclosing wrapper blocks.
+func (w *walker) walk(stmts []groovy.Stmt, wrappers []*groovy.Call) {
+	for _, stmt := range stmts {
+		if try, ok := stmt.(*groovy.Try); ok && containsStage(try.Body.Stmts) {
+			w.flush(wrappers)
+			w.walk(try.Body.Stmts, wrappers)
+			for _, catch := range try.Catches {
+				w.pipeline.Post = append(w.pipeline.Post, &Post{
+					Type:  "failure",
+					Steps: wrap(catch.Body.Stmts, wrappers),
+				})
+			}
+			if try.Finally != nil {
+				w.pipeline.Post = append(w.pipeline.Post, &Post{
+					Type:  "always",
+					Steps: wrap(try.Finally.Stmts, wrappers),
+				})
+			}
+			continue
+		}
+
+		call := stmtCall(stmt)
+		if call == nil {
+			w.pending = append(w.pending, stmt)
+			continue
+		}
+
+		switch {
+		case call.Name == "stage" && call.Receiver == nil && call.Closure != nil:
+			w.flush(wrappers)
+			w.pipeline.Stages = append(w.pipeline.Stages, &Stage{
+				Name:  stringValue(call),
+				Steps: wrap(call.Body(), wrappers),
+				Node:  call,
+			})
+		case call.Name == "parallel" && call.Receiver == nil:
+			w.flush(wrappers)
+			w.pipeline.Stages = append(w.pipeline.Stages, parseScriptedParallel(call, wrappers))
+		case call.Name == "properties" && call.Receiver == nil:
+			w.parseProperties(call)
+		case call.Name == "node" && call.Receiver == nil && call.Closure != nil:
+			if w.pipeline.Agent == nil {
+				w.pipeline.Agent = &Agent{Type: "any", Node: call}
+				if label := stringValue(call); label != "" {
+					w.pipeline.Agent.Type = "label"
+					w.pipeline.Agent.Label = label
+				}
+			}
+			w.flush(wrappers)
+			w.walk(call.Body(), wrappers)
+			w.flush(wrappers)
+		case call.Closure != nil && containsStage(call.Body()):
+			// copy the wrappers to prevent modification of
+			// the parent slice.
+			inner := append(append([]*groovy.Call{}, wrappers...), call)
+			w.flush(wrappers)
+			w.walk(call.Body(), inner)
+			w.flush(inner)
+		default:
+			w.pending = append(w.pending, stmt)
+		}
+	}
+	w.flush(wrappers)
+}
+
+// flush adds the pending statements to an implicit stage.
+func (w *walker) flush(wrappers []*groovy.Call) {
+	if len(w.pending) == 0 {
+		return
+	}
+	w.pipeline.Stages = append(w.pipeline.Stages, &Stage{
+		Steps: wrap(w.pending, wrappers),
+	})
+	w.pending = nil
+}
+
+// parseProperties parses the job properties step, which
+// defines the scripted pipeline options, parameters and
+// triggers.
+func (w *walker) parseProperties(call *groovy.Call) {
+	for _, arg := range call.Positional() {
+		list, ok := arg.(*groovy.List)
+		if !ok {
+			continue
+		}
+		for _, item := range list.Items {
+			prop, ok := item.(*groovy.Call)
+			if !ok {
+				continue
+			}
+			switch prop.Name {
+			case "parameters":
+				for _, arg := range prop.Positional() {
+					if list, ok := arg.(*groovy.List); ok {
+						w.pipeline.Parameters = append(w.pipeline.Parameters, parseParameters(exprStmts(list.Items))...)
+					}
+				}
+			case "pipelineTriggers":
+				for _, arg := range prop.Positional() {
+					if list, ok := arg.(*groovy.List); ok {
+						w.pipeline.Triggers = append(w.pipeline.Triggers, groovy.Calls(exprStmts(list.Items))...)
+					} else {
+						// the triggers are computed dynamically,
+						// for example by a method call.
+						w.pipeline.Unknown = append(w.pipeline.Unknown, prop)
+					}
+				}
+			default:
+				w.pipeline.Options = append(w.pipeline.Options, prop)
+			}
+		}
+	}
+}
+
+// helper function parses the parallel step of a scripted
+// pipeline, for example: parallel(a: { ... }, b: { ... })
+func parseScriptedParallel(call *groovy.Call, wrappers []*groovy.Call) *Stage {
+	stage := &Stage{Node: call}
+	for _, arg := range call.Args {
+		switch {
+		case arg.Name == "failFast":
+			stage.FailFast, _ = groovy.Value(arg.Value).(bool)
+			continue
+		case arg.Name == "":
+			continue
+		}
+		closure, ok := arg.Value.(*groovy.Closure)
+		if !ok {
+			stage.Unknown = append(stage.Unknown, arg.Value)
+			continue
+		}
+		stmts := closure.Body.Stmts
+		// if the branch contains a single stage, the stage
+		// is used as the parallel branch.
+		if len(stmts) == 1 {
+			if inner := stmtCall(stmts[0]); inner != nil && inner.Name == "stage" && inner.Closure != nil {
+				stage.Parallel = append(stage.Parallel, &Stage{
+					Name:  stringValue(inner),
+					Steps: wrap(inner.Body(), wrappers),
+					Node:  inner,
+				})
+				continue
+			}
+		}
+		stage.Parallel = append(stage.Parallel, &Stage{
+			Name:  arg.Name,
+			Steps: wrap(stmts, wrappers),
+			Node:  closure,
+		})
+	}
+	return stage
+}
+
+// helper function wraps the statements in the wrapper
+// blocks, from innermost to outermost.
+func wrap(stmts []groovy.Stmt, wrappers []*groovy.Call) []groovy.Stmt {
+	for i := len(wrappers) - 1; i >= 0; i-- {
+		clone := *wrappers[i]
+		clone.Closure = &groovy.Closure{
+			Pos:    wrappers[i].Closure.Pos,
+			Params: wrappers[i].Closure.Params,
+			Body: &groovy.Block{
+				Pos:   wrappers[i].Closure.Body.Pos,
+				Stmts: stmts,
+			},
+		}
+		stmts = []groovy.Stmt{
+			&groovy.ExprStmt{Pos: clone.Pos, X: &clone},
+		}
+	}
+	return stmts
+}
+
+// helper function returns true if the statements contain
+// a stage or parallel block at any depth.
+func containsStage(stmts []groovy.Stmt) bool {
+	for _, stmt := range stmts {
+		switch v := stmt.(type) {
+		case *groovy.Try:
+			if containsStage(v.Body.Stmts) {
+				return true
+			}
+		case *groovy.ExprStmt:
+			call, ok := v.X.(*groovy.Call)
+			if !ok {
+				continue
+			}
+			if call.Receiver == nil && (call.Name == "stage" || call.Name == "parallel") {
+				return true
+			}
+			if containsStage(call.Body()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// helper function converts expressions to statements.
+func exprStmts(exprs []groovy.Expr) []groovy.Stmt {
+	var stmts []groovy.Stmt
+	for _, expr := range exprs {
+		pos, end := expr.Span()
+		stmts = append(stmts, &groovy.ExprStmt{
+			Pos: groovy.Pos{Start: pos, Stop: end},
+			X:   expr,
+		})
+	}
+	return stmts
+}
@@ -0,0 +1,312 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jenkins
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/drone/go-convert/convert/jenkins/groovy"
+	"github.com/drone/go-convert/convert/jenkins/jenkinsfile"
+	"github.com/drone/go-convert/internal/store"
+	harness "github.com/drone/spec/dist/go"
+
+	"github.com/ghodss/yaml"
+)
+
+// ConvertLibrary converts the global steps of the shared
+// library to Harness step group templates, indexed by
+// step name. The templates are referenced by the converted
+// pipelines when the WithLibraryTemplates option is set.
+func (d *Converter) ConvertLibrary() (map[string][]byte, *Report, error) {
+	c, err := d.begin()
+	if err != nil {
+		return nil, nil, err
+	}
+	if c.library == nil {
+		return nil, nil, fmt.Errorf("shared library is not configured")
+	}
+
+	templates := map[string][]byte{}
+	for _, name := range c.libraryNames() {
+		v := c.library.Vars[name]
+		// steps that declare the complete pipeline are
+		// always inlined.
+		if v.Pipeline() != nil {
+			continue
+		}
+		out, err := c.convertTemplate(v)
+		if err != nil {
+			return nil, nil, err
+		}
+		templates[name] = out
+	}
+	return templates, c.report, nil
+}
+
+// helper function converts the shared library step to a
+// step group template.
+func (d *Converter) convertTemplate(v *jenkinsfile.Var) ([]byte, error) {
+	d.identifiers = store.New()
+	d.inputs = map[string]*harness.Input{}
+	d.stage = ""
+	d.spans = 0
+	d.enterVar(v)
+	defer func() { d.inputs = nil }()
+
+	// the step parameters are converted to template
+	// inputs, and the input defaults are taken from
+	// the parameter defaults.
+	method := v.Call()
+	sc := &scope{params: map[string]bool{}, depth: 1}
+	for _, param := range method.Params {
+		sc.params[param.Name] = true
+	}
+	steps := d.convertSteps(method.Body.Stmts, sc)
+	for _, param := range method.Params {
+		if m, ok := param.Default.(*groovy.Map); ok {
+			for _, entry := range m.Entries {
+				if arg := appendNamed(nil, entry); len(arg) != 0 {
+					setInputDefault(d.inputs[arg[0].Name], arg[0].Value)
+				}
+			}
+			continue
+		}
+		setInputDefault(d.inputs[param.Name], param.Default)
+	}
+	if len(d.inputs) == 0 {
+		d.inputs = nil
+	}
+
+	config := &harness.Config{
+		Version: 1,
+		Kind:    "template",
+		Type:    "step",
+		Name:    v.Name,
+		Spec: &harness.TemplateStep{
+			Name:   v.Name,
+			Inputs: d.inputs,
+			Step: &harness.Step{
+				Name: v.Name,
+				Type: "group",
+				Spec: &harness.StepGroup{
+					Steps: steps,
+				},
+			},
+		},
+	}
+	return yaml.Marshal(config)
+}
+
+// helper function sets the input default value, if the
+// default is a literal value.
+func setInputDefault(input *harness.Input, expr groovy.Expr) {
+	if input == nil || !isLiteral(expr) {
+		return
+	}
+	switch v := groovy.Value(expr).(type) {
+	case bool:
+		input.Type = "boolean"
+		input.Default = v
+	case float64:
+		input.Type = "number"
+		input.Default = v
+	case string:
+		input.Default = v
+	}
+}
+
+// helper function converts the call to the shared library
+// step. The step is inlined, or is converted to a template
+// step if the library is converted to templates.
+func (d *Converter) convertLibraryCall(v *jenkinsfile.Var, method *groovy.MethodDecl, call *groovy.Call, sc *scope) []*harness.Step {
+	if sc.depth >= maxDepth {
+		d.unmapped(unmappedStep, call.Name, call, "recursive method call is not converted")
+		return nil
+	}
+	if d.libraryTmpl && method.Name == "call" {
+		return single(d.templateStep(v, call, sc))
+	}
+	// the call arguments are converted in the scope of
+	// the calling script, before the library script is
+	// entered.
+	inner := d.bindParams(method, call, sc)
+	inner.params = nil
+	defer d.enterVar(v)()
+	return d.convertSteps(method.Body.Stmts, inner)
+}
+
+// helper function converts the call to the shared library
+// step to a step that references the step template.
+func (d *Converter) templateStep(v *jenkinsfile.Var, call *groovy.Call, sc *scope) *harness.Step {
+	inputs := map[string]interface{}{}
+	params := v.Call().Params
+	for i, arg := range call.Positional() {
+		if _, ok := arg.(*groovy.Map); ok && len(params) == 1 && len(call.Args) == 1 {
+			continue
+		}
+		if i < len(params) {
+			inputs[params[i].Name] = d.paramValue(arg, sc)
+		}
+	}
+	if len(params) == 1 {
+		for _, arg := range namedArgs(call) {
+			inputs[arg.Name] = d.paramValue(arg.Value, sc)
+		}
+	}
+	if call.Closure != nil {
+		d.unmapped(unmappedStep, call.Name, call, "the block argument is not passed to the template")
+	}
+	if len(inputs) == 0 {
+		inputs = nil
+	}
+	return &harness.Step{
+		Name: d.identifiers.Generate(call.Name),
+		Type: "template",
+		Spec: &harness.StepTemplate{
+			Name:   v.Name,
+			Inputs: inputs,
+		},
+	}
+}
+
+// helper function converts the method parameter, or the
+// property of a single map parameter, to a template input
+// expression. The input is added to the template inputs.
+func (d *Converter) templateInput(name string, sc *scope) (string, bool) {
+	if len(sc.params) == 0 {
+		return "", false
+	}
+	key := name
+	if param, prop, ok := strings.Cut(name, "."); ok {
+		if !sc.params[param] || strings.Contains(prop, ".") {
+			return "", false
+		}
+		key = prop
+	} else if !sc.params[name] {
+		return "", false
+	}
+	if d.inputs != nil && d.inputs[key] == nil {
+		d.inputs[key] = &harness.Input{Type: "string"}
+	}
+	return "<+inputs." + key + ">", true
+}
+
+// helper function returns the shared library step with
+// a call method, or nil if the step is not defined.
+func (d *Converter) libraryVar(name string) *jenkinsfile.Var {
+	if d.library == nil {
+		return nil
+	}
+	v, ok := d.library.Vars[name]
+	if !ok || v.Call() == nil {
+		return nil
+	}
+	return v
+}
+
+// helper function returns the method declared by the
+// shared library step that is the call receiver, or nil if
+// the receiver is not a shared library step.
+func (d *Converter) libraryMethod(call *groovy.Call) *groovy.MethodDecl {
+	if d.library == nil {
+		return nil
+	}
+	if _, ok := call.Receiver.(*groovy.Ident); !ok {
+		return nil
+	}
+	v, ok := d.library.Vars[groovy.Name(call.Receiver)]
+	if !ok {
+		return nil
+	}
+	return v.Methods[call.Name]
+}
+
+// helper function returns the shared library step that
+// declares the pipeline, if the Jenkinsfile only calls
+// the library step, for example:
+//
+//	@Library('ci-lib') _
+//	buildJavaService(name: 'orders')
+func (d *Converter) libraryPipeline(src *jenkinsfile.Pipeline) (*jenkinsfile.Var, *groovy.Call) {
+	if d.library == nil || !src.Scripted {
+		return nil, nil
+	}
+	var stmts []groovy.Stmt
+	for _, stmt := range src.Script.Body.Stmts {
+		switch stmt.(type) {
+		case *groovy.MethodDecl, *groovy.Annotation, *groovy.Import:
+			continue
+		}
+		stmts = append(stmts, stmt)
+	}
+	if len(stmts) != 1 {
+		return nil, nil
+	}
+	expr, ok := stmts[0].(*groovy.ExprStmt)
+	if !ok {
+		return nil, nil
+	}
+	call, ok := expr.X.(*groovy.Call)
+	if !ok || call.Receiver != nil {
+		return nil, nil
+	}
+	v := d.libraryVar(call.Name)
+	if v == nil || v.Pipeline() == nil {
+		return nil, nil
+	}
+	return v, call
+}
+
+// helper function enters the shared library script, so
+// that the methods declared in the script are inlined and
+// the unmapped constructs are reported with the library
+// file and line. Returns a function that restores the
+// calling script.
+func (d *Converter) enterVar(v *jenkinsfile.Var) func() {
+	script, methods, file := d.script, d.methods, d.file
+	d.script, d.methods, d.file = v.Script, v.Methods, v.Path
+	return func() {
+		d.script, d.methods, d.file = script, methods, file
+	}
+}
+
+// helper function parses the shared library, if
+// configured.
+func (d *Converter) loadLibrary() error {
+	if len(d.libraryPaths) == 0 {
+		return nil
+	}
+	library, err := jenkinsfile.ParseLibrary(d.libraryPaths...)
+	if err != nil {
+		return fmt.Errorf("failed to parse the shared library: %w", err)
+	}
+	d.library = library
+	return nil
+}
+
+// helper function returns the sorted shared library step
+// names.
+func (d *Converter) libraryNames() []string {
+	var names []string
+	for name, v := range d.library.Vars {
+		if v.Call() != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
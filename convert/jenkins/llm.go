@@ -0,0 +1,309 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jenkins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// LLMClient defines a large language model client that
+// is used to convert the Jenkinsfile.
+type LLMClient interface {
+	// Complete returns the model response to the chat
+	// messages.
+	Complete(ctx context.Context, messages []*LLMMessage) (string, error)
+}
+
+// LLMMessage defines a chat message.
+type LLMMessage struct {
+	// Role is the message role, for example system,
+	// user or assistant.
+	Role string `json:"role"`
+
+	// Content is the message text.
+	Content string `json:"content"`
+}
+
+// LLMConfig configures the large language model client.
+type LLMConfig struct {
+	// BaseURL is the api base url. The provider default
+	// is used if empty.
+	BaseURL string
+
+	// Token is the api token.
+	Token string
+
+	// Model is the model name. The provider default is
+	// used if empty.
+	Model string
+
+	// Temperature is the sampling temperature. The zero
+	// value gives the most deterministic output.
+	Temperature float64
+
+	// Client is the http client. The default client is
+	// used if nil.
+	Client *http.Client
+}
+
+// large language model providers.
+const (
+	ProviderOpenAI    = "openai"
+	ProviderAnthropic = "anthropic"
+	ProviderOllama    = "ollama"
+)
+
+// NewLLMClient returns a large language model client for
+// the named provider.
+func NewLLMClient(provider string, config LLMConfig) (LLMClient, error) {
+	switch strings.ToLower(strings.TrimSpace(provider)) {
+	case ProviderOpenAI, "":
+		return NewOpenAIClient(config), nil
+	case ProviderAnthropic:
+		return NewAnthropicClient(config), nil
+	case ProviderOllama:
+		return NewOllamaClient(config), nil
+	default:
+		return nil, fmt.Errorf("unsupported llm provider %q", provider)
+	}
+}
+
+//
+// OpenAI compatible client
+//
+
+// NewOpenAIClient returns a client for the OpenAI chat
+// completions api, or any api compatible with it.
+func NewOpenAIClient(config LLMConfig) LLMClient {
+	if config.BaseURL == "" {
+		config.BaseURL = "https://api.openai.com/v1"
+	}
+	config.BaseURL = strings.TrimSuffix(config.BaseURL, "/")
+	if config.Model == "" {
+		config.Model = "gpt-3.5-turbo"
+	}
+	return &openaiClient{config}
+}
+
+type openaiClient struct {
+	config LLMConfig
+}
+
+type openaiRequest struct {
+	Model       string        `json:"model"`
+	Messages    []*LLMMessage `json:"messages"`
+	Temperature float64       `json:"temperature"`
+}
+
+type openaiResponse struct {
+	Choices []struct {
+		Message struct {
+			Role    string `json:"role"`
+			Content string `json:"content"`
+		} `json:"message"`
+		FinishReason string `json:"finish_reason"`
+		Index        int    `json:"index"`
+	} `json:"choices"`
+}
+
+func (c *openaiClient) Complete(ctx context.Context, messages []*LLMMessage) (string, error) {
+	req := &openaiRequest{
+		Model:       c.config.Model,
+		Messages:    messages,
+		Temperature: c.config.Temperature,
+	}
+	header := http.Header{}
+	if c.config.Token != "" {
+		header.Set("Authorization", "Bearer "+c.config.Token)
+	}
+	res := new(openaiResponse)
+	err := post(ctx, c.config.Client, c.config.BaseURL+"/chat/completions", header, req, res)
+	if err != nil {
+		return "", err
+	}
+	if len(res.Choices) == 0 {
+		return "", errors.New("llm returned a response with zero choices. conversion not possible.")
+	}
+	return res.Choices[0].Message.Content, nil
+}
+
+//
+// Anthropic compatible client
+//
+
+// NewAnthropicClient returns a client for the Anthropic
+// messages api, or any api compatible with it.
+func NewAnthropicClient(config LLMConfig) LLMClient {
+	if config.BaseURL == "" {
+		config.BaseURL = "https://api.anthropic.com/v1"
+	}
+	config.BaseURL = strings.TrimSuffix(config.BaseURL, "/")
+	if config.Model == "" {
+		config.Model = "claude-3-5-sonnet-latest"
+	}
+	return &anthropicClient{config}
+}
+
+type anthropicClient struct {
+	config LLMConfig
+}
+
+type anthropicRequest struct {
+	Model       string        `json:"model"`
+	System      string        `json:"system,omitempty"`
+	Messages    []*LLMMessage `json:"messages"`
+	MaxTokens   int           `json:"max_tokens"`
+	Temperature float64       `json:"temperature"`
+}
+
+type anthropicResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	StopReason string `json:"stop_reason"`
+}
+
+func (c *anthropicClient) Complete(ctx context.Context, messages []*LLMMessage) (string, error) {
+	req := &anthropicRequest{
+		Model:       c.config.Model,
+		MaxTokens:   4096,
+		Temperature: c.config.Temperature,
+	}
+	// the messages api accepts the system prompt as a
+	// separate parameter.
+	for _, message := range messages {
+		if message.Role == "system" {
+			req.System = message.Content
+		} else {
+			req.Messages = append(req.Messages, message)
+		}
+	}
+	header := http.Header{}
+	header.Set("anthropic-version", "2023-06-01")
+	if c.config.Token != "" {
+		header.Set("x-api-key", c.config.Token)
+	}
+	res := new(anthropicResponse)
+	err := post(ctx, c.config.Client, c.config.BaseURL+"/messages", header, req, res)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	for _, content := range res.Content {
+		if content.Type == "text" {
+			sb.WriteString(content.Text)
+		}
+	}
+	if sb.Len() == 0 {
+		return "", errors.New("llm returned a response with zero content. conversion not possible.")
+	}
+	return sb.String(), nil
+}
+
+//
+// Ollama compatible client
+//
+
+// NewOllamaClient returns a client for a local Ollama
+// chat api, or any api compatible with it.
+func NewOllamaClient(config LLMConfig) LLMClient {
+	if config.BaseURL == "" {
+		config.BaseURL = "http://localhost:11434"
+	}
+	config.BaseURL = strings.TrimSuffix(config.BaseURL, "/")
+	if config.Model == "" {
+		config.Model = "llama3"
+	}
+	return &ollamaClient{config}
+}
+
+type ollamaClient struct {
+	config LLMConfig
+}
+
+type ollamaRequest struct {
+	Model    string        `json:"model"`
+	Messages []*LLMMessage `json:"messages"`
+	Stream   bool          `json:"stream"`
+	Options  struct {
+		Temperature float64 `json:"temperature"`
+	} `json:"options"`
+}
+
+type ollamaResponse struct {
+	Message struct {
+		Role    string `json:"role"`
+		Content string `json:"content"`
+	} `json:"message"`
+	Done bool `json:"done"`
+}
+
+func (c *ollamaClient) Complete(ctx context.Context, messages []*LLMMessage) (string, error) {
+	req := &ollamaRequest{
+		Model:    c.config.Model,
+		Messages: messages,
+	}
+	req.Options.Temperature = c.config.Temperature
+	header := http.Header{}
+	if c.config.Token != "" {
+		header.Set("Authorization", "Bearer "+c.config.Token)
+	}
+	res := new(ollamaResponse)
+	err := post(ctx, c.config.Client, c.config.BaseURL+"/api/chat", header, req, res)
+	if err != nil {
+		return "", err
+	}
+	if res.Message.Content == "" {
+		return "", errors.New("llm returned an empty response. conversion not possible.")
+	}
+	return res.Message.Content, nil
+}
+
+// helper function to make a json http post request.
+func post(ctx context.Context, client *http.Client, rawurl string, header http.Header, in, out interface{}) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", rawurl, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode > 299 {
+		out, _ := ioutil.ReadAll(res.Body)
+		return fmt.Errorf("client error %d: %s", res.StatusCode, string(out))
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
@@ -0,0 +1,262 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jenkins
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// fake in-process llm server that records the requests
+// and returns the responses in order.
+type fakeServer struct {
+	*httptest.Server
+	paths     []string
+	headers   []http.Header
+	requests  []map[string]interface{}
+	responses []string
+}
+
+func newFakeServer(t *testing.T, responses ...string) *fakeServer {
+	s := &fakeServer{responses: responses}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		in := map[string]interface{}{}
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			t.Error(err)
+		}
+		i := len(s.requests)
+		s.paths = append(s.paths, r.URL.Path)
+		s.headers = append(s.headers, r.Header)
+		s.requests = append(s.requests, in)
+		if i >= len(s.responses) {
+			w.WriteHeader(500)
+			return
+		}
+		w.Write([]byte(s.responses[i]))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// helper function returns an openai response body.
+func openaiBody(content string) string {
+	b, _ := json.Marshal(map[string]interface{}{
+		"choices": []interface{}{
+			map[string]interface{}{
+				"message": map[string]string{"role": "assistant", "content": content},
+			},
+		},
+	})
+	return string(b)
+}
+
+func TestOpenAIClient(t *testing.T) {
+	server := newFakeServer(t, openaiBody("hello"))
+	client := NewOpenAIClient(LLMConfig{
+		BaseURL:     server.URL + "/v1/",
+		Token:       "secret",
+		Model:       "gpt-4o",
+		Temperature: 0.2,
+	})
+	out, err := client.Complete(context.Background(), []*LLMMessage{{Role: "user", Content: "hi"}})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := out, "hello"; got != want {
+		t.Errorf("Want content %q, got %q", want, got)
+	}
+	if got, want := server.paths[0], "/v1/chat/completions"; got != want {
+		t.Errorf("Want path %q, got %q", want, got)
+	}
+	if got, want := server.headers[0].Get("Authorization"), "Bearer secret"; got != want {
+		t.Errorf("Want authorization %q, got %q", want, got)
+	}
+	if got, want := server.requests[0]["model"], "gpt-4o"; got != want {
+		t.Errorf("Want model %q, got %q", want, got)
+	}
+	if got, want := server.requests[0]["temperature"], 0.2; got != want {
+		t.Errorf("Want temperature %v, got %v", want, got)
+	}
+}
+
+func TestAnthropicClient(t *testing.T) {
+	server := newFakeServer(t, `{"content":[{"type":"text","text":"hello"}]}`)
+	client := NewAnthropicClient(LLMConfig{
+		BaseURL: server.URL,
+		Token:   "secret",
+		Model:   "claude",
+	})
+	out, err := client.Complete(context.Background(), []*LLMMessage{
+		{Role: "system", Content: "be brief"},
+		{Role: "user", Content: "hi"},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := out, "hello"; got != want {
+		t.Errorf("Want content %q, got %q", want, got)
+	}
+	if got, want := server.paths[0], "/messages"; got != want {
+		t.Errorf("Want path %q, got %q", want, got)
+	}
+	if got, want := server.headers[0].Get("x-api-key"), "secret"; got != want {
+		t.Errorf("Want api key %q, got %q", want, got)
+	}
+	if got, want := server.requests[0]["system"], "be brief"; got != want {
+		t.Errorf("Want system prompt %q, got %q", want, got)
+	}
+	if got, want := len(server.requests[0]["messages"].([]interface{})), 1; got != want {
+		t.Errorf("Want %d messages, got %d", want, got)
+	}
+}
+
+func TestOllamaClient(t *testing.T) {
+	server := newFakeServer(t, `{"message":{"role":"assistant","content":"hello"},"done":true}`)
+	client := NewOllamaClient(LLMConfig{
+		BaseURL:     server.URL,
+		Temperature: 0.5,
+	})
+	out, err := client.Complete(context.Background(), []*LLMMessage{{Role: "user", Content: "hi"}})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := out, "hello"; got != want {
+		t.Errorf("Want content %q, got %q", want, got)
+	}
+	if got, want := server.paths[0], "/api/chat"; got != want {
+		t.Errorf("Want path %q, got %q", want, got)
+	}
+	if got, want := server.requests[0]["model"], "llama3"; got != want {
+		t.Errorf("Want default model %q, got %q", want, got)
+	}
+	if got, want := server.requests[0]["stream"], false; got != want {
+		t.Errorf("Want stream %v, got %v", want, got)
+	}
+	options := server.requests[0]["options"].(map[string]interface{})
+	if got, want := options["temperature"], 0.5; got != want {
+		t.Errorf("Want temperature %v, got %v", want, got)
+	}
+}
+
+func TestNewLLMClient(t *testing.T) {
+	if _, err := NewLLMClient("unknown", LLMConfig{}); err == nil {
+		t.Errorf("Expect error for unknown provider")
+	}
+	for _, provider := range []string{ProviderOpenAI, ProviderAnthropic, ProviderOllama} {
+		if _, err := NewLLMClient(provider, LLMConfig{}); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestConvertLLM(t *testing.T) {
+	valid := "```yaml\njobs:\n  build:\n    runs-on: ubuntu-latest\n    steps:\n    - run: go build\n```"
+	server := newFakeServer(t,
+		openaiBody("```yaml\njobs: [\n```"),
+		openaiBody(valid),
+	)
+	converter := New(
+		WithLLM(NewOpenAIClient(LLMConfig{BaseURL: server.URL})),
+		WithAttempts(2),
+	)
+	out, err := converter.ConvertString("pipeline { stages { stage('build') { steps { sh 'go build' } } } }")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !strings.Contains(string(out), "go build") {
+		t.Errorf("Expect converted pipeline, got %s", out)
+	}
+	if got, want := len(server.requests), 2; got != want {
+		t.Errorf("Want %d requests, got %d", want, got)
+		return
+	}
+
+	// the first request includes the few-shot example
+	// for the default format.
+	messages := server.requests[0]["messages"].([]interface{})
+	prompt := messages[1].(map[string]interface{})["content"].(string)
+	if !strings.Contains(prompt, "Example GitHub Yaml") {
+		t.Errorf("Expect few-shot example in prompt")
+	}
+	if !strings.Contains(prompt, "sh 'go build'") {
+		t.Errorf("Expect jenkinsfile in prompt")
+	}
+
+	// the second request includes the invalid response
+	// and the parse error.
+	messages = server.requests[1]["messages"].([]interface{})
+	var roles []string
+	for _, message := range messages {
+		roles = append(roles, message.(map[string]interface{})["role"].(string))
+	}
+	if diff := cmp.Diff(roles, []string{"system", "user", "assistant", "user"}); diff != "" {
+		t.Errorf("Unexpected retry messages")
+		t.Log(diff)
+	}
+	feedback := messages[3].(map[string]interface{})["content"].(string)
+	if !strings.Contains(feedback, "not a valid GitHub Yaml") {
+		t.Errorf("Expect parse error in feedback, got %q", feedback)
+	}
+}
+
+func TestConvertLLMInvalid(t *testing.T) {
+	server := newFakeServer(t,
+		openaiBody("I cannot convert this pipeline."),
+		openaiBody("```yaml\nname: empty\n```"),
+	)
+	converter := New(
+		WithLLM(NewOpenAIClient(LLMConfig{BaseURL: server.URL})),
+		WithAttempts(2),
+	)
+	if _, err := converter.ConvertString("node { }"); err == nil {
+		t.Errorf("Expect error when the yaml is invalid")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		format Format
+		code   string
+		valid  bool
+	}{
+		{FromGithub, "jobs:\n  build:\n    steps:\n    - run: make\n", true},
+		{FromGithub, "name: test\n", false},
+		{FromGithub, "jobs: [", false},
+		{FromGitlab, "build:\n  script:\n  - make\n", true},
+		{FromGitlab, "", false},
+		{FromDrone, "kind: pipeline\nsteps:\n- name: build\n  commands:\n  - make\n", true},
+		{FromDrone, "kind: pipeline\n", false},
+	}
+	for i, test := range tests {
+		d := New(WithFormat(test.format))
+		err := d.validate(test.code)
+		if test.valid && err != nil {
+			t.Errorf("Want valid yaml at index %d, got %s", i, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("Want invalid yaml at index %d", i)
+		}
+	}
+}
@@ -14,7 +14,10 @@
 
 package jenkins
 
-import "strings"
+import (
+	"strings"
+	"time"
+)
 
 // Option configures a Converter option.
 type Option func(*Converter)
@@ -44,14 +47,39 @@ func WithAttempts(attempts int) Option {
 	}
 }
 
+// WithBackoff returns an option to set the pause before
+// a failed large language model request is retried.
+func WithBackoff(backoff time.Duration) Option {
+	return func(d *Converter) {
+		d.backoff = backoff
+	}
+}
+
 // WithToken returns an option to set the API token
-// for Chat GPT.
+// for Chat GPT. The token is ignored if a large language
+// model client is configured.
 func WithToken(token string) Option {
 	return func(d *Converter) {
 		d.token = token
 	}
 }
 
+// WithLLM returns an option to convert the Jenkinsfile
+// using the large language model client.
+func WithLLM(client LLMClient) Option {
+	return func(d *Converter) {
+		d.llm = client
+	}
+}
+
+// WithPrompt returns an option to customize the prompt
+// template. The template is executed with the PromptData.
+func WithPrompt(tmpl string) Option {
+	return func(d *Converter) {
+		d.promptTmpl = tmpl
+	}
+}
+
 // WithDebug returns an option to use debug mode.
 func WithDebug() Option {
 	return func(d *Converter) {
@@ -83,3 +111,23 @@ func WithFormatString(format string) Option {
 		}
 	}
 }
+
+// WithLibrary returns an option to inline the global
+// steps declared in the vars directory of the shared
+// library checkout. The option can be repeated to
+// configure multiple libraries.
+func WithLibrary(path string) Option {
+	return func(d *Converter) {
+		d.libraryPaths = append(d.libraryPaths, path)
+	}
+}
+
+// WithLibraryTemplates returns an option to convert calls
+// to the shared library global steps to template steps,
+// instead of inlining the steps. The templates are
+// generated with ConvertLibrary.
+func WithLibraryTemplates() Option {
+	return func(d *Converter) {
+		d.libraryTmpl = true
+	}
+}
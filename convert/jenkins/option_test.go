@@ -14,13 +14,17 @@
 
 package jenkins
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestOptions(t *testing.T) {
 	p := New(
 		WithDockerhub("account.docker"),
 		WithKubernetes("namespace", "connector.kubernetes"),
 		WithAttempts(5),
+		WithBackoff(time.Millisecond),
 		WithToken("dummy-da39a3ee5e6b4b0d3255bfef95601890afd80709"),
 	)
 
@@ -39,6 +43,9 @@ func TestOptions(t *testing.T) {
 	if got, want := p.attempts, 5; got != want {
 		t.Errorf("Want attempts %v, got %v", want, got)
 	}
+	if got, want := p.backoff, time.Millisecond; got != want {
+		t.Errorf("Want backoff %v, got %v", want, got)
+	}
 	if got, want := p.token, "dummy-da39a3ee5e6b4b0d3255bfef95601890afd80709"; got != want {
 		t.Errorf("Want token %q, got %q", want, got)
 	}
@@ -0,0 +1,193 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jenkins
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+)
+
+// system prompt sent with every conversion request.
+const systemPrompt = `You convert Jenkins pipelines to other continuous integration formats. You respond with the converted yaml in a single code fence, without explanation.`
+
+// DefaultPrompt is the default prompt template. The
+// template is executed with the PromptData.
+const DefaultPrompt = `Convert this Jenkinsfile to a {{ .Format }} Yaml.
+{{ range .Examples }}
+Example Jenkinsfile:
+
+` + "```" + `groovy
+{{ .Jenkinsfile }}
+` + "```" + `
+
+Example {{ $.Format }} Yaml:
+
+` + "```" + `yaml
+{{ .Yaml }}
+` + "```" + `
+{{ end }}
+Jenkinsfile:
+
+` + "```" + `groovy
+{{ .Jenkinsfile }}
+` + "```" + `
+`
+
+// feedback prompt sent when the returned yaml cannot
+// be parsed, so that the model can correct it.
+const feedbackPrompt = `The yaml is not a valid %s Yaml: %s
+
+Return the corrected yaml in a single code fence.`
+
+// PromptData provides the data used to execute the
+// prompt template.
+type PromptData struct {
+	// Format is the intermediate format name, for
+	// example GitHub.
+	Format string
+
+	// Jenkinsfile is the Jenkinsfile to convert.
+	Jenkinsfile string
+
+	// Examples are the few-shot examples for the
+	// intermediate format.
+	Examples []*PromptExample
+}
+
+// PromptExample defines a few-shot example.
+type PromptExample struct {
+	Jenkinsfile string
+	Yaml        string
+}
+
+// example Jenkinsfile used by the few-shot examples.
+const exampleJenkinsfile = `pipeline {
+    agent { docker { image 'golang:1.21' } }
+    environment {
+        GOOS = 'linux'
+    }
+    stages {
+        stage('build') {
+            steps {
+                sh 'go build ./...'
+            }
+        }
+        stage('test') {
+            when { branch 'main' }
+            steps {
+                sh 'go test ./...'
+            }
+        }
+    }
+}`
+
+// promptExamples defines the few-shot examples for each
+// intermediate format.
+var promptExamples = map[Format][]*PromptExample{
+	FromGithub: {
+		{
+			Jenkinsfile: exampleJenkinsfile,
+			Yaml: `name: pipeline
+on: [push, pull_request]
+env:
+  GOOS: linux
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    container: golang:1.21
+    steps:
+    - uses: actions/checkout@v3
+    - run: go build ./...
+  test:
+    runs-on: ubuntu-latest
+    container: golang:1.21
+    needs: build
+    if: github.ref == 'refs/heads/main'
+    steps:
+    - uses: actions/checkout@v3
+    - run: go test ./...`,
+		},
+	},
+	FromGitlab: {
+		{
+			Jenkinsfile: exampleJenkinsfile,
+			Yaml: `image: golang:1.21
+variables:
+  GOOS: linux
+stages:
+- build
+- test
+build:
+  stage: build
+  script:
+  - go build ./...
+test:
+  stage: test
+  script:
+  - go test ./...
+  rules:
+  - if: $CI_COMMIT_BRANCH == "main"`,
+		},
+	},
+	FromDrone: {
+		{
+			Jenkinsfile: exampleJenkinsfile,
+			Yaml: `kind: pipeline
+type: docker
+name: default
+environment:
+  GOOS: linux
+steps:
+- name: build
+  image: golang:1.21
+  commands:
+  - go build ./...
+- name: test
+  image: golang:1.21
+  commands:
+  - go test ./...
+  when:
+    branch:
+    - main`,
+		},
+	},
+}
+
+// helper function returns the chat messages used to
+// convert the Jenkinsfile.
+func (d *Converter) prompt(src []byte) ([]*LLMMessage, error) {
+	text := d.promptTmpl
+	if text == "" {
+		text = DefaultPrompt
+	}
+	tmpl, err := template.New("prompt").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	data := &PromptData{
+		Format:      d.format.String(),
+		Jenkinsfile: strings.TrimSpace(string(src)),
+		Examples:    promptExamples[d.format],
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return []*LLMMessage{
+		{Role: "system", Content: systemPrompt},
+		{Role: "user", Content: buf.String()},
+	}, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jenkins
+
+import "github.com/drone/go-convert/convert/jenkins/groovy"
+
+// Report lists the Jenkinsfile constructs that could not
+// be converted, or were only partially converted, to the
+// Harness pipeline.
+type Report struct {
+	Unmapped []*Unmapped `json:"unmapped"`
+}
+
+// Unmapped defines a Jenkinsfile construct that could not
+// be converted.
+type Unmapped struct {
+	// Kind is the construct kind, for example agent,
+	// option, trigger, condition, step or variable.
+	Kind string `json:"kind"`
+
+	// Name is the construct name, for example the
+	// option or step name.
+	Name string `json:"name"`
+
+	// Stage is the name of the enclosing stage.
+	Stage string `json:"stage,omitempty"`
+
+	// File is the path of the shared library file, if the
+	// construct is declared in a shared library step.
+	File string `json:"file,omitempty"`
+
+	// Line is the line number in the Jenkinsfile, or in
+	// the shared library file.
+	Line int `json:"line,omitempty"`
+
+	// Detail describes how the construct was handled.
+	Detail string `json:"detail,omitempty"`
+}
+
+// unmapped kinds.
+const (
+	unmappedSection   = "section"
+	unmappedAgent     = "agent"
+	unmappedOption    = "option"
+	unmappedTrigger   = "trigger"
+	unmappedLibrary   = "library"
+	unmappedTool      = "tool"
+	unmappedCondition = "condition"
+	unmappedPost      = "post"
+	unmappedStep      = "step"
+	unmappedVariable  = "variable"
+)
+
+// helper function adds an unmapped construct to the
+// conversion report.
+func (d *Converter) unmapped(kind, name string, node groovy.Node, detail string) {
+	item := &Unmapped{
+		Kind:   kind,
+		Name:   name,
+		Stage:  d.stage,
+		File:   d.file,
+		Detail: detail,
+	}
+	if node != nil && d.script != nil {
+		item.Line = d.script.Line(node)
+	}
+	// methods that are inlined more than once report
+	// the same construct for each call.
+	for _, v := range d.report.Unmapped {
+		if *v == *item {
+			return
+		}
+	}
+	d.report.Unmapped = append(d.report.Unmapped, item)
+}
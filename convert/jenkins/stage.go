@@ -0,0 +1,309 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jenkins
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/drone/go-convert/convert/jenkins/jenkinsfile"
+	harness "github.com/drone/spec/dist/go"
+)
+
+// helper function converts the jenkins stages to harness
+// stages. Stages without steps are omitted.
+func (d *Converter) convertStages(src []*jenkinsfile.Stage, sc *scope) []*harness.Stage {
+	var stages []*harness.Stage
+	for _, stage := range src {
+		if v := d.convertStage(stage, sc); v != nil {
+			stages = append(stages, v)
+		}
+	}
+	return stages
+}
+
+// helper function converts a jenkins stage to a harness
+// stage. Nested and parallel stages are converted to
+// stage groups and parallel stages. Returns nil if the
+// stage has no steps.
+func (d *Converter) convertStage(src *jenkinsfile.Stage, parent *scope) *harness.Stage {
+	prev := d.stage
+	d.stage = src.Name
+	defer func() { d.stage = prev }()
+
+	sc := parent.child()
+	d.applyAgent(src.Agent, sc)
+	d.applyTools(src.Tools, sc)
+	d.applyOptions(src.Options, sc, nil)
+	sc.stageEnvs = mergeEnv(sc.stageEnvs, d.convertEnvironment(src.Environment, sc))
+
+	stage := &harness.Stage{
+		Name: d.identifiers.Generate(src.Name, "stage"),
+		When: d.convertWhen(src.When),
+	}
+
+	if src.Input != nil {
+		d.unmapped(unmappedSection, "input", src.Input, "add an approval stage")
+	}
+	for _, node := range src.Unknown {
+		d.unmapped(unmappedSection, nodeName(node), node, "")
+	}
+
+	switch {
+	case len(src.Parallel) != 0:
+		stages := d.convertStages(src.Parallel, sc)
+		if len(stages) == 0 {
+			return nil
+		}
+		stage.Type = "parallel"
+		stage.Spec = &harness.StageParallel{
+			Stages: stages,
+		}
+		if src.FailFast {
+			d.unmapped(unmappedOption, "failFast", src.Node, "parallel stages run to completion")
+		}
+		d.unmappedPost(src.Post)
+	case len(src.Stages) != 0:
+		stages := d.convertStages(src.Stages, sc)
+		if len(stages) == 0 {
+			return nil
+		}
+		stage.Type = "group"
+		stage.Spec = &harness.StageGroup{
+			Stages: stages,
+		}
+		d.unmappedPost(src.Post)
+	case src.Matrix != nil:
+		axes := convertAxes(src.Matrix.Axes)
+		for name := range axes {
+			sc.stageEnvs = mergeEnv(sc.stageEnvs, map[string]string{
+				name: fmt.Sprintf("<+matrix.%s>", name),
+			})
+		}
+		var steps []*harness.Step
+		for _, child := range src.Matrix.Stages {
+			if step := d.convertMatrixStage(child, sc); step != nil {
+				steps = append(steps, step)
+			}
+		}
+		steps = append(steps, d.convertPostSteps(src.Post, sc)...)
+		if len(steps) == 0 {
+			return nil
+		}
+		stage.Type = "ci"
+		stage.Spec = d.convertStageCI(steps, sc)
+		stage.Strategy = &harness.Strategy{
+			Type: "matrix",
+			Spec: &harness.Matrix{
+				Axis:    axes,
+				Exclude: convertExcludes(src.Matrix),
+			},
+		}
+	default:
+		steps := d.convertSteps(src.Steps, sc)
+		steps = append(steps, d.convertPostSteps(src.Post, sc)...)
+		if len(steps) == 0 {
+			return nil
+		}
+		stage.Type = "ci"
+		stage.Spec = d.convertStageCI(steps, sc)
+	}
+
+	if stage.Type == "ci" && sc.retry != 0 {
+		stage.Failure = retryFailure(sc.retry)
+	}
+	return stage
+}
+
+// helper function converts a stage inside a matrix to a
+// step group, since harness does not support nested
+// stages in a matrix stage.
+func (d *Converter) convertMatrixStage(src *jenkinsfile.Stage, parent *scope) *harness.Step {
+	prev := d.stage
+	d.stage = src.Name
+	defer func() { d.stage = prev }()
+
+	sc := parent.child()
+	d.applyAgent(src.Agent, sc)
+	d.applyTools(src.Tools, sc)
+	d.applyOptions(src.Options, sc, nil)
+	sc.envs = mergeEnv(sc.envs, d.convertEnvironment(src.Environment, sc))
+
+	if len(src.Parallel) != 0 || len(src.Stages) != 0 || src.Matrix != nil {
+		d.unmapped(unmappedSection, "stages", src.Node, "nested stages in a matrix are not converted")
+	}
+	for _, node := range src.Unknown {
+		d.unmapped(unmappedSection, nodeName(node), node, "")
+	}
+
+	steps := d.convertSteps(src.Steps, sc)
+	steps = append(steps, d.convertPostSteps(src.Post, sc)...)
+	if len(steps) == 0 {
+		return nil
+	}
+	return &harness.Step{
+		Name: d.identifiers.Generate(src.Name, "group"),
+		Type: "group",
+		When: d.convertWhen(src.When),
+		Spec: &harness.StepGroup{
+			Steps: steps,
+		},
+	}
+}
+
+// helper function returns the ci stage spec.
+func (d *Converter) convertStageCI(steps []*harness.Step, sc *scope) *harness.StageCI {
+	spec := &harness.StageCI{
+		Envs:     sc.stageEnvs,
+		Platform: d.convertPlatform(sc),
+		Runtime:  d.convertRuntime(),
+		Steps:    steps,
+	}
+	if sc.skipClone {
+		spec.Clone = &harness.CloneStage{
+			Disabled: true,
+		}
+	}
+	return spec
+}
+
+// helper function converts the stage post section to
+// step groups that execute conditionally, based on the
+// stage status.
+func (d *Converter) convertPostSteps(posts []*jenkinsfile.Post, sc *scope) []*harness.Step {
+	var steps []*harness.Step
+	for _, post := range posts {
+		when, ok := d.convertPostWhen(post)
+		if !ok {
+			continue
+		}
+		items := d.convertSteps(post.Steps, sc)
+		if len(items) == 0 {
+			continue
+		}
+		steps = append(steps, &harness.Step{
+			Name: d.identifiers.Generate("post-" + post.Type),
+			Type: "group",
+			When: when,
+			Spec: &harness.StepGroup{
+				Steps: items,
+			},
+		})
+	}
+	return steps
+}
+
+// helper function converts the pipeline post section to
+// a stage that executes conditionally, based on the
+// pipeline status.
+func (d *Converter) convertPostStage(post *jenkinsfile.Post, sc *scope) *harness.Stage {
+	when, ok := d.convertPostWhen(post)
+	if !ok {
+		return nil
+	}
+	steps := d.convertSteps(post.Steps, sc)
+	if len(steps) == 0 {
+		return nil
+	}
+	return &harness.Stage{
+		Name: d.identifiers.Generate("post-" + post.Type),
+		Type: "ci",
+		When: when,
+		Spec: d.convertStageCI(steps, sc),
+	}
+}
+
+// helper function reports the post section of a stage
+// that does not execute steps, such as a parallel stage.
+func (d *Converter) unmappedPost(posts []*jenkinsfile.Post) {
+	for _, post := range posts {
+		d.unmapped(unmappedPost, post.Type, post.Node, "post section of a stage group is not converted")
+	}
+}
+
+// helper function converts the matrix axes.
+func convertAxes(axes []*jenkinsfile.Axis) map[string][]string {
+	if len(axes) == 0 {
+		return nil
+	}
+	out := map[string][]string{}
+	for _, axis := range axes {
+		out[axis.Name] = axis.Values
+	}
+	return out
+}
+
+// helper function converts the matrix excludes. Each
+// exclude is expanded to the combinations of the axis
+// values it matches.
+func convertExcludes(matrix *jenkinsfile.Matrix) []map[string]string {
+	values := map[string][]string{}
+	for _, axis := range matrix.Axes {
+		values[axis.Name] = axis.Values
+	}
+
+	var out []map[string]string
+	for _, exclude := range matrix.Excludes {
+		combos := []map[string]string{{}}
+		// sort the axes for deterministic output.
+		axes := append([]*jenkinsfile.Axis{}, exclude...)
+		sort.SliceStable(axes, func(i, j int) bool {
+			return axes[i].Name < axes[j].Name
+		})
+		for _, axis := range axes {
+			matched := axis.Values
+			if axis.Exclude {
+				matched = difference(values[axis.Name], axis.Values)
+			}
+			var next []map[string]string
+			for _, combo := range combos {
+				for _, value := range matched {
+					item := map[string]string{}
+					for k, v := range combo {
+						item[k] = v
+					}
+					item[axis.Name] = value
+					next = append(next, item)
+				}
+			}
+			combos = next
+		}
+		for _, combo := range combos {
+			if len(combo) != 0 {
+				out = append(out, combo)
+			}
+		}
+	}
+	return out
+}
+
+// helper function returns the values in a that are not
+// in b.
+func difference(a, b []string) []string {
+	var out []string
+	for _, s := range a {
+		found := false
+		for _, ss := range b {
+			if s == ss {
+				found = true
+				break
+			}
+		}
+		if !found {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,1049 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jenkins
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/drone/go-convert/convert/jenkins/groovy"
+	jenkinsjson "github.com/drone/go-convert/convert/jenkinsjson/json"
+	harness "github.com/drone/spec/dist/go"
+)
+
+// maxDepth limits the depth of nested method calls that
+// are inlined, to prevent infinite recursion.
+const maxDepth = 8
+
+// scope defines the settings inherited by nested stages
+// and steps.
+type scope struct {
+	image     string
+	os        string
+	stageEnvs map[string]string
+	envs      map[string]string
+	timeout   string
+	retry     int64
+	skipClone bool
+	dir       string
+	vars      map[string]*binding
+	depth     int
+
+	// params are the method parameters that are converted
+	// to template inputs, when the shared library step is
+	// converted to a step template.
+	params map[string]bool
+}
+
+// child returns a copy of the scope. Maps are never
+// modified in place and can be shared.
+func (s *scope) child() *scope {
+	c := *s
+	return &c
+}
+
+// stepConverter converts a jenkins step using the jenkins
+// json step converters.
+type stepConverter func(node jenkinsjson.Node, sc *scope) []*harness.Step
+
+// stepConverters maps jenkins steps to the jenkins json
+// step converters.
+var stepConverters = map[string]stepConverter{
+	"sh": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertSh(node, sc.envs, sc.timeout, sc.image, ""))
+	},
+	"bat": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertBat(node, sc.envs, sc.timeout, sc.image))
+	},
+	"pwsh": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertPwsh(node, sc.envs, sc.timeout, sc.image))
+	},
+	"powershell": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertPowerShell(node, sc.envs, sc.timeout, sc.image))
+	},
+	"git": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		if url, ok := node.ParameterMap["url"].(string); ok {
+			node.AttributesMap["http.url"] = url
+		}
+		if branch, ok := node.ParameterMap["branch"].(string); ok {
+			node.AttributesMap["git.branch"] = branch
+		}
+		return single(jenkinsjson.ConvertCheckout(node, sc.envs))
+	},
+	"checkout": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		// checkout([$class: 'GitSCM', ...]) is converted
+		// to the symbol and arguments structure.
+		if scm, ok := node.ParameterMap["scm"].(map[string]interface{}); ok {
+			if _, ok := scm["arguments"]; !ok {
+				node.ParameterMap["scm"] = map[string]interface{}{
+					"arguments": scm,
+				}
+			}
+		}
+		return single(jenkinsjson.ConvertCheckout(node, sc.envs))
+	},
+	"archiveArtifacts": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return jenkinsjson.ConvertArchive(node)
+	},
+	"emailext": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertEmailext(node, sc.envs, sc.timeout))
+	},
+	"mail": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertMailer(node, node.ParameterMap))
+	},
+	"junit": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertJunit(node, sc.envs))
+	},
+	"sleep": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertSleep(node, sc.envs))
+	},
+	"deleteDir": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertDeleteDir(node, sc.envs))
+	},
+	"cleanWs": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertDeleteDir(node, sc.envs))
+	},
+	"writeFile": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertWriteFile(node, sc.envs))
+	},
+	"readFile": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertReadFile(node, sc.envs))
+	},
+	"readJSON": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertReadJson(node, sc.envs))
+	},
+	"readYaml": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertReadYaml(node, sc.envs))
+	},
+	"readCSV": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertReadCsv(node, sc.envs))
+	},
+	"writeJSON": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertWriteJSON(node, sc.envs))
+	},
+	"writeYaml": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertWriteYaml(node, sc.envs))
+	},
+	"sha1": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertSHA1(node, sc.envs, sc.image))
+	},
+	"sha256": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertSHA256(node, sc.envs, sc.image))
+	},
+	"verifySha1": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertVerifySha1(node, sc.envs, sc.image))
+	},
+	"verifySha256": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertVerifySha256(node, sc.envs, sc.image))
+	},
+	"zip": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertZip(node, sc.envs))
+	},
+	"unzip": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertUnzip(node, sc.envs))
+	},
+	"tar": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertTar(node, sc.envs))
+	},
+	"untar": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertUntar(node, sc.envs))
+	},
+	"findFiles": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertFindFiles(node))
+	},
+	"publishHTML": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertPublishHtml(node, sc.envs))
+	},
+	"httpRequest": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertHttpRequest(node, sc.envs))
+	},
+	"jacoco": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertJacoco(node, sc.envs))
+	},
+	"cobertura": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertCobertura(node, sc.envs))
+	},
+	"slackSend": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertSlackSend(node, sc.envs))
+	},
+	"slackUploadFile": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertSlackUploadFile(node, sc.envs))
+	},
+	"nexusArtifactUploader": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertNexusArtifactUploader(node, sc.envs))
+	},
+	"readMavenPom": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertReadMavenPom(node))
+	},
+	"waitForQualityGate": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertSonarQualityGate(node))
+	},
+	"ansiblePlaybook": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertAnsiblePlaybook(node, node.ParameterMap))
+	},
+	"testNG": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertTestng(node, node.ParameterMap))
+	},
+	"cucumber": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertCucumber(node, node.ParameterMap))
+	},
+	"robot": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertRobot(node, node.ParameterMap))
+	},
+	"pagerduty": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertPagerDuty(node, node.ParameterMap))
+	},
+	"pagerdutyChangeEvent": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertPagerDutyChangeEvent(node, node.ParameterMap))
+	},
+	"notifyEndpoints": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertNotification(node, node.ParameterMap))
+	},
+	"gatlingArchive": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertGatling(node))
+	},
+	"unarchive": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertUnarchive(node, node.ParameterMap))
+	},
+	"anchore": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertAnchore(node, sc.envs))
+	},
+	"dockerPushStep": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertDockerPushStep(node, sc.envs, sc.timeout))
+	},
+	"jiraSendBuildInfo": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertJiraBuildInfo(node, sc.envs))
+	},
+	"jiraSendDeploymentInfo": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertJiraDeploymentInfo(node, sc.envs))
+	},
+	"readTrusted": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertReadTrusted(node))
+	},
+	"allure": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return jenkinsjson.ConvertAllureSteps(node)
+	},
+	"synopsys_detect": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertSynopsysDetect(node, sc.envs))
+	},
+	"flywayrunner": func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertFlywayRunner(node, sc.envs))
+	},
+	"rtDownload":         convertArtifactory("rtDownload"),
+	"rtMavenRun":         convertArtifactory("rtMavenRun"),
+	"rtGradleRun":        convertArtifactory("rtGradleRun"),
+	"rtPublishBuildInfo": convertArtifactory("rtPublishBuildInfo"),
+	"rtPromote":          convertArtifactory("rtPromote"),
+	"xrayScan":           convertArtifactory("xrayScan"),
+	"fileOperations":     convertFileOperations,
+}
+
+// defaultParams maps the jenkins steps to the parameter
+// name of the first positional argument, for example
+// sh 'make' is equivalent to sh script: 'make'.
+var defaultParams = map[string]string{
+	"sh":               "script",
+	"bat":              "script",
+	"pwsh":             "script",
+	"powershell":       "script",
+	"sleep":            "time",
+	"junit":            "testResults",
+	"git":              "url",
+	"checkout":         "scm",
+	"archiveArtifacts": "artifacts",
+	"readFile":         "file",
+	"readJSON":         "file",
+	"readYaml":         "file",
+	"readMavenPom":     "file",
+	"findFiles":        "glob",
+	"sha1":             "file",
+	"sha256":           "file",
+	"publishHTML":      "target",
+	"slackSend":        "message",
+	"readTrusted":      "path",
+	"fileOperations":   "operations",
+}
+
+// delegateSteps lists the jenkins steps that the jenkins
+// json converters read from the delegate symbol and
+// arguments structure, for example:
+// {"delegate": {"symbol": "allure", "arguments": {...}}}
+var delegateSteps = map[string]bool{
+	"archiveArtifacts": true,
+	"allure":           true,
+	"cucumber":         true,
+	"testNG":           true,
+	"flywayrunner":     true,
+	"anchore":          true,
+}
+
+// fileOperations maps the file operation symbols to the
+// jenkins json step converters.
+var fileOperations = map[string]func(jenkinsjson.Node, map[string]interface{}) *harness.Step{
+	"fileCreateOperation":           jenkinsjson.ConvertFileCreate,
+	"fileCopyOperation":             jenkinsjson.ConvertFileCopy,
+	"fileDeleteOperation":           jenkinsjson.ConvertFileDelete,
+	"fileDownloadOperation":         jenkinsjson.ConvertFileDownload,
+	"fileRenameOperation":           jenkinsjson.ConvertFileRename,
+	"filePropertiesToJsonOperation": jenkinsjson.ConvertFileJson,
+	"fileJoinOperation":             jenkinsjson.ConvertFileJoin,
+	"fileTransformOperation":        jenkinsjson.ConvertFileTranform,
+	"folderCopyOperation":           jenkinsjson.ConvertFolderCopy,
+	"folderCreateOperation":         jenkinsjson.ConvertFolderCreate,
+	"folderDeleteOperation":         jenkinsjson.ConvertFolderDelete,
+	"folderRenameOperation":         jenkinsjson.ConvertFolderRename,
+	"fileUnTarOperation":            jenkinsjson.ConvertFileUntar,
+	"fileUnZipOperation":            jenkinsjson.ConvertFileUnzip,
+	"fileZipOperation":              jenkinsjson.ConvertFileZip,
+}
+
+// helper function returns a converter for the artifactory
+// step.
+func convertArtifactory(name string) stepConverter {
+	return func(node jenkinsjson.Node, sc *scope) []*harness.Step {
+		return single(jenkinsjson.ConvertArtifactoryRtCommand(name, node, sc.envs))
+	}
+}
+
+// helper function converts the file operations step.
+func convertFileOperations(node jenkinsjson.Node, sc *scope) []*harness.Step {
+	var steps []*harness.Step
+	operations, _ := node.ParameterMap["operations"].([]interface{})
+	for _, item := range operations {
+		operation, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		symbol, _ := operation["symbol"].(string)
+		if convert, ok := fileOperations[symbol]; ok {
+			steps = append(steps, convert(node, operation))
+		}
+	}
+	return steps
+}
+
+// helper function converts the statements to steps.
+func (d *Converter) convertSteps(stmts []groovy.Stmt, sc *scope) []*harness.Step {
+	var steps []*harness.Step
+	for _, stmt := range stmts {
+		// literal variable assignments are bound to the
+		// scope of the subsequent statements.
+		if assign, ok := stmt.(*groovy.Assign); ok {
+			if next, ok := d.bindAssign(assign, sc); ok {
+				sc = next
+				continue
+			}
+		}
+		steps = append(steps, d.convertStmt(stmt, sc)...)
+	}
+	return steps
+}
+
+// helper function binds the assigned value to the
+// variable if the value is a literal or string expression.
+// Assignments to env properties are converted to
+// environment variables.
+func (d *Converter) bindAssign(assign *groovy.Assign, sc *scope) (*scope, bool) {
+	name := groovy.Name(assign.Target)
+	if assign.Op != "=" || name == "" {
+		return nil, false
+	}
+	next := sc.child()
+
+	// the tool step returns the tool installation
+	// directory, for example def mvnHome = tool 'M3'.
+	// The tool is converted to the tool image, and the
+	// variable is bound to the directory in the image.
+	if call, ok := assign.Value.(*groovy.Call); ok && call.Name == "tool" && call.Receiver == nil {
+		tool := stringArg(call)
+		if v := stringNamedArg(call, "name"); v != "" {
+			tool = v
+		}
+		typ := stringNamedArg(call, "type")
+		if typ == "" {
+			typ = guessToolType(tool)
+		}
+		home, ok := toolHomes[typ]
+		if !ok {
+			return nil, false
+		}
+		if typ != "jdk" || next.image == "" {
+			next.image = toolImage(typ, tool)
+		}
+		d.unmapped(unmappedTool, tool, call, "converted to the "+next.image+" image")
+		next.vars = bind(sc.vars, name, &binding{shell: home})
+		return next, true
+	}
+
+	if !isLiteral(assign.Value) {
+		return nil, false
+	}
+	if strings.HasPrefix(name, "env.") {
+		next.envs = mergeEnv(sc.envs, map[string]string{
+			strings.TrimPrefix(name, "env."): d.shellExpr(assign.Value, sc),
+		})
+		return next, true
+	}
+	jexl, _ := d.jexlExpr(assign.Value, sc)
+	next.vars = bind(sc.vars, name, &binding{
+		shell: d.shellExpr(assign.Value, sc),
+		jexl:  jexl,
+	})
+	return next, true
+}
+
+// helper function returns a copy of the variables with
+// the variable bound to the value.
+func bind(vars map[string]*binding, name string, value *binding) map[string]*binding {
+	out := map[string]*binding{}
+	for k, v := range vars {
+		out[k] = v
+	}
+	out[name] = value
+	return out
+}
+
+// helper function returns true if the expression is a
+// literal value or string expression.
+func isLiteral(expr groovy.Expr) bool {
+	switch v := expr.(type) {
+	case *groovy.String, *groovy.Number, *groovy.Bool:
+		return true
+	case *groovy.Binary:
+		return v.Op == "+" && isLiteral(v.X) && isLiteral(v.Y)
+	}
+	return false
+}
+
+// helper function converts the statement to steps.
+func (d *Converter) convertStmt(stmt groovy.Stmt, sc *scope) []*harness.Step {
+	switch v := stmt.(type) {
+	case *groovy.ExprStmt:
+		if call, ok := v.X.(*groovy.Call); ok {
+			return d.convertCall(call, sc)
+		}
+		d.unmapped(unmappedStep, nodeName(v), v, d.script.Text(v))
+	case *groovy.Assign:
+		d.unmapped(unmappedVariable, groovy.Name(v.Target), v, "variable assignment is not converted")
+		// the step output is not captured, however the
+		// step is still converted.
+		if call, ok := v.Value.(*groovy.Call); ok && call.Receiver == nil {
+			if _, ok := stepConverters[call.Name]; ok {
+				return d.convertCall(call, sc)
+			}
+		}
+	case *groovy.Block:
+		return d.convertSteps(v.Stmts, sc)
+	case *groovy.If:
+		return d.convertIf(v, sc)
+	case *groovy.Try:
+		steps := d.convertSteps(v.Body.Stmts, sc)
+		for _, catch := range v.Catches {
+			d.unmapped(unmappedStep, "catch", v, "converted to steps that execute on failure")
+			steps = append(steps, d.conditionalGroup("catch", statusWhen("failure"), d.convertSteps(catch.Body.Stmts, sc))...)
+		}
+		if v.Finally != nil {
+			steps = append(steps, d.conditionalGroup("finally", statusWhen("", "success", "failure"), d.convertSteps(v.Finally.Stmts, sc))...)
+		}
+		return steps
+	case *groovy.For:
+		d.unmapped(unmappedStep, "for", v, "loop body is converted once")
+		return d.convertStmt(v.Body, sc)
+	case *groovy.While:
+		d.unmapped(unmappedStep, "while", v, "loop body is converted once")
+		return d.convertStmt(v.Body, sc)
+	case *groovy.Jump:
+		if v.Keyword != "return" {
+			d.unmapped(unmappedStep, v.Keyword, v, d.script.Text(v))
+		}
+	case *groovy.MethodDecl, *groovy.Import, *groovy.Annotation:
+	default:
+		d.unmapped(unmappedStep, nodeName(stmt), stmt, "")
+	}
+	return nil
+}
+
+// helper function converts the if statement to steps
+// that execute conditionally.
+func (d *Converter) convertIf(stmt *groovy.If, sc *scope) []*harness.Step {
+	cond, ok := d.jexlExpr(stmt.Cond, sc)
+	if !ok {
+		d.unmapped(unmappedCondition, "if", stmt, d.script.Text(stmt.Cond))
+	}
+
+	var steps []*harness.Step
+	then := d.convertStmt(stmt.Then, sc)
+	if ok {
+		steps = append(steps, d.conditionalGroup("if", &harness.When{Eval: cond}, then)...)
+	} else {
+		steps = append(steps, then...)
+	}
+	if stmt.Else != nil {
+		other := d.convertStmt(stmt.Else, sc)
+		if ok {
+			steps = append(steps, d.conditionalGroup("else", &harness.When{Eval: "!(" + cond + ")"}, other)...)
+		} else {
+			steps = append(steps, other...)
+		}
+	}
+	return steps
+}
+
+// helper function applies the when clause to the steps.
+// Multiple steps are wrapped in a step group.
+func (d *Converter) conditionalGroup(name string, when *harness.When, steps []*harness.Step) []*harness.Step {
+	switch {
+	case len(steps) == 0:
+		return nil
+	case len(steps) == 1 && steps[0].When == nil:
+		steps[0].When = when
+		return steps
+	}
+	return []*harness.Step{
+		{
+			Name: d.identifiers.Generate(name),
+			Type: "group",
+			When: when,
+			Spec: &harness.StepGroup{
+				Steps: steps,
+			},
+		},
+	}
+}
+
+// helper function converts the method call to steps.
+func (d *Converter) convertCall(call *groovy.Call, sc *scope) []*harness.Step {
+	if call.Receiver != nil {
+		return d.convertMethodCall(call, sc)
+	}
+	if call.Name == "parallel" {
+		return d.convertParallel(call, sc)
+	}
+	if call.Closure != nil {
+		if steps, ok := d.convertWrapper(call, sc); ok {
+			return steps
+		}
+	}
+	if method, ok := d.methods[call.Name]; ok {
+		return d.convertMethod(method, call, sc)
+	}
+	if v := d.libraryVar(call.Name); v != nil {
+		return d.convertLibraryCall(v, v.Call(), call, sc)
+	}
+
+	switch call.Name {
+	case "echo", "println", "print":
+		return d.scriptStep(call.Name, "echo "+shellQuote(d.firstArg(call, sc)), sc)
+	case "error":
+		return d.scriptStep(call.Name, "echo "+shellQuote(d.firstArg(call, sc))+"\nexit 1", sc)
+	case "checkout":
+		// the codebase is cloned by default.
+		if args := call.Positional(); len(args) == 1 && groovy.Name(args[0]) == "scm" {
+			return nil
+		}
+	case "stash", "unstash":
+		d.unmapped(unmappedStep, call.Name, call, "the workspace is shared by the steps in a stage")
+		return nil
+	case "input":
+		d.unmapped(unmappedStep, call.Name, call, "add an approval stage")
+		return nil
+	case "build":
+		d.unmapped(unmappedStep, call.Name, call, "trigger the downstream pipeline")
+		return nil
+	}
+
+	convert, ok := stepConverters[call.Name]
+	if !ok {
+		d.unmapped(unmappedStep, call.Name, call, "converted to a placeholder step")
+		return []*harness.Step{d.placeholderStep(call, sc)}
+	}
+
+	node := d.stepNode(call, sc)
+	var steps []*harness.Step
+	for _, step := range convert(node, sc) {
+		// the jenkins json converters return an empty
+		// step if required parameters are missing.
+		if step == nil || step.Spec == nil {
+			continue
+		}
+		steps = append(steps, d.finalizeStep(step, node.SpanName, sc))
+	}
+	if len(steps) == 0 {
+		d.unmapped(unmappedStep, call.Name, call, "missing or unsupported arguments")
+	}
+	return steps
+}
+
+// helper function converts the method call with a receiver,
+// such as docker.image('golang').inside { ... }
+func (d *Converter) convertMethodCall(call *groovy.Call, sc *scope) []*harness.Step {
+	recv, _ := call.Receiver.(*groovy.Call)
+	switch {
+	case call.Name == "inside" && recv != nil && recv.Name == "image" && groovy.Name(recv.Receiver) == "docker":
+		inner := sc.child()
+		inner.image = d.firstArg(recv, sc)
+		return d.convertSteps(call.Body(), inner)
+	case d.libraryMethod(call) != nil:
+		// the method is declared by the shared library
+		// step, for example maven.build('verify').
+		v := d.library.Vars[groovy.Name(call.Receiver)]
+		return d.convertLibraryCall(v, d.libraryMethod(call), call, sc)
+	case call.Closure != nil:
+		d.unmapped(unmappedStep, d.script.Text(call.Receiver)+"."+call.Name, call, "converted the block body")
+		return d.convertSteps(call.Body(), sc)
+	}
+	d.unmapped(unmappedStep, d.script.Text(call.Receiver)+"."+call.Name, call, "")
+	return nil
+}
+
+// helper function converts the wrapper step, which
+// executes the block body with modified settings.
+// Returns false if the step is not a wrapper.
+func (d *Converter) convertWrapper(call *groovy.Call, sc *scope) ([]*harness.Step, bool) {
+	inner := sc.child()
+	switch call.Name {
+	case "script", "node", "ws", "lock", "timestamps", "ansiColor",
+		"container", "wrap", "withSonarQubeEnv", "withGradle", "throttle":
+	case "dir":
+		dir := d.firstArg(call, sc)
+		if v := call.Arg("path"); v != nil {
+			dir = d.shellExpr(v, sc)
+		}
+		if sc.dir != "" && !path.IsAbs(dir) {
+			dir = path.Join(sc.dir, dir)
+		}
+		inner.dir = dir
+	case "withEnv":
+		envs := map[string]string{}
+		for _, arg := range call.Positional() {
+			list, ok := arg.(*groovy.List)
+			if !ok {
+				continue
+			}
+			for _, item := range list.Items {
+				if k, v, ok := strings.Cut(d.shellExpr(item, sc), "="); ok {
+					envs[k] = v
+				}
+			}
+		}
+		inner.envs = mergeEnv(sc.envs, envs)
+	case "withCredentials":
+		inner.envs = mergeEnv(sc.envs, d.convertCredentials(call))
+	case "timeout":
+		inner.timeout = convertTimeout(d.paramArgs(call.Args, sc))
+	case "withMaven":
+		if inner.image == "" {
+			inner.image = "maven:latest"
+		}
+	case "nodejs":
+		name := d.firstArg(call, sc)
+		if v := call.Arg("nodeJSInstallationName"); v != nil {
+			name = d.shellExpr(v, sc)
+		}
+		inner.image = toolImage("nodejs", name)
+	case "retry":
+		n, _ := numberArg(call)
+		steps := d.convertSteps(call.Body(), sc)
+		if len(steps) == 0 {
+			return nil, true
+		}
+		return []*harness.Step{
+			{
+				Name:    d.identifiers.Generate("retry"),
+				Type:    "group",
+				Failure: retryFailure(int64(n)),
+				Spec: &harness.StepGroup{
+					Steps: steps,
+				},
+			},
+		}, true
+	case "catchError", "warnError":
+		steps := d.convertSteps(call.Body(), sc)
+		if len(steps) == 0 {
+			return nil, true
+		}
+		return []*harness.Step{
+			{
+				Name:    d.identifiers.Generate(call.Name),
+				Type:    "group",
+				Failure: ignoreFailure(),
+				Spec: &harness.StepGroup{
+					Steps: steps,
+				},
+			},
+		}, true
+	case "stage":
+		steps := d.convertSteps(call.Body(), sc)
+		if len(steps) == 0 {
+			return nil, true
+		}
+		return []*harness.Step{
+			{
+				Name: d.identifiers.Generate(d.firstArg(call, sc), "group"),
+				Type: "group",
+				Spec: &harness.StepGroup{
+					Steps: steps,
+				},
+			},
+		}, true
+	case "sshagent", "withAWS", "withKubeConfig", "withDockerRegistry",
+		"configFileProvider", "withVault", "withNPM", "withFolderProperties":
+		d.unmapped(unmappedStep, call.Name, call, "credentials are not converted")
+	default:
+		if _, ok := stepConverters[call.Name]; ok {
+			return nil, false
+		}
+		if _, ok := d.methods[call.Name]; ok {
+			return nil, false
+		}
+		if d.libraryVar(call.Name) != nil {
+			return nil, false
+		}
+		d.unmapped(unmappedStep, call.Name, call, "converted the block body")
+	}
+	return d.convertSteps(call.Body(), inner), true
+}
+
+// helper function converts the parallel step to a
+// parallel step group.
+func (d *Converter) convertParallel(call *groovy.Call, sc *scope) []*harness.Step {
+	var steps []*harness.Step
+	for _, arg := range call.Args {
+		name := arg.Name
+		if name == "" && arg.Key != nil {
+			name, _ = groovy.Value(arg.Key).(string)
+		}
+		if name == "failFast" {
+			if v, _ := groovy.Value(arg.Value).(bool); v {
+				d.unmapped(unmappedOption, "failFast", call, "parallel branches run to completion")
+			}
+			continue
+		}
+		closure, ok := arg.Value.(*groovy.Closure)
+		if !ok {
+			d.unmapped(unmappedStep, "parallel", call, "parallel branches are not literal closures")
+			continue
+		}
+		items := d.convertSteps(closure.Body.Stmts, sc)
+		if len(items) == 0 {
+			continue
+		}
+		steps = append(steps, &harness.Step{
+			Name: d.identifiers.Generate(name, "group"),
+			Type: "group",
+			Spec: &harness.StepGroup{
+				Steps: items,
+			},
+		})
+	}
+	if len(steps) == 0 {
+		return nil
+	}
+	return []*harness.Step{
+		{
+			Name: d.identifiers.Generate("parallel"),
+			Type: "parallel",
+			Spec: &harness.StepParallel{
+				Steps: steps,
+			},
+		},
+	}
+}
+
+// helper function inlines the method declared in the
+// Jenkinsfile. The method parameters are bound to the
+// converted call arguments.
+func (d *Converter) convertMethod(method *groovy.MethodDecl, call *groovy.Call, sc *scope) []*harness.Step {
+	if sc.depth >= maxDepth {
+		d.unmapped(unmappedStep, call.Name, call, "recursive method call is not converted")
+		return nil
+	}
+	return d.convertSteps(method.Body.Stmts, d.bindParams(method, call, sc))
+}
+
+// helper function returns the scope of the method body,
+// with the method parameters bound to the converted call
+// arguments.
+func (d *Converter) bindParams(method *groovy.MethodDecl, call *groovy.Call, sc *scope) *scope {
+	inner := sc.child()
+	inner.depth++
+	inner.vars = map[string]*binding{}
+
+	bind := func(name string, value groovy.Expr) {
+		jexl, _ := d.jexlExpr(value, sc)
+		inner.vars[name] = &binding{
+			shell: d.shellExpr(value, sc),
+			jexl:  jexl,
+		}
+	}
+
+	args := call.Positional()
+	for i, param := range method.Params {
+		switch {
+		case i < len(args):
+			bind(param.Name, args[i])
+		case param.Default != nil:
+			// map parameters are accessed by property, and
+			// the properties are bound to named arguments.
+			if _, ok := param.Default.(*groovy.Map); !ok {
+				bind(param.Name, param.Default)
+			}
+		}
+	}
+	// named arguments are passed to a single map
+	// parameter, for example deploy(env: 'prod').
+	if len(method.Params) == 1 {
+		for _, arg := range namedArgs(call) {
+			bind(method.Params[0].Name+"."+arg.Name, arg.Value)
+		}
+	}
+	return inner
+}
+
+// helper function returns the named arguments of the
+// call, including the entries of a single map argument,
+// for example deploy([env: 'prod']).
+func namedArgs(call *groovy.Call) []*groovy.Arg {
+	var args []*groovy.Arg
+	for _, arg := range call.Args {
+		switch {
+		case arg.Name != "" || arg.Key != nil:
+			args = appendNamed(args, arg)
+		case len(call.Args) == 1:
+			if m, ok := arg.Value.(*groovy.Map); ok {
+				for _, entry := range m.Entries {
+					args = appendNamed(args, entry)
+				}
+			}
+		}
+	}
+	return args
+}
+
+// helper function appends the argument if the argument
+// name or key is a literal string.
+func appendNamed(args []*groovy.Arg, arg *groovy.Arg) []*groovy.Arg {
+	if arg.Name != "" {
+		return append(args, arg)
+	}
+	if name, ok := groovy.Value(arg.Key).(string); ok && name != "" {
+		return append(args, &groovy.Arg{Name: name, Value: arg.Value})
+	}
+	return args
+}
+
+// helper function converts the withCredentials bindings
+// to secret environment variables.
+func (d *Converter) convertCredentials(call *groovy.Call) map[string]string {
+	envs := map[string]string{}
+	for _, arg := range call.Positional() {
+		list, ok := arg.(*groovy.List)
+		if !ok {
+			continue
+		}
+		for _, item := range list.Items {
+			binding, ok := item.(*groovy.Call)
+			if !ok {
+				continue
+			}
+			id := stringNamedArg(binding, "credentialsId")
+			switch binding.Name {
+			case "string", "file", "usernameColonPassword", "certificate":
+				if name := stringNamedArg(binding, "variable"); name != "" {
+					envs[name] = secretExpr(id)
+				}
+			case "usernamePassword":
+				if name := stringNamedArg(binding, "passwordVariable"); name != "" {
+					envs[name] = secretExpr(id)
+				}
+				if name := stringNamedArg(binding, "usernameVariable"); name != "" {
+					envs[name] = secretExpr(id + "_username")
+					d.unmapped(unmappedStep, binding.Name, binding, "username is mapped to secret "+id+"_username")
+				}
+			case "sshUserPrivateKey":
+				if name := stringNamedArg(binding, "keyFileVariable"); name != "" {
+					envs[name] = secretExpr(id)
+					d.unmapped(unmappedStep, binding.Name, binding, "the private key is mapped to the secret value, not a file")
+				}
+			default:
+				d.unmapped(unmappedStep, binding.Name, binding, "credentials binding is not converted")
+			}
+		}
+	}
+	return envs
+}
+
+// helper function creates the jenkins json node for the
+// step, used as input to the jenkins json converters.
+func (d *Converter) stepNode(call *groovy.Call, sc *scope) jenkinsjson.Node {
+	params := map[string]interface{}{}
+	for i, arg := range call.Args {
+		switch {
+		case arg.Name != "":
+			params[arg.Name] = d.paramValue(arg.Value, sc)
+		case arg.Key != nil:
+			if key, ok := groovy.Value(arg.Key).(string); ok {
+				params[key] = d.paramValue(arg.Value, sc)
+			}
+		case i == 0:
+			if key, ok := defaultParams[call.Name]; ok {
+				params[key] = d.paramValue(arg.Value, sc)
+			}
+		}
+	}
+
+	name := call.Name
+	if label, ok := params["label"].(string); ok && label != "" {
+		name = label
+	}
+
+	if delegateSteps[call.Name] {
+		params = map[string]interface{}{
+			"delegate": map[string]interface{}{
+				"symbol":    call.Name,
+				"arguments": params,
+			},
+		}
+	}
+
+	attrs := map[string]string{
+		"jenkins.pipeline.step.type": call.Name,
+	}
+	if b, err := json.Marshal(params); err == nil {
+		attrs[jenkinsjson.HarnessAttribute] = string(b)
+	}
+
+	// the jenkins json converters generate identifiers
+	// from the span id, which must be unique.
+	d.spans++
+	return jenkinsjson.Node{
+		SpanName:      name,
+		SpanId:        fmt.Sprintf("%016x", d.spans),
+		ParameterMap:  params,
+		AttributesMap: attrs,
+	}
+}
+
+// helper function creates a script step.
+func (d *Converter) scriptStep(name, run string, sc *scope) []*harness.Step {
+	step := &harness.Step{
+		Type: "script",
+		Spec: &harness.StepExec{
+			Shell: "sh",
+			Run:   run,
+		},
+	}
+	return []*harness.Step{d.finalizeStep(step, name, sc)}
+}
+
+// helper function creates a placeholder script step for
+// a step that cannot be converted.
+func (d *Converter) placeholderStep(call *groovy.Call, sc *scope) *harness.Step {
+	run := fmt.Sprintf("echo %q", "This is a place holder for: "+call.Name)
+	run += "\n" + commentLines(d.script.Text(call))
+	step := &harness.Step{
+		Desc: "This is a place holder for: " + call.Name,
+		Type: "script",
+		Spec: &harness.StepExec{
+			Shell: "sh",
+			Run:   run,
+		},
+	}
+	return d.finalizeStep(step, call.Name, sc)
+}
+
+// helper function applies the scope to the converted
+// step, and generates a unique step name.
+func (d *Converter) finalizeStep(step *harness.Step, name string, sc *scope) *harness.Step {
+	step.Name = d.identifiers.Generate(
+		jenkinsjson.SanitizeForName(name),
+	)
+	// the identifier is derived from the name.
+	step.Id = ""
+	if step.Timeout == "" {
+		step.Timeout = sc.timeout
+	}
+	switch spec := step.Spec.(type) {
+	case *harness.StepExec:
+		if spec.Image == "" {
+			spec.Image = sc.image
+		}
+		spec.Envs = mergeEnv(sc.envs, spec.Envs)
+		if sc.dir != "" {
+			spec.Run = "cd " + sc.dir + "\n" + spec.Run
+		}
+	case *harness.StepPlugin:
+		spec.Envs = mergeEnv(sc.envs, spec.Envs)
+	}
+	return step
+}
+
+// helper function returns the first argument of the call
+// as shell text.
+func (d *Converter) firstArg(call *groovy.Call, sc *scope) string {
+	if args := call.Positional(); len(args) != 0 {
+		return d.shellExpr(args[0], sc)
+	}
+	for _, name := range []string{"message", "text", "name"} {
+		if v := call.Arg(name); v != nil {
+			return d.shellExpr(v, sc)
+		}
+	}
+	return ""
+}
+
+// helper function returns a single step slice.
+func single(step *harness.Step) []*harness.Step {
+	if step == nil {
+		return nil
+	}
+	return []*harness.Step{step}
+}
+
+// helper function returns a failure strategy that
+// retries the step.
+func retryFailure(attempts int64) *harness.FailureList {
+	return &harness.FailureList{
+		Items: []*harness.Failure{
+			{
+				Errors: []string{"all"},
+				Action: &harness.FailureAction{
+					Type: "retry",
+					Spec: &harness.Retry{
+						Attempts: attempts,
+					},
+				},
+			},
+		},
+	}
+}
+
+// helper function returns a failure strategy that
+// ignores the failure.
+func ignoreFailure() *harness.FailureList {
+	return &harness.FailureList{
+		Items: []*harness.Failure{
+			{
+				Errors: []string{"all"},
+				Action: &harness.FailureAction{
+					Type: "ignore",
+					Spec: &harness.Ignore{},
+				},
+			},
+		},
+	}
+}
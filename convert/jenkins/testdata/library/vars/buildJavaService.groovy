@@ -0,0 +1,14 @@
+// buildJavaService builds and publishes a java service.
+//
+//   buildJavaService(name: 'orders', publish: true)
+def call(Map config = [:]) {
+    sh "mvn -B -pl ${config.name} package"
+    if (config.publish) {
+        sh "docker build -t acme/${config.name} ."
+    }
+    notify(config.name)
+}
+
+def notify(String service) {
+    echo "built ${service}"
+}
@@ -0,0 +1,23 @@
+// javaPipeline declares the standard java service pipeline.
+//
+//   javaPipeline(name: 'orders')
+def call(Map config = [:]) {
+    pipeline {
+        agent { docker { image 'maven:3-eclipse-temurin-17' } }
+        environment {
+            SERVICE = "${config.name}"
+        }
+        stages {
+            stage('Build') {
+                steps {
+                    buildJavaService(name: config.name, publish: true)
+                }
+            }
+            stage('Test') {
+                steps {
+                    maven.verify()
+                }
+            }
+        }
+    }
+}
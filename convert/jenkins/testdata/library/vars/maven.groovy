@@ -0,0 +1,6 @@
+// maven runs maven goals.
+//
+//   maven.verify('-DskipITs')
+def verify(String args = '') {
+    sh "mvn -B verify ${args}"
+}
@@ -0,0 +1,244 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jenkins
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/drone/go-convert/convert/jenkins/groovy"
+	harness "github.com/drone/spec/dist/go"
+
+	"github.com/ghodss/yaml"
+)
+
+// toolImages maps the jenkins tool types to container
+// image repositories.
+var toolImages = map[string]string{
+	"maven":  "maven",
+	"gradle": "gradle",
+	"nodejs": "node",
+	"go":     "golang",
+	"jdk":    "eclipse-temurin",
+}
+
+// toolHomes maps the jenkins tool types to the tool
+// installation directory in the container image.
+var toolHomes = map[string]string{
+	"maven":  "/usr/share/maven",
+	"gradle": "/opt/gradle",
+	"nodejs": "/usr/local",
+	"go":     "/usr/local/go",
+	"jdk":    "/opt/java/openjdk",
+}
+
+var (
+	toolVersion = regexp.MustCompile(`\d+(\.\d+)*`)
+	mavenName   = regexp.MustCompile(`^m\d`)
+)
+
+// helper function guesses the jenkins tool type from the
+// tool installation name, for example M3 or jdk8.
+func guessToolType(name string) string {
+	name = strings.ToLower(name)
+	switch {
+	case strings.Contains(name, "maven"),
+		strings.Contains(name, "mvn"),
+		mavenName.MatchString(name):
+		return "maven"
+	case strings.Contains(name, "gradle"):
+		return "gradle"
+	case strings.Contains(name, "jdk"),
+		strings.Contains(name, "java"):
+		return "jdk"
+	case strings.Contains(name, "node"):
+		return "nodejs"
+	case strings.HasPrefix(name, "go"):
+		return "go"
+	}
+	return ""
+}
+
+// helper function returns the container image for the
+// jenkins tool. The image tag is parsed from the tool
+// installation name, for example 'Maven 3.9' or 'jdk17'.
+func toolImage(typ, name string) string {
+	image, ok := toolImages[typ]
+	if !ok {
+		return ""
+	}
+	if version := toolVersion.FindString(name); version != "" {
+		return image + ":" + version
+	}
+	return image + ":latest"
+}
+
+// helper function returns the container image of the
+// kubernetes pod template. The default container is used
+// if set, otherwise the first container that is not the
+// jenkins agent.
+func podImage(pod, container string) string {
+	out := struct {
+		Spec struct {
+			Containers []struct {
+				Name  string `json:"name"`
+				Image string `json:"image"`
+			} `json:"containers"`
+		} `json:"spec"`
+	}{}
+	if err := yaml.Unmarshal([]byte(pod), &out); err != nil {
+		return ""
+	}
+	var image string
+	for _, c := range out.Spec.Containers {
+		if c.Name == container {
+			return c.Image
+		}
+		if c.Name != "jnlp" && image == "" {
+			image = c.Image
+		}
+	}
+	return image
+}
+
+// helper function returns true if the agent label
+// targets a windows agent.
+func isWindowsLabel(label string) bool {
+	label = strings.ToLower(label)
+	return strings.Contains(label, "windows") || label == "win"
+}
+
+// helper function converts the timeout arguments to a
+// harness timeout. The timeout unit defaults to minutes.
+func convertTimeout(v interface{}) string {
+	var time float64
+	var unit string
+	switch v := v.(type) {
+	case float64:
+		time = v
+	case map[string]interface{}:
+		time, _ = v["time"].(float64)
+		unit, _ = v["unit"].(string)
+	}
+	if time == 0 {
+		return ""
+	}
+	switch strings.ToUpper(unit) {
+	case "SECONDS":
+		return fmt.Sprintf("%.0fs", time)
+	case "HOURS":
+		return fmt.Sprintf("%.0fh", time)
+	case "DAYS":
+		return fmt.Sprintf("%.0fd", time)
+	default:
+		return fmt.Sprintf("%.0fm", time)
+	}
+}
+
+// helper function returns the first positional argument,
+// or the count argument, as a number.
+func numberArg(call *groovy.Call) (float64, bool) {
+	if args := call.Positional(); len(args) != 0 {
+		f, ok := groovy.Value(args[0]).(float64)
+		return f, ok
+	}
+	f, ok := groovy.Value(call.Arg("count")).(float64)
+	return f, ok
+}
+
+// helper function returns the first positional argument
+// as a string literal.
+func stringArg(call *groovy.Call) string {
+	if args := call.Positional(); len(args) != 0 {
+		s, _ := groovy.Value(args[0]).(string)
+		return s
+	}
+	return ""
+}
+
+// helper function returns the named argument as a
+// string literal.
+func stringNamedArg(call *groovy.Call, name string) string {
+	s, _ := groovy.Value(call.Arg(name)).(string)
+	return s
+}
+
+// helper function returns a descriptive name for the
+// syntax tree node, used in the conversion report.
+func nodeName(node groovy.Node) string {
+	switch v := node.(type) {
+	case *groovy.Call:
+		return v.Name
+	case *groovy.ExprStmt:
+		if call, ok := v.X.(*groovy.Call); ok {
+			return call.Name
+		}
+		return "expression"
+	case *groovy.Assign:
+		return groovy.Name(v.Target)
+	case *groovy.Raw:
+		return v.Keyword
+	case *groovy.If:
+		return "if"
+	case *groovy.For:
+		return "for"
+	case *groovy.While:
+		return "while"
+	case *groovy.Try:
+		return "try"
+	}
+	return "statement"
+}
+
+// helper function returns true if the pipeline options
+// are empty.
+func isDefaultEmpty(opts *harness.Default) bool {
+	return opts.Clone == nil &&
+		opts.Timeout == "" &&
+		len(opts.Envs) == 0
+}
+
+// helper function merges the environment variables.
+// The second map takes precedence.
+func mergeEnv(a, b map[string]string) map[string]string {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+	out := map[string]string{}
+	for k, v := range a {
+		out[k] = v
+	}
+	for k, v := range b {
+		out[k] = v
+	}
+	return out
+}
+
+// helper function quotes the text for use in a shell
+// double quoted string. Variable references are kept.
+func shellQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "`", "\\`")
+	return `"` + r.Replace(s) + `"`
+}
+
+// helper function comments out the lines of text.
+func commentLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = "# " + line
+	}
+	return strings.Join(lines, "\n")
+}
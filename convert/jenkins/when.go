@@ -0,0 +1,254 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jenkins
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/drone/go-convert/convert/jenkins/groovy"
+	"github.com/drone/go-convert/convert/jenkins/jenkinsfile"
+	harness "github.com/drone/spec/dist/go"
+)
+
+// changeRequestVars maps the changeRequest condition
+// arguments to harness expressions.
+var changeRequestVars = map[string]string{
+	"id":          "<+codebase.prNumber>",
+	"target":      "<+codebase.targetBranch>",
+	"branch":      "<+codebase.sourceBranch>",
+	"title":       "<+codebase.prTitle>",
+	"author":      "<+codebase.gitUserId>",
+	"authorEmail": "<+codebase.gitUserEmail>",
+}
+
+// helper function converts the stage when section to a
+// harness when clause. Conditions that cannot be converted
+// are reported and ignored.
+func (d *Converter) convertWhen(src *jenkinsfile.When) *harness.When {
+	if src == nil {
+		return nil
+	}
+	var exprs []string
+	for _, cond := range src.Conditions {
+		if expr, ok := d.convertCondition(cond); ok {
+			exprs = append(exprs, expr)
+		}
+	}
+	switch len(exprs) {
+	case 0:
+		return nil
+	case 1:
+		return &harness.When{Eval: exprs[0]}
+	default:
+		return &harness.When{Eval: joinExpr(exprs, "&&")}
+	}
+}
+
+// helper function converts a when condition to a jexl
+// expression.
+func (d *Converter) convertCondition(cond *jenkinsfile.Condition) (string, bool) {
+	switch cond.Type {
+	case "branch":
+		return compareVar("<+codebase.branch>", cond.Pattern, cond.Comparator), true
+	case "tag":
+		if cond.Pattern == "" {
+			return `<+codebase.build.type> == "tag"`, true
+		}
+		return compareVar("<+codebase.tag>", cond.Pattern, cond.Comparator), true
+	case "buildingTag":
+		return `<+codebase.build.type> == "tag"`, true
+	case "changeRequest":
+		expr := `<+trigger.event> == "PR"`
+		if cond.Name == "" {
+			return expr, true
+		}
+		variable, ok := changeRequestVars[cond.Name]
+		if !ok {
+			d.unmapped(unmappedCondition, "changeRequest", cond.Node, "argument "+cond.Name+" is not converted")
+			return expr, true
+		}
+		return expr + " && " + compareVar(variable, cond.Pattern, cond.Comparator), true
+	case "environment":
+		return fmt.Sprintf("<+pipeline.variables.%s> == %q", cond.Name, cond.Value), true
+	case "equals":
+		actual, ok1 := d.jexlExpr(cond.Actual, nil)
+		expected, ok2 := d.jexlExpr(cond.Expected, nil)
+		if !ok1 || !ok2 {
+			d.unmapped(unmappedCondition, "equals", cond.Node, "")
+			return "", false
+		}
+		return actual + " == " + expected, true
+	case "expression":
+		expr, ok := d.jexlBody(cond.Expr)
+		if !ok {
+			d.unmapped(unmappedCondition, "expression", cond.Node, d.script.Text(cond.Node))
+			return "", false
+		}
+		return expr, true
+	case "not":
+		if len(cond.Conditions) != 1 {
+			d.unmapped(unmappedCondition, "not", cond.Node, "")
+			return "", false
+		}
+		expr, ok := d.convertCondition(cond.Conditions[0])
+		if !ok {
+			return "", false
+		}
+		return "!(" + expr + ")", true
+	case "allOf", "anyOf":
+		op := "&&"
+		if cond.Type == "anyOf" {
+			op = "||"
+		}
+		var exprs []string
+		for _, inner := range cond.Conditions {
+			expr, ok := d.convertCondition(inner)
+			if !ok {
+				// a partially converted anyOf condition
+				// would be more restrictive than the
+				// original condition.
+				if cond.Type == "anyOf" {
+					return "", false
+				}
+				continue
+			}
+			exprs = append(exprs, expr)
+		}
+		if len(exprs) == 0 {
+			return "", false
+		}
+		return joinExpr(exprs, op), true
+	default:
+		// changeset, changelog, triggeredBy and
+		// isRestartedRun have no harness equivalent.
+		d.unmapped(unmappedCondition, cond.Type, cond.Node, "")
+		return "", false
+	}
+}
+
+// helper function converts the post condition to a
+// harness when clause based on the pipeline or stage
+// status. Returns false if the condition cannot be
+// converted.
+func (d *Converter) convertPostWhen(post *jenkinsfile.Post) (*harness.When, bool) {
+	switch post.Type {
+	case "always", "cleanup":
+		return statusWhen("", "success", "failure"), true
+	case "success":
+		return statusWhen("success"), true
+	case "failure", "unsuccessful":
+		return statusWhen("failure"), true
+	case "changed":
+		d.unmapped(unmappedPost, post.Type, post.Node, "converted to always")
+		return statusWhen("", "success", "failure"), true
+	case "fixed":
+		d.unmapped(unmappedPost, post.Type, post.Node, "converted to success")
+		return statusWhen("success"), true
+	case "regression":
+		d.unmapped(unmappedPost, post.Type, post.Node, "converted to failure")
+		return statusWhen("failure"), true
+	default:
+		// unstable, aborted and notBuilt have no
+		// harness equivalent.
+		d.unmapped(unmappedPost, post.Type, post.Node, "")
+		return nil, false
+	}
+}
+
+// helper function returns a status when clause. If the
+// status is empty, the clause matches any of the statuses.
+func statusWhen(status string, in ...string) *harness.When {
+	expr := &harness.Expr{Eq: status}
+	if status == "" {
+		expr = &harness.Expr{In: in}
+	}
+	return &harness.When{
+		Cond: []map[string]*harness.Expr{
+			{"status": expr},
+		},
+	}
+}
+
+// helper function compares the variable to the pattern
+// using the jenkins comparator, which defaults to GLOB.
+func compareVar(variable, pattern, comparator string) string {
+	switch strings.ToUpper(comparator) {
+	case "EQUALS":
+		return fmt.Sprintf("%s == %q", variable, pattern)
+	case "REGEXP":
+		return fmt.Sprintf("%s =~ %q", variable, pattern)
+	default:
+		if !strings.ContainsAny(pattern, "*?") {
+			return fmt.Sprintf("%s == %q", variable, pattern)
+		}
+		return fmt.Sprintf("%s =~ %q", variable, globToRegexp(pattern))
+	}
+}
+
+// helper function converts an ant-style glob pattern to
+// a regular expression.
+func globToRegexp(glob string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				sb.WriteString(".*")
+				i++
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+	return sb.String()
+}
+
+// helper function joins the expressions with the logical
+// operator, wrapping compound expressions in parentheses.
+func joinExpr(exprs []string, op string) string {
+	var parts []string
+	for _, expr := range exprs {
+		if strings.Contains(expr, "&&") || strings.Contains(expr, "||") {
+			expr = "(" + expr + ")"
+		}
+		parts = append(parts, expr)
+	}
+	return strings.Join(parts, " "+op+" ")
+}
+
+// helper function converts the body of an expression
+// condition, which must return a single expression.
+func (d *Converter) jexlBody(stmts []groovy.Stmt) (string, bool) {
+	if len(stmts) != 1 {
+		return "", false
+	}
+	switch v := stmts[0].(type) {
+	case *groovy.ExprStmt:
+		return d.jexlExpr(v.X, nil)
+	case *groovy.Jump:
+		if v.Keyword == "return" && v.X != nil {
+			return d.jexlExpr(v.X, nil)
+		}
+	}
+	return "", false
+}
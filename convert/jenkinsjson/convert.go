@@ -21,10 +21,12 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"reflect"
 	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/drone/go-convert/convert/jenkins/jenkinsfile"
 	jenkinsjson "github.com/drone/go-convert/convert/jenkinsjson/json"
 	"github.com/drone/go-convert/internal/store"
 	harness "github.com/drone/spec/dist/go"
@@ -75,11 +77,12 @@ var tags = []string{
 	"hadoop", "mariadb", "mysql", "psql", "mongo", "redis", "jdbc",
 }
 
-var mavenGoals string
-var gradleGoals string
-
 var defaultWindowsImage string = "mcr.microsoft.com/powershell"
 
+// defaultIgnoredSteps are the steps that wrap other steps
+// and are not converted.
+var defaultIgnoredSteps = []string{"", "parallel", "script", "withAnt", "tool", "envVarsForTool", "ws", "ansiColor", "newBuildInfo", "getArtifactoryServer"}
+
 // Converter converts a jenkinsjson pipeline to a Harness
 // v1 pipeline.
 type Converter struct {
@@ -97,6 +100,31 @@ type Converter struct {
 	useIntelligence bool
 	configFile      string
 	disableConversionForSteps    string
+
+	// strict fails the conversion if the pipeline
+	// includes unsupported steps.
+	strict bool
+
+	// report lists the converted steps, for the
+	// current conversion.
+	report *Report
+
+	// mappings are the user-defined step mappings loaded
+	// from the config file, keyed by step name.
+	mappings map[string]*StepMapping
+
+	// libraryPaths are the shared library checkouts, and
+	// library is the parsed shared library. The global
+	// steps are inlined, or converted to template steps
+	// if libraryTmpl is set.
+	libraryPaths []string
+	libraryTmpl  bool
+	library      *jenkinsfile.Library
+
+	// conditions are the when clauses for the spans that
+	// were not executed in every merged trace, keyed by
+	// span id.
+	conditions map[string]*harness.When
 }
 
 // parseDisableConversionForSteps parses the comma-separated disable conversion for steps string into a map for fast lookup
@@ -104,8 +132,7 @@ func (d *Converter) parseDisableConversionForSteps() map[string]bool {
 	disabledConversionForStepsMap := make(map[string]bool)
 	
 	// Default ignored steps (existing hardcoded list)
-	defaultIgnored := []string{"", "parallel", "script", "withAnt", "tool", "envVarsForTool", "ws", "ansiColor", "newBuildInfo", "getArtifactoryServer"}
-	for _, step := range defaultIgnored {
+	for _, step := range defaultIgnoredSteps {
 		disabledConversionForStepsMap[step] = true
 	}
 	
@@ -154,37 +181,58 @@ func New(options ...Option) *Converter {
 
 // Convert downgrades a v1 pipeline.
 func (d *Converter) Convert(r io.Reader) ([]byte, error) {
-	// Validate infrastructure options first
-	if err := d.ValidateInfrastructureOptions(); err != nil {
-		return nil, err
+	out, _, err := d.ConvertWithReport(r)
+	return out, err
+}
+
+// ConvertWithReport converts the pipeline trace, and
+// returns the converted pipeline with a report of the
+// converted, approximated, skipped and failed steps.
+func (d *Converter) ConvertWithReport(r io.Reader) ([]byte, *Report, error) {
+	// validate the options and copy the converter, so the
+	// conversion state is not shared.
+	c, err := d.begin()
+	if err != nil {
+		return nil, nil, err
 	}
 
 	buf := new(bytes.Buffer)
 	buf.ReadFrom(r)
-	var pipelineJson jenkinsjson.Node
-	err := json.Unmarshal(buf.Bytes(), &pipelineJson)
+	pipelineJson, err := jenkinsjson.ParseTrace(buf.Bytes())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config := c.convertNode(pipelineJson)
+	if err := c.finish(); err != nil {
+		return nil, c.report, err
+	}
 
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return out, c.report, nil
+}
+
+// convertNode converts the pipeline trace to a Harness
+// pipeline resource.
+func (d *Converter) convertNode(pipelineJson *jenkinsjson.Node) *harness.Config {
 	// create the harness pipeline spec
 	dst := &harness.Pipeline{}
 
 	processedTools := &ProcessedTools{false, false, false, false, false, false, false, false, false, []string{}}
 	var variable map[string]string
-	d.recursiveParseJsonToStages(&pipelineJson, dst, processedTools, variable)
+	d.recursiveParseJsonToStages(pipelineJson, dst, processedTools, variable)
 	// create the harness pipeline resource
-	config := &harness.Config{
+	return &harness.Config{
 		Version: 1,
 		Name:    jenkinsjson.SanitizeForName(pipelineJson.Name),
 		Kind:    "pipeline",
 		Type:    strings.Join(processedTools.Tags, ","),
 		Spec:    dst,
 	}
-
-	out, err := yaml.Marshal(config)
-	if err != nil {
-		return nil, err
-	}
-
-	return out, nil
 }
 
 // Recursive function to parse JSON nodes into stages and steps
@@ -299,6 +347,7 @@ func (d *Converter) collectStagesWithID(jsonNode *jenkinsjson.Node, processedToo
 				Spec: &harness.StepGroup{
 					Steps: stepsInStage,
 				},
+				When: d.conditions[jsonNode.SpanId],
 			}
 
 			// identify technology tags
@@ -463,6 +512,10 @@ func (d *Converter) collectStepsWithID(currentNode jenkinsjson.Node, stepGroupWi
 	}
 
 	stepType := currentNode.AttributesMap["jenkins.pipeline.step.type"]
+
+	// steps converted from spans that were not executed
+	// in every merged trace are conditional.
+	defer d.applyCondition(currentNode.SpanId, stepWithIDList, len(*stepWithIDList))
 	
 	switch stepType {
 	case "node":
@@ -472,12 +525,46 @@ func (d *Converter) collectStepsWithID(currentNode jenkinsjson.Node, stepGroupWi
 	
 	// Check if this step type should be ignored
 	if disabledConversionForSteps[stepType] {
+		if !containsString(defaultIgnoredSteps, stepType) {
+			d.reportSkipped(currentNode, stepType, false, "conversion is disabled")
+		}
 		// Skip processing this step, just process its children
 		for _, child := range currentNode.Children {
 			clone, repo = d.collectStepsWithID(child, stepGroupWithId, stepWithIDList, processedTools, variables, timeout, dockerImage, disabledConversionForSteps)
 		}
 		return clone, repo
 	}
+
+	// user-defined step mappings take precedence over the
+	// built-in step conversion. The mapped step replaces
+	// the step, including its children.
+	if mapping, ok := d.mappings[stepType]; ok {
+		step, err := mapping.convert(currentNode)
+		if err == nil {
+			*stepWithIDList = append(*stepWithIDList, StepWithID{Step: step, ID: id})
+			d.reportStep(currentNode, stepType, (*stepWithIDList)[len(*stepWithIDList)-1:])
+			return clone, repo
+		}
+		d.reportFailed(currentNode, stepType, err)
+	}
+
+	// shared library global steps are converted from the
+	// library step, which replaces the step, including
+	// its children.
+	if d.isLibraryStep(stepType) {
+		step, err := d.convertLibraryStep(currentNode, stepType)
+		if err == nil {
+			*stepWithIDList = append(*stepWithIDList, StepWithID{Step: step, ID: id})
+			d.reportStep(currentNode, stepType, (*stepWithIDList)[len(*stepWithIDList)-1:])
+			return clone, repo
+		}
+		d.reportFailed(currentNode, stepType, err)
+	}
+
+	// the steps converted from this span are reported
+	// after the span is converted.
+	from := len(*stepWithIDList)
+	placeholder := false
 	
 	switch stepType {
 
@@ -693,6 +780,7 @@ func (d *Converter) collectStepsWithID(currentNode jenkinsjson.Node, stepGroupWi
 		if symbol, ok := currentNode.ParameterMap["delegate"].(map[string]interface{})["symbol"]; ok && symbol == "nodejs" {
 			*stepWithIDList = append(*stepWithIDList, StepWithID{Step: jenkinsjson.ConvertNodejs(currentNode), ID: id})
 		}
+		d.reportStep(currentNode, stepType, (*stepWithIDList)[from:])
 		return clone, repo
 
 	case "zip":
@@ -943,6 +1031,7 @@ func (d *Converter) collectStepsWithID(currentNode jenkinsjson.Node, stepGroupWi
 		*stepWithIDList = append(*stepWithIDList, StepWithID{Step: jenkinsjson.ConvertReadTrusted(currentNode), ID: id})
 
 	default:
+		placeholder = true
 		placeholderStr := fmt.Sprintf("echo %q", "This is a place holder for: "+currentNode.AttributesMap["jenkins.pipeline.step.type"])
 		b, err := json.MarshalIndent(currentNode.ParameterMap, "", "  ")
 		if err != nil {
@@ -961,6 +1050,12 @@ func (d *Converter) collectStepsWithID(currentNode jenkinsjson.Node, stepGroupWi
 		}, ID: id})
 	}
 
+	if placeholder {
+		d.reportSkipped(currentNode, stepType, true, "unsupported step, converted to a placeholder")
+	} else {
+		d.reportStep(currentNode, stepType, (*stepWithIDList)[from:])
+	}
+
 	for _, child := range currentNode.Children {
 		clone, repo = d.collectStepsWithID(child, stepGroupWithId, stepWithIDList, processedTools, variables, timeout, dockerImage, disabledConversionForSteps)
 	}
@@ -1206,6 +1301,11 @@ func canMergeSteps(step1, step2 *harness.Step) bool {
 	if step1.Type != "script" || step2.Type != "script" {
 		return false
 	}
+	// conditional steps are only merged with steps that
+	// have the same condition.
+	if !reflect.DeepEqual(step1.When, step2.When) {
+		return false
+	}
 
 	exec1, ok1 := step1.Spec.(*harness.StepExec)
 	exec2, ok2 := step2.Spec.(*harness.StepExec)
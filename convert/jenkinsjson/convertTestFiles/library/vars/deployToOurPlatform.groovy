@@ -0,0 +1,7 @@
+// deployToOurPlatform deploys the application.
+//
+//   deployToOurPlatform(app: 'api', env: 'prod')
+def call(Map config = [:]) {
+    sh "./deploy.sh ${config.app} ${config.env}"
+    echo "deployed ${config.app}"
+}
@@ -0,0 +1,6 @@
+// notifyTeam posts a message to the team channel.
+//
+//   notifyTeam(channel: '#deploys')
+def call(Map config = [:]) {
+    sh "./notify.sh '${config.channel}'"
+}
@@ -3,6 +3,7 @@ package jenkinsjson
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -31,6 +32,16 @@ func TestConvert(t *testing.T) {
 			input: "./convertTestFiles/convert/build-and-multiple-deploy.json",
 			want:  "./convertTestFiles/convert/build-and-multiple-deploy.yaml",
 		},
+		{
+			name:  "otlp-trace-export",
+			input: "./convertTestFiles/trace/otlp.json",
+			want:  "./convertTestFiles/trace/hello-world.yaml",
+		},
+		{
+			name:  "jaeger-trace-export",
+			input: "./convertTestFiles/trace/jaeger.json",
+			want:  "./convertTestFiles/trace/hello-world.yaml",
+		},
 	}
 
 	for _, tc := range tests {
@@ -1016,3 +1027,328 @@ func TestInfrastructureOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertTraces(t *testing.T) {
+	got, err := New().ConvertTraceFiles(
+		"./convertTestFiles/merge/run1.json",
+		"./convertTestFiles/merge/run2.json",
+		"./convertTestFiles/merge/run3.json",
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want, err := os.ReadFile("./convertTestFiles/merge/merged.yaml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if diff := cmp.Diff(string(want), string(got)); diff != "" {
+		t.Errorf("TestConvertTraces mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConvertTracesWithReport(t *testing.T) {
+	var traces []io.Reader
+	for _, path := range []string{
+		"./convertTestFiles/merge/run1.json",
+		"./convertTestFiles/merge/run2.json",
+		"./convertTestFiles/merge/run3.json",
+	} {
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		traces = append(traces, f)
+	}
+
+	got, report, err := New().ConvertTracesWithReport(traces...)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	want, err := os.ReadFile("./convertTestFiles/merge/merged.yaml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if diff := cmp.Diff(string(want), string(got)); diff != "" {
+		t.Errorf("TestConvertTracesWithReport mismatch (-want +got):\n%s", diff)
+	}
+	if report == nil || len(report.Converted()) == 0 {
+		t.Errorf("Want the converted steps of the merged trace in the report")
+	}
+}
+
+func TestMergeTracesWhen(t *testing.T) {
+	m := &traceMerge{
+		runs: []*run{
+			{branch: "main", params: map[string]string{"ENV": "prod"}},
+			{branch: "main", params: map[string]string{"ENV": "dev"}},
+			{branch: "develop", params: map[string]string{"ENV": "dev"}},
+		},
+	}
+	tests := []struct {
+		runs []bool
+		want string
+	}{
+		{[]bool{true, true, false}, `<+codebase.branch> == "main"`},
+		{[]bool{false, false, true}, `<+codebase.branch> == "develop"`},
+		{[]bool{true, false, false}, `<+inputs.ENV> == "prod"`},
+		{[]bool{false, true, false}, ""},
+	}
+	for _, test := range tests {
+		var got string
+		if when := m.when(test.runs); when != nil {
+			got = when.Eval
+		}
+		if got != test.want {
+			t.Errorf("Want condition %q for runs %v, got %q", test.want, test.runs, got)
+		}
+	}
+}
+
+func TestConvertStepMapping(t *testing.T) {
+	converter := New(WithConfigFile("./convertTestFiles/mapping/mapping.yaml"))
+	got, err := converter.ConvertFile("./convertTestFiles/mapping/deploy.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want, err := os.ReadFile("./convertTestFiles/mapping/deploy.yaml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if diff := cmp.Diff(string(want), string(got)); diff != "" {
+		t.Errorf("TestConvertStepMapping mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConvertLibrary(t *testing.T) {
+	tests := []struct {
+		golden  string
+		options []Option
+	}{
+		{"./convertTestFiles/library/deploy.yaml", []Option{WithLibrary("./convertTestFiles/library")}},
+		{"./convertTestFiles/library/deploy.template.yaml", []Option{WithLibrary("./convertTestFiles/library"), WithLibraryTemplates()}},
+	}
+	for _, test := range tests {
+		got, err := New(test.options...).ConvertFile("./convertTestFiles/mapping/deploy.json")
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		want, err := os.ReadFile(test.golden)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if diff := cmp.Diff(string(want), string(got)); diff != "" {
+			t.Errorf("TestConvertLibrary %s mismatch (-want +got):\n%s", test.golden, diff)
+		}
+	}
+}
+
+func TestConvertLibraryReport(t *testing.T) {
+	f, err := os.Open("./convertTestFiles/mapping/deploy.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	_, report, err := New(WithLibrary("./convertTestFiles/library"), WithStrict(true)).ConvertWithReport(f)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// the shared library steps replace the span children.
+	var got []string
+	for _, step := range report.Steps {
+		got = append(got, fmt.Sprintf("%s %s %s", step.SpanID, step.Type, step.Status))
+	}
+	want := []string{
+		"2b8f6d4a1e9c7053 sh converted",
+		"c5a1e93f7b2d4086 deployToOurPlatform converted",
+		"4f9a3c7e1b5d8062 notifyTeam converted",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected report")
+		t.Log(diff)
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	tests := []struct {
+		config string
+		valid  bool
+	}{
+		{"steps:\n- step: deploy\n  image: acme/deploy\n", true},
+		{"steps:\n- step: deploy\n  uses: deploy\n", true},
+		{"steps:\n- step: deploy\n  template: deploy\n", true},
+		{"steps:\n- image: acme/deploy\n", false},
+		{"steps:\n- step: deploy\n", false},
+		{"steps:\n- step: stage\n  image: acme/deploy\n", false},
+		{"steps:\n- step: deploy\n  image: acme/deploy\n  template: deploy\n", false},
+		{"steps:\n- step: deploy\n  image: acme/deploy\n  with:\n    target: \"{{ .app \"\n", false},
+	}
+	for i, test := range tests {
+		_, err := ParseConfig([]byte(test.config))
+		if test.valid && err != nil {
+			t.Errorf("Want valid config at index %d, got %s", i, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("Want invalid config at index %d", i)
+		}
+	}
+}
+
+func TestStepMappingMissingKey(t *testing.T) {
+	mapping := &StepMapping{
+		Step:  "deployToOurPlatform",
+		Image: "acme/deploy",
+		With:  map[string]string{"target": "{{ .app }}-{{ .env }}"},
+	}
+	node := jenkinsjson.Node{
+		SpanName:     "deployToOurPlatform",
+		SpanId:       "c5a1e93f7b2d4086",
+		ParameterMap: map[string]interface{}{"app": "api"},
+	}
+	if _, err := mapping.convert(node); err == nil {
+		t.Errorf("Expect error when the template references a missing parameter")
+	}
+}
+
+func TestConvertWithReport(t *testing.T) {
+	f, err := os.Open("./convertTestFiles/mapping/deploy.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	_, report, err := New().ConvertWithReport(f)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	var got []string
+	for _, step := range report.Steps {
+		got = append(got, fmt.Sprintf("%s %s %s %v", step.SpanID, step.Type, step.Status, step.Unsupported))
+	}
+	want := []string{
+		"2b8f6d4a1e9c7053 sh converted false",
+		"c5a1e93f7b2d4086 deployToOurPlatform skipped true",
+		"e6d2b8f4a0c19357 sh converted false",
+		"4f9a3c7e1b5d8062 notifyTeam skipped true",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected report")
+		t.Log(diff)
+	}
+	if got, want := len(report.Unsupported()), 2; got != want {
+		t.Errorf("Want %d unsupported steps, got %d", want, got)
+	}
+}
+
+func TestConvertStrict(t *testing.T) {
+	_, err := New(WithStrict(true)).ConvertFile("./convertTestFiles/mapping/deploy.json")
+	if err == nil {
+		t.Errorf("Expect error when strict and the pipeline includes unsupported steps")
+	} else if !strings.Contains(err.Error(), "deployToOurPlatform (span c5a1e93f7b2d4086)") {
+		t.Errorf("Expect unsupported step in error, got %s", err)
+	}
+
+	// the unsupported steps are mapped using the config
+	// file, and the conversion succeeds.
+	_, err = New(
+		WithStrict(true),
+		WithConfigFile("./convertTestFiles/mapping/mapping.yaml"),
+	).ConvertFile("./convertTestFiles/mapping/deploy.json")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestConvertFailedMapping(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "mapping.yaml")
+	data := []byte("steps:\n- step: deployToOurPlatform\n  image: registry.acme.com/plugins/deploy:1\n  with:\n    target: \"{{ .missing }}\"\n")
+	if err := os.WriteFile(config, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open("./convertTestFiles/mapping/deploy.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	// the failed step mapping is reported, and the step is
+	// then converted by the built-in step conversion.
+	_, report, err := New(WithConfigFile(config)).ConvertWithReport(f)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	failed := report.Failed()
+	if got, want := len(failed), 1; got != want {
+		t.Errorf("Want %d failed steps, got %d", want, got)
+		return
+	}
+	if got, want := failed[0].SpanID, "c5a1e93f7b2d4086"; got != want {
+		t.Errorf("Want failed span %s, got %s", want, got)
+	}
+	if !strings.Contains(failed[0].Detail, "missing") {
+		t.Errorf("Want the mapping error in the detail, got %s", failed[0].Detail)
+	}
+
+	_, err = New(WithStrict(true), WithConfigFile(config)).ConvertFile("./convertTestFiles/mapping/deploy.json")
+	if err == nil {
+		t.Errorf("Expect error when strict and a step mapping fails")
+	} else if !strings.Contains(err.Error(), "failed steps: deployToOurPlatform (span c5a1e93f7b2d4086)") {
+		t.Errorf("Expect failed step in error, got %s", err)
+	}
+}
+
+func TestConvertInvalidJSON(t *testing.T) {
+	if _, err := New().ConvertString(`{"spanId": `); err == nil {
+		t.Errorf("Expect error when the json is invalid")
+	}
+
+	// non-string attribute values are skipped.
+	if _, err := New().ConvertString(`{"attributesMap": {"git.clone.shallow": false}}`); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestConvertConcurrent(t *testing.T) {
+	converter := New(WithConfigFile("./convertTestFiles/mapping/mapping.yaml"))
+	want, err := converter.ConvertFile("./convertTestFiles/mapping/deploy.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		go func() {
+			got, err := converter.ConvertFile("./convertTestFiles/mapping/deploy.json")
+			if err == nil && string(got) != string(want) {
+				err = fmt.Errorf("unexpected output")
+			}
+			errs <- err
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		if err := <-errs; err != nil {
+			t.Error(err)
+		}
+	}
+}
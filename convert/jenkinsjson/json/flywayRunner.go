@@ -18,7 +18,10 @@ var JenkinsToFlywayRunnerParamMapperList = []JenkinsToDroneParamMapper{
 
 func ConvertFlywayRunner(node Node, variables map[string]string) *harness.Step {
 	step := GetStepUsingParameterMapDelegate(&node, JenkinsToFlywayRunnerParamMapperList, FlywayRunnerPluginImage)
-	tmpStepPlugin := step.Spec.(*harness.StepPlugin)
+	tmpStepPlugin, ok := step.Spec.(*harness.StepPlugin)
+	if !ok || tmpStepPlugin == nil {
+		return step
+	}
 	tmpStepPlugin.With["username"] = "<+input>"
 	tmpStepPlugin.With["password"] = "<+input>"
 
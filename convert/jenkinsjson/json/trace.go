@@ -0,0 +1,432 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Trace formats.
+const (
+	FormatNode   = "node"
+	FormatOTLP   = "otlp"
+	FormatJaeger = "jaeger"
+	FormatZipkin = "zipkin"
+)
+
+// DetectFormat returns the format of the trace. The trace
+// is either a pre-nested Node tree, or a trace exported by
+// the Jenkins OpenTelemetry plugin in the OTLP JSON, Jaeger
+// JSON or Zipkin v2 JSON format.
+func DetectFormat(data []byte) string {
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("[")) {
+		return FormatZipkin
+	}
+	keys := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return FormatNode
+	}
+	if _, ok := keys["resourceSpans"]; ok {
+		return FormatOTLP
+	}
+	if _, ok := keys["data"]; ok {
+		return FormatJaeger
+	}
+	return FormatNode
+}
+
+// ParseTrace parses the trace and returns the Node tree.
+// Non-string attribute values are skipped when the trace is
+// a Node tree, and are converted to strings when the trace
+// is exported by the OpenTelemetry plugin.
+func ParseTrace(data []byte) (*Node, error) {
+	switch DetectFormat(data) {
+	case FormatOTLP:
+		return ParseOTLP(data)
+	case FormatJaeger:
+		return ParseJaeger(data)
+	case FormatZipkin:
+		return ParseZipkin(data)
+	}
+	node := new(Node)
+	var typeErr *json.UnmarshalTypeError
+	if err := json.Unmarshal(data, node); err != nil && !errors.As(err, &typeErr) {
+		return nil, err
+	}
+	return node, nil
+}
+
+// span is the format independent representation of an
+// exported span.
+type span struct {
+	traceID    string
+	spanID     string
+	parentID   string
+	name       string
+	start      int64
+	attributes map[string]string
+}
+
+//
+// OTLP JSON
+//
+
+type (
+	otlpExport struct {
+		ResourceSpans []struct {
+			ScopeSpans                  []otlpScopeSpans `json:"scopeSpans"`
+			InstrumentationLibrarySpans []otlpScopeSpans `json:"instrumentationLibrarySpans"`
+		} `json:"resourceSpans"`
+	}
+
+	otlpScopeSpans struct {
+		Spans []otlpSpan `json:"spans"`
+	}
+
+	otlpSpan struct {
+		TraceID           string          `json:"traceId"`
+		SpanID            string          `json:"spanId"`
+		ParentSpanID      string          `json:"parentSpanId"`
+		Name              string          `json:"name"`
+		StartTimeUnixNano json.RawMessage `json:"startTimeUnixNano"`
+		Attributes        []otlpKeyValue  `json:"attributes"`
+	}
+
+	otlpKeyValue struct {
+		Key   string       `json:"key"`
+		Value otlpAnyValue `json:"value"`
+	}
+
+	otlpAnyValue struct {
+		StringValue *string         `json:"stringValue"`
+		BoolValue   *bool           `json:"boolValue"`
+		IntValue    json.RawMessage `json:"intValue"`
+		DoubleValue *float64        `json:"doubleValue"`
+		ArrayValue  *struct {
+			Values []otlpAnyValue `json:"values"`
+		} `json:"arrayValue"`
+		KvlistValue *struct {
+			Values []otlpKeyValue `json:"values"`
+		} `json:"kvlistValue"`
+	}
+)
+
+// ParseOTLP parses a trace in the OTLP JSON format and
+// returns the Node tree.
+func ParseOTLP(data []byte) (*Node, error) {
+	export := new(otlpExport)
+	if err := json.Unmarshal(data, export); err != nil {
+		return nil, err
+	}
+	var spans []*span
+	for _, resource := range export.ResourceSpans {
+		scopes := append(resource.ScopeSpans, resource.InstrumentationLibrarySpans...)
+		for _, scope := range scopes {
+			for _, s := range scope.Spans {
+				attributes := map[string]string{}
+				for _, kv := range s.Attributes {
+					attributes[kv.Key] = attributeString(kv.Value.value())
+				}
+				spans = append(spans, &span{
+					traceID:    otlpID(s.TraceID),
+					spanID:     otlpID(s.SpanID),
+					parentID:   otlpID(s.ParentSpanID),
+					name:       s.Name,
+					start:      rawInt(s.StartTimeUnixNano),
+					attributes: attributes,
+				})
+			}
+		}
+	}
+	return buildTree(spans)
+}
+
+// helper function returns the native value of the OTLP
+// attribute value.
+func (v otlpAnyValue) value() interface{} {
+	switch {
+	case v.StringValue != nil:
+		return *v.StringValue
+	case v.BoolValue != nil:
+		return *v.BoolValue
+	case len(v.IntValue) != 0:
+		return rawInt(v.IntValue)
+	case v.DoubleValue != nil:
+		return *v.DoubleValue
+	case v.ArrayValue != nil:
+		var values []interface{}
+		for _, value := range v.ArrayValue.Values {
+			values = append(values, value.value())
+		}
+		return values
+	case v.KvlistValue != nil:
+		values := map[string]interface{}{}
+		for _, kv := range v.KvlistValue.Values {
+			values[kv.Key] = kv.Value.value()
+		}
+		return values
+	}
+	return nil
+}
+
+// helper function returns the OTLP trace or span id as a
+// hex string. The ids are hex encoded in the OTLP JSON
+// format, but some exporters use base64 encoding.
+func otlpID(s string) string {
+	if _, err := hex.DecodeString(s); err == nil {
+		return s
+	}
+	if b, err := base64.StdEncoding.DecodeString(s); err == nil {
+		return hex.EncodeToString(b)
+	}
+	return s
+}
+
+//
+// Jaeger JSON
+//
+
+type (
+	jaegerExport struct {
+		Data []struct {
+			Spans []jaegerSpan `json:"spans"`
+		} `json:"data"`
+	}
+
+	jaegerSpan struct {
+		TraceID       string `json:"traceID"`
+		SpanID        string `json:"spanID"`
+		ParentSpanID  string `json:"parentSpanID"`
+		OperationName string `json:"operationName"`
+		References    []struct {
+			RefType string `json:"refType"`
+			SpanID  string `json:"spanID"`
+		} `json:"references"`
+		StartTime int64 `json:"startTime"`
+		Tags      []struct {
+			Key   string      `json:"key"`
+			Value interface{} `json:"value"`
+		} `json:"tags"`
+	}
+)
+
+// ParseJaeger parses a trace in the Jaeger JSON format and
+// returns the Node tree.
+func ParseJaeger(data []byte) (*Node, error) {
+	export := new(jaegerExport)
+	if err := json.Unmarshal(data, export); err != nil {
+		return nil, err
+	}
+	var spans []*span
+	for _, trace := range export.Data {
+		for _, s := range trace.Spans {
+			parentID := s.ParentSpanID
+			for _, ref := range s.References {
+				if ref.RefType == "CHILD_OF" {
+					parentID = ref.SpanID
+					break
+				}
+			}
+			attributes := map[string]string{}
+			for _, tag := range s.Tags {
+				attributes[tag.Key] = attributeString(tag.Value)
+			}
+			spans = append(spans, &span{
+				traceID:    s.TraceID,
+				spanID:     s.SpanID,
+				parentID:   parentID,
+				name:       s.OperationName,
+				start:      s.StartTime,
+				attributes: attributes,
+			})
+		}
+	}
+	return buildTree(spans)
+}
+
+//
+// Zipkin v2 JSON
+//
+
+type zipkinSpan struct {
+	TraceID   string            `json:"traceId"`
+	ID        string            `json:"id"`
+	ParentID  string            `json:"parentId"`
+	Name      string            `json:"name"`
+	Timestamp int64             `json:"timestamp"`
+	Tags      map[string]string `json:"tags"`
+}
+
+// ParseZipkin parses a trace in the Zipkin v2 JSON format
+// and returns the Node tree.
+func ParseZipkin(data []byte) (*Node, error) {
+	var export []zipkinSpan
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, err
+	}
+	var spans []*span
+	for _, s := range export {
+		attributes := map[string]string{}
+		for k, v := range s.Tags {
+			attributes[k] = v
+		}
+		spans = append(spans, &span{
+			traceID:    s.TraceID,
+			spanID:     s.ID,
+			parentID:   s.ParentID,
+			name:       s.Name,
+			start:      s.Timestamp,
+			attributes: attributes,
+		})
+	}
+	return buildTree(spans)
+}
+
+//
+// Span tree
+//
+
+// helper function rebuilds the Node tree from the flat
+// list of spans. The root is the earliest span without a
+// parent. Spans with a missing parent are added to the
+// root, and spans of other traces are ignored.
+func buildTree(spans []*span) (*Node, error) {
+	sort.SliceStable(spans, func(i, j int) bool {
+		return spans[i].start < spans[j].start
+	})
+
+	index := map[string]*span{}
+	for _, s := range spans {
+		index[s.spanID] = s
+	}
+
+	var root *span
+	for _, s := range spans {
+		if _, ok := index[s.parentID]; !ok {
+			root = s
+			break
+		}
+	}
+	if root == nil {
+		return nil, errors.New("trace does not contain a root span")
+	}
+
+	children := map[string][]*span{}
+	for _, s := range spans {
+		if s == root || s.traceID != root.traceID {
+			continue
+		}
+		parentID := s.parentID
+		if _, ok := index[parentID]; !ok {
+			parentID = root.spanID
+		}
+		children[parentID] = append(children[parentID], s)
+	}
+
+	// the pipeline name and id are read from the root span
+	// attributes, and are added to every node.
+	name := root.name
+	if pipeline := root.attributes["ci.pipeline.name"]; pipeline != "" {
+		name = pipeline
+		if number := root.attributes["ci.pipeline.run.number"]; number != "" {
+			name = fmt.Sprintf("%s #%s", pipeline, number)
+		}
+	}
+	parent := root.attributes["ci.pipeline.id"]
+
+	var build func(s *span, parentID string) Node
+	build = func(s *span, parentID string) Node {
+		node := Node{
+			Name:          name,
+			Parent:        parent,
+			SpanName:      s.name,
+			SpanId:        s.spanID,
+			ParentSpanId:  parentID,
+			TraceId:       s.traceID,
+			Children:      []Node{},
+			ParameterMap:  spanParameters(s),
+			AttributesMap: s.attributes,
+		}
+		if s != root {
+			node.Type = "Run Phase Span"
+		}
+		for _, child := range children[s.spanID] {
+			node.Children = append(node.Children, build(child, s.spanID))
+		}
+		return node
+	}
+
+	node := build(root, Root_Id)
+	return &node, nil
+}
+
+// helper function returns the step parameters of the span.
+// The parameters are read from the harness-attribute json
+// attribute, if present, and from the jenkins.pipeline.step
+// attributes for stage and node steps.
+func spanParameters(s *span) map[string]interface{} {
+	params := map[string]interface{}{}
+	if attr := s.attributes["harness-attribute"]; attr != "" {
+		json.Unmarshal([]byte(attr), &params)
+	}
+	switch s.attributes["jenkins.pipeline.step.type"] {
+	case "stage":
+		if _, ok := params["name"]; !ok && s.attributes["jenkins.pipeline.step.name"] != "" {
+			params["name"] = s.attributes["jenkins.pipeline.step.name"]
+		}
+	case "node":
+		if _, ok := params["label"]; !ok && s.attributes["jenkins.pipeline.step.agent.label"] != "" {
+			params["label"] = s.attributes["jenkins.pipeline.step.agent.label"]
+		}
+	}
+	return params
+}
+
+// helper function converts the attribute value to a string.
+// Arrays and maps are json encoded.
+func attributeString(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+}
+
+// helper function parses an integer that is encoded as a
+// json number or a json string.
+func rawInt(raw json.RawMessage) int64 {
+	s := strings.Trim(string(raw), `"`)
+	i, _ := strconv.ParseInt(s, 10, 64)
+	return i
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseTrace(t *testing.T) {
+	// the span tree is flattened to one line per span, in
+	// depth first order. The span names are lower case
+	// since zipkin does not preserve the case.
+	want := []string{
+		"build hello-world 5c1e0a9d3b7f2e41 0000000000000000",
+		"  phase: start 8f3a6b2c9d104e57 5c1e0a9d3b7f2e41",
+		"  phase: run 2d7e4f1a6c8b3e90 5c1e0a9d3b7f2e41",
+		"    agent e4b19c7a2f5d6031 2d7e4f1a6c8b3e90 node label=linux",
+		"      stage: build 71c8d2e5a9f04b36 e4b19c7a2f5d6031 stage name=Build",
+		"        checkout: github.com/acme/hello b3f60e8d1c7a2954 71c8d2e5a9f04b36 checkout scm=map[$class:GitSCM branches:[map[name:*/main]] userRemoteConfigs:[map[url:https://github.com/acme/hello.git]]]",
+		"        sh 0a9d5c3e7b2f8146 71c8d2e5a9f04b36 sh script=make build",
+		"      stage: test c6e2a7f1d94b0835 e4b19c7a2f5d6031 stage name=Test",
+		"        sh 39b7d1f6e0c5a482 c6e2a7f1d94b0835 sh script=make test",
+		"  phase: finalise f0d4c8a2b6e19357 5c1e0a9d3b7f2e41",
+	}
+
+	tests := []struct {
+		file   string
+		format string
+	}{
+		{"otlp", FormatOTLP},
+		{"jaeger", FormatJaeger},
+		{"zipkin", FormatZipkin},
+	}
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			data, err := os.ReadFile("../convertTestFiles/trace/" + test.file + ".json")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := DetectFormat(data); got != test.format {
+				t.Errorf("Want format %q, got %q", test.format, got)
+			}
+			node, err := ParseTrace(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := node.Name, "hello-world #12"; got != want {
+				t.Errorf("Want name %q, got %q", want, got)
+			}
+			if diff := cmp.Diff(want, flattenTrace(node, 0)); diff != "" {
+				t.Errorf("Unexpected span tree")
+				t.Log(diff)
+			}
+
+			// non-string attribute values are converted
+			// to strings.
+			checkout := node.Children[1].Children[0].Children[0].Children[0]
+			if got, want := checkout.AttributesMap["git.clone.shallow"], "false"; got != want {
+				t.Errorf("Want attribute %q, got %q", want, got)
+			}
+			if got, want := checkout.AttributesMap["git.clone.depth"], "0"; got != want {
+				t.Errorf("Want attribute %q, got %q", want, got)
+			}
+			if got, want := checkout.Parent, "hello-world"; got != want {
+				t.Errorf("Want parent %q, got %q", want, got)
+			}
+		})
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		data   string
+		format string
+	}{
+		{`{"spanId":"5c1e0a9d3b7f2e41","children":[]}`, FormatNode},
+		{`{"resourceSpans":[]}`, FormatOTLP},
+		{`{"data":[{"traceID":"4bf92f3577b34da6","spans":[]}]}`, FormatJaeger},
+		{` [{"traceId":"4bf92f3577b34da6","id":"5c1e0a9d3b7f2e41"}]`, FormatZipkin},
+		{`not json`, FormatNode},
+	}
+	for _, test := range tests {
+		if got := DetectFormat([]byte(test.data)); got != test.format {
+			t.Errorf("Want format %q for %s, got %q", test.format, test.data, got)
+		}
+	}
+}
+
+func TestOTLPID(t *testing.T) {
+	if got, want := otlpID("5c1e0a9d3b7f2e41"), "5c1e0a9d3b7f2e41"; got != want {
+		t.Errorf("Want hex id %q, got %q", want, got)
+	}
+	if got, want := otlpID("XB4KnTt/LkE="), "5c1e0a9d3b7f2e41"; got != want {
+		t.Errorf("Want base64 id decoded to %q, got %q", want, got)
+	}
+}
+
+// helper function flattens the span tree.
+func flattenTrace(node *Node, depth int) []string {
+	line := []string{strings.ToLower(node.SpanName), node.SpanId, node.ParentSpanId}
+	if kind := node.AttributesMap["jenkins.pipeline.step.type"]; kind != "" {
+		line = append(line, kind)
+	}
+	for k, v := range node.ParameterMap {
+		line = append(line, fmt.Sprintf("%s=%v", k, v))
+	}
+	lines := []string{strings.Repeat("  ", depth) + strings.Join(line, " ")}
+	for i := range node.Children {
+		lines = append(lines, flattenTrace(&node.Children[i], depth+1)...)
+	}
+	return lines
+}
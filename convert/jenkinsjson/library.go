@@ -0,0 +1,166 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jenkinsjson
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/drone/go-convert/convert/jenkins"
+	"github.com/drone/go-convert/convert/jenkins/jenkinsfile"
+	jenkinsjson "github.com/drone/go-convert/convert/jenkinsjson/json"
+	harness "github.com/drone/spec/dist/go"
+)
+
+// helper function converts the span of a shared library
+// global step. The step is converted by the Jenkinsfile
+// converter, from a call with the span parameters, and
+// replaces the span, including its children. The steps
+// are inlined in a step group, or the call is converted
+// to a template step if the library is converted to
+// templates.
+func (d *Converter) convertLibraryStep(node jenkinsjson.Node, stepType string) (*harness.Step, error) {
+	options := []jenkins.Option{}
+	for _, path := range d.libraryPaths {
+		options = append(options, jenkins.WithLibrary(path))
+	}
+	if d.libraryTmpl {
+		options = append(options, jenkins.WithLibraryTemplates())
+	}
+
+	script := fmt.Sprintf("pipeline {\n    agent any\n    stages {\n        stage('library') {\n            steps {\n                %s(%s)\n            }\n        }\n    }\n}\n", stepType, groovyArgs(node.ParameterMap))
+	out, err := jenkins.New(options...).ConvertString(script)
+	if err != nil {
+		return nil, err
+	}
+	config, err := harness.ParseBytes(out)
+	if err != nil {
+		return nil, err
+	}
+	pipeline, ok := config.Spec.(*harness.Pipeline)
+	if !ok || len(pipeline.Stages) == 0 {
+		return nil, fmt.Errorf("shared library step %s is not converted", stepType)
+	}
+	stage, ok := pipeline.Stages[0].Spec.(*harness.StageCI)
+	if !ok || len(stage.Steps) == 0 {
+		return nil, fmt.Errorf("shared library step %s is not converted", stepType)
+	}
+
+	name := jenkinsjson.SanitizeForName(node.SpanName)
+	id := jenkinsjson.SanitizeForId(node.SpanName, node.SpanId)
+	if len(stage.Steps) == 1 && stage.Steps[0].Type == "template" {
+		step := stage.Steps[0]
+		step.Name, step.Id = name, id
+		return step, nil
+	}
+	setLibraryIds(stage.Steps, node.SpanId)
+	return &harness.Step{
+		Name: name,
+		Id:   id,
+		Type: "group",
+		Spec: &harness.StepGroup{
+			Steps: stage.Steps,
+		},
+	}, nil
+}
+
+// helper function sets the identifiers of the inlined
+// shared library steps. The step names are unique in the
+// converted library step, and are suffixed with the span
+// id to be unique in the pipeline.
+func setLibraryIds(steps []*harness.Step, spanID string) {
+	for _, step := range steps {
+		if step == nil {
+			continue
+		}
+		step.Id = jenkinsjson.SanitizeForId(step.Name, spanID)
+		switch spec := step.Spec.(type) {
+		case *harness.StepGroup:
+			setLibraryIds(spec.Steps, spanID)
+		case *harness.StepParallel:
+			setLibraryIds(spec.Steps, spanID)
+		}
+	}
+}
+
+// helper function returns true if the step type is a
+// shared library global step.
+func (d *Converter) isLibraryStep(stepType string) bool {
+	if d.library == nil {
+		return false
+	}
+	v, ok := d.library.Vars[stepType]
+	// steps that declare the complete pipeline cannot be
+	// converted from a span.
+	return ok && v.Call() != nil && v.Pipeline() == nil
+}
+
+// helper function parses the shared library, if
+// configured and not already parsed.
+func (d *Converter) loadLibrary() error {
+	if len(d.libraryPaths) == 0 || d.library != nil {
+		return nil
+	}
+	library, err := jenkinsfile.ParseLibrary(d.libraryPaths...)
+	if err != nil {
+		return fmt.Errorf("failed to parse the shared library: %w", err)
+	}
+	d.library = library
+	return nil
+}
+
+// helper function returns the span parameters as groovy
+// named arguments.
+func groovyArgs(params map[string]interface{}) string {
+	var keys []string
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var args []string
+	for _, key := range keys {
+		args = append(args, key+": "+groovyValue(params[key]))
+	}
+	return strings.Join(args, ", ")
+}
+
+// helper function returns the value as a groovy literal.
+func groovyValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`).Replace(v) + "'"
+	case []interface{}:
+		var items []string
+		for _, item := range v {
+			items = append(items, groovyValue(item))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return "[:]"
+		}
+		return "[" + groovyArgs(v) + "]"
+	default:
+		return groovyValue(fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jenkinsjson
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"text/template"
+
+	jenkinsjson "github.com/drone/go-convert/convert/jenkinsjson/json"
+	harness "github.com/drone/spec/dist/go"
+	"gopkg.in/yaml.v2"
+)
+
+// Config defines the user-defined step mappings, loaded
+// from the configuration file.
+//
+//	steps:
+//	- step: deployToOurPlatform
+//	  image: registry.acme.com/plugins/deploy:1
+//	  rename:
+//	    env: environment
+//	  defaults:
+//	    region: us-east-1
+//	  with:
+//	    target: "{{ .app }}-{{ .environment }}"
+type Config struct {
+	Steps []*StepMapping `yaml:"steps"`
+}
+
+// StepMapping maps a Jenkins step to a Harness plugin step
+// or step template. The step parameters are passed to the
+// plugin settings, or to the template inputs.
+type StepMapping struct {
+	// Step is the Jenkins step name, for example
+	// deployToOurPlatform.
+	Step string `yaml:"step"`
+
+	// Name is the optional name of the converted step.
+	// The span name is used by default.
+	Name string `yaml:"name"`
+
+	// Image is the plugin image.
+	Image string `yaml:"image"`
+
+	// Uses is the plugin name.
+	Uses string `yaml:"uses"`
+
+	// Template is the step template name.
+	Template string `yaml:"template"`
+
+	// Rename renames the step parameters.
+	Rename map[string]string `yaml:"rename"`
+
+	// Defaults are the default parameter values, used when
+	// the parameter is not set.
+	Defaults map[string]interface{} `yaml:"defaults"`
+
+	// With are parameters expanded from Go templates. The
+	// template data is the step parameters, after the
+	// parameters are renamed and the defaults are applied.
+	With map[string]string `yaml:"with"`
+}
+
+// ParseConfig parses the step mapping configuration.
+func ParseConfig(b []byte) (*Config, error) {
+	config := new(Config)
+	if err := yaml.Unmarshal(b, config); err != nil {
+		return nil, err
+	}
+	for _, mapping := range config.Steps {
+		if err := mapping.validate(); err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
+}
+
+// ParseConfigFile parses the step mapping configuration
+// file.
+func ParseConfigFile(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfig(b)
+}
+
+// helper function validates the step mapping.
+func (m *StepMapping) validate() error {
+	switch m.Step {
+	case "":
+		return fmt.Errorf("step mapping is missing the step name")
+	case "node", "stage", "parallel":
+		return fmt.Errorf("step mapping cannot map the %s step", m.Step)
+	}
+
+	var targets int
+	for _, target := range []string{m.Image, m.Uses, m.Template} {
+		if target != "" {
+			targets++
+		}
+	}
+	if m.Template != "" && targets > 1 {
+		return fmt.Errorf("step mapping %s cannot define a template and a plugin", m.Step)
+	}
+	if targets == 0 {
+		return fmt.Errorf("step mapping %s is missing the image, uses or template", m.Step)
+	}
+
+	for key, text := range m.With {
+		if _, err := template.New(key).Parse(text); err != nil {
+			return fmt.Errorf("step mapping %s: %w", m.Step, err)
+		}
+	}
+	return nil
+}
+
+// helper function converts the step node to a Harness
+// step using the step mapping.
+func (m *StepMapping) convert(node jenkinsjson.Node) (*harness.Step, error) {
+	params := map[string]interface{}{}
+	for key, value := range node.ParameterMap {
+		if rename, ok := m.Rename[key]; ok {
+			key = rename
+		}
+		params[key] = value
+	}
+	for key, value := range m.Defaults {
+		if _, ok := params[key]; !ok {
+			params[key] = value
+		}
+	}
+
+	// expand the templates using the parameters, before
+	// the expanded values are added to the parameters.
+	expanded := map[string]interface{}{}
+	for key, text := range m.With {
+		t, err := template.New(key).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, err
+		}
+		buf := new(bytes.Buffer)
+		if err := t.Execute(buf, params); err != nil {
+			return nil, fmt.Errorf("step mapping %s: %w", m.Step, err)
+		}
+		expanded[key] = buf.String()
+	}
+	for key, value := range expanded {
+		params[key] = value
+	}
+
+	name := m.Name
+	if name == "" {
+		name = node.SpanName
+	}
+	step := &harness.Step{
+		Name: jenkinsjson.SanitizeForName(name),
+		Id:   jenkinsjson.SanitizeForId(name, node.SpanId),
+	}
+	if m.Template != "" {
+		step.Type = "template"
+		step.Spec = &harness.StepTemplate{
+			Name:   m.Template,
+			Inputs: params,
+		}
+	} else {
+		step.Type = "plugin"
+		step.Spec = &harness.StepPlugin{
+			Image: m.Image,
+			Uses:  m.Uses,
+			With:  params,
+		}
+	}
+	return step, nil
+}
+
+// helper function loads the step mappings from the
+// configuration file, if configured.
+func (d *Converter) loadConfig() error {
+	if d.configFile == "" || d.mappings != nil {
+		return nil
+	}
+	config, err := ParseConfigFile(d.configFile)
+	if err != nil {
+		return fmt.Errorf("failed to parse the config file: %w", err)
+	}
+	d.mappings = map[string]*StepMapping{}
+	for _, mapping := range config.Steps {
+		d.mappings[mapping.Step] = mapping
+	}
+	return nil
+}
@@ -0,0 +1,467 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jenkinsjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	jenkinsjson "github.com/drone/go-convert/convert/jenkinsjson/json"
+	harness "github.com/drone/spec/dist/go"
+
+	"github.com/ghodss/yaml"
+)
+
+// ConvertTraces converts multiple execution traces of the
+// same pipeline to a single Harness pipeline.
+//
+// A single run only executes one side of each condition,
+// so the traces are merged before they are converted.
+// Stages are aligned by name and steps are aligned by
+// their position in the trace. Stages and steps that are
+// only executed in some runs are converted with a when
+// clause that compares the branch, or a pipeline
+// parameter, that differs between the runs.
+func (d *Converter) ConvertTraces(traces ...io.Reader) ([]byte, error) {
+	out, _, err := d.ConvertTracesWithReport(traces...)
+	return out, err
+}
+
+// ConvertTracesWithReport converts multiple execution
+// traces of the same pipeline to a single Harness
+// pipeline, and returns the converted pipeline with a
+// report of the converted, approximated, skipped and
+// failed steps of the merged trace.
+func (d *Converter) ConvertTracesWithReport(traces ...io.Reader) ([]byte, *Report, error) {
+	if len(traces) == 0 {
+		return nil, nil, errors.New("no traces to convert")
+	}
+
+	var nodes []*jenkinsjson.Node
+	for i, r := range traces {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		node, err := jenkinsjson.ParseTrace(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse trace %d: %w", i+1, err)
+		}
+		nodes = append(nodes, node)
+	}
+
+	c, err := d.begin()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the conditions are applied to the converted steps
+	// by span id.
+	root, conditions := mergeTraces(nodes)
+	c.conditions = conditions
+
+	config := c.convertNode(root)
+	if err := c.finish(); err != nil {
+		return nil, c.report, err
+	}
+
+	// the json compatible encoder omits an empty runtime
+	// spec, which is required when the yaml is parsed.
+	if pipeline, ok := config.Spec.(*harness.Pipeline); ok {
+		for _, stage := range pipeline.Stages {
+			if ci, ok := stage.Spec.(*harness.StageCI); ok && ci.Runtime != nil && ci.Runtime.Spec == nil {
+				switch ci.Runtime.Type {
+				case "kubernetes":
+					ci.Runtime.Spec = new(harness.RuntimeKube)
+				case "cloud":
+					ci.Runtime.Spec = new(harness.RuntimeCloud)
+				}
+			}
+		}
+	}
+
+	// the when clause implements the json.Marshaler
+	// interface, which is not supported by the yaml.v2
+	// encoder, so the pipeline is encoded with the json
+	// compatible yaml encoder.
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, c.report, nil
+}
+
+// ConvertTraceFiles converts multiple execution trace
+// files of the same pipeline to a single Harness pipeline.
+func (d *Converter) ConvertTraceFiles(paths ...string) ([]byte, error) {
+	var traces []io.Reader
+	for _, path := range paths {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		traces = append(traces, bytes.NewReader(b))
+	}
+	return d.ConvertTraces(traces...)
+}
+
+// run describes the attributes of a pipeline run that
+// determine which stages and steps are executed.
+type run struct {
+	branch string
+	params map[string]string
+}
+
+// traceNode is a node in the merged trace. The runs
+// slice records the runs in which the node was executed.
+type traceNode struct {
+	node     jenkinsjson.Node
+	children []*traceNode
+	runs     []bool
+}
+
+// mergeTraces merges the traces of multiple pipeline runs
+// and returns the merged trace, and the when clauses for
+// the spans that were not executed in every run, keyed by
+// span id.
+func mergeTraces(nodes []*jenkinsjson.Node) (*jenkinsjson.Node, map[string]*harness.When) {
+	var runs []*run
+	var root *traceNode
+	for i, node := range nodes {
+		runs = append(runs, newRun(node))
+		next := newTraceNode(node, i, len(nodes))
+		if root == nil {
+			root = next
+			continue
+		}
+		root.runs[i] = true
+		root.children = mergeTraceNodes(root.children, next.children, i)
+	}
+
+	m := &traceMerge{
+		runs:       runs,
+		conditions: map[string]*harness.When{},
+	}
+	node := m.build(root, root.runs)
+	return &node, m.conditions
+}
+
+// helper function returns the trace node for the node
+// executed in the run.
+func newTraceNode(node *jenkinsjson.Node, index, total int) *traceNode {
+	t := &traceNode{
+		node: *node,
+		runs: make([]bool, total),
+	}
+	t.node.Children = nil
+	t.runs[index] = true
+
+	// the trace does not list the children in the order
+	// of execution, so they are sorted by step id.
+	children := make([]*traceNode, 0, len(node.Children))
+	for i := range node.Children {
+		children = append(children, newTraceNode(&node.Children[i], index, total))
+	}
+	sort.SliceStable(children, func(i, j int) bool {
+		return minStepID(&children[i].node, children[i].children) < minStepID(&children[j].node, children[j].children)
+	})
+	t.children = children
+	return t
+}
+
+// mergeTraceNodes merges the child nodes from the run
+// into the merged child nodes. The nodes are aligned by
+// the longest common subsequence of their keys, which
+// preserves the order of the steps in both lists.
+func mergeTraceNodes(a, b []*traceNode, index int) []*traceNode {
+	n, m := len(a), len(b)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if traceKey(a[i]) == traceKey(b[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var out []*traceNode
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case traceKey(a[i]) == traceKey(b[j]):
+			a[i].runs[index] = true
+			a[i].children = mergeTraceNodes(a[i].children, b[j].children, index)
+			out = append(out, a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, a[i])
+			i++
+		default:
+			out = append(out, b[j])
+			j++
+		}
+	}
+	out = append(out, a[i:]...)
+	out = append(out, b[j:]...)
+	return out
+}
+
+// helper function returns the key used to align the
+// nodes. Stages are aligned by name, and steps are
+// aligned by type.
+func traceKey(t *traceNode) string {
+	switch stepType := t.node.AttributesMap["jenkins.pipeline.step.type"]; stepType {
+	case "stage":
+		return "stage/" + traceStageName(t)
+	case "":
+		return "span/" + t.node.SpanName
+	default:
+		return "step/" + stepType
+	}
+}
+
+// helper function returns the stage name from the node,
+// or the first child node that defines the name.
+func traceStageName(t *traceNode) string {
+	if name, ok := t.node.ParameterMap["name"].(string); ok {
+		return name
+	}
+	if name, ok := t.node.AttributesMap["jenkins.pipeline.step.name"]; ok {
+		return name
+	}
+	for _, child := range t.children {
+		if name := traceStageName(child); name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// helper function returns the lowest step id in the
+// subtree, which is used to sort the nodes in the order
+// of execution.
+func minStepID(node *jenkinsjson.Node, children []*traceNode) int {
+	min := int(^uint(0) >> 1)
+	if id, err := strconv.Atoi(node.AttributesMap["jenkins.pipeline.step.id"]); err == nil {
+		min = id
+	}
+	for _, child := range children {
+		if id := minStepID(&child.node, child.children); id < min {
+			min = id
+		}
+	}
+	return min
+}
+
+// traceMerge builds the merged trace.
+type traceMerge struct {
+	runs       []*run
+	conditions map[string]*harness.When
+	next       int
+}
+
+// build returns the merged node. The step ids are
+// renumbered in the order of the merged trace, since
+// the converter sorts the steps by id.
+func (m *traceMerge) build(t *traceNode, parent []bool) jenkinsjson.Node {
+	node := t.node
+	if _, ok := node.AttributesMap["jenkins.pipeline.step.id"]; ok {
+		attrs := make(map[string]string, len(node.AttributesMap))
+		for k, v := range node.AttributesMap {
+			attrs[k] = v
+		}
+		m.next++
+		attrs["jenkins.pipeline.step.id"] = strconv.Itoa(m.next)
+		node.AttributesMap = attrs
+	}
+
+	// nodes that were executed in the same runs as the
+	// parent node inherit the parent node condition.
+	if !equalRuns(t.runs, parent) {
+		if when := m.when(t.runs); when != nil {
+			m.conditions[node.SpanId] = when
+		}
+	}
+
+	for _, child := range t.children {
+		node.Children = append(node.Children, m.build(child, t.runs))
+	}
+	return node
+}
+
+// when returns the when clause for the node executed in
+// the runs. The branch, and then the pipeline parameters,
+// are compared to find an attribute whose values in the
+// runs that executed the node differ from the values in
+// the runs that did not. If no attribute explains the
+// difference, a nil value is returned.
+func (m *traceMerge) when(runs []bool) *harness.When {
+	if expr := m.compare(runs, "<+codebase.branch>", func(r *run) (string, bool) {
+		return r.branch, r.branch != ""
+	}); expr != "" {
+		return &harness.When{Eval: expr}
+	}
+
+	var names []string
+	for _, r := range m.runs {
+		for name := range r.params {
+			if !containsString(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if expr := m.compare(runs, "<+inputs."+name+">", func(r *run) (string, bool) {
+			v, ok := r.params[name]
+			return v, ok
+		}); expr != "" {
+			return &harness.When{Eval: expr}
+		}
+	}
+	return nil
+}
+
+// compare returns an expression that matches the values
+// of the attribute in the runs that executed the node,
+// if the values do not overlap with the values in the
+// runs that did not.
+func (m *traceMerge) compare(runs []bool, variable string, value func(*run) (string, bool)) string {
+	var in, out []string
+	for i, r := range m.runs {
+		v, ok := value(r)
+		if !ok {
+			return ""
+		}
+		if runs[i] {
+			if !containsString(in, v) {
+				in = append(in, v)
+			}
+		} else if !containsString(out, v) {
+			out = append(out, v)
+		}
+	}
+	for _, v := range in {
+		if containsString(out, v) {
+			return ""
+		}
+	}
+	var exprs []string
+	for _, v := range in {
+		exprs = append(exprs, fmt.Sprintf("%s == %q", variable, v))
+	}
+	return strings.Join(exprs, " || ")
+}
+
+// helper function returns the run attributes from the
+// trace. The branch is read from the git.branch span
+// attribute. The parameters are read from the root span
+// parameters, or the ci.pipeline.parameter attributes.
+func newRun(node *jenkinsjson.Node) *run {
+	r := &run{
+		branch: traceBranch(node),
+		params: map[string]string{},
+	}
+	for k, v := range node.ParameterMap {
+		switch v.(type) {
+		case string, bool, float64, int:
+			r.params[k] = fmt.Sprint(v)
+		}
+	}
+	var names, values []string
+	if json.Unmarshal([]byte(node.AttributesMap["ci.pipeline.parameter.name"]), &names) == nil &&
+		json.Unmarshal([]byte(node.AttributesMap["ci.pipeline.parameter.value"]), &values) == nil {
+		for i, name := range names {
+			if i < len(values) {
+				r.params[name] = values[i]
+			}
+		}
+	}
+	return r
+}
+
+// helper function returns the branch name from the first
+// span with a git.branch attribute.
+func traceBranch(node *jenkinsjson.Node) string {
+	if branch := node.AttributesMap["git.branch"]; branch != "" {
+		branch = strings.TrimPrefix(branch, "refs/heads/")
+		branch = strings.TrimPrefix(branch, "origin/")
+		branch = strings.TrimPrefix(branch, "*/")
+		return branch
+	}
+	for i := range node.Children {
+		if branch := traceBranch(&node.Children[i]); branch != "" {
+			return branch
+		}
+	}
+	return ""
+}
+
+// applyCondition sets the when clause of the steps
+// converted from the span, if the span was not executed
+// in every run. Steps that define a when clause are not
+// modified, since the child span condition is more
+// specific than the parent span condition.
+func (d *Converter) applyCondition(spanID string, steps *[]StepWithID, from int) {
+	when, ok := d.conditions[spanID]
+	if !ok {
+		return
+	}
+	for _, step := range (*steps)[from:] {
+		if step.Step != nil && step.Step.When == nil {
+			step.Step.When = when
+		}
+	}
+}
+
+// helper function returns true if the nodes were
+// executed in the same runs.
+func equalRuns(a, b []bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// helper function returns true if the string is in
+// the slice.
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
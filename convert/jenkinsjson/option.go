@@ -61,15 +61,45 @@ func WithUseIntelligence(useIntelligence bool) Option {
 	}
 }
 
+// WithConfigFile returns an option to set the step mapping
+// configuration file.
 func WithConfigFile(configFile string) Option {
 	return func(d *Converter) {
 		d.configFile = configFile
 	}
 }
 
+// WithStrict returns an option to fail the conversion if
+// the pipeline includes unsupported steps.
+func WithStrict(strict bool) Option {
+	return func(d *Converter) {
+		d.strict = strict
+	}
+}
+
 // WithDisableConversionForSteps returns an option to set the list of step types to disable conversion for.
 func WithDisableConversionForSteps(disableConversionForSteps string) Option {
 	return func(d *Converter) {
 		d.disableConversionForSteps = disableConversionForSteps
 	}
 }
+
+// WithLibrary returns an option to convert the steps that
+// call the global steps declared in the vars directory of
+// the shared library checkout. The option can be repeated
+// to configure multiple libraries.
+func WithLibrary(path string) Option {
+	return func(d *Converter) {
+		d.libraryPaths = append(d.libraryPaths, path)
+	}
+}
+
+// WithLibraryTemplates returns an option to convert the
+// shared library global steps to template steps, instead
+// of inlining the steps. The templates are generated with
+// the jenkins package Converter.ConvertLibrary.
+func WithLibraryTemplates() Option {
+	return func(d *Converter) {
+		d.libraryTmpl = true
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jenkinsjson
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	jenkinsjson "github.com/drone/go-convert/convert/jenkinsjson/json"
+)
+
+// Report lists the steps in the trace and how each step
+// was converted to the Harness pipeline.
+type Report struct {
+	Steps []*ReportStep `json:"steps"`
+}
+
+// ReportStep describes the conversion of a step.
+type ReportStep struct {
+	// SpanID is the id of the step span.
+	SpanID string `json:"span_id"`
+
+	// Name is the span name.
+	Name string `json:"name"`
+
+	// Type is the Jenkins step type, for example sh.
+	Type string `json:"type"`
+
+	// Status is the conversion status.
+	Status string `json:"status"`
+
+	// Unsupported is true if the step could not be
+	// mapped to a Harness step.
+	Unsupported bool `json:"unsupported,omitempty"`
+
+	// Detail describes how the step was handled.
+	Detail string `json:"detail,omitempty"`
+}
+
+// conversion status.
+const (
+	// StatusConverted indicates the step is converted to
+	// an equivalent Harness step.
+	StatusConverted = "converted"
+
+	// StatusApproximated indicates the step is converted
+	// to a script that emulates the step.
+	StatusApproximated = "approximated"
+
+	// StatusSkipped indicates the step is not converted.
+	StatusSkipped = "skipped"
+
+	// StatusFailed indicates the step mapping or shared
+	// library step failed to convert the step.
+	StatusFailed = "failed"
+)
+
+// Converted returns the converted steps.
+func (r *Report) Converted() []*ReportStep {
+	return r.filter(StatusConverted)
+}
+
+// Approximated returns the approximated steps.
+func (r *Report) Approximated() []*ReportStep {
+	return r.filter(StatusApproximated)
+}
+
+// Skipped returns the skipped steps.
+func (r *Report) Skipped() []*ReportStep {
+	return r.filter(StatusSkipped)
+}
+
+// Failed returns the steps that failed to convert.
+func (r *Report) Failed() []*ReportStep {
+	return r.filter(StatusFailed)
+}
+
+// Unsupported returns the steps that could not be mapped
+// to a Harness step.
+func (r *Report) Unsupported() []*ReportStep {
+	var steps []*ReportStep
+	for _, step := range r.Steps {
+		if step.Unsupported {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+// helper function returns the steps with the status.
+func (r *Report) filter(status string) []*ReportStep {
+	var steps []*ReportStep
+	for _, step := range r.Steps {
+		if step.Status == status {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+// helper function returns an error listing the
+// unsupported and failed steps, if any.
+func (r *Report) strict() error {
+	var errs []string
+	if unsupported := r.Unsupported(); len(unsupported) != 0 {
+		var names []string
+		for _, step := range unsupported {
+			names = append(names, fmt.Sprintf("%s (span %s)", step.Type, step.SpanID))
+		}
+		errs = append(errs, fmt.Sprintf("unsupported steps: %s", strings.Join(names, ", ")))
+	}
+	if failed := r.Failed(); len(failed) != 0 {
+		var names []string
+		for _, step := range failed {
+			names = append(names, fmt.Sprintf("%s (span %s): %s", step.Type, step.SpanID, step.Detail))
+		}
+		errs = append(errs, fmt.Sprintf("failed steps: %s", strings.Join(names, ", ")))
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(errs, "; "))
+}
+
+// script steps are converted to an equivalent script,
+// all other steps that are converted to a script are
+// approximated.
+var scriptSteps = map[string]bool{
+	"sh":                  true,
+	"bat":                 true,
+	"powershell":          true,
+	"pwsh":                true,
+	unifiedBranchedShStep: true,
+}
+
+// helper function adds the step to the conversion report.
+// The status is derived from the converted steps.
+func (d *Converter) reportStep(node jenkinsjson.Node, stepType string, steps []StepWithID) {
+	if d.report == nil || len(steps) == 0 {
+		return
+	}
+	item := &ReportStep{
+		SpanID: node.SpanId,
+		Name:   node.SpanName,
+		Type:   stepType,
+		Status: StatusConverted,
+	}
+	if !scriptSteps[stepType] {
+		for _, step := range steps {
+			if step.Step != nil && step.Step.Type == "script" {
+				item.Status = StatusApproximated
+				item.Detail = "converted to a script step"
+				break
+			}
+		}
+	}
+	d.report.Steps = append(d.report.Steps, item)
+}
+
+// helper function adds the skipped step to the conversion
+// report.
+func (d *Converter) reportSkipped(node jenkinsjson.Node, stepType string, unsupported bool, detail string) {
+	if d.report == nil {
+		return
+	}
+	d.report.Steps = append(d.report.Steps, &ReportStep{
+		SpanID:      node.SpanId,
+		Name:        node.SpanName,
+		Type:        stepType,
+		Status:      StatusSkipped,
+		Unsupported: unsupported,
+		Detail:      detail,
+	})
+}
+
+// helper function adds the step that the step mapping
+// or shared library step failed to convert to the
+// conversion report. The step is then converted
+// by the built-in step conversion, which reports the
+// step again.
+func (d *Converter) reportFailed(node jenkinsjson.Node, stepType string, err error) {
+	if d.report == nil {
+		return
+	}
+	d.report.Steps = append(d.report.Steps, &ReportStep{
+		SpanID: node.SpanId,
+		Name:   node.SpanName,
+		Type:   stepType,
+		Status: StatusFailed,
+		Detail: err.Error(),
+	})
+}
+
+// helper function returns a copy of the converter with
+// the per-conversion state, so that a converter can be
+// used by concurrent conversions.
+func (d *Converter) begin() (*Converter, error) {
+	if err := d.ValidateInfrastructureOptions(); err != nil {
+		return nil, err
+	}
+	c := *d
+	c.report = new(Report)
+	c.conditions = nil
+	if err := c.loadConfig(); err != nil {
+		return nil, err
+	}
+	if err := c.loadLibrary(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// helper function completes the conversion, returning an
+// error if strict mode is enabled and the pipeline
+// includes unsupported steps.
+func (d *Converter) finish() error {
+	if d.strict {
+		return d.report.strict()
+	}
+	return nil
+}
@@ -0,0 +1,631 @@
+// Copyright 2024 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jenkinsxml
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	jenkinsxml "github.com/drone/go-convert/convert/jenkinsxml/xml"
+	"github.com/drone/go-convert/internal/slug"
+	"github.com/drone/go-convert/internal/store"
+	harness "github.com/drone/spec/dist/go"
+
+	"github.com/ghodss/yaml"
+)
+
+// jenkins job types that contain nested jobs or load
+// the Jenkinsfile from source control.
+const (
+	classFolder       = "com.cloudbees.hudson.plugins.folder.Folder"
+	classOrganization = "jenkins.branch.OrganizationFolder"
+	classMultibranch  = "org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject"
+)
+
+// job conversion status.
+const (
+	StatusConverted = "converted"
+	StatusSkipped   = "skipped"
+	StatusFailed    = "failed"
+)
+
+// minimum number of jobs that must share a step before
+// the step is extracted to a template.
+const templateThreshold = 2
+
+// Summary reports the result of a jobs directory
+// conversion. It is written to the summary.json file in
+// the output directory.
+type Summary struct {
+	Counts    SummaryCounts      `json:"counts"`
+	Jobs      []*JobSummary      `json:"jobs,omitempty"`
+	Templates []*TemplateSummary `json:"templates,omitempty"`
+}
+
+// SummaryCounts summarises the job totals per status.
+type SummaryCounts struct {
+	Jobs      int `json:"jobs"`
+	Converted int `json:"converted"`
+	Skipped   int `json:"skipped"`
+	Failed    int `json:"failed"`
+	Templates int `json:"templates"`
+}
+
+// JobSummary reports the result of a job conversion.
+type JobSummary struct {
+	// FilePath is the job config.xml path, relative to
+	// the jobs directory.
+	FilePath string `json:"file_path"`
+
+	// Type is the job type, for example project or
+	// flow-definition.
+	Type string `json:"type,omitempty"`
+
+	// Organization, Project and Pipeline are the harness
+	// identifiers derived from the folder hierarchy.
+	Organization string `json:"organization,omitempty"`
+	Project      string `json:"project,omitempty"`
+	Pipeline     string `json:"pipeline,omitempty"`
+
+	// Output and Triggers are the converted pipeline and
+	// trigger paths, relative to the output directory.
+	Output   string `json:"output,omitempty"`
+	Triggers string `json:"triggers,omitempty"`
+
+	// Status is the conversion status.
+	Status string `json:"status"`
+
+	// Templates lists the step templates used by the
+	// converted pipeline.
+	Templates []string `json:"templates,omitempty"`
+
+	// Messages describe the job configuration that
+	// could not be converted.
+	Messages []string `json:"messages,omitempty"`
+}
+
+// TemplateSummary reports a step template extracted
+// from the steps shared by multiple jobs.
+type TemplateSummary struct {
+	Name   string   `json:"name"`
+	Output string   `json:"output"`
+	Jobs   []string `json:"jobs"`
+}
+
+// bulk conversion job.
+type bulkJob struct {
+	path     string
+	name     string
+	branch   string
+	folders  []string
+	project  *jenkinsxml.Project
+	source   *jenkinsxml.Project
+	config   *harness.Config
+	triggers []byte
+	summary  *JobSummary
+}
+
+// bulk conversion step template.
+type bulkTemplate struct {
+	name string
+	step *harness.Step
+	jobs []*bulkJob
+}
+
+// ConvertDir converts the jobs in the Jenkins jobs
+// directory, for example $JENKINS_HOME/jobs, and writes
+// the converted pipelines to the output directory.
+//
+// Folders and organization folders are walked
+// recursively. The top-level folder is mapped to the
+// harness organization, the second-level folder is
+// mapped to the harness project, and the remaining
+// folders are prefixed to the pipeline identifier. The
+// branch jobs of a multibranch project are converted to
+// one pipeline per branch, using the Jenkinsfile loaded
+// by the latest branch build. Steps shared by multiple
+// jobs are extracted to step templates.
+func (d *Converter) ConvertDir(src, dst string) (*Summary, error) {
+	jobs, err := d.walkJobs(src, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// pipeline identifiers must be unique per project.
+	identifiers := map[string]*store.Identifiers{}
+
+	summary := new(Summary)
+	for _, job := range jobs {
+		org, project, pipeline := job.identifiers()
+		key := org + "/" + project
+		if path, err := filepath.Rel(src, job.path); err == nil {
+			job.summary.FilePath = filepath.ToSlash(path)
+		}
+		if identifiers[key] == nil {
+			identifiers[key] = store.New()
+		}
+		job.summary.Organization = org
+		job.summary.Project = project
+		job.summary.Pipeline = identifiers[key].Generate(pipeline)
+
+		d.convertJob(job)
+		summary.Jobs = append(summary.Jobs, job.summary)
+	}
+
+	templates := extractTemplates(jobs)
+	for _, template := range templates {
+		out, err := yaml.Marshal(&harness.Config{
+			Version: 1,
+			Kind:    "template",
+			Type:    "step",
+			Name:    template.name,
+			Spec: &harness.TemplateStep{
+				Name: template.name,
+				Step: template.step,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		path := filepath.Join("templates", template.name+".yaml")
+		if err := writeFile(filepath.Join(dst, path), out); err != nil {
+			return nil, err
+		}
+		item := &TemplateSummary{
+			Name:   template.name,
+			Output: filepath.ToSlash(path),
+		}
+		for _, job := range template.jobs {
+			item.Jobs = append(item.Jobs, job.summary.FilePath)
+		}
+		summary.Templates = append(summary.Templates, item)
+	}
+
+	for _, job := range jobs {
+		if job.config == nil {
+			continue
+		}
+		out, err := yaml.Marshal(job.config)
+		if err != nil {
+			return nil, err
+		}
+		dir := filepath.Join(job.summary.Organization, job.summary.Project)
+		path := filepath.Join(dir, job.summary.Pipeline+".yaml")
+		if err := writeFile(filepath.Join(dst, path), out); err != nil {
+			return nil, err
+		}
+		job.summary.Output = filepath.ToSlash(path)
+
+		if len(job.triggers) != 0 {
+			path := filepath.Join(dir, job.summary.Pipeline+".triggers.yaml")
+			if err := writeFile(filepath.Join(dst, path), job.triggers); err != nil {
+				return nil, err
+			}
+			job.summary.Triggers = filepath.ToSlash(path)
+		}
+	}
+
+	summary.Counts.Jobs = len(jobs)
+	summary.Counts.Templates = len(templates)
+	for _, job := range jobs {
+		switch job.summary.Status {
+		case StatusConverted:
+			summary.Counts.Converted++
+		case StatusSkipped:
+			summary.Counts.Skipped++
+		case StatusFailed:
+			summary.Counts.Failed++
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(summary); err != nil {
+		return nil, err
+	}
+	if err := writeFile(filepath.Join(dst, "summary.json"), buf.Bytes()); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// walkJobs walks the jobs directory and returns the
+// jobs. Folders and organization folders are walked
+// recursively.
+func (d *Converter) walkJobs(dir string, folders []string, jobs []*bulkJob) ([]*bulkJob, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) && len(folders) != 0 {
+		// the folder does not contain any jobs.
+		return jobs, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name(), "config.xml")
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+
+		job := &bulkJob{
+			path:    path,
+			name:    entry.Name(),
+			folders: folders,
+			summary: new(JobSummary),
+		}
+
+		project, err := jenkinsxml.ParseFile(path)
+		if err != nil {
+			job.summary.Status = StatusFailed
+			job.summary.Messages = append(job.summary.Messages, err.Error())
+			jobs = append(jobs, job)
+			continue
+		}
+		job.project = project
+		job.summary.Type = project.XMLName.Local
+
+		switch project.XMLName.Local {
+		case classFolder, classOrganization:
+			// the folder jobs are stored in the nested
+			// jobs directory.
+			nested := append(append([]string{}, folders...), entry.Name())
+			jobs, err = d.walkJobs(filepath.Join(dir, entry.Name(), "jobs"), nested, jobs)
+			if err != nil {
+				return nil, err
+			}
+		case classMultibranch:
+			// the branch jobs are stored in the nested jobs
+			// directory. The multibranch project is reported
+			// as skipped if the branches are not indexed.
+			branches, err := d.walkBranches(filepath.Join(dir, entry.Name(), "jobs"), job)
+			if err != nil {
+				return nil, err
+			}
+			if len(branches) == 0 {
+				branches = append(branches, job)
+			}
+			jobs = append(jobs, branches...)
+		default:
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
+// walkBranches walks the multibranch project jobs
+// directory and returns the branch jobs. The branch
+// Jenkinsfile is loaded from the latest branch build.
+func (d *Converter) walkBranches(dir string, parent *bulkJob) ([]*bulkJob, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var jobs []*bulkJob
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name(), "config.xml")
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+
+		job := &bulkJob{
+			path:    path,
+			name:    parent.name,
+			branch:  entry.Name(),
+			folders: parent.folders,
+			source:  parent.project,
+			summary: new(JobSummary),
+		}
+		jobs = append(jobs, job)
+
+		project, err := jenkinsxml.ParseFile(path)
+		if err != nil {
+			job.summary.Status = StatusFailed
+			job.summary.Messages = append(job.summary.Messages, err.Error())
+			continue
+		}
+		job.project = project
+		job.summary.Type = project.XMLName.Local
+		if props := project.Properties; props != nil && props.Branch != nil && props.Branch.Name != "" {
+			job.branch = props.Branch.Name
+		}
+
+		script, err := latestScript(filepath.Join(dir, entry.Name(), "builds"))
+		if err != nil {
+			return nil, err
+		}
+		if script != "" {
+			if project.Definition == nil {
+				project.Definition = new(jenkinsxml.Definition)
+			}
+			project.Definition.Script = script
+		}
+	}
+	return jobs, nil
+}
+
+// latestScript returns the Jenkinsfile loaded by the
+// latest pipeline build in the builds directory, or an
+// empty string if no build recorded the Jenkinsfile.
+func latestScript(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	// the build directories are named by build number.
+	var numbers []int
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if number, err := strconv.Atoi(entry.Name()); err == nil {
+			numbers = append(numbers, number)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(numbers)))
+	for _, number := range numbers {
+		build, err := jenkinsxml.ParseBuildFile(filepath.Join(dir, strconv.Itoa(number), "build.xml"))
+		if err != nil {
+			// skip builds that are in progress or
+			// were not recorded.
+			continue
+		}
+		if build.Execution != nil && build.Execution.Script != "" {
+			return build.Execution.Script, nil
+		}
+	}
+	return "", nil
+}
+
+// convertJob converts the job pipeline and triggers.
+func (d *Converter) convertJob(job *bulkJob) {
+	if job.project == nil {
+		return
+	}
+
+	if reason := skipReason(job.project); reason != "" {
+		// report the multibranch project source for the
+		// branch jobs.
+		if job.source != nil {
+			reason = fmt.Sprintf("no build of branch %s recorded the Jenkinsfile: %s", job.branch, skipReason(job.source))
+		}
+		job.summary.Status = StatusSkipped
+		job.summary.Messages = append(job.summary.Messages, reason)
+		return
+	}
+
+	// create a converter for the job, which is used to
+	// convert the triggers for the job pipeline.
+	c := *d
+	c.identifiers = store.New()
+	c.organization = job.summary.Organization
+	c.project = job.summary.Project
+	c.pipeline = job.summary.Pipeline
+
+	ctx := &context{config: job.project}
+	out, err := c.convert(ctx)
+	if err == nil {
+		job.config, err = harness.ParseBytes(out)
+	}
+	if err == nil {
+		job.triggers, err = c.convertTriggers(job.project)
+	}
+	job.summary.Messages = append(job.summary.Messages, ctx.messages...)
+	if err != nil {
+		job.config = nil
+		job.summary.Status = StatusFailed
+		job.summary.Messages = append(job.summary.Messages, err.Error())
+		return
+	}
+	if job.project.Disabled {
+		job.summary.Messages = append(job.summary.Messages, "the job is disabled")
+	}
+	job.summary.Status = StatusConverted
+}
+
+// identifiers returns the harness organization, project
+// and pipeline identifiers for the job.
+func (j *bulkJob) identifiers() (org, project, pipeline string) {
+	org, project = "default", "default"
+	var names []string
+	for i, folder := range j.folders {
+		switch i {
+		case 0:
+			org = identifier(folder)
+		case 1:
+			project = identifier(folder)
+		default:
+			names = append(names, identifier(folder))
+		}
+	}
+	names = append(names, identifier(j.name))
+	if j.branch != "" {
+		names = append(names, identifier(j.branch))
+	}
+	return org, project, strings.Join(names, "_")
+}
+
+// helper function returns the reason the job cannot be
+// converted, or an empty string.
+func skipReason(project *jenkinsxml.Project) string {
+	if project.XMLName.Local == classMultibranch {
+		path := "Jenkinsfile"
+		if project.Factory != nil && project.Factory.ScriptPath != "" {
+			path = project.Factory.ScriptPath
+		}
+		for _, source := range project.Sources {
+			switch {
+			case source.Remote != "":
+				return fmt.Sprintf("the pipeline is loaded from %s in %s, convert the Jenkinsfile instead", path, source.Remote)
+			case source.Repository != "":
+				return fmt.Sprintf("the pipeline is loaded from %s in %s/%s, convert the Jenkinsfile instead", path, source.RepoOwner, source.Repository)
+			}
+		}
+		return fmt.Sprintf("the pipeline is loaded from %s in source control, convert the Jenkinsfile instead", path)
+	}
+	if def := project.Definition; def != nil && def.Script == "" && def.ScriptPath != "" {
+		return fmt.Sprintf("the pipeline is loaded from %s in source control, convert the Jenkinsfile instead", def.ScriptPath)
+	}
+	return ""
+}
+
+// extractTemplates extracts the steps shared by multiple
+// jobs to step templates, and replaces the shared steps
+// with template steps.
+func extractTemplates(jobs []*bulkJob) []*bulkTemplate {
+	var templates []*bulkTemplate
+	index := map[string]*bulkTemplate{}
+
+	// index the steps by their yaml encoding, and record
+	// the jobs that use each step.
+	for _, job := range jobs {
+		walkSteps(job.config, func(step *harness.Step) *harness.Step {
+			key, err := yaml.Marshal(step)
+			if err != nil {
+				return step
+			}
+			template, ok := index[string(key)]
+			if !ok {
+				template = &bulkTemplate{step: step}
+				index[string(key)] = template
+				templates = append(templates, template)
+			}
+			if n := len(template.jobs); n == 0 || template.jobs[n-1] != job {
+				template.jobs = append(template.jobs, job)
+			}
+			return step
+		})
+	}
+
+	// name the shared steps.
+	var shared []*bulkTemplate
+	names := store.New()
+	for _, template := range templates {
+		if len(template.jobs) < templateThreshold {
+			continue
+		}
+		template.name = names.Generate(slug.Create(template.step.Name), "step")
+		shared = append(shared, template)
+	}
+	if len(shared) == 0 {
+		return nil
+	}
+
+	// replace the shared steps with template steps.
+	for _, job := range jobs {
+		walkSteps(job.config, func(step *harness.Step) *harness.Step {
+			key, err := yaml.Marshal(step)
+			if err != nil {
+				return step
+			}
+			template := index[string(key)]
+			if template == nil || template.name == "" {
+				return step
+			}
+			if !containsString(job.summary.Templates, template.name) {
+				job.summary.Templates = append(job.summary.Templates, template.name)
+			}
+			return &harness.Step{
+				Name: step.Name,
+				Type: "template",
+				Spec: &harness.StepTemplate{
+					Name: template.name,
+				},
+			}
+		})
+	}
+	return shared
+}
+
+// helper function calls fn for each step in the
+// pipeline, and replaces the step with the returned
+// step. Group and parallel steps are walked recursively.
+func walkSteps(config *harness.Config, fn func(*harness.Step) *harness.Step) {
+	if config == nil {
+		return
+	}
+	pipeline, ok := config.Spec.(*harness.Pipeline)
+	if !ok {
+		return
+	}
+	for _, stage := range pipeline.Stages {
+		if spec, ok := stage.Spec.(*harness.StageCI); ok {
+			walkStepList(spec.Steps, fn)
+		}
+	}
+}
+
+func walkStepList(steps []*harness.Step, fn func(*harness.Step) *harness.Step) {
+	for i, step := range steps {
+		if step == nil {
+			continue
+		}
+		switch spec := step.Spec.(type) {
+		case *harness.StepGroup:
+			walkStepList(spec.Steps, fn)
+		case *harness.StepParallel:
+			walkStepList(spec.Steps, fn)
+		default:
+			steps[i] = fn(step)
+		}
+	}
+}
+
+// helper function returns the harness identifier for
+// the jenkins job or folder name.
+func identifier(name string) string {
+	if s := slug.Create(name); s != "" {
+		return s
+	}
+	return "default"
+}
+
+// helper function returns true if the string is in
+// the slice.
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// helper function writes the file, creating the parent
+// directories if they do not exist.
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
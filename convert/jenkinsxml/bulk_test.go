@@ -0,0 +1,127 @@
+// Copyright 2024 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jenkinsxml
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	harness "github.com/drone/spec/dist/go"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConvertDir(t *testing.T) {
+	dst := t.TempDir()
+	if _, err := New().ConvertDir("testdata/jobs", dst); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// compare the summary report to the golden file
+	got := map[string]interface{}{}
+	raw, err := ioutil.ReadFile(filepath.Join(dst, "summary.json"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Error(err)
+		return
+	}
+	want := map[string]interface{}{}
+	raw, err = ioutil.ReadFile("testdata/jobs.summary.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err := json.Unmarshal(raw, &want); err != nil {
+		t.Error(err)
+		return
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected summary")
+		t.Log(diff)
+	}
+
+	// the shared step is extracted to a step template
+	config, err := harness.ParseFile(filepath.Join(dst, "templates", "shell.yaml"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	template, ok := config.Spec.(*harness.TemplateStep)
+	if !ok {
+		t.Errorf("Expect step template")
+		return
+	}
+	if got, want := template.Step.Spec.(*harness.StepExec).Run, "make test"; got != want {
+		t.Errorf("Want template command %q, got %q", want, got)
+	}
+
+	// the shared step is replaced with a template step
+	config, err = harness.ParseFile(filepath.Join(dst, "platform", "backend", "api.yaml"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	steps := config.Spec.(*harness.Pipeline).Stages[0].Spec.(*harness.StageCI).Steps
+	if got, want := steps[0].Type, "template"; got != want {
+		t.Errorf("Want step type %q, got %q", want, got)
+	}
+	if got, want := steps[0].Spec.(*harness.StepTemplate).Name, "shell"; got != want {
+		t.Errorf("Want template name %q, got %q", want, got)
+	}
+	if got, want := steps[1].Type, "script"; got != want {
+		t.Errorf("Want step type %q, got %q", want, got)
+	}
+
+	// the multibranch branch job is converted from the
+	// Jenkinsfile loaded by the latest branch build.
+	raw, err = ioutil.ReadFile(filepath.Join(dst, "platform", "default", "web_main.yaml"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !strings.Contains(string(raw), "npm ci") {
+		t.Errorf("Want the latest build Jenkinsfile, got\n%s", raw)
+	}
+}
+
+func TestJobIdentifiers(t *testing.T) {
+	tests := []struct {
+		folders []string
+		name    string
+		branch  string
+		want    []string
+	}{
+		{nil, "hello-world", "", []string{"default", "default", "helloworld"}},
+		{[]string{"Platform"}, "api", "", []string{"platform", "default", "api"}},
+		{[]string{"platform", "backend"}, "api", "", []string{"platform", "backend", "api"}},
+		{[]string{"platform", "backend", "legacy", "v1"}, "api", "", []string{"platform", "backend", "legacy_v1_api"}},
+		{[]string{"platform"}, "web", "feature/login", []string{"platform", "default", "web_featurelogin"}},
+	}
+	for _, test := range tests {
+		job := &bulkJob{name: test.name, branch: test.branch, folders: test.folders}
+		org, project, pipeline := job.identifiers()
+		if diff := cmp.Diff([]string{org, project, pipeline}, test.want); diff != "" {
+			t.Errorf("Unexpected identifiers for %s", test.name)
+			t.Log(diff)
+		}
+	}
+}
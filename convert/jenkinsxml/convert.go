@@ -18,9 +18,14 @@ package jenkinsxml
 import (
 	"bytes"
 	"encoding/xml"
+	"fmt"
 	"io"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
 
+	"github.com/drone/go-convert/convert/jenkins"
 	jenkinsxml "github.com/drone/go-convert/convert/jenkinsxml/xml"
 	"github.com/drone/go-convert/internal/store"
 	harness "github.com/drone/spec/dist/go"
@@ -31,6 +36,10 @@ import (
 // conversion context
 type context struct {
 	config *jenkinsxml.Project
+
+	// messages describe the job configuration that
+	// could not be converted.
+	messages []string
 }
 
 // Converter converts a Jenkins XML file to a Harness
@@ -40,6 +49,11 @@ type Converter struct {
 	kubeNamespace string
 	kubeConnector string
 	dockerhubConn string
+	repoConnector string
+	organization  string
+	project       string
+	pipeline      string
+	libraries     []string
 	identifiers   *store.Identifiers
 }
 
@@ -67,6 +81,18 @@ func New(options ...Option) *Converter {
 		d.kubeEnabled = true
 	}
 
+	// set the default harness organization, project
+	// and pipeline used by converted triggers.
+	if d.organization == "" {
+		d.organization = "default"
+	}
+	if d.project == "" {
+		d.project = "default"
+	}
+	if d.pipeline == "" {
+		d.pipeline = "default"
+	}
+
 	return d
 }
 
@@ -108,8 +134,16 @@ func (d *Converter) ConvertFile(p string) ([]byte, error) {
 // converts converts a Jenkins XML pipeline to a Harness pipeline.
 func (d *Converter) convert(ctx *context) ([]byte, error) {
 
+	// pipeline jobs embed the jenkinsfile, which is
+	// converted by the jenkinsfile converter.
+	if ctx.config.Definition != nil {
+		return d.convertDefinition(ctx)
+	}
+
 	// create the harness pipeline spec
-	dst := &harness.Pipeline{}
+	dst := &harness.Pipeline{
+		Inputs: convertParameters(ctx.config.Properties),
+	}
 
 	// create the harness pipeline resource
 	config := &harness.Config{
@@ -125,8 +159,9 @@ func (d *Converter) convert(ctx *context) ([]byte, error) {
 		Type: "ci",
 		// When: convertCond(from.Trigger),
 		Spec: &harness.StageCI{
+			Clone: convertSCM(ctx.config.SCM),
 			// Delegate: convertNode(from.Node),
-			// Envs: convertVariables(ctx.config.Variables),
+			Envs: convertParameterEnvs(ctx.config.Properties),
 			// Platform: convertPlatform(from.Platform),
 			// Runtime:  convertRuntime(from),
 			Steps: make([]*harness.Step, 0), // Initialize the Steps slice
@@ -135,7 +170,37 @@ func (d *Converter) convert(ctx *context) ([]byte, error) {
 	dst.Stages = append(dst.Stages, dstStage)
 	stageSteps := make([]*harness.Step, 0)
 
-	tasks := ctx.config.Builders.Tasks
+	// convert the matrix-project axes to a matrix
+	// strategy.
+	// label axes select the build machine for each
+	// matrix combination.
+	labelAxis := false
+	if axes := ctx.config.Axes; axes != nil && len(axes.Axes) != 0 {
+		dstStage.Strategy = convertAxes(axes)
+		spec := dstStage.Spec.(*harness.StageCI)
+		for _, axis := range axes.Axes {
+			// label axes select the build machine rather
+			// than a build variable.
+			if isLabelAxis(axis) {
+				if !labelAxis {
+					convertLabelAxis(dstStage, axis)
+					labelAxis = true
+				}
+				continue
+			}
+			// jenkins exposes the axis values to the build
+			// as environment variables.
+			if spec.Envs == nil {
+				spec.Envs = map[string]string{}
+			}
+			spec.Envs[axis.Name] = fmt.Sprintf("<+matrix.%s>", axis.Name)
+		}
+	}
+
+	var tasks []jenkinsxml.Task
+	if ctx.config.Builders != nil {
+		tasks = ctx.config.Builders.Tasks
+	}
 	for _, task := range tasks {
 		step := &harness.Step{}
 
@@ -144,12 +209,49 @@ func (d *Converter) convert(ctx *context) ([]byte, error) {
 			step = convertShellTaskToStep(&task)
 		case "hudson.tasks.Ant":
 			step = convertAntTaskToStep(&task)
+		case "hudson.tasks.BatchFile":
+			step = convertBatchFileTaskToStep(&task)
+			// batch files require a windows build machine,
+			// unless a label axis selects the machine.
+			if !labelAxis {
+				dstStage.Spec.(*harness.StageCI).Platform = &harness.Platform{
+					Os:   harness.OSWindows.String(),
+					Arch: harness.ArchAmd64.String(),
+				}
+			}
+		case "hudson.tasks.Maven":
+			step = convertMavenTaskToStep(&task)
+		case "hudson.plugins.gradle.Gradle":
+			step = convertGradleTaskToStep(&task)
 		default:
 			step = unsupportedTaskToStep(taskname)
+			ctx.messages = append(ctx.messages, "unsupported builder "+taskname)
 		}
 
 		stageSteps = append(stageSteps, step)
 	}
+
+	// convert the publishers, which execute after the
+	// builders.
+	if ctx.config.Publishers != nil {
+		for _, task := range ctx.config.Publishers.Tasks {
+			step := &harness.Step{}
+
+			switch taskname := task.XMLName.Local; taskname {
+			case "hudson.tasks.junit.JUnitResultArchiver":
+				step = convertJUnitTaskToStep(&task)
+			case "hudson.tasks.ArtifactArchiver":
+				step = convertArtifactArchiverTaskToStep(&task)
+			case "hudson.tasks.Mailer":
+				step = convertMailerTaskToStep(&task)
+			default:
+				step = unsupportedTaskToStep(taskname)
+				ctx.messages = append(ctx.messages, "unsupported publisher "+taskname)
+			}
+
+			stageSteps = append(stageSteps, step)
+		}
+	}
 	dstStage.Spec.(*harness.StageCI).Steps = stageSteps
 
 	// marshal the harness yaml
@@ -161,7 +263,81 @@ func (d *Converter) convert(ctx *context) ([]byte, error) {
 	return out, nil
 }
 
-// convertAntTaskToStep converts a Jenkins Ant task to a Harness step.
+// convertDefinition converts the pipeline job definition.
+// The inline Jenkinsfile is converted by the Jenkinsfile
+// converter, and the job parameters are appended to the
+// pipeline inputs.
+func (d *Converter) convertDefinition(ctx *context) ([]byte, error) {
+	def := ctx.config.Definition
+	if def.Script == "" {
+		if def.ScriptPath != "" {
+			return nil, fmt.Errorf("the pipeline is loaded from %s in source control, convert the Jenkinsfile instead", def.ScriptPath)
+		}
+		return nil, fmt.Errorf("unsupported pipeline definition %s", def.Class)
+	}
+
+	options := []jenkins.Option{
+		jenkins.WithDockerhub(d.dockerhubConn),
+		jenkins.WithKubernetes(d.kubeNamespace, d.kubeConnector),
+	}
+	for _, library := range d.libraries {
+		options = append(options, jenkins.WithLibrary(library))
+	}
+	converter := jenkins.New(options...)
+	out, report, err := converter.ConvertWithReport(strings.NewReader(def.Script))
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range report.Unmapped {
+		ctx.messages = append(ctx.messages, formatUnmapped(item))
+	}
+
+	inputs := convertParameters(ctx.config.Properties)
+	if len(inputs) == 0 {
+		return out, nil
+	}
+
+	// parameters defined in the job configuration are
+	// appended to the parameters defined in the
+	// Jenkinsfile.
+	config, err := harness.ParseBytes(out)
+	if err != nil {
+		return nil, err
+	}
+	pipeline, ok := config.Spec.(*harness.Pipeline)
+	if !ok {
+		return out, nil
+	}
+	if pipeline.Inputs == nil {
+		pipeline.Inputs = map[string]*harness.Input{}
+	}
+	for name, input := range inputs {
+		if _, ok := pipeline.Inputs[name]; !ok {
+			pipeline.Inputs[name] = input
+		}
+	}
+	return yaml.Marshal(config)
+}
+
+// helper function formats the unmapped Jenkinsfile
+// construct as a conversion message.
+func formatUnmapped(item *jenkins.Unmapped) string {
+	msg := fmt.Sprintf("unsupported %s %s", item.Kind, item.Name)
+	if item.Stage != "" {
+		msg += fmt.Sprintf(" in stage %s", item.Stage)
+	}
+	if item.File != "" {
+		msg += fmt.Sprintf(" in %s", item.File)
+	}
+	if item.Line != 0 {
+		msg += fmt.Sprintf(" at line %d", item.Line)
+	}
+	if item.Detail != "" {
+		msg += ": " + item.Detail
+	}
+	return msg
+}
+
 func convertAntTaskToStep(task *jenkinsxml.Task) *harness.Step {
 	antTask := &jenkinsxml.HudsonAntTask{}
 	// TODO: wrapping task.Content with 'builders' tags is ugly.
@@ -217,3 +393,366 @@ func unsupportedTaskToStep(task string) *harness.Step {
 
 	return step
 }
+
+// batchScript writes a jenkins batch command to a batch
+// file and runs it with cmd.exe.
+const batchScript = `Set-Content -Path jenkins.bat -Encoding Ascii -Value @'
+%s
+'@
+cmd /c call jenkins.bat
+exit $LASTEXITCODE`
+
+// convertBatchFileTaskToStep converts a Jenkins BatchFile task to a Harness step.
+func convertBatchFileTaskToStep(task *jenkinsxml.Task) *harness.Step {
+	batchTask := &jenkinsxml.HudsonBatchFileTask{}
+	// TODO: wrapping task.Content with 'builders' tags is ugly.
+	err := xml.Unmarshal([]byte("<builders>"+task.Content+"</builders>"), batchTask)
+	if err != nil {
+		return nil
+	}
+
+	// batch commands are cmd.exe syntax, so write them to
+	// a batch file and run it with cmd, as jenkins does.
+	spec := new(harness.StepExec)
+	spec.Run = fmt.Sprintf(batchScript, batchTask.Command)
+	spec.Shell = "powershell"
+	step := &harness.Step{
+		Name: "batch",
+		Type: "script",
+		Spec: spec,
+	}
+
+	return step
+}
+
+func convertMavenTaskToStep(task *jenkinsxml.Task) *harness.Step {
+	mavenTask := &jenkinsxml.HudsonMavenTask{}
+	// TODO: wrapping task.Content with 'builders' tags is ugly.
+	err := xml.Unmarshal([]byte("<builders>"+task.Content+"</builders>"), mavenTask)
+	if err != nil {
+		return nil
+	}
+
+	args := []string{"mvn"}
+	if mavenTask.POM != "" {
+		args = append(args, "-f", mavenTask.POM)
+	}
+	for _, prop := range splitLines(mavenTask.Properties) {
+		args = append(args, "-D"+prop)
+	}
+	if mavenTask.Targets != "" {
+		args = append(args, strings.Fields(mavenTask.Targets)...)
+	}
+
+	spec := new(harness.StepExec)
+	spec.Image = toolImage("maven", mavenTask.MavenName)
+	spec.Run = strings.Join(args, " ")
+	if mavenTask.JVMOptions != "" {
+		spec.Envs = map[string]string{
+			"MAVEN_OPTS": mavenTask.JVMOptions,
+		}
+	}
+	step := &harness.Step{
+		Name: "maven",
+		Type: "script",
+		Spec: spec,
+	}
+
+	return step
+}
+
+func convertGradleTaskToStep(task *jenkinsxml.Task) *harness.Step {
+	gradleTask := &jenkinsxml.GradleTask{}
+	// TODO: wrapping task.Content with 'builders' tags is ugly.
+	err := xml.Unmarshal([]byte("<builders>"+task.Content+"</builders>"), gradleTask)
+	if err != nil {
+		return nil
+	}
+
+	args := []string{"gradle"}
+	if gradleTask.UseWrapper {
+		args[0] = "./gradlew"
+		if v := gradleTask.WrapperLocation; v != "" {
+			args[0] = strings.TrimSuffix(v, "/") + "/gradlew"
+		}
+	}
+	if gradleTask.RootBuildScriptDir != "" {
+		args = append(args, "-p", gradleTask.RootBuildScriptDir)
+	}
+	if gradleTask.BuildFile != "" {
+		args = append(args, "-b", gradleTask.BuildFile)
+	}
+	args = append(args, strings.Fields(gradleTask.Switches)...)
+	args = append(args, strings.Fields(gradleTask.Tasks)...)
+
+	spec := new(harness.StepExec)
+	spec.Image = toolImage("gradle", gradleTask.GradleName)
+	spec.Run = strings.Join(args, " ")
+	step := &harness.Step{
+		Name: "gradle",
+		Type: "script",
+		Spec: spec,
+	}
+
+	return step
+}
+
+func convertJUnitTaskToStep(task *jenkinsxml.Task) *harness.Step {
+	junitTask := &jenkinsxml.JUnitResultArchiver{}
+	// TODO: wrapping task.Content with 'publishers' tags is ugly.
+	err := xml.Unmarshal([]byte("<publishers>"+task.Content+"</publishers>"), junitTask)
+	if err != nil {
+		return nil
+	}
+
+	spec := new(harness.StepExec)
+	spec.Run = "echo 'This Step is to Upload JUNIT Reports'"
+	spec.Reports = []*harness.Report{
+		{
+			Type: "junit",
+			Path: splitList(junitTask.TestResults),
+		},
+	}
+	step := &harness.Step{
+		Name: "junit",
+		Type: "script",
+		// test results are published for successful and
+		// failed builds.
+		When: statusWhen("success", "failure"),
+		Spec: spec,
+	}
+
+	return step
+}
+
+func convertArtifactArchiverTaskToStep(task *jenkinsxml.Task) *harness.Step {
+	archiveTask := &jenkinsxml.ArtifactArchiver{}
+	// TODO: wrapping task.Content with 'publishers' tags is ugly.
+	err := xml.Unmarshal([]byte("<publishers>"+task.Content+"</publishers>"), archiveTask)
+	if err != nil {
+		return nil
+	}
+
+	spec := new(harness.StepPlugin)
+	spec.Image = "plugins/s3"
+	spec.With = map[string]interface{}{
+		"source":     strings.Join(splitList(archiveTask.Artifacts), ","),
+		"bucket":     "<+input>",
+		"access_key": "<+input>",
+		"secret_key": "<+input>",
+	}
+	if archiveTask.Excludes != "" {
+		spec.With["exclude"] = archiveTask.Excludes
+	}
+	step := &harness.Step{
+		Name: "archive",
+		Type: "plugin",
+		Spec: spec,
+	}
+	if !archiveTask.OnlyIfSuccessful {
+		step.When = statusWhen("success", "failure")
+	}
+
+	return step
+}
+
+func convertMailerTaskToStep(task *jenkinsxml.Task) *harness.Step {
+	mailerTask := &jenkinsxml.Mailer{}
+	// TODO: wrapping task.Content with 'publishers' tags is ugly.
+	err := xml.Unmarshal([]byte("<publishers>"+task.Content+"</publishers>"), mailerTask)
+	if err != nil {
+		return nil
+	}
+
+	spec := new(harness.StepPlugin)
+	spec.Image = "plugins/email"
+	spec.With = map[string]interface{}{
+		"recipients":   strings.Join(strings.Fields(mailerTask.Recipients), ","),
+		"host":         "<+input>",
+		"port":         "<+input>",
+		"username":     "<+input>",
+		"password":     "<+input>",
+		"from.address": "<+input>",
+	}
+	step := &harness.Step{
+		Name: "mailer",
+		Type: "plugin",
+		// the mailer notifies the recipients of failed
+		// builds.
+		When: statusWhen("failure"),
+		Spec: spec,
+	}
+
+	return step
+}
+
+// helper function converts the job parameters to
+// pipeline inputs.
+func convertParameters(props *jenkinsxml.Properties) map[string]*harness.Input {
+	if props == nil || props.Parameters == nil || len(props.Parameters.Parameters) == 0 {
+		return nil
+	}
+	inputs := map[string]*harness.Input{}
+	for _, param := range props.Parameters.Parameters {
+		if param.Name == "" {
+			continue
+		}
+		input := &harness.Input{
+			Type:        "string",
+			Description: param.Description,
+		}
+		switch param.XMLName.Local {
+		case "hudson.model.BooleanParameterDefinition":
+			input.Type = "boolean"
+			input.Default, _ = strconv.ParseBool(param.DefaultValue)
+		case "hudson.model.ChoiceParameterDefinition":
+			input.Enum = param.Choices
+			if len(param.Choices) != 0 {
+				input.Default = param.Choices[0]
+			}
+		case "hudson.model.PasswordParameterDefinition":
+			// the default value is encrypted with the
+			// jenkins secret key and is not converted.
+			input.Mask = true
+		default:
+			if param.DefaultValue != "" {
+				input.Default = param.DefaultValue
+			}
+		}
+		inputs[param.Name] = input
+	}
+	return inputs
+}
+
+// helper function returns the job parameters as stage
+// environment variables. Jenkins exposes the parameters
+// to the build as environment variables.
+func convertParameterEnvs(props *jenkinsxml.Properties) map[string]string {
+	if props == nil || props.Parameters == nil || len(props.Parameters.Parameters) == 0 {
+		return nil
+	}
+	envs := map[string]string{}
+	for _, param := range props.Parameters.Parameters {
+		if param.Name != "" {
+			envs[param.Name] = fmt.Sprintf("<+inputs.%s>", param.Name)
+		}
+	}
+	return envs
+}
+
+// helper function converts the job scm to the stage
+// clone configuration.
+func convertSCM(scm *jenkinsxml.SCM) *harness.CloneStage {
+	if scm == nil {
+		return nil
+	}
+	switch scm.Class {
+	case "hudson.scm.NullSCM":
+		// the job does not checkout source code.
+		return &harness.CloneStage{
+			Disabled: true,
+		}
+	case "hudson.plugins.git.GitSCM":
+		if opt := scm.CloneOption; opt != nil && opt.Shallow {
+			depth := opt.Depth
+			if depth == 0 {
+				depth = 1
+			}
+			return &harness.CloneStage{
+				Depth: depth,
+			}
+		}
+	}
+	return nil
+}
+
+// helper function converts the matrix-project axes to a
+// matrix strategy.
+func convertAxes(axes *jenkinsxml.Axes) *harness.Strategy {
+	axis := map[string][]string{}
+	for _, v := range axes.Axes {
+		axis[v.Name] = v.Values
+	}
+	return &harness.Strategy{
+		Type: "matrix",
+		Spec: &harness.Matrix{
+			Axis: axis,
+		},
+	}
+}
+
+// helper function returns true if the axis selects the
+// build machine by label.
+func isLabelAxis(axis jenkinsxml.Axis) bool {
+	switch axis.XMLName.Local {
+	case "hudson.matrix.LabelAxis", "hudson.matrix.LabelExpAxis":
+		return true
+	}
+	return false
+}
+
+// helper function maps a label axis to the stage platform
+// when every label names an operating system, and to the
+// delegate selector otherwise.
+func convertLabelAxis(stage *harness.Stage, axis jenkinsxml.Axis) {
+	value := fmt.Sprintf("<+matrix.%s>", axis.Name)
+	for _, label := range axis.Values {
+		switch label {
+		case harness.OSLinux.String(), harness.OSWindows.String(), harness.OSMacos.String():
+		default:
+			stage.Delegate = harness.Stringorslice{value}
+			return
+		}
+	}
+	stage.Spec.(*harness.StageCI).Platform = &harness.Platform{
+		Os: value,
+	}
+}
+
+// helper function returns a when clause that executes
+// the step for the pipeline status.
+func statusWhen(status ...string) *harness.When {
+	expr := &harness.Expr{Eq: status[0]}
+	if len(status) > 1 {
+		expr = &harness.Expr{In: status}
+	}
+	return &harness.When{
+		Cond: []map[string]*harness.Expr{
+			{"status": expr},
+		},
+	}
+}
+
+var toolVersion = regexp.MustCompile(`\d+(\.\d+)*`)
+
+// helper function returns the container image for the
+// jenkins tool. The image tag is parsed from the tool
+// installation name, for example 'Maven 3.9'.
+func toolImage(image, name string) string {
+	if version := toolVersion.FindString(name); version != "" {
+		return image + ":" + version
+	}
+	return image + ":latest"
+}
+
+// helper function splits the comma separated list.
+func splitList(s string) []string {
+	var out []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// helper function splits the text into non-empty lines.
+func splitLines(s string) []string {
+	var out []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			out = append(out, line)
+		}
+	}
+	return out
+}
@@ -17,6 +17,7 @@ package jenkinsxml
 import (
 	"encoding/xml"
 	"io/ioutil"
+	"path/filepath"
 	"testing"
 
 	jenkinsxml "github.com/drone/go-convert/convert/jenkinsxml/xml"
@@ -26,41 +27,113 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-// TODO: add more teste in subdirectories, as we have for other providers
 func TestConvert(t *testing.T) {
-	// convert the XML file from Jenkins to harness
-	converter := New()
-	tmp1, err := converter.ConvertFile("testdata/hello.xml")
+	tests, err := filepath.Glob("testdata/*.xml")
 	if err != nil {
 		t.Error(err)
 		return
 	}
 
-	// unmarshal the yaml to a map
-	got := map[string]interface{}{}
-	if err := yaml.Unmarshal(tmp1, &got); err != nil {
-		t.Error(err)
-		return
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			// convert the XML file from Jenkins to harness
+			converter := New()
+			tmp1, err := converter.ConvertFile(test)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			// unmarshal the yaml to a map
+			got := map[string]interface{}{}
+			if err := yaml.Unmarshal(tmp1, &got); err != nil {
+				t.Error(err)
+				return
+			}
+
+			// parse the golden yaml file
+			data, err := ioutil.ReadFile(test + ".golden")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			// unmarshal the golden yaml file to a map
+			want := map[string]interface{}{}
+			if err := yaml.Unmarshal(data, &want); err != nil {
+				t.Error(err)
+				return
+			}
+
+			// compare the converted yaml to the golden file
+			if diff := cmp.Diff(got, want); diff != "" {
+				t.Errorf("Unexpected conversion result")
+				t.Log(diff)
+			}
+		})
 	}
+}
 
-	// parse the golden yaml file
-	data, err := ioutil.ReadFile("testdata/hello.xml.golden")
+func TestConvertTrigger(t *testing.T) {
+	tests, err := filepath.Glob("testdata/triggers/*.xml")
 	if err != nil {
 		t.Error(err)
 		return
 	}
 
-	// unmarshal the golden yaml file to a map
-	want := map[string]interface{}{}
-	if err := yaml.Unmarshal(data, &want); err != nil {
-		t.Error(err)
-		return
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			// convert the job triggers from Jenkins to harness
+			converter := New()
+			got, err := converter.ConvertTriggerFile(test)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			// parse the golden yaml file
+			want, err := ioutil.ReadFile(test + ".golden")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			// compare the converted yaml to the golden file
+			if diff := cmp.Diff(string(got), string(want)); diff != "" {
+				t.Errorf("Unexpected conversion result")
+				t.Log(diff)
+			}
+		})
 	}
+}
 
-	// compare the converted yaml to the golden file
-	if diff := cmp.Diff(got, want); diff != "" {
-		t.Errorf("Unexpected conversion result")
-		t.Log(diff)
+func TestConvertCron(t *testing.T) {
+	tests := []struct {
+		before string
+		after  string
+	}{
+		{"H 2 * * 1-5", "0 2 * * 1-5"},
+		{"H/15 * * * *", "*/15 * * * *"},
+		{"H(0-29)/10 H * * *", "0-29/10 0 * * *"},
+		{"H H(3-5) H * *", "0 3 1 * *"},
+		{"@daily", "0 0 * * *"},
+		{"0 12 * * *", "0 12 * * *"},
+	}
+	for _, test := range tests {
+		if got, want := convertCron(test.before), test.after; got != want {
+			t.Errorf("Want cron %q for %q, got %q", want, test.before, got)
+		}
+	}
+}
+
+func TestConvertDefinitionScriptPath(t *testing.T) {
+	src := `<flow-definition>
+  <definition class="org.jenkinsci.plugins.workflow.cps.CpsScmFlowDefinition">
+    <scriptPath>Jenkinsfile</scriptPath>
+  </definition>
+</flow-definition>`
+	if _, err := New().ConvertString(src); err == nil {
+		t.Errorf("Expect error when the Jenkinsfile is loaded from source control")
 	}
 }
 
@@ -0,0 +1,295 @@
+// Copyright 2024 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jenkinsxml
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	jenkinsxml "github.com/drone/go-convert/convert/jenkinsxml/xml"
+
+	"github.com/ghodss/yaml"
+)
+
+// ConvertTrigger converts the Jenkins job build triggers
+// to Harness triggers. Timer triggers are converted to
+// scheduled triggers, and SCM polling triggers are
+// converted to push webhook triggers. The triggers are
+// returned as a multi-document yaml.
+func (d *Converter) ConvertTrigger(r io.Reader) ([]byte, error) {
+	src, err := jenkinsxml.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	return d.convertTriggers(src)
+}
+
+// ConvertTriggerBytes converts the Jenkins job build
+// triggers to Harness triggers.
+func (d *Converter) ConvertTriggerBytes(b []byte) ([]byte, error) {
+	return d.ConvertTrigger(
+		bytes.NewBuffer(b),
+	)
+}
+
+// ConvertTriggerFile converts the Jenkins job build
+// triggers to Harness triggers.
+func (d *Converter) ConvertTriggerFile(p string) ([]byte, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return d.ConvertTrigger(f)
+}
+
+// convertTriggers converts the job build triggers.
+func (d *Converter) convertTriggers(src *jenkinsxml.Project) ([]byte, error) {
+	var triggers []*v0.Trigger
+	if src.Triggers != nil {
+		if v := src.Triggers.Timer; v != nil {
+			for i, expr := range cronLines(v.Spec) {
+				triggers = append(triggers, d.newTrigger(
+					fmt.Sprintf("cron-%d", i+1),
+					&v0.TriggerSource{
+						Type: "Scheduled",
+						Spec: &v0.TriggerSourceSpec{
+							Type: "Cron",
+							Spec: &v0.TriggerCron{
+								Expression: convertCron(expr),
+								Type:       "UNIX",
+							},
+						},
+					},
+				))
+			}
+		}
+		// scm polling detects pushed commits, which is
+		// replaced by a push webhook.
+		if v := src.Triggers.SCM; v != nil {
+			triggers = append(triggers, d.newTrigger("push", &v0.TriggerSource{
+				Type: "Webhook",
+				Spec: d.convertPushSource(src.SCM),
+			}))
+		}
+	}
+
+	var buf bytes.Buffer
+	for i, trigger := range triggers {
+		if i != 0 {
+			buf.WriteString("---\n")
+		}
+		out, err := yaml.Marshal(
+			struct {
+				Trigger *v0.Trigger `json:"trigger"`
+			}{trigger},
+		)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(out)
+	}
+	return buf.Bytes(), nil
+}
+
+// helper function returns a trigger for the pipeline.
+func (d *Converter) newTrigger(name string, source *v0.TriggerSource) *v0.Trigger {
+	return &v0.Trigger{
+		Name:               name,
+		ID:                 strings.ReplaceAll(name, "-", "_"),
+		Enabled:            true,
+		Org:                d.organization,
+		Project:            d.project,
+		PipelineIdentifier: d.pipeline,
+		Source:             source,
+	}
+}
+
+// helper function converts the git scm to a push webhook
+// source. The webhook type is derived from the repository
+// host, and defaults to a custom webhook.
+func (d *Converter) convertPushSource(scm *jenkinsxml.SCM) *v0.TriggerSourceSpec {
+	conditions := []*v0.TriggerCondition{}
+	if scm != nil {
+		if cond := convertBranches(scm.Branches); cond != nil {
+			conditions = append(conditions, cond)
+		}
+	}
+
+	var host, name string
+	if scm != nil && len(scm.UserRemoteConfigs) != 0 {
+		host, name = parseRemote(scm.UserRemoteConfigs[0].URL)
+	}
+
+	var typ string
+	switch {
+	case strings.Contains(host, "github"):
+		typ = "Github"
+	case strings.Contains(host, "gitlab"):
+		typ = "Gitlab"
+	case strings.Contains(host, "bitbucket"):
+		typ = "Bitbucket"
+	default:
+		return &v0.TriggerSourceSpec{
+			Type: "Custom",
+			Spec: &v0.TriggerCustom{
+				PayloadConditions: conditions,
+				HeaderConditions:  []*v0.TriggerCondition{},
+			},
+		}
+	}
+	return &v0.TriggerSourceSpec{
+		Type: typ,
+		Spec: &v0.TriggerWebhook{
+			Type: "Push",
+			Spec: &v0.TriggerGit{
+				ConnectorRef:      d.repoConnector,
+				RepoName:          name,
+				PayloadConditions: conditions,
+				HeaderConditions:  []*v0.TriggerCondition{},
+				Actions:           []string{},
+			},
+		},
+	}
+}
+
+// helper function converts the git branch specifiers to
+// a target branch condition. Returns nil if any branch
+// is built.
+func convertBranches(branches []string) *v0.TriggerCondition {
+	var patterns []string
+	for _, branch := range branches {
+		branch = strings.TrimSpace(branch)
+		branch = strings.TrimPrefix(branch, "refs/heads/")
+		branch = strings.TrimPrefix(branch, "origin/")
+		branch = strings.TrimPrefix(branch, "*/")
+		if branch == "" || branch == "*" || branch == "**" {
+			return nil
+		}
+		patterns = append(patterns, globToRegexp(branch))
+	}
+	if len(patterns) == 0 {
+		return nil
+	}
+	return &v0.TriggerCondition{
+		Key:      "targetBranch",
+		Operator: "Regex",
+		Value:    "^(" + strings.Join(patterns, "|") + ")$",
+	}
+}
+
+// helper function returns the host and repository name
+// of the git remote url, including scp-like urls.
+func parseRemote(remote string) (host, name string) {
+	if !strings.Contains(remote, "://") {
+		// git@github.com:octocat/hello-world.git
+		if i := strings.Index(remote, "@"); i != -1 {
+			remote = remote[i+1:]
+		}
+		remote = "ssh://" + strings.Replace(remote, ":", "/", 1)
+	}
+	uri, err := url.Parse(remote)
+	if err != nil {
+		return "", ""
+	}
+	name = strings.TrimSuffix(path.Base(uri.Path), ".git")
+	return uri.Hostname(), name
+}
+
+// helper function returns the cron expressions in the
+// trigger spec. Comments and empty lines are ignored.
+func cronLines(spec string) []string {
+	var out []string
+	for _, line := range splitLines(spec) {
+		if !strings.HasPrefix(line, "#") {
+			out = append(out, line)
+		}
+	}
+	return out
+}
+
+// minimum value of each cron field, used to replace the
+// jenkins hash symbol.
+var cronMin = []string{"0", "0", "1", "1", "0"}
+
+var cronHash = regexp.MustCompile(`^H(?:\((\d+)-(\d+)\))?(?:/(\d+))?$`)
+
+// helper function converts the jenkins cron expression
+// to a unix cron expression. The jenkins hash symbol H
+// spreads the load using a hash of the job name, and is
+// replaced with the first value in the range.
+func convertCron(expr string) string {
+	switch expr {
+	case "@midnight":
+		return "0 0 * * *"
+	case "@hourly":
+		return "0 * * * *"
+	case "@daily":
+		return "0 0 * * *"
+	case "@weekly":
+		return "0 0 * * 0"
+	case "@monthly":
+		return "0 0 1 * *"
+	case "@yearly", "@annually":
+		return "0 0 1 1 *"
+	}
+	fields := strings.Fields(expr)
+	for i, field := range fields {
+		match := cronHash.FindStringSubmatch(field)
+		if match == nil || i >= len(cronMin) {
+			continue
+		}
+		switch low, high, step := match[1], match[2], match[3]; {
+		case step != "" && low != "":
+			fields[i] = low + "-" + high + "/" + step
+		case step != "":
+			fields[i] = "*/" + step
+		case low != "":
+			fields[i] = low
+		default:
+			fields[i] = cronMin[i]
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// helper function converts a git branch glob to a
+// regular expression.
+func globToRegexp(glob string) string {
+	var sb strings.Builder
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				sb.WriteString(".*")
+				i++
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return sb.String()
+}
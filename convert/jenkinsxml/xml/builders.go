@@ -21,6 +21,10 @@ type (
 		Tasks []Task `xml:",any"`
 	}
 
+	Publishers struct {
+		Tasks []Task `xml:",any"`
+	}
+
 	Task struct {
 		XMLName xml.Name
 		Content string `xml:",innerxml"`
@@ -26,16 +26,7 @@ import (
 // Parse parses the configuration from io.Reader r.
 func Parse(r io.Reader) (*Project, error) {
 	out := new(Project)
-
-	// see https://github.com/golang/go/issues/25755
-	// encoding/xml does not support XML 1.1, which jenkins uses
-	//
-	// TODO: this approach is likely brittle and will need to be revisited
-	data, _ := io.ReadAll(r)
-	res := strings.Replace(string(data), "<?xml version='1.1", "<?xml version='1.0", 1)
-
-	dec := xml.NewDecoder(strings.NewReader(res))
-	err := dec.Decode(out)
+	err := decode(r, out)
 	return out, err
 }
 
@@ -62,3 +53,34 @@ func ParseFile(p string) (*Project, error) {
 	defer f.Close()
 	return Parse(f)
 }
+
+// ParseBuild parses the build from io.Reader r.
+func ParseBuild(r io.Reader) (*Build, error) {
+	out := new(Build)
+	err := decode(r, out)
+	return out, err
+}
+
+// ParseBuildFile parses the build from path p.
+func ParseBuildFile(p string) (*Build, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseBuild(f)
+}
+
+// helper function decodes the jenkins xml document from
+// io.Reader r.
+func decode(r io.Reader, v interface{}) error {
+	// see https://github.com/golang/go/issues/25755
+	// encoding/xml does not support XML 1.1, which jenkins uses
+	//
+	// TODO: this approach is likely brittle and will need to be revisited
+	data, _ := io.ReadAll(r)
+	res := strings.Replace(string(data), "<?xml version='1.1", "<?xml version='1.0", 1)
+
+	dec := xml.NewDecoder(strings.NewReader(res))
+	return dec.Decode(v)
+}
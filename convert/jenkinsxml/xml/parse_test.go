@@ -17,6 +17,7 @@ package xml
 import (
 	"encoding/xml"
 	"io/ioutil"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -29,6 +30,9 @@ func TestParseFile(t *testing.T) {
 	}
 
 	want := &Project{
+		XMLName: xml.Name{
+			Local: "project",
+		},
 		Disabled: false,
 		Builders: &Builders{
 			Tasks: []Task{
@@ -56,7 +60,16 @@ func TestParseFile(t *testing.T) {
 	}
 
 	want = &Project{
-		Disabled: false,
+		XMLName: xml.Name{
+			Local: "project",
+		},
+		Disabled:   false,
+		Properties: &Properties{},
+		SCM: &SCM{
+			Class: "hudson.scm.NullSCM",
+		},
+		Triggers:   &Triggers{},
+		Publishers: &Publishers{},
 		Builders: &Builders{
 			Tasks: []Task{
 				{
@@ -110,6 +123,9 @@ func TestParseString(t *testing.T) {
 	}
 
 	want := &Project{
+		XMLName: xml.Name{
+			Local: "project",
+		},
 		Disabled: false,
 		Builders: &Builders{
 			Tasks: []Task{
@@ -131,3 +147,100 @@ func TestParseString(t *testing.T) {
 		t.Log(diff)
 	}
 }
+
+func TestParseFreestyle(t *testing.T) {
+	got, err := ParseFile("testdata/freestyle.xml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	wantParams := []Parameter{
+		{XMLName: xml.Name{Local: "hudson.model.StringParameterDefinition"}, Name: "VERSION", Description: "release version", DefaultValue: "1.0.0"},
+		{XMLName: xml.Name{Local: "hudson.model.BooleanParameterDefinition"}, Name: "DEPLOY", DefaultValue: "true"},
+		{XMLName: xml.Name{Local: "hudson.model.ChoiceParameterDefinition"}, Name: "ENV", Choices: []string{"dev", "prod"}},
+		{XMLName: xml.Name{Local: "hudson.model.PasswordParameterDefinition"}, Name: "TOKEN", DefaultValue: "{AQAAABAAAAAQ}"},
+	}
+	if diff := cmp.Diff(got.Properties.Parameters.Parameters, wantParams); diff != "" {
+		t.Errorf("Unexpected parameters")
+		t.Log(diff)
+	}
+
+	wantSCM := &SCM{
+		Class: "hudson.plugins.git.GitSCM",
+		UserRemoteConfigs: []UserRemoteConfig{
+			{URL: "https://github.com/octocat/hello-world.git", CredentialsID: "github"},
+		},
+		Branches:    []string{"*/main"},
+		CloneOption: &CloneOption{Shallow: true, Depth: 1},
+	}
+	if diff := cmp.Diff(got.SCM, wantSCM); diff != "" {
+		t.Errorf("Unexpected scm")
+		t.Log(diff)
+	}
+
+	wantTriggers := &Triggers{
+		Timer: &Trigger{Spec: "H 2 * * 1-5"},
+		SCM:   &Trigger{Spec: "H/15 * * * *"},
+	}
+	if diff := cmp.Diff(got.Triggers, wantTriggers); diff != "" {
+		t.Errorf("Unexpected triggers")
+		t.Log(diff)
+	}
+
+	var publishers []string
+	for _, task := range got.Publishers.Tasks {
+		publishers = append(publishers, task.XMLName.Local)
+	}
+	wantPublishers := []string{
+		"hudson.tasks.ArtifactArchiver",
+		"hudson.tasks.junit.JUnitResultArchiver",
+		"hudson.tasks.Mailer",
+	}
+	if diff := cmp.Diff(publishers, wantPublishers); diff != "" {
+		t.Errorf("Unexpected publishers")
+		t.Log(diff)
+	}
+}
+
+func TestParseMatrix(t *testing.T) {
+	got, err := ParseFile("testdata/matrix.xml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := got.XMLName.Local, "matrix-project"; got != want {
+		t.Errorf("Want project type %q, got %q", want, got)
+	}
+	want := &Axes{
+		Axes: []Axis{
+			{XMLName: xml.Name{Local: "hudson.matrix.TextAxis"}, Name: "GO_VERSION", Values: []string{"1.20", "1.21"}},
+			{XMLName: xml.Name{Local: "hudson.matrix.LabelAxis"}, Name: "label", Values: []string{"linux", "windows"}},
+		},
+	}
+	if diff := cmp.Diff(got.Axes, want); diff != "" {
+		t.Errorf("Unexpected axes")
+		t.Log(diff)
+	}
+}
+
+func TestParsePipeline(t *testing.T) {
+	got, err := ParseFile("testdata/pipeline.xml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := got.XMLName.Local, "flow-definition"; got != want {
+		t.Errorf("Want project type %q, got %q", want, got)
+	}
+	if got.Definition == nil {
+		t.Errorf("Want pipeline definition")
+		return
+	}
+	if got, want := got.Definition.Class, "org.jenkinsci.plugins.workflow.cps.CpsFlowDefinition"; got != want {
+		t.Errorf("Want definition class %q, got %q", want, got)
+	}
+	if !strings.Contains(got.Definition.Script, "sh 'go build ./...'") {
+		t.Errorf("Want unescaped inline script, got %q", got.Definition.Script)
+	}
+}
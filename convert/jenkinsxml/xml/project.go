@@ -14,13 +14,149 @@
 
 package xml
 
+import "encoding/xml"
+
 type (
-	// Project defines a jenkins project.
+	// Project defines a jenkins project. The project type
+	// is defined by the root element name, for example
+	// project, matrix-project or flow-definition.
 	Project struct {
-		// TODO: add support for more fields.
-		ConcurrentBuild bool `xml:"concurrentBuild,omitempty"`
-		Disabled        bool `xml:"disabled,omitempty"`
+		XMLName         xml.Name
+		Description     string `xml:"description,omitempty"`
+		ConcurrentBuild bool   `xml:"concurrentBuild,omitempty"`
+		Disabled        bool   `xml:"disabled,omitempty"`
+
+		Properties *Properties `xml:"properties"`
+		SCM        *SCM        `xml:"scm"`
+		Triggers   *Triggers   `xml:"triggers"`
+		Builders   *Builders   `xml:"builders"`
+		Publishers *Publishers `xml:"publishers"`
+
+		// Axes and CombinationFilter are set for
+		// matrix-project jobs.
+		Axes              *Axes  `xml:"axes"`
+		CombinationFilter string `xml:"combinationFilter,omitempty"`
+
+		// Definition is set for flow-definition jobs.
+		Definition *Definition `xml:"definition"`
+
+		// Sources and Factory are set for multibranch
+		// projects.
+		Sources []BranchSource `xml:"sources>data>jenkins.branch.BranchSource>source"`
+		Factory *Factory       `xml:"factory"`
+	}
+
+	// BranchSource defines a multibranch project source.
+	BranchSource struct {
+		Class      string `xml:"class,attr"`
+		Remote     string `xml:"remote,omitempty"`
+		RepoOwner  string `xml:"repoOwner,omitempty"`
+		Repository string `xml:"repository,omitempty"`
+	}
+
+	// Factory defines the multibranch project factory,
+	// which loads the Jenkinsfile from source control.
+	Factory struct {
+		Class      string `xml:"class,attr"`
+		ScriptPath string `xml:"scriptPath,omitempty"`
+	}
+
+	// Properties defines the job properties.
+	Properties struct {
+		Parameters *Parameters `xml:"hudson.model.ParametersDefinitionProperty>parameterDefinitions"`
+
+		// Branch is set for the branch jobs of a
+		// multibranch project.
+		Branch *BranchHead `xml:"org.jenkinsci.plugins.workflow.multibranch.BranchJobProperty>branch>head"`
+	}
+
+	// BranchHead defines the branch built by a
+	// multibranch project branch job.
+	BranchHead struct {
+		Class string `xml:"class,attr"`
+		Name  string `xml:"name"`
+	}
+
+	// Parameters defines the job parameter definitions.
+	Parameters struct {
+		Parameters []Parameter `xml:",any"`
+	}
+
+	// Parameter defines a job parameter. The parameter
+	// type is defined by the element name, for example
+	// hudson.model.StringParameterDefinition.
+	Parameter struct {
+		XMLName      xml.Name
+		Name         string   `xml:"name"`
+		Description  string   `xml:"description,omitempty"`
+		DefaultValue string   `xml:"defaultValue,omitempty"`
+		Choices      []string `xml:"choices>a>string"`
+	}
+
+	// SCM defines the job source code management.
+	SCM struct {
+		Class             string             `xml:"class,attr"`
+		UserRemoteConfigs []UserRemoteConfig `xml:"userRemoteConfigs>hudson.plugins.git.UserRemoteConfig"`
+		Branches          []string           `xml:"branches>hudson.plugins.git.BranchSpec>name"`
+		CloneOption       *CloneOption       `xml:"extensions>hudson.plugins.git.extensions.impl.CloneOption"`
+	}
+
+	// CloneOption defines the git clone options.
+	CloneOption struct {
+		Shallow bool  `xml:"shallow,omitempty"`
+		Depth   int64 `xml:"depth,omitempty"`
+	}
+
+	// UserRemoteConfig defines a git remote repository.
+	UserRemoteConfig struct {
+		URL           string `xml:"url"`
+		CredentialsID string `xml:"credentialsId,omitempty"`
+	}
+
+	// Triggers defines the job build triggers.
+	Triggers struct {
+		Timer *Trigger `xml:"hudson.triggers.TimerTrigger"`
+		SCM   *Trigger `xml:"hudson.triggers.SCMTrigger"`
+	}
+
+	// Trigger defines a cron build trigger.
+	Trigger struct {
+		Spec string `xml:"spec"`
+	}
+
+	// Axes defines the matrix-project axes.
+	Axes struct {
+		Axes []Axis `xml:",any"`
+	}
+
+	// Axis defines a matrix-project axis. The axis type
+	// is defined by the element name, for example
+	// hudson.matrix.TextAxis or hudson.matrix.LabelAxis.
+	Axis struct {
+		XMLName xml.Name
+		Name    string   `xml:"name"`
+		Values  []string `xml:"values>string"`
+	}
+
+	// Definition defines the flow-definition pipeline.
+	Definition struct {
+		Class      string `xml:"class,attr"`
+		Script     string `xml:"script,omitempty"`
+		ScriptPath string `xml:"scriptPath,omitempty"`
+		Sandbox    bool   `xml:"sandbox,omitempty"`
+	}
+
+	// Build defines a job build, stored in the build.xml
+	// file of the build directory.
+	Build struct {
+		XMLName   xml.Name
+		Execution *Execution `xml:"execution"`
+	}
 
-		Builders *Builders `xml:"builders"`
+	// Execution defines a pipeline build execution. The
+	// script is the Jenkinsfile loaded by the build.
+	Execution struct {
+		Class  string `xml:"class,attr"`
+		Script string `xml:"script,omitempty"`
 	}
 )
@@ -24,4 +24,43 @@ type (
 		Plugin  string `xml:"plugin,attr"`
 		Targets string `xml:"targets"`
 	}
+
+	HudsonBatchFileTask struct {
+		Command              string `xml:"command"`
+		ConfiguredLocalRules string `xml:"configuredLocalRules,omitempty"`
+	}
+
+	HudsonMavenTask struct {
+		Targets    string `xml:"targets"`
+		MavenName  string `xml:"mavenName,omitempty"`
+		POM        string `xml:"pom,omitempty"`
+		Properties string `xml:"properties,omitempty"`
+		JVMOptions string `xml:"jvmOptions,omitempty"`
+	}
+
+	GradleTask struct {
+		Tasks              string `xml:"tasks"`
+		Switches           string `xml:"switches,omitempty"`
+		RootBuildScriptDir string `xml:"rootBuildScriptDir,omitempty"`
+		BuildFile          string `xml:"buildFile,omitempty"`
+		GradleName         string `xml:"gradleName,omitempty"`
+		UseWrapper         bool   `xml:"useWrapper,omitempty"`
+		WrapperLocation    string `xml:"wrapperLocation,omitempty"`
+	}
+
+	JUnitResultArchiver struct {
+		TestResults string `xml:"testResults"`
+	}
+
+	ArtifactArchiver struct {
+		Artifacts        string `xml:"artifacts"`
+		Excludes         string `xml:"excludes,omitempty"`
+		OnlyIfSuccessful bool   `xml:"onlyIfSuccessful,omitempty"`
+	}
+
+	Mailer struct {
+		Recipients                   string `xml:"recipients"`
+		DontNotifyEveryUnstableBuild bool   `xml:"dontNotifyEveryUnstableBuild,omitempty"`
+		SendToIndividuals            bool   `xml:"sendToIndividuals,omitempty"`
+	}
 )
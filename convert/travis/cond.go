@@ -0,0 +1,479 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package travis
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	travis "github.com/drone/go-convert/convert/travis/yaml"
+)
+
+//
+// this file contains helper functions that convert travis
+// conditions to harness expressions.
+//
+// https://docs.travis-ci.com/user/conditions-v1
+//
+
+// condVars maps travis condition attributes to harness
+// expressions.
+var condVars = map[string]string{
+	"branch":         "<+codebase.branch>",
+	"head_branch":    "<+codebase.sourceBranch>",
+	"tag":            "<+codebase.tag>",
+	"repo":           "<+trigger.payload.repository.full_name>",
+	"sender":         "<+codebase.gitUserId>",
+	"commit_message": "<+codebase.commitMessage>",
+}
+
+// condEvents maps travis build types to harness trigger
+// expressions.
+var condEvents = map[string][2]string{
+	"push":         {"<+trigger.event>", "PUSH"},
+	"pull_request": {"<+trigger.event>", "PR"},
+	"cron":         {"<+trigger.type>", "Scheduled"},
+	"api":          {"<+trigger.type>", "Manual"},
+}
+
+// expression precedence, used to determine when
+// an expression must be wrapped in parentheses.
+const (
+	precOr = iota
+	precAnd
+	precCmp
+	precUnary
+)
+
+// condToken defines a condition token.
+type condToken struct {
+	text   string
+	quoted bool
+}
+
+// condParser parses a travis condition.
+type condParser struct {
+	tokens []condToken
+	pos    int
+}
+
+// helper function converts a travis condition to a
+// harness expression.
+func convertIf(src string) (string, error) {
+	if strings.TrimSpace(src) == "" {
+		return "", nil
+	}
+	tokens, err := tokenizeCond(src)
+	if err != nil {
+		return "", err
+	}
+	p := &condParser{tokens: tokens}
+	expr, _, err := p.parseOr()
+	if err != nil {
+		return "", err
+	}
+	if p.pos != len(p.tokens) {
+		return "", fmt.Errorf("unexpected token %q in condition", p.tokens[p.pos].text)
+	}
+	return expr, nil
+}
+
+// helper function converts travis branch filters to a
+// harness expression.
+func convertBranches(src *travis.Branches) string {
+	if src == nil {
+		return ""
+	}
+	var exprs []string
+	var only []string
+	for _, branch := range src.Only {
+		only = append(only, convertBranch(branch, false))
+	}
+	if len(only) > 1 {
+		exprs = append(exprs, "("+strings.Join(only, " || ")+")")
+	} else {
+		exprs = append(exprs, only...)
+	}
+	for _, branch := range src.Except {
+		exprs = append(exprs, convertBranch(branch, true))
+	}
+	return strings.Join(exprs, " && ")
+}
+
+// helper function converts a travis branch name or
+// regular expression to a harness expression.
+func convertBranch(branch string, negate bool) string {
+	if re, ok := unwrapRegexp(branch); ok {
+		return compareExpr(condVars["branch"], "=~", re, negate)
+	}
+	return compareExpr(condVars["branch"], "==", branch, negate)
+}
+
+// helper function combines the expressions using the
+// logical and operator.
+func joinConds(exprs ...string) string {
+	var parts []string
+	for _, expr := range exprs {
+		if expr != "" {
+			parts = append(parts, expr)
+		}
+	}
+	if len(parts) > 1 {
+		for i, part := range parts {
+			if hasTopLevelOr(part) {
+				parts[i] = "(" + part + ")"
+			}
+		}
+	}
+	return strings.Join(parts, " && ")
+}
+
+// helper function returns true if the expression has a
+// logical or operator outside of parentheses and quotes.
+func hasTopLevelOr(expr string) bool {
+	depth := 0
+	quoted := false
+	for i := 0; i < len(expr); i++ {
+		switch c := expr[i]; {
+		case quoted && c == '\\':
+			i++
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case depth == 0 && strings.HasPrefix(expr[i:], " || "):
+			return true
+		}
+	}
+	return false
+}
+
+// helper function returns a harness comparison
+// expression.
+func compareExpr(variable, op, value string, negate bool) string {
+	if negate {
+		switch op {
+		case "==":
+			op = "!="
+		case "=~":
+			op = "!~"
+		}
+	}
+	return fmt.Sprintf("%s %s %q", variable, op, value)
+}
+
+// helper function returns the regular expression if
+// the value is wrapped in slashes (e.g. /^deploy.*$/).
+func unwrapRegexp(s string) (string, bool) {
+	if len(s) > 1 && strings.HasPrefix(s, "/") && strings.HasSuffix(s, "/") {
+		return s[1 : len(s)-1], true
+	}
+	return "", false
+}
+
+func (p *condParser) peek() string {
+	if p.pos < len(p.tokens) && !p.tokens[p.pos].quoted {
+		return strings.ToUpper(p.tokens[p.pos].text)
+	}
+	return ""
+}
+
+func (p *condParser) next() (condToken, error) {
+	if p.pos >= len(p.tokens) {
+		return condToken{}, errors.New("unexpected end of condition")
+	}
+	tok := p.tokens[p.pos]
+	p.pos++
+	return tok, nil
+}
+
+func (p *condParser) expect(text string) error {
+	if p.peek() != text {
+		return fmt.Errorf("expected %q in condition", text)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *condParser) parseOr() (string, int, error) {
+	expr, prec, err := p.parseAnd()
+	if err != nil {
+		return "", 0, err
+	}
+	for p.peek() == "OR" || p.peek() == "||" {
+		p.pos++
+		right, _, err := p.parseAnd()
+		if err != nil {
+			return "", 0, err
+		}
+		expr, prec = expr+" || "+right, precOr
+	}
+	return expr, prec, nil
+}
+
+func (p *condParser) parseAnd() (string, int, error) {
+	expr, prec, err := p.parseUnary()
+	if err != nil {
+		return "", 0, err
+	}
+	for p.peek() == "AND" || p.peek() == "&&" {
+		p.pos++
+		right, rprec, err := p.parseUnary()
+		if err != nil {
+			return "", 0, err
+		}
+		expr, prec = wrapPrec(expr, prec, precAnd)+" && "+wrapPrec(right, rprec, precAnd), precAnd
+	}
+	return expr, prec, nil
+}
+
+func (p *condParser) parseUnary() (string, int, error) {
+	switch p.peek() {
+	case "NOT", "!":
+		p.pos++
+		expr, prec, err := p.parseUnary()
+		if err != nil {
+			return "", 0, err
+		}
+		if prec < precUnary {
+			expr = "(" + expr + ")"
+		}
+		return "!" + expr, precUnary, nil
+	case "(":
+		p.pos++
+		expr, _, err := p.parseOr()
+		if err != nil {
+			return "", 0, err
+		}
+		if err := p.expect(")"); err != nil {
+			return "", 0, err
+		}
+		return "(" + expr + ")", precUnary, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *condParser) parseComparison() (string, int, error) {
+	tok, err := p.next()
+	if err != nil {
+		return "", 0, err
+	}
+	name := strings.ToLower(tok.text)
+
+	// resolve the attribute to a harness expression.
+	var variable string
+	switch {
+	case name == "env" && p.peek() == "(":
+		p.pos++
+		env, err := p.next()
+		if err != nil {
+			return "", 0, err
+		}
+		if err := p.expect(")"); err != nil {
+			return "", 0, err
+		}
+		variable = fmt.Sprintf("<+pipeline.variables.%s>", env.text)
+	case name == "type":
+		// build types are handled below
+	default:
+		var ok bool
+		if variable, ok = condVars[name]; !ok {
+			return "", 0, fmt.Errorf("unsupported condition attribute %q", tok.text)
+		}
+	}
+
+	switch op := p.peek(); op {
+	case "=", "==", "!=", "=~", "~=", "!~":
+		p.pos++
+		val, err := p.next()
+		if err != nil {
+			return "", 0, err
+		}
+		negate := op == "!=" || op == "!~"
+		if name == "type" {
+			if op != "=" && op != "==" && op != "!=" {
+				return "", 0, fmt.Errorf("unsupported operator %q for build type", op)
+			}
+			expr, err := convertBuildType(val.text, negate)
+			return expr, precCmp, err
+		}
+		if op == "=~" || op == "~=" || op == "!~" {
+			re, _ := unwrapRegexp(val.text)
+			if re == "" {
+				re = val.text
+			}
+			return compareExpr(variable, "=~", re, negate), precCmp, nil
+		}
+		return compareExpr(variable, "==", val.text, negate), precCmp, nil
+	case "IN", "NOT":
+		negate := op == "NOT"
+		if negate {
+			p.pos++
+		}
+		if err := p.expect("IN"); err != nil {
+			return "", 0, err
+		}
+		values, err := p.parseList()
+		if err != nil {
+			return "", 0, err
+		}
+		var exprs []string
+		for _, val := range values {
+			var expr string
+			if name == "type" {
+				if expr, err = convertBuildType(val, negate); err != nil {
+					return "", 0, err
+				}
+			} else {
+				expr = compareExpr(variable, "==", val, negate)
+			}
+			exprs = append(exprs, expr)
+		}
+		if negate {
+			return strings.Join(exprs, " && "), precAnd, nil
+		}
+		if len(exprs) == 1 {
+			return exprs[0], precCmp, nil
+		}
+		return strings.Join(exprs, " || "), precOr, nil
+	case "IS":
+		p.pos++
+		negate := p.peek() == "NOT"
+		if negate {
+			p.pos++
+		}
+		if name == "type" {
+			return "", 0, errors.New("unsupported operator IS for build type")
+		}
+		switch p.peek() {
+		case "PRESENT":
+		case "BLANK":
+			negate = !negate
+		default:
+			return "", 0, errors.New("expected present or blank in condition")
+		}
+		p.pos++
+		if negate {
+			return variable + " == null", precCmp, nil
+		}
+		return variable + " != null", precCmp, nil
+	default:
+		return "", 0, fmt.Errorf("unsupported condition %q", tok.text)
+	}
+}
+
+// helper function parses a parenthesized, comma-separated
+// list of values.
+func (p *condParser) parseList() ([]string, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var values []string
+	for {
+		tok, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, tok.text)
+		if p.peek() == "," {
+			p.pos++
+			continue
+		}
+		return values, p.expect(")")
+	}
+}
+
+// helper function converts a travis build type to a
+// harness trigger expression.
+func convertBuildType(name string, negate bool) (string, error) {
+	event, ok := condEvents[strings.ToLower(name)]
+	if !ok {
+		return "", fmt.Errorf("unsupported build type %q", name)
+	}
+	return compareExpr(event[0], "==", event[1], negate), nil
+}
+
+// helper function wraps the expression in parentheses
+// if the expression has a lower precedence.
+func wrapPrec(expr string, prec, want int) string {
+	if prec < want {
+		return "(" + expr + ")"
+	}
+	return expr
+}
+
+// helper function splits the condition into tokens.
+func tokenizeCond(src string) ([]condToken, error) {
+	var tokens []condToken
+	runes := []rune(src)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')' || r == ',':
+			tokens = append(tokens, condToken{text: string(r)})
+			i++
+		case r == '"' || r == '\'':
+			j := i + 1
+			for j < len(runes) && runes[j] != r {
+				if runes[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(runes) {
+				return nil, errors.New("unterminated string in condition")
+			}
+			tokens = append(tokens, condToken{text: string(runes[i+1 : j]), quoted: true})
+			i = j + 1
+		case r == '/' && len(tokens) != 0 && strings.ContainsAny(tokens[len(tokens)-1].text, "~"):
+			// regular expressions are only permitted as
+			// the operand of the match operator.
+			j := i + 1
+			for j < len(runes) && runes[j] != '/' {
+				if runes[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(runes) {
+				return nil, errors.New("unterminated regular expression in condition")
+			}
+			tokens = append(tokens, condToken{text: string(runes[i : j+1]), quoted: true})
+			i = j + 1
+		case strings.ContainsRune("=!~&|", r):
+			j := i + 1
+			for j < len(runes) && strings.ContainsRune("=!~&|", runes[j]) {
+				j++
+			}
+			tokens = append(tokens, condToken{text: string(runes[i:j])})
+			i = j
+		default:
+			j := i
+			for j < len(runes) && !unicode.IsSpace(runes[j]) && !strings.ContainsRune("(),=!~&|\"'", runes[j]) {
+				j++
+			}
+			tokens = append(tokens, condToken{text: string(runes[i:j])})
+			i = j
+		}
+	}
+	return tokens, nil
+}
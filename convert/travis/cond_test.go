@@ -0,0 +1,89 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package travis
+
+import (
+	"testing"
+
+	travis "github.com/drone/go-convert/convert/travis/yaml"
+)
+
+func TestConvertIf(t *testing.T) {
+	tests := []struct {
+		cond string
+		want string
+	}{
+		{
+			cond: `branch = master`,
+			want: `<+codebase.branch> == "master"`,
+		},
+		{
+			cond: `branch = master AND type = push`,
+			want: `<+codebase.branch> == "master" && <+trigger.event> == "PUSH"`,
+		},
+		{
+			cond: `type IN (push, cron) OR tag IS present`,
+			want: `<+trigger.event> == "PUSH" || <+trigger.type> == "Scheduled" || <+codebase.tag> != null`,
+		},
+		{
+			cond: `(branch = main OR branch =~ /^release\/.*$/) AND NOT type = pull_request`,
+			want: `(<+codebase.branch> == "main" || <+codebase.branch> =~ "^release\\/.*$") && !(<+trigger.event> == "PR")`,
+		},
+		{
+			cond: `branch NOT IN (develop, "feature x") && env(DEPLOY) = true`,
+			want: `<+codebase.branch> != "develop" && <+codebase.branch> != "feature x" && <+pipeline.variables.DEPLOY> == "true"`,
+		},
+		{
+			cond: `NOT (tag IS blank OR type != api)`,
+			want: `!(<+codebase.tag> == null || <+trigger.type> != "Manual")`,
+		},
+	}
+	for _, test := range tests {
+		got, err := convertIf(test.cond)
+		if err != nil {
+			t.Errorf("Unexpected error for condition %q: %s", test.cond, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("Want expression %s, got %s", test.want, got)
+		}
+	}
+}
+
+func TestConvertIf_Error(t *testing.T) {
+	tests := []string{
+		`os = linux`,
+		`branch = `,
+		`(branch = master`,
+		`type =~ /push/`,
+		`branch = "master`,
+	}
+	for _, test := range tests {
+		if _, err := convertIf(test); err == nil {
+			t.Errorf("Want error for condition %q", test)
+		}
+	}
+}
+
+func TestConvertBranches(t *testing.T) {
+	got := convertBranches(&travis.Branches{
+		Only:   []string{"main", "/^v\\d+$/"},
+		Except: []string{"legacy"},
+	})
+	want := `(<+codebase.branch> == "main" || <+codebase.branch> =~ "^v\\d+$") && <+codebase.branch> != "legacy"`
+	if got != want {
+		t.Errorf("Want expression %s, got %s", want, got)
+	}
+}
@@ -17,6 +17,7 @@ package travis
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -34,6 +35,34 @@ import (
 // its parents.
 type context struct {
 	config *travis.Pipeline
+
+	// fixed defines matrix axis values that are fixed
+	// for the current stage, and are therefore removed
+	// from the stage matrix.
+	fixed map[string]string
+
+	// errs collects the conversion errors, such as
+	// unsupported conditions. It is shared with the
+	// derived contexts.
+	errs *[]error
+}
+
+// helper function returns a context for the given
+// configuration that shares the parent's errors.
+func (c *context) derive(config *travis.Pipeline, fixed map[string]string) *context {
+	return &context{config: config, fixed: fixed, errs: c.errs}
+}
+
+// helper function converts the travis condition to a
+// harness expression. Unsupported conditions are recorded
+// as errors, since dropping them would widen the condition.
+func (c *context) convertIf(src string) string {
+	expr, err := convertIf(src)
+	if err != nil {
+		*c.errs = append(*c.errs, fmt.Errorf("travis: cannot convert condition %q: %w", src, err))
+		return ""
+	}
+	return expr
 }
 
 // Converter converts a Travis pipeline to a Harness
@@ -83,6 +112,7 @@ func (d *Converter) Convert(r io.Reader) ([]byte, error) {
 	}
 	return d.convert(&context{
 		config: config,
+		errs:   new([]error),
 	})
 }
 
@@ -129,32 +159,209 @@ func (d *Converter) convert(ctx *context) ([]byte, error) {
 		pipeline.Options.Clone = v
 	}
 
-	// conver pipeilne stages
-	pipeline.Stages = append(pipeline.Stages, &harness.Stage{
-		Name:     "pipeline",
+	// convert pipeline stages
+	pipeline.Stages = d.convertStages(ctx)
+	if len(*ctx.errs) != 0 {
+		return nil, errors.Join(*ctx.errs...)
+	}
+
+	// marshal the harness yaml
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// helper function converts the travis build stages and
+// jobs to harness stages. Travis stages execute in order,
+// and the jobs in each stage execute in parallel.
+func (d *Converter) convertStages(ctx *context) []*harness.Stage {
+	root := ctx.config
+	jobs := root.Jobs
+	if jobs == nil {
+		jobs = root.Matrix
+	}
+	if jobs == nil {
+		jobs = new(travis.Jobs)
+	}
+
+	// the root conditions apply to all stages.
+	cond := joinConds(
+		ctx.convertIf(root.If),
+		convertBranches(root.Branches),
+	)
+
+	// group the harness stages by travis stage name,
+	// in order of appearance.
+	var names []string
+	groups := map[string][]*harness.Stage{}
+	add := func(name string, stage *harness.Stage) {
+		key := strings.ToLower(name)
+		if _, ok := groups[key]; !ok {
+			names = append(names, name)
+		}
+		groups[key] = append(groups[key], stage)
+	}
+
+	// the root job expands to the build matrix, unless
+	// the jobs are explicitly included and the root job
+	// does not define matrix expansion keys.
+	if len(jobs.Include) == 0 || hasMatrix(root) {
+		for _, stage := range d.convertMatrix(ctx, jobs) {
+			add(defaultStage, stage)
+		}
+	}
+
+	// jobs without a stage inherit the stage of the
+	// previous job.
+	current := defaultStage
+	for _, job := range jobs.Include {
+		if job == nil {
+			continue
+		}
+		if job.Stage != "" {
+			current = job.Stage
+		}
+		add(current, d.convertJob(ctx, job, current, jobs))
+	}
+
+	// stages execute in the order defined by the stages
+	// section, followed by the order of appearance.
+	var order []string
+	conds := map[string]string{}
+	if root.Stages != nil {
+		for _, stage := range root.Stages.Items {
+			if stage == nil {
+				continue
+			}
+			key := strings.ToLower(stage.Name)
+			if _, ok := groups[key]; ok {
+				order = append(order, stage.Name)
+				conds[key] = ctx.convertIf(stage.If)
+			}
+		}
+	}
+	for _, name := range names {
+		if _, ok := conds[strings.ToLower(name)]; !ok {
+			order = append(order, name)
+		}
+	}
+
+	var dst []*harness.Stage
+	for _, name := range order {
+		key := strings.ToLower(name)
+		stages := groups[key]
+		expr := joinConds(cond, conds[key])
+		if len(stages) == 1 {
+			stage := stages[0]
+			if expr != "" {
+				stage.When = joinWhen(&harness.When{Eval: expr}, whenEval(stage.When))
+			}
+			dst = append(dst, stage)
+			continue
+		}
+		stage := &harness.Stage{
+			Name: d.identifiers.Generate(name),
+			Type: "parallel",
+			Spec: &harness.StageParallel{
+				Stages: stages,
+			},
+		}
+		if expr != "" {
+			stage.When = &harness.When{Eval: expr}
+		}
+		dst = append(dst, stage)
+	}
+	return dst
+}
+
+// helper function converts the root job to a harness
+// stage with a matrix strategy. Matrix combinations that
+// are allowed to fail are excluded from the matrix and
+// converted to separate stages that ignore failures.
+func (d *Converter) convertMatrix(ctx *context, jobs *travis.Jobs) []*harness.Stage {
+	stage := d.convertStage(ctx, "pipeline")
+
+	var exclude []map[string]string
+	for _, entry := range jobs.Exclude {
+		if fixed, ok := matchMatrix(ctx.config, entry, nil); ok && len(fixed) != 0 {
+			exclude = append(exclude, fixed)
+		}
+	}
+
+	dst := []*harness.Stage{stage}
+	for _, entry := range jobs.AllowFailures {
+		fixed, ok := matchMatrix(ctx.config, entry, nil)
+		if !ok {
+			continue
+		}
+		// if the entry does not match a matrix axis the
+		// entire matrix is allowed to fail.
+		if len(fixed) == 0 {
+			stage.Failure = convertAllowFailure()
+			continue
+		}
+		exclude = append(exclude, fixed)
+
+		sub := ctx.derive(ctx.config, fixed)
+		allowed := d.convertStage(sub, d.identifiers.Generate(matrixName(fixed)))
+		allowed.Failure = convertAllowFailure()
+		dst = append(dst, allowed)
+	}
+
+	if spec, ok := strategyMatrix(stage); ok {
+		spec.Exclude = exclude
+	}
+	return dst
+}
+
+// helper function converts a travis job to a harness
+// stage. The job inherits the root job configuration.
+func (d *Converter) convertJob(ctx *context, job *travis.Job, stage string, jobs *travis.Jobs) *harness.Stage {
+	config := mergeJob(ctx.config, &job.Pipeline)
+
+	name := job.Name
+	if name == "" {
+		name = stage
+	}
+	dst := d.convertStage(ctx.derive(config, nil), d.identifiers.Generate(name))
+	if expr := ctx.convertIf(job.If); expr != "" {
+		dst.When = &harness.When{Eval: expr}
+	}
+
+	allowFailure := job.AllowFailure
+	for _, entry := range jobs.AllowFailures {
+		if _, ok := matchMatrix(config, entry, map[string]string{"name": job.Name, "stage": stage}); ok {
+			allowFailure = true
+		}
+	}
+	if allowFailure {
+		dst.Failure = convertAllowFailure()
+	}
+	return dst
+}
+
+// helper function converts the travis job configuration
+// to a harness stage.
+func (d *Converter) convertStage(ctx *context, name string) *harness.Stage {
+	return &harness.Stage{
+		Name:     name,
 		Desc:     "converted from travis.yml",
 		Type:     "ci",
 		Delegate: nil, // No Travis equivalent
 		Failure:  nil, // No Travis equivalent
 		Strategy: convertStrategy(ctx),
-		When:     nil, // TODO convert travis condition (if, branches)
 		Spec: &harness.StageCI{
 			Cache: convertCache(ctx),
 			// TODO support for other env variabes, like TRAVIS_RETHINKDB_VERSION
-			Envs:     createMatrixEnvs(ctx),
+			Envs:     convertEnvs(ctx),
 			Platform: convertPlatform(ctx),
 			Runtime:  nil, // TODO convert runtime
 			Steps:    d.convertSteps(ctx),
 		},
-	})
-
-	// marshal the harness yaml
-	out, err := yaml.Marshal(config)
-	if err != nil {
-		return nil, err
 	}
-
-	return out, nil
 }
 
 func (d *Converter) convertSteps(ctx *context) []*harness.Step {
@@ -206,9 +413,7 @@ func (d *Converter) convertSteps(ctx *context) []*harness.Step {
 	for _, script := range ctx.config.BeforeDeploy {
 		steps = append(steps, d.convertStep(ctx, "before_deploy", script))
 	}
-	//
-	// TODO support deploy steps
-	//
+	steps = append(steps, d.convertDeploys(ctx)...)
 	for _, script := range ctx.config.AfterDeploy {
 		steps = append(steps, d.convertStep(ctx, "after_deploy", script))
 	}
@@ -219,6 +424,12 @@ func (d *Converter) convertSteps(ctx *context) []*harness.Step {
 }
 
 func (d *Converter) convertStep(ctx *context, section, command string) *harness.Step {
+	// the env matrix entries are exported to the shell,
+	// since the entries cannot be mapped to individual
+	// environment variables.
+	if hasEnvMatrix(ctx) {
+		command = "export <+matrix.env>\n" + command
+	}
 	return &harness.Step{
 		Name: d.identifiers.Generate(section),
 		// Desc: "",
@@ -247,63 +458,18 @@ func (d *Converter) convertStep(ctx *context, section, command string) *harness.
 }
 
 func convertStrategy(ctx *context) *harness.Strategy {
-	// TODO env.matrix
-	// TODO jobs
 	// TODO dart_tasks
 
 	// https://config.travis-ci.com/matrix_expansion
 	spec := &harness.Matrix{}
 	spec.Axis = map[string][]string{}
-
-	// helper function to append the axis
-	// to the matrix definition.
-	appendAxis := func(name string, items []string) {
-		// ignore empty matrix
-		if len(items) > 0 {
-			var temp []string
-			for _, item := range items {
-				item = strings.ReplaceAll(item, "1.x", "1")
-				temp = append(temp, item)
-			}
-			spec.Axis[name] = temp
+	for _, axis := range matrixAxis(ctx.config) {
+		// ignore axis values that are fixed for the
+		// current stage.
+		if _, ok := ctx.fixed[axis.name]; !ok {
+			spec.Axis[axis.name] = axis.items
 		}
 	}
-
-	appendAxis("compiler", ctx.config.Compiler)
-	appendAxis("crystal", ctx.config.Crystal)
-	appendAxis("d", ctx.config.D)
-	appendAxis("dart", ctx.config.Dart)
-	appendAxis("dotnet", ctx.config.Dotnet)
-	appendAxis("mono", ctx.config.DotnetMono)
-	appendAxis("solution", ctx.config.DotnetSolution)
-	appendAxis("elixir", ctx.config.Elixir)
-	appendAxis("elm", ctx.config.Elm)
-	appendAxis("otp_release", ctx.config.ErlangOTP)
-	appendAxis("go", ctx.config.Go)
-	appendAxis("hhvm", ctx.config.HHVM)
-	appendAxis("haxe", ctx.config.Haxe)
-	appendAxis("ghc", ctx.config.GHC)
-	appendAxis("jdk", ctx.config.JDK)
-	appendAxis("node_js", ctx.config.Node)
-	appendAxis("julia", ctx.config.Julia)
-	appendAxis("matlab", ctx.config.Matlab)
-	appendAxis("nix", ctx.config.Nix)
-	appendAxis("xcode_scheme", ctx.config.XcodeScheme)
-	appendAxis("xcode_sdk", ctx.config.XcodeSDK)
-	appendAxis("php", ctx.config.PHP)
-	appendAxis("perl", ctx.config.Perl)
-	appendAxis("perl6", ctx.config.Perl6)
-	appendAxis("python", ctx.config.Python)
-	appendAxis("r", ctx.config.R)
-	appendAxis("rvm", append(ctx.config.RubyRVM, append(ctx.config.Ruby, ctx.config.RubyRBenv...)...)) // ruby, rvm, rbenv
-	appendAxis("gemfile", append(ctx.config.RubyGemfile, ctx.config.RubyGemfiles...))                  // gemfile, gemfiles
-	appendAxis("rust", ctx.config.Rust)
-	appendAxis("scala", ctx.config.Scala)
-	appendAxis("smalltalk", ctx.config.Smalltalk)
-	appendAxis("smalltalk_config", ctx.config.SmalltalkConfig)
-	appendAxis("smalltalk_vm", ctx.config.SmalltalkVM)
-	appendAxis("os", ctx.config.OS)
-	appendAxis("arch", ctx.config.Arch)
 	if len(spec.Axis) == 0 {
 		return nil
 	}
@@ -318,6 +484,10 @@ func createMatrixEnvs(ctx *context) map[string]string {
 	envs := map[string]string{}
 
 	appendEnvs := func(name, env string, slice []string) {
+		if v, ok := ctx.fixed[name]; ok {
+			envs[env] = v
+			return
+		}
 		switch len(slice) {
 		case 0:
 		case 1:
@@ -19,6 +19,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	travis "github.com/drone/go-convert/convert/travis/yaml"
 	"github.com/google/go-cmp/cmp"
 	"gopkg.in/yaml.v3"
 )
@@ -70,3 +71,47 @@ func TestConvert(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertUnsupportedCondition(t *testing.T) {
+	src := `
+language: node_js
+deploy:
+  provider: npm
+  api_token: $NPM_TOKEN
+  if: tag IS present AND fork = false
+`
+	if _, err := New().ConvertString(src); err == nil {
+		t.Errorf("expected an error for the unsupported fork attribute")
+	}
+}
+
+func TestConvertDeployCond(t *testing.T) {
+	tests := []struct {
+		deploy *travis.Deploy
+		want   string
+		errors int
+	}{
+		{
+			deploy: &travis.Deploy{Provider: "npm", If: "type = push"},
+			want:   `<+trigger.event> == "PUSH" && <+codebase.branch> == "master"`,
+		},
+		{
+			deploy: &travis.Deploy{Provider: "npm", If: "tag IS present"},
+			want:   `<+codebase.tag> != null`,
+		},
+		{
+			deploy: &travis.Deploy{Provider: "npm", If: "os = osx"},
+			want:   `<+codebase.branch> == "master"`,
+			errors: 1,
+		},
+	}
+	for _, test := range tests {
+		ctx := &context{errs: new([]error)}
+		if got := convertDeployCond(ctx, test.deploy); got != test.want {
+			t.Errorf("want condition %s, got %s", test.want, got)
+		}
+		if got := len(*ctx.errs); got != test.errors {
+			t.Errorf("want %d errors, got %d", test.errors, got)
+		}
+	}
+}
@@ -0,0 +1,362 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package travis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"dario.cat/mergo"
+	travis "github.com/drone/go-convert/convert/travis/yaml"
+	harness "github.com/drone/spec/dist/go"
+)
+
+//
+// this file contains helper functions that convert the
+// travis build matrix and jobs.
+//
+// https://config.travis-ci.com/matrix_expansion
+//
+
+// defaultStage defines the default travis stage name.
+const defaultStage = "test"
+
+// matrixKey maps a travis matrix expansion key to the
+// harness matrix axis.
+type matrixKey struct {
+	axis  string
+	field *travis.Stringorslice
+}
+
+// matrixValues defines the values of a harness matrix
+// axis.
+type matrixValues struct {
+	name  string
+	items []string
+}
+
+// helper function returns the travis matrix expansion
+// keys. Aliases map to the same matrix axis.
+func matrixKeys(p *travis.Pipeline) []matrixKey {
+	return []matrixKey{
+		{"compiler", &p.Compiler},
+		{"crystal", &p.Crystal},
+		{"d", &p.D},
+		{"dart", &p.Dart},
+		{"dotnet", &p.Dotnet},
+		{"mono", &p.DotnetMono},
+		{"solution", &p.DotnetSolution},
+		{"elixir", &p.Elixir},
+		{"elm", &p.Elm},
+		{"otp_release", &p.ErlangOTP},
+		{"go", &p.Go},
+		{"hhvm", &p.HHVM},
+		{"haxe", &p.Haxe},
+		{"ghc", &p.GHC},
+		{"jdk", &p.JDK},
+		{"node_js", &p.Node},
+		{"julia", &p.Julia},
+		{"matlab", &p.Matlab},
+		{"nix", &p.Nix},
+		{"xcode_scheme", &p.XcodeScheme},
+		{"xcode_sdk", &p.XcodeSDK},
+		{"php", &p.PHP},
+		{"perl", &p.Perl},
+		{"perl6", &p.Perl6},
+		{"python", &p.Python},
+		{"r", &p.R},
+		{"rvm", &p.RubyRVM},
+		{"rvm", &p.Ruby},
+		{"rvm", &p.RubyRBenv},
+		{"gemfile", &p.RubyGemfile},
+		{"gemfile", &p.RubyGemfiles},
+		{"rust", &p.Rust},
+		{"scala", &p.Scala},
+		{"smalltalk", &p.Smalltalk},
+		{"smalltalk_config", &p.SmalltalkConfig},
+		{"smalltalk_vm", &p.SmalltalkVM},
+		{"os", &p.OS},
+		{"arch", &p.Arch},
+	}
+}
+
+// matrixAliases maps travis matrix key aliases to the
+// harness matrix axis.
+var matrixAliases = map[string]string{
+	"ruby":     "rvm",
+	"rbenv":    "rvm",
+	"gemfiles": "gemfile",
+}
+
+// helper function returns the harness matrix axes, in
+// the order defined by the travis matrix expansion keys.
+func matrixAxis(p *travis.Pipeline) []matrixValues {
+	var dst []matrixValues
+	index := map[string]int{}
+	for _, key := range matrixKeys(p) {
+		if len(*key.field) == 0 {
+			continue
+		}
+		i, ok := index[key.axis]
+		if !ok {
+			i = len(dst)
+			index[key.axis] = i
+			dst = append(dst, matrixValues{name: key.axis})
+		}
+		for _, item := range *key.field {
+			dst[i].items = append(dst[i].items, normalizeAxis(item))
+		}
+	}
+	// the env matrix is an additional matrix axis, where
+	// each value is a list of key=value pairs.
+	if p.Env != nil && len(p.Env.Jobs) > 1 {
+		axis := matrixValues{name: "env"}
+		for _, env := range p.Env.Jobs {
+			axis.items = append(axis.items, formatEnv(env))
+		}
+		dst = append(dst, axis)
+	}
+	return dst
+}
+
+// helper function normalizes the matrix axis value.
+func normalizeAxis(s string) string {
+	return strings.ReplaceAll(s, "1.x", "1")
+}
+
+// helper function returns true if the pipeline defines
+// matrix expansion keys with more than one value.
+func hasMatrix(p *travis.Pipeline) bool {
+	for _, axis := range matrixAxis(p) {
+		if len(axis.items) > 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// helper function returns true if the env matrix is
+// expanded for the current stage.
+func hasEnvMatrix(ctx *context) bool {
+	if _, ok := ctx.fixed["env"]; ok {
+		return false
+	}
+	return ctx.config.Env != nil && len(ctx.config.Env.Jobs) > 1
+}
+
+// helper function matches a travis exclude or allow
+// failures entry against the pipeline configuration. If
+// the entry matches, the matched matrix axis values are
+// returned. The attrs map defines additional job
+// attributes (e.g. name, stage) that can be matched.
+func matchMatrix(p *travis.Pipeline, entry map[string]string, attrs map[string]string) (map[string]string, bool) {
+	axes := map[string][]string{}
+	for _, axis := range matrixAxis(p) {
+		axes[axis.name] = axis.items
+	}
+	fixed := map[string]string{}
+	for key, val := range entry {
+		if alias, ok := matrixAliases[key]; ok {
+			key = alias
+		}
+		if key == "env" {
+			val = formatEnv(travis.SplitEnv(val))
+		} else {
+			val = normalizeAxis(val)
+		}
+		if items, ok := axes[key]; ok {
+			if !contains(items, val) {
+				return nil, false
+			}
+			fixed[key] = val
+			continue
+		}
+		switch key {
+		case "language":
+			if !strings.EqualFold(p.Language, val) {
+				return nil, false
+			}
+		case "dist":
+			if p.Dist != val {
+				return nil, false
+			}
+		case "env":
+			if p.Env == nil || len(p.Env.Global) == 0 || formatEnv(mergeEnvs(p.Env.Global)) != val {
+				return nil, false
+			}
+		default:
+			if v, ok := attrs[key]; !ok || !strings.EqualFold(v, val) {
+				return nil, false
+			}
+		}
+	}
+	return fixed, true
+}
+
+// helper function returns a stage name for the fixed
+// matrix axis values.
+func matrixName(fixed map[string]string) string {
+	var keys []string
+	for key := range fixed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, key := range keys {
+		parts = append(parts, key+" "+fixed[key])
+	}
+	return strings.Join(parts, " ")
+}
+
+// helper function returns the stage matrix strategy.
+func strategyMatrix(stage *harness.Stage) (*harness.Matrix, bool) {
+	if stage.Strategy == nil {
+		return nil, false
+	}
+	spec, ok := stage.Strategy.Spec.(*harness.Matrix)
+	return spec, ok
+}
+
+// helper function returns a failure strategy that
+// ignores all failures.
+func convertAllowFailure() *harness.FailureList {
+	return &harness.FailureList{
+		Items: []*harness.Failure{
+			{
+				Errors: []string{"all"},
+				Action: &harness.FailureAction{
+					Type: "ignore",
+					Spec: new(harness.Ignore),
+				},
+			},
+		},
+	}
+}
+
+// helper function merges the job configuration with the
+// root configuration. The job inherits the root keys that
+// are not overridden, and the first value of each matrix
+// expansion key.
+func mergeJob(root, job *travis.Pipeline) *travis.Pipeline {
+	base := *root
+	base.Jobs = nil
+	base.Matrix = nil
+	base.Stages = nil
+	base.Branches = nil
+	base.If = ""
+	base.Env = nil
+	for _, key := range matrixKeys(&base) {
+		if len(*key.field) > 1 {
+			*key.field = (*key.field)[:1]
+		}
+	}
+
+	dst := *job
+	dst.Jobs = nil
+	dst.Matrix = nil
+	dst.Stages = nil
+	dst.If = ""
+	// merging values of the same type cannot fail.
+	_ = mergo.Merge(&dst, base)
+
+	// the job inherits the global environment, but does
+	// not inherit the environment matrix.
+	var global []map[string]string
+	if root.Env != nil {
+		global = append(global, root.Env.Global...)
+	}
+	if job.Env != nil {
+		global = append(global, job.Env.Global...)
+		global = append(global, job.Env.Jobs...)
+	}
+	if len(global) != 0 {
+		dst.Env = &travis.Env{Global: global}
+	}
+	return &dst
+}
+
+// helper function converts the stage environment,
+// including the matrix environment variables, the global
+// environment and the fixed env matrix entry.
+func convertEnvs(ctx *context) map[string]string {
+	envs := createMatrixEnvs(ctx)
+	if src := ctx.config.Env; src != nil {
+		var items []map[string]string
+		items = append(items, src.Global...)
+		if len(src.Jobs) == 1 {
+			items = append(items, src.Jobs...)
+		}
+		if v, ok := ctx.fixed["env"]; ok {
+			items = append(items, travis.SplitEnv(v))
+		}
+		for key, val := range mergeEnvs(items) {
+			if envs == nil {
+				envs = map[string]string{}
+			}
+			envs[key] = val
+		}
+	}
+	return envs
+}
+
+// helper function merges the environment maps.
+func mergeEnvs(src []map[string]string) map[string]string {
+	dst := map[string]string{}
+	for _, env := range src {
+		for key, val := range env {
+			dst[key] = val
+		}
+	}
+	return dst
+}
+
+// helper function formats the environment map as a
+// sorted list of space-separated key=value pairs.
+func formatEnv(env map[string]string) string {
+	var keys []string
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, key := range keys {
+		val := env[key]
+		if strings.ContainsAny(val, " \t") {
+			val = fmt.Sprintf("%q", val)
+		}
+		parts = append(parts, key+"="+val)
+	}
+	return strings.Join(parts, " ")
+}
+
+// helper function returns the when clause expression.
+func whenEval(when *harness.When) string {
+	if when == nil {
+		return ""
+	}
+	return when.Eval
+}
+
+// helper function returns true if the slice contains
+// the string.
+func contains(items []string, s string) bool {
+	for _, item := range items {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yaml
+
+import "errors"
+
+type (
+	// Deploys defines a list of deployments.
+	//
+	// https://config.travis-ci.com/ref/deploy
+	Deploys struct {
+		Items []*Deploy
+	}
+
+	// Deploy defines a deployment. The provider-specific
+	// options are stored in the Params map.
+	Deploy struct {
+		Provider    string                 `yaml:"provider,omitempty"`
+		If          string                 `yaml:"if,omitempty"`
+		On          *DeployOn              `yaml:"on,omitempty"`
+		SkipCleanup bool                   `yaml:"skip_cleanup,omitempty"`
+		Cleanup     bool                   `yaml:"cleanup,omitempty"`
+		Edge        interface{}            `yaml:"edge,omitempty"`
+		Params      map[string]interface{} `yaml:",inline"`
+	}
+
+	// DeployOn defines the deployment conditions.
+	DeployOn struct {
+		Branch      Stringorslice `yaml:"branch,omitempty"`
+		Tags        bool          `yaml:"tags,omitempty"`
+		AllBranches bool          `yaml:"all_branches,omitempty"`
+		Repo        string        `yaml:"repo,omitempty"`
+		Condition   Stringorslice `yaml:"condition,omitempty"`
+	}
+)
+
+// UnmarshalYAML implements the unmarshal interface.
+func (v *Deploys) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var out1 *Deploy
+	var out2 []*Deploy
+	if err := unmarshal(&out2); err == nil {
+		v.Items = out2
+		return nil
+	}
+	if err := unmarshal(&out1); err == nil {
+		v.Items = append(v.Items, out1)
+		return nil
+	}
+	return errors.New("failed to unmarshal deploy")
+}
+
+// MarshalYAML implements the marshal interface.
+func (v *Deploys) MarshalYAML() (interface{}, error) {
+	return v.Items, nil
+}
+
+// UnmarshalYAML implements the unmarshal interface.
+func (v *DeployOn) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var out1 string
+	var out2 = struct {
+		Branch      Stringorslice `yaml:"branch"`
+		Branches    Stringorslice `yaml:"branches"` // alias for branch
+		Tags        bool          `yaml:"tags"`
+		AllBranches bool          `yaml:"all_branches"`
+		Repo        string        `yaml:"repo"`
+		Condition   Stringorslice `yaml:"condition"`
+	}{}
+	// a string value is an alias for the branch.
+	if err := unmarshal(&out1); err == nil {
+		v.Branch = Stringorslice{out1}
+		return nil
+	}
+	if err := unmarshal(&out2); err == nil {
+		v.Branch = append(out2.Branch, out2.Branches...)
+		v.Tags = out2.Tags
+		v.AllBranches = out2.AllBranches
+		v.Repo = out2.Repo
+		v.Condition = out2.Condition
+		return nil
+	}
+	return errors.New("failed to unmarshal deploy conditions")
+}
@@ -0,0 +1,82 @@
+// Copyright 2022 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yaml
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDeploys(t *testing.T) {
+	tests := []struct {
+		yaml string
+		want Deploys
+	}{
+		// test map value
+		{
+			yaml: `{ provider: npm, email: octocat@github.com, on: { tags: true } }`,
+			want: Deploys{
+				Items: []*Deploy{
+					{
+						Provider: "npm",
+						On:       &DeployOn{Tags: true},
+						Params:   map[string]interface{}{"email": "octocat@github.com"},
+					},
+				},
+			},
+		},
+		// test map slice value
+		{
+			yaml: `[{ provider: s3, bucket: my-bucket, on: main }, { provider: pages, skip_cleanup: true, on: { branches: [ main, develop ] } }]`,
+			want: Deploys{
+				Items: []*Deploy{
+					{
+						Provider: "s3",
+						On:       &DeployOn{Branch: Stringorslice{"main"}},
+						Params:   map[string]interface{}{"bucket": "my-bucket"},
+					},
+					{
+						Provider:    "pages",
+						SkipCleanup: true,
+						On:          &DeployOn{Branch: Stringorslice{"main", "develop"}},
+					},
+				},
+			},
+		},
+	}
+
+	for i, test := range tests {
+		got := new(Deploys)
+		if err := yaml.Unmarshal([]byte(test.yaml), got); err != nil {
+			t.Log(test.yaml)
+			t.Error(err)
+			return
+		}
+		if diff := cmp.Diff(got, &test.want); diff != "" {
+			t.Log(test.yaml)
+			t.Errorf("Unexpected parsing results for test %v", i)
+			t.Log(diff)
+		}
+	}
+}
+
+func TestDeploys_Error(t *testing.T) {
+	err := yaml.Unmarshal([]byte("[[]]"), new(Deploys))
+	if err == nil || err.Error() != "failed to unmarshal deploy" {
+		t.Errorf("Expect error, got %s", err)
+	}
+}
@@ -31,6 +31,7 @@ func (v *Env) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	var out3 = struct {
 		Global []*Envmap `yaml:"global"`
 		Jobs   []*Envmap `yaml:"jobs"`
+		Matrix []*Envmap `yaml:"matrix"` // alias for jobs
 	}{}
 
 	if err := unmarshal(&out1); err == nil {
@@ -52,6 +53,9 @@ func (v *Env) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		for _, vv := range out3.Jobs {
 			v.Jobs = append(v.Jobs, vv.Items)
 		}
+		for _, vv := range out3.Matrix {
+			v.Jobs = append(v.Jobs, vv.Items)
+		}
 		return nil
 	}
 
@@ -70,23 +74,14 @@ func (v *Envmap) UnmarshalYAML(unmarshal func(interface{}) error) error {
 
 	// fist we attempt to unmarshal a string in key=value format
 	if err := unmarshal(&out1); err == nil {
-		v.Items = map[string]string{}
-		parts := strings.SplitN(out1, "=", 2)
-		if len(parts) == 2 {
-			key := parts[0]
-			val := parts[1]
-			v.Items[key] = val
-		}
+		v.Items = SplitEnv(out1)
 		return nil
 	}
 	// then we attempt to unmarshal a string slice in key=value format
 	if err := unmarshal(&out2); err == nil {
 		v.Items = map[string]string{}
 		for _, vv := range out2 {
-			parts := strings.SplitN(vv, "=", 2)
-			if len(parts) == 2 {
-				key := parts[0]
-				val := parts[1]
+			for key, val := range SplitEnv(vv) {
 				v.Items[key] = val
 			}
 		}
@@ -103,3 +98,36 @@ func (v *Envmap) UnmarshalYAML(unmarshal func(interface{}) error) error {
 
 	return errors.New("failed to unmarshal env")
 }
+
+// SplitEnv splits a string of space-separated key=value
+// pairs (e.g. FOO=foo BAR="bar baz") into a map.
+func SplitEnv(s string) map[string]string {
+	m := map[string]string{}
+	var fields []string
+	var field strings.Builder
+	var quote rune
+	for _, r := range s {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote == 0 && (r == '"' || r == '\''):
+			quote = r
+		case quote == 0 && (r == ' ' || r == '\t'):
+			if field.Len() != 0 {
+				fields = append(fields, field.String())
+				field.Reset()
+			}
+		default:
+			field.WriteRune(r)
+		}
+	}
+	if field.Len() != 0 {
+		fields = append(fields, field.String())
+	}
+	for _, field := range fields {
+		if key, val, ok := strings.Cut(field, "="); ok {
+			m[key] = val
+		}
+	}
+	return m
+}
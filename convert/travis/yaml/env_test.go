@@ -35,6 +35,15 @@ func TestEnv(t *testing.T) {
 				},
 			},
 		},
+		// test string with multiple key=value pairs
+		{
+			yaml: `"FOO=foo BAR='bar baz'"`,
+			want: Env{
+				Global: []map[string]string{
+					{"FOO": "foo", "BAR": "bar baz"},
+				},
+			},
+		},
 		// test map
 		{
 			yaml: `{ FOO: foo }`,
@@ -74,6 +83,16 @@ func TestEnv(t *testing.T) {
 				},
 			},
 		},
+		// test env matrix alias
+		{
+			yaml: `{ matrix: [ FOO=foo, FOO=bar ] }`,
+			want: Env{
+				Jobs: []map[string]string{
+					{"FOO": "foo"},
+					{"FOO": "bar"},
+				},
+			},
+		},
 		// test env struct
 		{
 			yaml: `{ global: [ FOO: foo ], jobs: [ BAR: bar ] }`,
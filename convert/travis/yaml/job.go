@@ -16,27 +16,47 @@ package yaml
 
 import "errors"
 
+// Jobs defines the build matrix jobs.
+//
+// https://config.travis-ci.com/ref/jobs
 type Jobs struct {
-	Include       []map[string]string `yaml:"include,omitempty"`
+	Include       []*Job              `yaml:"include,omitempty"`
 	Exclude       []map[string]string `yaml:"exclude,omitempty"`
 	AllowFailures []map[string]string `yaml:"allow_failures,omitempty"`
 	FastFinish    bool                `yaml:"fast_finish,omitempty"`
 }
 
+// Job defines a build matrix job. A job accepts the same
+// keys as the root pipeline, which the job inherits unless
+// overridden.
+//
+// https://config.travis-ci.com/ref/job
+type Job struct {
+	Name         string `yaml:"name,omitempty"`
+	Stage        string `yaml:"stage,omitempty"`
+	AllowFailure bool   `yaml:"allow_failure,omitempty"`
+	Pipeline     `yaml:",inline"`
+}
+
 // UnmarshalYAML implements the unmarshal interface.
 func (v *Jobs) UnmarshalYAML(unmarshal func(interface{}) error) error {
-	var out1 map[string]string
-	var out2 []map[string]string
+	var out1 map[string]interface{}
+	var out2 []*Job
 	var out3 = struct {
-		Include            []map[string]string `yaml:"include,omitempty"`
+		Include            []*Job              `yaml:"include,omitempty"`
 		Exclude            []map[string]string `yaml:"exclude,omitempty"`
 		AllowFailures      []map[string]string `yaml:"allow_failures,omitempty"`
 		AllowFailuresAlias []map[string]string `yaml:"allowed_failures,omitempty"`
 		FastFinish         bool                `yaml:"fast_finish,omitempty"`
 		FastFinishAlias    bool                `yaml:"fast_failure,omitempty"`
 	}{}
-	if err := unmarshal(&out1); err == nil {
-		v.Include = append(v.Include, out1)
+	// a map without matrix keys is a single job.
+	if err := unmarshal(&out1); err == nil && !isJobsMatrix(out1) {
+		job := new(Job)
+		if err := unmarshal(job); err != nil {
+			return err
+		}
+		v.Include = append(v.Include, job)
 		return nil
 	}
 	if err := unmarshal(&out2); err == nil {
@@ -58,3 +78,21 @@ func (v *Jobs) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	}
 	return errors.New("failed to unmarshal jobs")
 }
+
+// helper function returns true if the map defines the
+// build matrix, as opposed to a single job.
+func isJobsMatrix(m map[string]interface{}) bool {
+	for _, key := range []string{
+		"include",
+		"exclude",
+		"allow_failures",
+		"allowed_failures",
+		"fast_finish",
+		"fast_failure",
+	} {
+		if _, ok := m[key]; ok {
+			return true
+		}
+	}
+	return false
+}
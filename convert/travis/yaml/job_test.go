@@ -30,8 +30,8 @@ func TestJobs(t *testing.T) {
 		{
 			yaml: `{ language: ruby, os: linux, dist: trusty }`,
 			want: Jobs{
-				Include: []map[string]string{
-					{"language": "ruby", "os": "linux", "dist": "trusty"},
+				Include: []*Job{
+					{Pipeline: Pipeline{Language: "ruby", OS: Stringorslice{"linux"}, Dist: "trusty"}},
 				},
 			},
 		},
@@ -39,8 +39,8 @@ func TestJobs(t *testing.T) {
 		{
 			yaml: `[{ language: ruby, os: linux, dist: trusty }]`,
 			want: Jobs{
-				Include: []map[string]string{
-					{"language": "ruby", "os": "linux", "dist": "trusty"},
+				Include: []*Job{
+					{Pipeline: Pipeline{Language: "ruby", OS: Stringorslice{"linux"}, Dist: "trusty"}},
 				},
 			},
 		},
@@ -48,8 +48,8 @@ func TestJobs(t *testing.T) {
 		{
 			yaml: `{ include: [{ language: ruby, os: linux, dist: trusty }], exclude: [{ language: ruby, os: linux, dist: trusty }], allow_failures: [{ language: ruby, os: linux, dist: trusty }] }`,
 			want: Jobs{
-				Include: []map[string]string{
-					{"language": "ruby", "os": "linux", "dist": "trusty"},
+				Include: []*Job{
+					{Pipeline: Pipeline{Language: "ruby", OS: Stringorslice{"linux"}, Dist: "trusty"}},
 				},
 				Exclude: []map[string]string{
 					{"language": "ruby", "os": "linux", "dist": "trusty"},
@@ -59,12 +59,22 @@ func TestJobs(t *testing.T) {
 				},
 			},
 		},
+		// test stages
+		{
+			yaml: `{ include: [{ stage: test, script: make test }, { stage: deploy, name: publish, script: [make, make publish] }] }`,
+			want: Jobs{
+				Include: []*Job{
+					{Stage: "test", Pipeline: Pipeline{Script: Stringorslice{"make test"}}},
+					{Stage: "deploy", Name: "publish", Pipeline: Pipeline{Script: Stringorslice{"make", "make publish"}}},
+				},
+			},
+		},
 		// test fast_finish
 		{
 			yaml: `{ include: [{ language: ruby, os: linux, dist: trusty }], fast_finish: true }`,
 			want: Jobs{
-				Include: []map[string]string{
-					{"language": "ruby", "os": "linux", "dist": "trusty"},
+				Include: []*Job{
+					{Pipeline: Pipeline{Language: "ruby", OS: Stringorslice{"linux"}, Dist: "trusty"}},
 				},
 				FastFinish: true,
 			},
@@ -73,8 +83,8 @@ func TestJobs(t *testing.T) {
 		{
 			yaml: `{ include: [{ language: ruby, os: linux, dist: trusty }], fast_failure: true }`,
 			want: Jobs{
-				Include: []map[string]string{
-					{"language": "ruby", "os": "linux", "dist": "trusty"},
+				Include: []*Job{
+					{Pipeline: Pipeline{Language: "ruby", OS: Stringorslice{"linux"}, Dist: "trusty"}},
 				},
 				FastFinish: true,
 			},
@@ -26,13 +26,14 @@ type (
 		Branches      *Branches      `yaml:"branches,omitempty"`
 		Cache         *Cache         `yaml:"cache,omitempty"`
 		Compiler      Stringorslice  `yaml:"compiler,omitempty"`
-		Deploy        interface{}    `yaml:"deploy,omitempty"` // TODO
+		Deploy        *Deploys       `yaml:"deploy,omitempty"`
 		Dist          string         `yaml:"dist,omitempty"`
 		Env           *Env           `yaml:"env,omitempty"`
 		Git           *Git           `yaml:"git,omitempty"`
 		If            string         `yaml:"if,omitempty"`
 		Import        *Imports       `yaml:"import,omitempty"`
 		Jobs          *Jobs          `yaml:"jobs,omitempty"`
+		Matrix        *Jobs          `yaml:"matrix,omitempty"`        // alias for jobs
 		Notifications *Notifications `yaml:"notifications,omitempty"` // TODO
 		Services      Stringorslice  `yaml:"services,omitempty"`
 		Stages        *Stages        `yaml:"stages,omitempty"`
@@ -0,0 +1,128 @@
+package converthelpers
+
+import (
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+)
+
+// ConvertStepAsgRollingDeploy converts a v0 AsgRollingDeploy step to the v1 asgRollingDeployStep template.
+func ConvertStepAsgRollingDeploy(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepAsgRollingDeploy)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := make(map[string]interface{})
+	if spec.UseAlreadyRunningInstances != nil {
+		with["same_as_running_instances"] = spec.UseAlreadyRunningInstances
+	}
+	if spec.MinimumHealthyPercentage != nil {
+		with["min_healthy_percentage"] = spec.MinimumHealthyPercentage
+	}
+	if spec.InstanceWarmup != nil {
+		with["instance_warmup"] = spec.InstanceWarmup
+	}
+	if spec.SkipMatching != nil {
+		with["skip_matching"] = spec.SkipMatching
+	}
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeAsgRollingDeploy,
+		With: with,
+	}
+}
+
+// ConvertStepAsgRollingRollback converts a v0 AsgRollingRollback step to the v1 asgRollingRollbackStep template.
+func ConvertStepAsgRollingRollback(src *v0.Step) *v1.StepTemplate {
+	if src == nil {
+		return nil
+	}
+	if _, ok := src.Spec.(*v0.StepAsgRollingRollback); src.Spec != nil && !ok {
+		return nil
+	}
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeAsgRollingRollback,
+	}
+}
+
+// ConvertStepAsgBlueGreenDeploy converts a v0 AsgBlueGreenDeploy step to the v1 asgBlueGreenDeployStep template.
+func ConvertStepAsgBlueGreenDeploy(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepAsgBlueGreenDeploy)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := make(map[string]interface{})
+	if len(spec.LoadBalancers) > 0 {
+		lbs := make([]map[string]string, 0, len(spec.LoadBalancers))
+		for _, lb := range spec.LoadBalancers {
+			if lb == nil {
+				continue
+			}
+			entry := map[string]string{
+				"load_balancer":       lb.LoadBalancer,
+				"prod_listener":       lb.ProdListener,
+				"prod_listener_rule":  lb.ProdListenerRuleArn,
+				"stage_listener":      lb.StageListener,
+				"stage_listener_rule": lb.StageListenerRuleArn,
+			}
+			// listener rules are optional; omit the unset ones.
+			for k, v := range entry {
+				if v == "" {
+					delete(entry, k)
+				}
+			}
+			lbs = append(lbs, entry)
+		}
+		with["load_balancers"] = lbs
+	}
+	if spec.UseAlreadyRunningInstances != nil {
+		with["same_as_running_instances"] = spec.UseAlreadyRunningInstances
+	}
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeAsgBlueGreenDeploy,
+		With: with,
+	}
+}
+
+// ConvertStepAsgBlueGreenSwapService converts a v0 AsgBlueGreenSwapService step to the v1
+// asgBlueGreenSwapServiceStep template.
+func ConvertStepAsgBlueGreenSwapService(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepAsgBlueGreenSwapService)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := make(map[string]interface{})
+	if spec.DownsizeOldAsg != nil {
+		with["downsize_old_asg"] = spec.DownsizeOldAsg
+	}
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeAsgBlueGreenSwapService,
+		With: with,
+	}
+}
+
+// ConvertStepAsgBlueGreenRollback converts a v0 AsgBlueGreenRollback step to the v1 asgBlueGreenRollbackStep template.
+func ConvertStepAsgBlueGreenRollback(src *v0.Step) *v1.StepTemplate {
+	if src == nil {
+		return nil
+	}
+	if _, ok := src.Spec.(*v0.StepAsgBlueGreenRollback); src.Spec != nil && !ok {
+		return nil
+	}
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeAsgBlueGreenRollback,
+	}
+}
@@ -0,0 +1,77 @@
+package converthelpers
+
+import (
+	"testing"
+
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+	"github.com/drone/go-convert/internal/flexible"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConvertStepAsg(t *testing.T) {
+	tests := []struct {
+		name     string
+		step     *v0.Step
+		convert  func(*v0.Step) *v1.StepTemplate
+		expected *v1.StepTemplate
+	}{
+		{
+			name: "rolling deploy",
+			step: &v0.Step{
+				Spec: &v0.StepAsgRollingDeploy{
+					UseAlreadyRunningInstances: &flexible.Field[bool]{Value: false},
+					MinimumHealthyPercentage:   &flexible.Field[int]{Value: 90},
+					InstanceWarmup:             &flexible.Field[int]{Value: 60},
+				},
+			},
+			convert: ConvertStepAsgRollingDeploy,
+			expected: &v1.StepTemplate{
+				Uses: "asgRollingDeployStep",
+				With: map[string]interface{}{
+					"same_as_running_instances": &flexible.Field[bool]{Value: false},
+					"min_healthy_percentage":    &flexible.Field[int]{Value: 90},
+					"instance_warmup":           &flexible.Field[int]{Value: 60},
+				},
+			},
+		},
+		{
+			name: "blue green deploy",
+			step: &v0.Step{
+				Spec: &v0.StepAsgBlueGreenDeploy{
+					LoadBalancers: []*v0.AsgLoadBalancer{{
+						LoadBalancer:  "app-lb",
+						ProdListener:  "arn:prod",
+						StageListener: "arn:stage",
+					}},
+				},
+			},
+			convert: ConvertStepAsgBlueGreenDeploy,
+			expected: &v1.StepTemplate{
+				Uses: "asgBlueGreenDeployStep",
+				With: map[string]interface{}{
+					"load_balancers": []map[string]string{{
+						"load_balancer":  "app-lb",
+						"prod_listener":  "arn:prod",
+						"stage_listener": "arn:stage",
+					}},
+				},
+			},
+		},
+		{
+			name:     "blue green rollback",
+			step:     &v0.Step{Spec: &v0.StepAsgBlueGreenRollback{}},
+			convert:  ConvertStepAsgBlueGreenRollback,
+			expected: &v1.StepTemplate{Uses: "asgBlueGreenRollbackStep"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.convert(tt.step)
+			if diff := cmp.Diff(tt.expected, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package converthelpers
+
+import (
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+)
+
+// ConvertStepAzureSlotDeployment converts a v0 AzureSlotDeployment step to the v1 azureWebAppSlotDeployStep template.
+func ConvertStepAzureSlotDeployment(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepAzureSlotDeployment)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := make(map[string]interface{})
+	if spec.WebApp != "" {
+		with["web_app"] = spec.WebApp
+	}
+	if spec.DeploymentSlot != "" {
+		with["deployment_slot"] = spec.DeploymentSlot
+	}
+	if spec.Clean != nil {
+		with["clean"] = spec.Clean
+	}
+
+	// FEATURE GAP: the v0 startup command, application settings and connection
+	// strings are declared on the service, not the step; they are converted with
+	// the service and have no azureWebAppSlotDeployStep input.
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeAzureSlotDeployment,
+		With: with,
+	}
+}
+
+// ConvertStepAzureTrafficShift converts a v0 AzureTrafficShift step to the v1 azureWebAppTrafficShiftStep template.
+func ConvertStepAzureTrafficShift(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepAzureTrafficShift)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := make(map[string]interface{})
+	if spec.Traffic != "" {
+		with["traffic"] = spec.Traffic
+	}
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeAzureTrafficShift,
+		With: with,
+	}
+}
+
+// ConvertStepAzureSwapSlot converts a v0 AzureSwapSlot step to the v1 azureWebAppSwapSlotStep template.
+func ConvertStepAzureSwapSlot(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepAzureSwapSlot)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := make(map[string]interface{})
+	if spec.TargetSlot != "" {
+		with["target_slot"] = spec.TargetSlot
+	}
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeAzureSwapSlot,
+		With: with,
+	}
+}
+
+// ConvertStepAzureWebAppRollback converts a v0 AzureWebAppRollback step to the v1 azureWebAppRollbackStep template.
+func ConvertStepAzureWebAppRollback(src *v0.Step) *v1.StepTemplate {
+	if src == nil {
+		return nil
+	}
+	if _, ok := src.Spec.(*v0.StepAzureWebAppRollback); src.Spec != nil && !ok {
+		return nil
+	}
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeAzureWebAppRollback,
+	}
+}
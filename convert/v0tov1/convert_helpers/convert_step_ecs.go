@@ -0,0 +1,189 @@
+package converthelpers
+
+import (
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+)
+
+// The ECS steps deploy the task and service definitions of the stage service to
+// the cluster of the stage infrastructure, so the templates only take the
+// step-level deployment options.
+
+// ConvertStepEcsRollingDeploy converts a v0 EcsRollingDeploy step to the v1 ecsRollingDeployStep template.
+func ConvertStepEcsRollingDeploy(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepEcsRollingDeploy)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := make(map[string]interface{})
+	if spec.SameAsAlreadyRunningInstances != nil {
+		with["same_as_running_instances"] = spec.SameAsAlreadyRunningInstances
+	}
+	if spec.ForceNewDeployment != nil {
+		with["force_new_deployment"] = spec.ForceNewDeployment
+	}
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeEcsRollingDeploy,
+		With: with,
+	}
+}
+
+// ConvertStepEcsRollingRollback converts a v0 EcsRollingRollback step to the v1 ecsRollingRollbackStep template.
+func ConvertStepEcsRollingRollback(src *v0.Step) *v1.StepTemplate {
+	if src == nil {
+		return nil
+	}
+	if _, ok := src.Spec.(*v0.StepEcsRollingRollback); src.Spec != nil && !ok {
+		return nil
+	}
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeEcsRollingRollback,
+	}
+}
+
+// ConvertStepEcsCanaryDeploy converts a v0 EcsCanaryDeploy step to the v1 ecsCanaryDeployStep template.
+func ConvertStepEcsCanaryDeploy(src *v0.Step) *v1.StepTemplate {
+	if src == nil {
+		return nil
+	}
+	if _, ok := src.Spec.(*v0.StepEcsCanaryDeploy); src.Spec != nil && !ok {
+		return nil
+	}
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeEcsCanaryDeploy,
+	}
+}
+
+// ConvertStepEcsCanaryDelete converts a v0 EcsCanaryDelete step to the v1 ecsCanaryDeleteStep template.
+func ConvertStepEcsCanaryDelete(src *v0.Step) *v1.StepTemplate {
+	if src == nil {
+		return nil
+	}
+	if _, ok := src.Spec.(*v0.StepEcsCanaryDelete); src.Spec != nil && !ok {
+		return nil
+	}
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeEcsCanaryDelete,
+	}
+}
+
+// ConvertStepEcsBlueGreenCreateService converts a v0 EcsBlueGreenCreateService step to the v1
+// ecsBlueGreenCreateServiceStep template.
+func ConvertStepEcsBlueGreenCreateService(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepEcsBlueGreenCreateService)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := make(map[string]interface{})
+	if spec.LoadBalancer != "" {
+		with["load_balancer"] = spec.LoadBalancer
+	}
+	if spec.ProdListener != "" {
+		with["prod_listener"] = spec.ProdListener
+	}
+	if spec.ProdListenerRuleArn != "" {
+		with["prod_listener_rule"] = spec.ProdListenerRuleArn
+	}
+	if spec.StageListener != "" {
+		with["stage_listener"] = spec.StageListener
+	}
+	if spec.StageListenerRuleArn != "" {
+		with["stage_listener_rule"] = spec.StageListenerRuleArn
+	}
+	if spec.SameAsAlreadyRunningInstances != nil {
+		with["same_as_running_instances"] = spec.SameAsAlreadyRunningInstances
+	}
+	if spec.UpdateGreenService != nil {
+		with["update_green_service"] = spec.UpdateGreenService
+	}
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeEcsBlueGreenCreateService,
+		With: with,
+	}
+}
+
+// ConvertStepEcsBlueGreenSwapTargetGroups converts a v0 EcsBlueGreenSwapTargetGroups step to the v1
+// ecsBlueGreenSwapTargetGroupsStep template.
+func ConvertStepEcsBlueGreenSwapTargetGroups(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepEcsBlueGreenSwapTargetGroups)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := make(map[string]interface{})
+	if spec.DoNotDownsizeOldService != nil {
+		with["skip_downsize_old_service"] = spec.DoNotDownsizeOldService
+	}
+	if spec.DownsizeOldServiceDelayInSecs != nil {
+		with["downsize_delay"] = spec.DownsizeOldServiceDelayInSecs
+	}
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeEcsBlueGreenSwapTargetGroups,
+		With: with,
+	}
+}
+
+// ConvertStepEcsBlueGreenRollback converts a v0 EcsBlueGreenRollback step to the v1 ecsBlueGreenRollbackStep template.
+func ConvertStepEcsBlueGreenRollback(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepEcsBlueGreenRollback)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := make(map[string]interface{})
+	if spec.SwitchTrafficAlsoAsRollback != nil {
+		with["switch_traffic"] = spec.SwitchTrafficAlsoAsRollback
+	}
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeEcsBlueGreenRollback,
+		With: with,
+	}
+}
+
+// ConvertStepEcsRunTask converts a v0 EcsRunTask step to the v1 ecsRunTaskStep template.
+func ConvertStepEcsRunTask(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepEcsRunTask)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := make(map[string]interface{})
+	if store := convertProvisionerStore(spec.TaskDefinition); store != nil {
+		with["task_definition"] = store
+	}
+	if spec.TaskDefinitionArn != "" {
+		with["task_definition_arn"] = spec.TaskDefinitionArn
+	}
+	if store := convertProvisionerStore(spec.RunTaskRequestDefinition); store != nil {
+		with["run_task_request"] = store
+	}
+	if spec.SkipSteadyStateCheck != nil {
+		with["skip_steady_state_check"] = spec.SkipSteadyStateCheck
+	}
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeEcsRunTask,
+		With: with,
+	}
+}
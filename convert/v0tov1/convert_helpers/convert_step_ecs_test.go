@@ -0,0 +1,125 @@
+package converthelpers
+
+import (
+	"testing"
+
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+	"github.com/drone/go-convert/internal/flexible"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConvertStepEcs(t *testing.T) {
+	tests := []struct {
+		name     string
+		step     *v0.Step
+		convert  func(*v0.Step) *v1.StepTemplate
+		expected *v1.StepTemplate
+	}{
+		{
+			name: "rolling deploy",
+			step: &v0.Step{
+				Spec: &v0.StepEcsRollingDeploy{
+					SameAsAlreadyRunningInstances: &flexible.Field[bool]{Value: true},
+					ForceNewDeployment:            &flexible.Field[bool]{Value: "<+input>"},
+				},
+			},
+			convert: ConvertStepEcsRollingDeploy,
+			expected: &v1.StepTemplate{
+				Uses: "ecsRollingDeployStep",
+				With: map[string]interface{}{
+					"same_as_running_instances": &flexible.Field[bool]{Value: true},
+					"force_new_deployment":      &flexible.Field[bool]{Value: "<+input>"},
+				},
+			},
+		},
+		{
+			name:     "rolling rollback without spec",
+			step:     &v0.Step{},
+			convert:  ConvertStepEcsRollingRollback,
+			expected: &v1.StepTemplate{Uses: "ecsRollingRollbackStep"},
+		},
+		{
+			name: "blue green create service",
+			step: &v0.Step{
+				Spec: &v0.StepEcsBlueGreenCreateService{
+					LoadBalancer:         "app-lb",
+					ProdListener:         "arn:prod",
+					ProdListenerRuleArn:  "arn:prod-rule",
+					StageListener:        "arn:stage",
+					StageListenerRuleArn: "arn:stage-rule",
+				},
+			},
+			convert: ConvertStepEcsBlueGreenCreateService,
+			expected: &v1.StepTemplate{
+				Uses: "ecsBlueGreenCreateServiceStep",
+				With: map[string]interface{}{
+					"load_balancer":       "app-lb",
+					"prod_listener":       "arn:prod",
+					"prod_listener_rule":  "arn:prod-rule",
+					"stage_listener":      "arn:stage",
+					"stage_listener_rule": "arn:stage-rule",
+				},
+			},
+		},
+		{
+			name: "swap target groups",
+			step: &v0.Step{
+				Spec: &v0.StepEcsBlueGreenSwapTargetGroups{
+					DownsizeOldServiceDelayInSecs: &flexible.Field[int]{Value: 300},
+				},
+			},
+			convert: ConvertStepEcsBlueGreenSwapTargetGroups,
+			expected: &v1.StepTemplate{
+				Uses: "ecsBlueGreenSwapTargetGroupsStep",
+				With: map[string]interface{}{
+					"downsize_delay": &flexible.Field[int]{Value: 300},
+				},
+			},
+		},
+		{
+			name: "run task from remote definition",
+			step: &v0.Step{
+				Spec: &v0.StepEcsRunTask{
+					TaskDefinition: &v0.ProvisionerStore{
+						Type: "Github",
+						Spec: &v0.ProvisionerStoreSpec{
+							ConnectorRef: "github",
+							Branch:       "main",
+							Paths:        &flexible.Field[[]string]{Value: []string{"ecs/task.json"}},
+						},
+					},
+					SkipSteadyStateCheck: &flexible.Field[bool]{Value: true},
+				},
+			},
+			convert: ConvertStepEcsRunTask,
+			expected: &v1.StepTemplate{
+				Uses: "ecsRunTaskStep",
+				With: map[string]interface{}{
+					"task_definition": &ProvisionerStoreWith{
+						Type:      "github",
+						Connector: "github",
+						Branch:    "main",
+						Paths:     &flexible.Field[[]string]{Value: []string{"ecs/task.json"}},
+					},
+					"skip_steady_state_check": &flexible.Field[bool]{Value: true},
+				},
+			},
+		},
+		{
+			name:     "wrong spec type",
+			step:     &v0.Step{Spec: &v0.StepEcsRollingDeploy{}},
+			convert:  ConvertStepEcsCanaryDeploy,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.convert(tt.step)
+			if diff := cmp.Diff(tt.expected, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
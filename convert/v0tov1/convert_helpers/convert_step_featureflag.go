@@ -0,0 +1,154 @@
+package converthelpers
+
+import (
+	"fmt"
+
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	"github.com/drone/go-convert/convert/v0tov1/messagelog"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+	"github.com/drone/go-convert/internal/flexible"
+)
+
+// FlagConfigurationWith is the v1 flagConfigurationStep template input.
+type FlagConfigurationWith struct {
+	Flag         string                `json:"flag,omitempty"`
+	Environment  string                `json:"environment,omitempty"`
+	Instructions []FlagInstructionWith `json:"instructions,omitempty"`
+}
+
+// FlagInstructionWith is a single flag change. Kind selects which of the
+// remaining fields apply.
+type FlagInstructionWith struct {
+	Kind      string                    `json:"kind"`
+	State     string                    `json:"state,omitempty"`
+	Variation string                    `json:"variation,omitempty"`
+	On        string                    `json:"on,omitempty"`
+	Off       string                    `json:"off,omitempty"`
+	Priority  *flexible.Field[int]      `json:"priority,omitempty"`
+	Clauses   []FlagClauseWith          `json:"clauses,omitempty"`
+	Rollout   *FlagRolloutWith          `json:"rollout,omitempty"`
+	Targets   *flexible.Field[[]string] `json:"targets,omitempty"`
+	Segments  *flexible.Field[[]string] `json:"segments,omitempty"`
+}
+
+// FlagClauseWith is a targeting rule clause.
+type FlagClauseWith struct {
+	Attribute string   `json:"attribute,omitempty"`
+	Op        string   `json:"op,omitempty"`
+	Values    []string `json:"values,omitempty"`
+}
+
+// FlagRolloutWith is a percentage rollout across variations.
+type FlagRolloutWith struct {
+	BucketBy   string           `json:"bucket_by,omitempty"`
+	Variations []FlagWeightWith `json:"variations,omitempty"`
+}
+
+// FlagWeightWith is the share of a rollout served a variation.
+type FlagWeightWith struct {
+	Variation string               `json:"variation,omitempty"`
+	Weight    *flexible.Field[int] `json:"weight,omitempty"`
+}
+
+// flagInstructionKinds maps the v0 instruction types to the v1 instruction kinds.
+var flagInstructionKinds = map[string]string{
+	v0.FlagInstructionSetFeatureFlagState:               "set_state",
+	v0.FlagInstructionSetOnVariation:                    "set_on_variation",
+	v0.FlagInstructionSetOffVariation:                   "set_off_variation",
+	v0.FlagInstructionSetDefaultVariations:              "set_default_variations",
+	v0.FlagInstructionAddRule:                           "add_rule",
+	v0.FlagInstructionAddTargetsToVariationTargetMap:    "add_targets",
+	v0.FlagInstructionRemoveTargetsToVariationTargetMap: "remove_targets",
+	v0.FlagInstructionAddSegmentToVariationTargetMap:    "add_segments",
+	v0.FlagInstructionRemoveSegmentToVariationTargetMap: "remove_segments",
+}
+
+// ConvertStepFlagConfiguration converts a v0 FlagConfiguration step to the v1
+// flagConfigurationStep template. Instructions keep their order; instructions
+// of an unknown type are dropped with an error message.
+func ConvertStepFlagConfiguration(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepFlagConfiguration)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := FlagConfigurationWith{
+		Flag:        spec.Feature,
+		Environment: spec.Environment,
+	}
+	for _, instruction := range spec.Instructions {
+		if instruction == nil {
+			continue
+		}
+		kind, ok := flagInstructionKinds[instruction.Type]
+		if !ok {
+			messagelog.GetMessageLogger().LogError(
+				"UNSUPPORTED_FLAG_INSTRUCTION",
+				fmt.Sprintf("flag configuration instruction type %q is not supported", instruction.Type),
+				messagelog.WithStep(src.ID, src.Type),
+			)
+			continue
+		}
+		with.Instructions = append(with.Instructions, convertFlagInstruction(kind, instruction.Spec))
+	}
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeFeatureFlagConfiguration,
+		With: with,
+	}
+}
+
+func convertFlagInstruction(kind string, spec *v0.FlagInstructionSpec) FlagInstructionWith {
+	dst := FlagInstructionWith{Kind: kind}
+	if spec == nil {
+		return dst
+	}
+	dst.State = spec.State
+	dst.Variation = spec.Variation
+	dst.On = spec.On
+	dst.Off = spec.Off
+	dst.Priority = spec.Priority
+	dst.Targets = spec.Targets
+	dst.Segments = spec.Segments
+	dst.Clauses = convertFlagClauses(spec.Clauses)
+
+	// AddRule serves either a single variation or a percentage rollout.
+	if spec.Serve != nil && dst.Variation == "" {
+		dst.Variation = spec.Serve.Variation
+	}
+	if d := spec.Distribution; d != nil {
+		if len(dst.Clauses) == 0 {
+			dst.Clauses = convertFlagClauses(d.Clauses)
+		}
+		rollout := &FlagRolloutWith{BucketBy: d.BucketBy}
+		for _, v := range d.Variations {
+			if v == nil {
+				continue
+			}
+			rollout.Variations = append(rollout.Variations, FlagWeightWith{
+				Variation: v.Variation,
+				Weight:    v.Weight,
+			})
+		}
+		dst.Rollout = rollout
+	}
+	return dst
+}
+
+func convertFlagClauses(clauses []*v0.FlagClause) []FlagClauseWith {
+	var dst []FlagClauseWith
+	for _, c := range clauses {
+		if c == nil {
+			continue
+		}
+		dst = append(dst, FlagClauseWith{
+			Attribute: c.Attribute,
+			Op:        c.Op,
+			Values:    c.Values,
+		})
+	}
+	return dst
+}
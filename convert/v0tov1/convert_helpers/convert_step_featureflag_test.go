@@ -0,0 +1,84 @@
+package converthelpers
+
+import (
+	"encoding/json"
+	"testing"
+
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+	"github.com/drone/go-convert/internal/flexible"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConvertStepFlagConfiguration(t *testing.T) {
+	src := `{
+		"identifier": "enableCheckout",
+		"type": "FlagConfiguration",
+		"spec": {
+			"feature": "new_checkout",
+			"environment": "prod",
+			"instructions": [
+				{"identifier": "SetFeatureFlagStateIdentifier", "type": "SetFeatureFlagState", "spec": {"state": "on"}},
+				{"identifier": "SetDefaultVariationsIdentifier", "type": "SetDefaultVariations", "spec": {"on": "true", "off": "false"}},
+				{"identifier": "AddRuleIdentifier_1", "type": "AddRule", "spec": {
+					"priority": 100,
+					"serve": {"variation": "true"},
+					"clauses": [{"op": "segmentMatch", "values": ["beta_users"]}]
+				}},
+				{"identifier": "AddRuleIdentifier_2", "type": "AddRule", "spec": {
+					"priority": 101,
+					"distribution": {
+						"bucketBy": "identifier",
+						"clauses": [{"attribute": "identifier", "op": "segmentMatch", "values": ["everyone"]}],
+						"variations": [{"variation": "true", "weight": 20}, {"variation": "false", "weight": 80}]
+					}
+				}},
+				{"identifier": "AddTargetsIdentifier", "type": "AddTargetsToVariationTargetMap", "spec": {"variation": "true", "targets": ["qa_team"]}},
+				{"identifier": "AddSegmentsIdentifier", "type": "AddSegmentToVariationTargetMap", "spec": {"variation": "false", "segments": ["legacy"]}},
+				{"identifier": "Unknown", "type": "SetFlagSwitch", "spec": {}}
+			]
+		}
+	}`
+
+	step := new(v0.Step)
+	if err := json.Unmarshal([]byte(src), step); err != nil {
+		t.Fatal(err)
+	}
+
+	want := &v1.StepTemplate{
+		Uses: "flagConfigurationStep",
+		With: FlagConfigurationWith{
+			Flag:        "new_checkout",
+			Environment: "prod",
+			Instructions: []FlagInstructionWith{
+				{Kind: "set_state", State: "on"},
+				{Kind: "set_default_variations", On: "true", Off: "false"},
+				{
+					Kind:      "add_rule",
+					Priority:  &flexible.Field[int]{Value: 100},
+					Variation: "true",
+					Clauses:   []FlagClauseWith{{Op: "segmentMatch", Values: []string{"beta_users"}}},
+				},
+				{
+					Kind:     "add_rule",
+					Priority: &flexible.Field[int]{Value: 101},
+					Clauses:  []FlagClauseWith{{Attribute: "identifier", Op: "segmentMatch", Values: []string{"everyone"}}},
+					Rollout: &FlagRolloutWith{
+						BucketBy: "identifier",
+						Variations: []FlagWeightWith{
+							{Variation: "true", Weight: &flexible.Field[int]{Value: 20}},
+							{Variation: "false", Weight: &flexible.Field[int]{Value: 80}},
+						},
+					},
+				},
+				{Kind: "add_targets", Variation: "true", Targets: &flexible.Field[[]string]{Value: []string{"qa_team"}}},
+				{Kind: "add_segments", Variation: "false", Segments: &flexible.Field[[]string]{Value: []string{"legacy"}}},
+			},
+		},
+	}
+
+	got := ConvertStepFlagConfiguration(step)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ConvertStepFlagConfiguration() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,86 @@
+package converthelpers
+
+import (
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+)
+
+// ConvertStepDeployCloudFunction converts a v0 DeployCloudFunction step to the v1
+// googleCloudFunctionDeployStep template.
+func ConvertStepDeployCloudFunction(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepDeployCloudFunction)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := make(map[string]interface{})
+	if spec.UpdateFieldMask != "" {
+		with["update_field_mask"] = spec.UpdateFieldMask
+	}
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeDeployCloudFunction,
+		With: with,
+	}
+}
+
+// ConvertStepDeployCloudFunctionWithNoTraffic converts a v0 DeployCloudFunctionWithNoTraffic step
+// to the v1 googleCloudFunctionDeployWithoutTrafficStep template.
+func ConvertStepDeployCloudFunctionWithNoTraffic(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepDeployCloudFunctionWithNoTraffic)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := make(map[string]interface{})
+	if spec.UpdateFieldMask != "" {
+		with["update_field_mask"] = spec.UpdateFieldMask
+	}
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeDeployCloudFunctionWithNoTraffic,
+		With: with,
+	}
+}
+
+// ConvertStepCloudFunctionTrafficShift converts a v0 CloudFunctionTrafficShift step to the v1
+// googleCloudFunctionTrafficShiftStep template.
+func ConvertStepCloudFunctionTrafficShift(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepCloudFunctionTrafficShift)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := make(map[string]interface{})
+	if spec.TrafficPercent != nil {
+		with["traffic_percent"] = instancesToString(spec.TrafficPercent)
+	}
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeCloudFunctionTrafficShift,
+		With: with,
+	}
+}
+
+// ConvertStepCloudFunctionRollback converts a v0 CloudFunctionRollback step to the v1
+// googleCloudFunctionRollbackStep template.
+func ConvertStepCloudFunctionRollback(src *v0.Step) *v1.StepTemplate {
+	if src == nil {
+		return nil
+	}
+	if _, ok := src.Spec.(*v0.StepCloudFunctionRollback); src.Spec != nil && !ok {
+		return nil
+	}
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeCloudFunctionRollback,
+	}
+}
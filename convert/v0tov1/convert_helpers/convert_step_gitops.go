@@ -0,0 +1,187 @@
+package converthelpers
+
+import (
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+)
+
+// The GitOps steps act on the release repo and applications linked to the
+// stage service and environment, so the templates only take the step-level
+// options.
+
+// gitOpsVariables converts the v0 release repo variables to the v1 key/value list.
+func gitOpsVariables(vars []*v0.Variable) []map[string]interface{} {
+	if len(vars) == 0 {
+		return nil
+	}
+	dst := make([]map[string]interface{}, 0, len(vars))
+	for _, v := range vars {
+		if v == nil || v.Name == "" {
+			continue
+		}
+		dst = append(dst, map[string]interface{}{"key": v.Name, "value": v.Value})
+	}
+	return dst
+}
+
+// ConvertStepGitOpsUpdateReleaseRepo converts a v0 GitOpsUpdateReleaseRepo step to the v1 gitopsUpdateReleaseRepoStep template.
+func ConvertStepGitOpsUpdateReleaseRepo(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepGitOpsUpdateReleaseRepo)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := make(map[string]interface{})
+	if vars := gitOpsVariables(spec.Variables); len(vars) > 0 {
+		with["variables"] = vars
+	}
+	if spec.PrTitle != "" {
+		with["pr_title"] = spec.PrTitle
+	}
+	if spec.AllowEmptyCommit != nil {
+		with["allow_empty_commit"] = spec.AllowEmptyCommit
+	}
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeGitOpsUpdateReleaseRepo,
+		With: with,
+	}
+}
+
+// ConvertStepGitOpsMergePR converts a v0 MergePR step to the v1 gitopsMergePrStep template.
+func ConvertStepGitOpsMergePR(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepGitOpsMergePR)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := make(map[string]interface{})
+	if spec.DeleteSourceBranch != nil {
+		with["delete_source_branch"] = spec.DeleteSourceBranch
+	}
+	if vars := gitOpsVariables(spec.Variables); len(vars) > 0 {
+		with["variables"] = vars
+	}
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeGitOpsMergePR,
+		With: with,
+	}
+}
+
+// ConvertStepGitOpsFetchLinkedApps converts a v0 GitOpsFetchLinkedApps step to the v1 gitopsFetchLinkedAppsStep template.
+func ConvertStepGitOpsFetchLinkedApps(src *v0.Step) *v1.StepTemplate {
+	if src == nil {
+		return nil
+	}
+	if _, ok := src.Spec.(*v0.StepGitOpsFetchLinkedApps); src.Spec != nil && !ok {
+		return nil
+	}
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeGitOpsFetchLinkedApps,
+	}
+}
+
+// ConvertStepGitOpsSync converts a v0 GitOpsSync step to the v1 gitopsSyncStep template.
+func ConvertStepGitOpsSync(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepGitOpsSync)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := make(map[string]interface{})
+	if spec.Prune != nil {
+		with["prune"] = spec.Prune
+	}
+	if spec.DryRun != nil {
+		with["dry_run"] = spec.DryRun
+	}
+	if spec.ApplyOnly != nil {
+		with["apply_only"] = spec.ApplyOnly
+	}
+	if spec.ForceApply != nil {
+		with["force_apply"] = spec.ForceApply
+	}
+	if len(spec.ApplicationsList) > 0 {
+		apps := make([]map[string]string, 0, len(spec.ApplicationsList))
+		for _, app := range spec.ApplicationsList {
+			if app == nil {
+				continue
+			}
+			apps = append(apps, map[string]string{"name": app.ApplicationName, "agent": app.AgentID})
+		}
+		with["applications"] = apps
+	}
+	if r := spec.RetryStrategy; r != nil {
+		if r.Limit != nil {
+			with["retry_limit"] = r.Limit
+		}
+		if r.BaseBackoffDuration != "" {
+			with["retry_backoff_duration"] = r.BaseBackoffDuration
+		}
+		if r.IncreaseBackoffByFactor != nil {
+			with["retry_backoff_factor"] = r.IncreaseBackoffByFactor
+		}
+		if r.MaxBackoffDuration != "" {
+			with["retry_max_backoff_duration"] = r.MaxBackoffDuration
+		}
+	}
+	if o := spec.SyncOptions; o != nil {
+		if o.SkipSchemaValidation != nil {
+			with["skip_schema_validation"] = o.SkipSchemaValidation
+		}
+		if o.AutoCreateNamespace != nil {
+			with["auto_create_namespace"] = o.AutoCreateNamespace
+		}
+		if o.PruneResourcesAtLast != nil {
+			with["prune_last"] = o.PruneResourcesAtLast
+		}
+		if o.ApplyOutOfSyncOnly != nil {
+			with["apply_out_of_sync_only"] = o.ApplyOutOfSyncOnly
+		}
+		if o.ReplaceResources != nil {
+			with["replace"] = o.ReplaceResources
+		}
+		if o.PrunePropagationPolicy != "" {
+			with["prune_propagation_policy"] = o.PrunePropagationPolicy
+		}
+	}
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeGitOpsSync,
+		With: with,
+	}
+}
+
+// ConvertStepGitOpsRevertPR converts a v0 RevertPR step to the v1 gitopsRevertPrStep template.
+func ConvertStepGitOpsRevertPR(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepGitOpsRevertPR)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := make(map[string]interface{})
+	if spec.CommitID != "" {
+		with["commit_id"] = spec.CommitID
+	}
+	if spec.PrTitle != "" {
+		with["pr_title"] = spec.PrTitle
+	}
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeGitOpsRevertPR,
+		With: with,
+	}
+}
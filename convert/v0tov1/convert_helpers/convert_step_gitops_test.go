@@ -0,0 +1,130 @@
+package converthelpers
+
+import (
+	"encoding/json"
+	"testing"
+
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+	"github.com/drone/go-convert/internal/flexible"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConvertStepGitOps(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		convert  func(*v0.Step) *v1.StepTemplate
+		expected *v1.StepTemplate
+	}{
+		{
+			name: "update release repo",
+			src: `{
+				"identifier": "updateRepo",
+				"type": "GitOpsUpdateReleaseRepo",
+				"spec": {
+					"variables": [{"name": "image_tag", "type": "String", "value": "<+artifacts.primary.tag>"}],
+					"prTitle": "Release <+pipeline.sequenceId>"
+				}
+			}`,
+			convert: ConvertStepGitOpsUpdateReleaseRepo,
+			expected: &v1.StepTemplate{
+				Uses: "gitopsUpdateReleaseRepoStep",
+				With: map[string]interface{}{
+					"variables": []map[string]interface{}{{"key": "image_tag", "value": "<+artifacts.primary.tag>"}},
+					"pr_title":  "Release <+pipeline.sequenceId>",
+				},
+			},
+		},
+		{
+			name: "merge pr",
+			src: `{
+				"identifier": "merge",
+				"type": "MergePR",
+				"spec": {"deleteSourceBranch": true}
+			}`,
+			convert: ConvertStepGitOpsMergePR,
+			expected: &v1.StepTemplate{
+				Uses: "gitopsMergePrStep",
+				With: map[string]interface{}{
+					"delete_source_branch": &flexible.Field[bool]{Value: true},
+				},
+			},
+		},
+		{
+			name: "sync",
+			src: `{
+				"identifier": "sync",
+				"type": "GitOpsSync",
+				"spec": {
+					"prune": true,
+					"applicationsList": [{"applicationName": "checkout", "agentId": "prodagent"}],
+					"retryStrategy": {"limit": 3, "baseBackoffDuration": "5s"},
+					"syncOptions": {"autoCreateNamespace": true, "prunePropagationPolicy": "foreground"}
+				}
+			}`,
+			convert: ConvertStepGitOpsSync,
+			expected: &v1.StepTemplate{
+				Uses: "gitopsSyncStep",
+				With: map[string]interface{}{
+					"prune":                    &flexible.Field[bool]{Value: true},
+					"applications":             []map[string]string{{"name": "checkout", "agent": "prodagent"}},
+					"retry_limit":              &flexible.Field[int]{Value: 3},
+					"retry_backoff_duration":   "5s",
+					"auto_create_namespace":    &flexible.Field[bool]{Value: true},
+					"prune_propagation_policy": "foreground",
+				},
+			},
+		},
+		{
+			name: "revert pr",
+			src: `{
+				"identifier": "revert",
+				"type": "RevertPR",
+				"spec": {"commitId": "<+pipeline.stages.gitops.spec.execution.steps.merge.commitId>", "prTitle": "Revert"}
+			}`,
+			convert: ConvertStepGitOpsRevertPR,
+			expected: &v1.StepTemplate{
+				Uses: "gitopsRevertPrStep",
+				With: map[string]interface{}{
+					"commit_id": "<+pipeline.stages.gitops.spec.execution.steps.merge.commitId>",
+					"pr_title":  "Revert",
+				},
+			},
+		},
+		{
+			name: "policy",
+			src: `{
+				"identifier": "opa",
+				"type": "Policy",
+				"spec": {
+					"policySets": ["account.require_approval"],
+					"type": "Custom",
+					"policySpec": {"payload": "{\"service\": \"checkout\"}"}
+				}
+			}`,
+			convert: ConvertStepPolicy,
+			expected: &v1.StepTemplate{
+				Uses: "policyStep",
+				With: map[string]interface{}{
+					"policy_sets": &flexible.Field[[]string]{Value: []string{"account.require_approval"}},
+					"type":        "custom",
+					"payload":     `{"service": "checkout"}`,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := new(v0.Step)
+			if err := json.Unmarshal([]byte(tt.src), step); err != nil {
+				t.Fatal(err)
+			}
+			got := tt.convert(step)
+			if diff := cmp.Diff(tt.expected, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package converthelpers
+
+import (
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+)
+
+// The AWS Lambda steps deploy the function definition of the stage service to
+// the region of the stage infrastructure, so the templates take no step-level
+// inputs.
+
+// ConvertStepAwsLambdaDeploy converts a v0 AwsLambdaDeploy step to the v1 awsLambdaDeployStep template.
+func ConvertStepAwsLambdaDeploy(src *v0.Step) *v1.StepTemplate {
+	if src == nil {
+		return nil
+	}
+	if _, ok := src.Spec.(*v0.StepAwsLambdaDeploy); src.Spec != nil && !ok {
+		return nil
+	}
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeAwsLambdaDeploy,
+	}
+}
+
+// ConvertStepAwsLambdaRollback converts a v0 AwsLambdaRollback step to the v1 awsLambdaRollbackStep template.
+func ConvertStepAwsLambdaRollback(src *v0.Step) *v1.StepTemplate {
+	if src == nil {
+		return nil
+	}
+	if _, ok := src.Spec.(*v0.StepAwsLambdaRollback); src.Spec != nil && !ok {
+		return nil
+	}
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeAwsLambdaRollback,
+	}
+}
@@ -0,0 +1,36 @@
+package converthelpers
+
+import (
+	"strings"
+
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+)
+
+// ConvertStepPolicy converts a v0 Policy step to the v1 policyStep template.
+// The policy sets are evaluated against the payload of the Custom policy type.
+func ConvertStepPolicy(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepPolicy)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := make(map[string]interface{})
+	if spec.PolicySets != nil {
+		with["policy_sets"] = spec.PolicySets
+	}
+	if spec.Type != "" {
+		with["type"] = strings.ToLower(spec.Type)
+	}
+	if spec.PolicySpec != nil && spec.PolicySpec.Payload != "" {
+		with["payload"] = spec.PolicySpec.Payload
+	}
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypePolicy,
+		With: with,
+	}
+}
@@ -0,0 +1,553 @@
+package converthelpers
+
+import (
+	"strings"
+
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+	"github.com/drone/go-convert/internal/flexible"
+)
+
+// ProvisionerStoreWith is the v1 template shape of a remote file store
+// (config files, var files, backend config, CloudFormation templates).
+type ProvisionerStoreWith struct {
+	Type       string                    `json:"type,omitempty"`
+	Connector  string                    `json:"connector,omitempty"`
+	Repo       string                    `json:"repo,omitempty"`
+	Branch     string                    `json:"branch,omitempty"`
+	Commit     string                    `json:"commit,omitempty"`
+	FolderPath string                    `json:"folder_path,omitempty"`
+	Paths      *flexible.Field[[]string] `json:"paths,omitempty"`
+	Bucket     string                    `json:"bucket,omitempty"`
+	Region     string                    `json:"region,omitempty"`
+}
+
+// ProvisionerVarFileWith is a single entry of the v1 template `var_files` input.
+type ProvisionerVarFileWith struct {
+	Identifier string                `json:"identifier,omitempty"`
+	Type       string                `json:"type,omitempty"`
+	Content    string                `json:"content,omitempty"`
+	Store      *ProvisionerStoreWith `json:"store,omitempty"`
+	Optional   *flexible.Field[bool] `json:"optional,omitempty"`
+}
+
+// ProvisionerBackendConfigWith is the v1 template `backend_config` input.
+type ProvisionerBackendConfigWith struct {
+	Type    string                `json:"type,omitempty"`
+	Content string                `json:"content,omitempty"`
+	Store   *ProvisionerStoreWith `json:"store,omitempty"`
+}
+
+// TerraformExecutionWith holds the inline Terraform configuration inputs shared
+// by the plan, apply and destroy templates.
+type TerraformExecutionWith struct {
+	Workspace               string                        `json:"workspace,omitempty"`
+	ConfigFiles             *ProvisionerStoreWith         `json:"config_files,omitempty"`
+	UseConnectorCredentials *flexible.Field[bool]         `json:"use_connector_credentials,omitempty"`
+	VarFiles                []ProvisionerVarFileWith      `json:"var_files,omitempty"`
+	BackendConfig           *ProvisionerBackendConfigWith `json:"backend_config,omitempty"`
+	Targets                 *flexible.Field[[]string]     `json:"targets,omitempty"`
+	Envvars                 []map[string]string           `json:"env_vars,omitempty"`
+	SkipRefresh             *flexible.Field[bool]         `json:"skip_refresh,omitempty"`
+	Flags                   []map[string]string           `json:"flags,omitempty"`
+}
+
+type TerraformPlanWith struct {
+	ProvisionerIdentifier string `json:"provisioner_identifier,omitempty"`
+	Command               string `json:"command,omitempty"`
+	TerraformExecutionWith
+	ExportPlanJson          *flexible.Field[bool] `json:"export_plan_json,omitempty"`
+	ExportHumanReadablePlan *flexible.Field[bool] `json:"export_human_readable_plan,omitempty"`
+}
+
+type TerraformApplyWith struct {
+	ProvisionerIdentifier string `json:"provisioner_identifier,omitempty"`
+	InheritFrom           string `json:"inherit_from,omitempty"`
+	TerraformExecutionWith
+	SkipStateStorage *flexible.Field[bool] `json:"skip_state_storage,omitempty"`
+}
+
+type TerraformRollbackWith struct {
+	ProvisionerIdentifier string                `json:"provisioner_identifier,omitempty"`
+	SkipRefresh           *flexible.Field[bool] `json:"skip_refresh,omitempty"`
+	Flags                 []map[string]string   `json:"flags,omitempty"`
+}
+
+type TerragruntPlanWith struct {
+	ProvisionerIdentifier string `json:"provisioner_identifier,omitempty"`
+	Command               string `json:"command,omitempty"`
+	TerraformExecutionWith
+	RunType        string                `json:"run_type,omitempty"`
+	ModulePath     string                `json:"module_path,omitempty"`
+	ExportPlanJson *flexible.Field[bool] `json:"export_plan_json,omitempty"`
+}
+
+type TerragruntApplyWith struct {
+	ProvisionerIdentifier string `json:"provisioner_identifier,omitempty"`
+	InheritFrom           string `json:"inherit_from,omitempty"`
+	TerraformExecutionWith
+	RunType    string `json:"run_type,omitempty"`
+	ModulePath string `json:"module_path,omitempty"`
+}
+
+type TerragruntRollbackWith struct {
+	ProvisionerIdentifier string `json:"provisioner_identifier,omitempty"`
+}
+
+type CloudformationCreateStackWith struct {
+	ProvisionerIdentifier string                    `json:"provisioner_identifier,omitempty"`
+	StackName             string                    `json:"stack_name,omitempty"`
+	Connector             string                    `json:"connector,omitempty"`
+	Region                string                    `json:"region,omitempty"`
+	RoleArn               string                    `json:"role_arn,omitempty"`
+	TemplateType          string                    `json:"template_type,omitempty"`
+	TemplateBody          string                    `json:"template_body,omitempty"`
+	TemplateUrl           string                    `json:"template_url,omitempty"`
+	TemplateStore         *ProvisionerStoreWith     `json:"template_store,omitempty"`
+	ParameterFiles        []*ProvisionerStoreWith   `json:"parameter_files,omitempty"`
+	ParameterOverrides    []map[string]string       `json:"parameter_overrides,omitempty"`
+	Capabilities          *flexible.Field[[]string] `json:"capabilities,omitempty"`
+	Tags                  string                    `json:"tags,omitempty"`
+	TagsStore             *ProvisionerStoreWith     `json:"tags_store,omitempty"`
+	SkipOnStackStatuses   *flexible.Field[[]string] `json:"skip_on_stack_statuses,omitempty"`
+	SkipWaitForResources  *flexible.Field[bool]     `json:"skip_wait_for_resources,omitempty"`
+}
+
+type CloudformationDeleteStackWith struct {
+	ProvisionerIdentifier string `json:"provisioner_identifier,omitempty"`
+	StackName             string `json:"stack_name,omitempty"`
+	Connector             string `json:"connector,omitempty"`
+	Region                string `json:"region,omitempty"`
+	RoleArn               string `json:"role_arn,omitempty"`
+}
+
+type CloudformationRollbackStackWith struct {
+	ProvisionerIdentifier string `json:"provisioner_identifier,omitempty"`
+}
+
+// convertProvisionerStore maps a v0 provisioner store ({type, spec}) to the v1
+// template store input. Git stores fetch either a branch or a commit; the
+// Harness file store `files`, Artifactory `artifactPaths` and S3 `urls` all map
+// to the template `paths` input.
+func convertProvisionerStore(store *v0.ProvisionerStore) *ProvisionerStoreWith {
+	if store == nil {
+		return nil
+	}
+	out := &ProvisionerStoreWith{Type: strings.ToLower(store.Type)}
+	spec := store.Spec
+	if spec == nil {
+		return out
+	}
+	out.Connector = spec.ConnectorRef
+	out.Repo = spec.RepoName
+	if spec.RepositoryName != "" {
+		out.Repo = spec.RepositoryName
+	}
+	if spec.GitFetchType == "Commit" {
+		out.Commit = spec.CommitId
+	} else {
+		out.Branch = spec.Branch
+	}
+	out.FolderPath = spec.FolderPath
+	out.Bucket = spec.Bucket
+	out.Region = spec.Region
+	for _, paths := range []*flexible.Field[[]string]{spec.Paths, spec.Files, spec.ArtifactPaths, spec.URLs} {
+		if paths != nil {
+			out.Paths = paths
+			break
+		}
+	}
+	return out
+}
+
+// convertProvisionerVarFiles maps v0 varFiles ([]{varFile: {identifier, type, spec}})
+// to the v1 template `var_files` input.
+func convertProvisionerVarFiles(files []*v0.TerraformVarFileWrapper) []ProvisionerVarFileWith {
+	var out []ProvisionerVarFileWith
+	for _, f := range files {
+		if f == nil || f.VarFile == nil {
+			continue
+		}
+		varFile := ProvisionerVarFileWith{
+			Identifier: f.VarFile.Identifier,
+			Type:       strings.ToLower(f.VarFile.Type),
+		}
+		if spec := f.VarFile.Spec; spec != nil {
+			varFile.Content = spec.Content
+			varFile.Store = convertProvisionerStore(spec.Store)
+			varFile.Optional = spec.Optional
+		}
+		out = append(out, varFile)
+	}
+	return out
+}
+
+// convertProvisionerBackendConfig maps a v0 Inline or Remote backendConfig to
+// the v1 template `backend_config` input.
+func convertProvisionerBackendConfig(config *v0.TerraformBackendConfig) *ProvisionerBackendConfigWith {
+	if config == nil {
+		return nil
+	}
+	out := &ProvisionerBackendConfigWith{Type: strings.ToLower(config.Type)}
+	if config.Spec != nil {
+		out.Content = config.Spec.Content
+		out.Store = convertProvisionerStore(config.Spec.Store)
+	}
+	return out
+}
+
+// provisionerEnvVars converts v0 environmentVariables ([]{name, value, type}) to
+// the v1 `env_vars` list format.
+func provisionerEnvVars(vars []*v0.ProvisionerVariable) []map[string]string {
+	var out []map[string]string
+	for _, v := range vars {
+		if v == nil || v.Name == "" {
+			continue
+		}
+		out = append(out, map[string]string{"key": v.Name, "value": v.Value})
+	}
+	return out
+}
+
+// provisionerCommandFlags maps v0 commandFlags ({commandType, flag}) to the
+// template `flags` input shape (a list of {command, flag} objects).
+func provisionerCommandFlags(flags []*v0.ProvisionerCommandFlag) []map[string]string {
+	var out []map[string]string
+	for _, cf := range flags {
+		if cf == nil {
+			continue
+		}
+		out = append(out, map[string]string{
+			"command": strings.ToLower(cf.CommandType),
+			"flag":    cf.Flag,
+		})
+	}
+	return out
+}
+
+// convertTerraformExecution maps the v0 inline Terraform configuration to the
+// shared template inputs.
+func convertTerraformExecution(data *v0.TerraformExecutionData) TerraformExecutionWith {
+	if data == nil {
+		return TerraformExecutionWith{}
+	}
+	with := TerraformExecutionWith{
+		Workspace:     data.Workspace,
+		VarFiles:      convertProvisionerVarFiles(data.VarFiles),
+		BackendConfig: convertProvisionerBackendConfig(data.BackendConfig),
+		Targets:       data.Targets,
+		Envvars:       provisionerEnvVars(data.EnvironmentVariables),
+		SkipRefresh:   data.SkipRefreshCommand,
+		Flags:         provisionerCommandFlags(data.CommandFlags),
+	}
+	if data.ConfigFiles != nil {
+		with.ConfigFiles = convertProvisionerStore(data.ConfigFiles.Store)
+		if data.ConfigFiles.ModuleSource != nil {
+			with.UseConnectorCredentials = data.ConfigFiles.ModuleSource.UseConnectorCredentials
+		}
+	}
+	return with
+}
+
+// provisionerInheritFrom maps the v0 apply/destroy configuration type to the
+// template `inherit_from` input. Inline configuration has no inherit_from.
+func provisionerInheritFrom(configType string) string {
+	switch configType {
+	case v0.ProvisionerConfigurationInheritFromPlan:
+		return "plan"
+	case v0.ProvisionerConfigurationInheritFromApply:
+		return "apply"
+	default:
+		return ""
+	}
+}
+
+// ConvertStepTerraformPlan converts a v0 TerraformPlan step to the v1 terraformPlanStep template.
+func ConvertStepTerraformPlan(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepTerraformPlan)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := TerraformPlanWith{ProvisionerIdentifier: spec.ProvisionerIdentifier}
+	if cfg := spec.Configuration; cfg != nil {
+		with.Command = strings.ToLower(cfg.Command)
+		with.TerraformExecutionWith = convertTerraformExecution(&cfg.TerraformExecutionData)
+		with.ExportPlanJson = cfg.ExportTerraformPlanJson
+		with.ExportHumanReadablePlan = cfg.ExportTerraformHumanReadablePlan
+	}
+
+	// FEATURE GAP: v0 secretManagerRef (the secret manager used to encrypt the
+	// saved plan) has no terraformPlanStep template input; the plan is stored
+	// with the template's default encryption.
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeTerraformPlan,
+		With: with,
+	}
+}
+
+// convertTerraformStep converts the v0 TerraformApply/TerraformDestroy
+// configuration to the template inputs shared by both steps.
+func convertTerraformStep(provisionerIdentifier string, cfg *v0.TerraformStepConfiguration) TerraformApplyWith {
+	with := TerraformApplyWith{ProvisionerIdentifier: provisionerIdentifier}
+	if cfg == nil {
+		return with
+	}
+	with.InheritFrom = provisionerInheritFrom(cfg.Type)
+	with.TerraformExecutionWith = convertTerraformExecution(cfg.Spec)
+	if flags := provisionerCommandFlags(cfg.CommandFlags); flags != nil {
+		with.Flags = flags
+	}
+	with.SkipStateStorage = cfg.SkipStateStorage
+
+	// FEATURE GAP: v0 encryptOutput.outputSecretManagerRef has no template
+	// input; the outputs are published as plain output variables.
+	return with
+}
+
+// ConvertStepTerraformApply converts a v0 TerraformApply step to the v1 terraformApplyStep template.
+func ConvertStepTerraformApply(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepTerraformApply)
+	if !ok || spec == nil {
+		return nil
+	}
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeTerraformApply,
+		With: convertTerraformStep(spec.ProvisionerIdentifier, spec.Configuration),
+	}
+}
+
+// ConvertStepTerraformDestroy converts a v0 TerraformDestroy step to the v1 terraformDestroyStep template.
+func ConvertStepTerraformDestroy(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepTerraformDestroy)
+	if !ok || spec == nil {
+		return nil
+	}
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeTerraformDestroy,
+		With: convertTerraformStep(spec.ProvisionerIdentifier, spec.Configuration),
+	}
+}
+
+// ConvertStepTerraformRollback converts a v0 TerraformRollback step to the v1 terraformRollbackStep template.
+func ConvertStepTerraformRollback(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepTerraformRollback)
+	if !ok || spec == nil {
+		return nil
+	}
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeTerraformRollback,
+		With: TerraformRollbackWith{
+			ProvisionerIdentifier: spec.ProvisionerIdentifier,
+			SkipRefresh:           spec.SkipRefreshCommand,
+			Flags:                 provisionerCommandFlags(spec.CommandFlags),
+		},
+	}
+}
+
+// convertTerragruntModuleConfig returns the template run_type and module_path
+// inputs for the v0 moduleConfig (terragruntRunType RunModule or RunAll).
+func convertTerragruntModuleConfig(data *v0.TerragruntExecutionData) (string, string) {
+	if data == nil || data.ModuleConfig == nil {
+		return "", ""
+	}
+	runType := data.ModuleConfig.TerragruntRunType
+	switch runType {
+	case "RunModule":
+		runType = "run_module"
+	case "RunAll":
+		runType = "run_all"
+	}
+	return runType, data.ModuleConfig.Path
+}
+
+// ConvertStepTerragruntPlan converts a v0 TerragruntPlan step to the v1 terragruntPlanStep template.
+func ConvertStepTerragruntPlan(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepTerragruntPlan)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := TerragruntPlanWith{ProvisionerIdentifier: spec.ProvisionerIdentifier}
+	if cfg := spec.Configuration; cfg != nil {
+		with.Command = strings.ToLower(cfg.Command)
+		with.TerraformExecutionWith = convertTerraformExecution(&cfg.TerraformExecutionData)
+		with.RunType, with.ModulePath = convertTerragruntModuleConfig(&cfg.TerragruntExecutionData)
+		with.ExportPlanJson = cfg.ExportTerragruntPlanJson
+	}
+
+	// FEATURE GAP: v0 secretManagerRef has no terragruntPlanStep template input.
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeTerragruntPlan,
+		With: with,
+	}
+}
+
+// convertTerragruntStep converts the v0 TerragruntApply/TerragruntDestroy
+// configuration to the template inputs shared by both steps.
+func convertTerragruntStep(provisionerIdentifier string, cfg *v0.TerragruntStepConfiguration) TerragruntApplyWith {
+	with := TerragruntApplyWith{ProvisionerIdentifier: provisionerIdentifier}
+	if cfg == nil {
+		return with
+	}
+	with.InheritFrom = provisionerInheritFrom(cfg.Type)
+	if cfg.Spec != nil {
+		with.TerraformExecutionWith = convertTerraformExecution(&cfg.Spec.TerraformExecutionData)
+		with.RunType, with.ModulePath = convertTerragruntModuleConfig(cfg.Spec)
+	}
+	return with
+}
+
+// ConvertStepTerragruntApply converts a v0 TerragruntApply step to the v1 terragruntApplyStep template.
+func ConvertStepTerragruntApply(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepTerragruntApply)
+	if !ok || spec == nil {
+		return nil
+	}
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeTerragruntApply,
+		With: convertTerragruntStep(spec.ProvisionerIdentifier, spec.Configuration),
+	}
+}
+
+// ConvertStepTerragruntDestroy converts a v0 TerragruntDestroy step to the v1 terragruntDestroyStep template.
+func ConvertStepTerragruntDestroy(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepTerragruntDestroy)
+	if !ok || spec == nil {
+		return nil
+	}
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeTerragruntDestroy,
+		With: convertTerragruntStep(spec.ProvisionerIdentifier, spec.Configuration),
+	}
+}
+
+// ConvertStepTerragruntRollback converts a v0 TerragruntRollback step to the v1 terragruntRollbackStep template.
+func ConvertStepTerragruntRollback(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepTerragruntRollback)
+	if !ok || spec == nil {
+		return nil
+	}
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeTerragruntRollback,
+		With: TerragruntRollbackWith{ProvisionerIdentifier: spec.ProvisionerIdentifier},
+	}
+}
+
+// ConvertStepCloudformationCreateStack converts a v0 CreateStack step to the v1 cloudformationCreateStackStep template.
+func ConvertStepCloudformationCreateStack(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepCloudformationCreateStack)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := CloudformationCreateStackWith{ProvisionerIdentifier: spec.ProvisionerIdentifier}
+	if cfg := spec.Configuration; cfg != nil {
+		with.StackName = cfg.StackName
+		with.Connector = cfg.ConnectorRef
+		with.Region = cfg.Region
+		with.RoleArn = cfg.RoleArn
+		if tf := cfg.TemplateFile; tf != nil {
+			with.TemplateType = strings.ToLower(tf.Type)
+			if tf.Spec != nil {
+				with.TemplateBody = tf.Spec.TemplateBody
+				with.TemplateUrl = tf.Spec.TemplateUrl
+				with.TemplateStore = convertProvisionerStore(tf.Spec.Store)
+			}
+		}
+		for _, p := range cfg.Parameters {
+			if p != nil && p.Store != nil {
+				with.ParameterFiles = append(with.ParameterFiles, convertProvisionerStore(p.Store))
+			}
+		}
+		with.ParameterOverrides = provisionerEnvVars(cfg.ParameterOverrides)
+		with.Capabilities = cfg.Capabilities
+		if tags := cfg.Tags; tags != nil && tags.Spec != nil {
+			with.Tags = tags.Spec.Content
+			with.TagsStore = convertProvisionerStore(tags.Spec.Store)
+		}
+		with.SkipOnStackStatuses = cfg.SkipOnStackStatuses
+		with.SkipWaitForResources = cfg.SkipWaitForResources
+	}
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeCloudformationCreateStack,
+		With: with,
+	}
+}
+
+// ConvertStepCloudformationDeleteStack converts a v0 DeleteStack step to the v1 cloudformationDeleteStackStep template.
+// Inherited configuration only carries the provisioner identifier of the create stack step.
+func ConvertStepCloudformationDeleteStack(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepCloudformationDeleteStack)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := CloudformationDeleteStackWith{}
+	if cfg := spec.Configuration; cfg != nil && cfg.Spec != nil {
+		with.ProvisionerIdentifier = cfg.Spec.ProvisionerIdentifier
+		with.StackName = cfg.Spec.StackName
+		with.Connector = cfg.Spec.ConnectorRef
+		with.Region = cfg.Spec.Region
+		with.RoleArn = cfg.Spec.RoleArn
+	}
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeCloudformationDeleteStack,
+		With: with,
+	}
+}
+
+// ConvertStepCloudformationRollbackStack converts a v0 RollbackStack step to the v1 cloudformationRollbackStackStep template.
+func ConvertStepCloudformationRollbackStack(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepCloudformationRollbackStack)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := CloudformationRollbackStackWith{}
+	if spec.Configuration != nil {
+		with.ProvisionerIdentifier = spec.Configuration.ProvisionerIdentifier
+	}
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeCloudformationRollbackStack,
+		With: with,
+	}
+}
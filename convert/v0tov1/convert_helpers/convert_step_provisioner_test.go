@@ -0,0 +1,322 @@
+package converthelpers
+
+import (
+	"encoding/json"
+	"testing"
+
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+	"github.com/drone/go-convert/internal/flexible"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConvertStepTerraformPlan(t *testing.T) {
+	src := `{
+		"identifier": "tfPlan",
+		"type": "TerraformPlan",
+		"spec": {
+			"provisionerIdentifier": "vpc",
+			"configuration": {
+				"command": "Apply",
+				"workspace": "prod",
+				"secretManagerRef": "harnessSecretManager",
+				"configFiles": {
+					"store": {
+						"type": "Github",
+						"spec": {
+							"connectorRef": "github",
+							"repoName": "infra",
+							"gitFetchType": "Branch",
+							"branch": "main",
+							"folderPath": "terraform/vpc"
+						}
+					},
+					"moduleSource": {"useConnectorCredentials": true}
+				},
+				"varFiles": [
+					{"varFile": {"identifier": "common", "type": "Inline", "spec": {"content": "region = \"us-east-1\""}}},
+					{"varFile": {"identifier": "prod", "type": "Remote", "spec": {"store": {
+						"type": "Git",
+						"spec": {"connectorRef": "git", "gitFetchType": "Commit", "commitId": "abc123", "paths": ["vars/prod.tfvars"]}
+					}}}}
+				],
+				"backendConfig": {"type": "Inline", "spec": {"content": "bucket = \"tf-state\""}},
+				"targets": ["module.vpc"],
+				"environmentVariables": [{"name": "TF_LOG", "value": "DEBUG", "type": "String"}],
+				"commandFlags": [{"commandType": "PLAN", "flag": "-lock=false"}],
+				"exportTerraformPlanJson": true
+			}
+		}
+	}`
+
+	step := new(v0.Step)
+	if err := json.Unmarshal([]byte(src), step); err != nil {
+		t.Fatal(err)
+	}
+
+	want := &v1.StepTemplate{
+		Uses: "terraformPlanStep",
+		With: TerraformPlanWith{
+			ProvisionerIdentifier: "vpc",
+			Command:               "apply",
+			TerraformExecutionWith: TerraformExecutionWith{
+				Workspace: "prod",
+				ConfigFiles: &ProvisionerStoreWith{
+					Type:       "github",
+					Connector:  "github",
+					Repo:       "infra",
+					Branch:     "main",
+					FolderPath: "terraform/vpc",
+				},
+				UseConnectorCredentials: &flexible.Field[bool]{Value: true},
+				VarFiles: []ProvisionerVarFileWith{
+					{Identifier: "common", Type: "inline", Content: `region = "us-east-1"`},
+					{Identifier: "prod", Type: "remote", Store: &ProvisionerStoreWith{
+						Type:      "git",
+						Connector: "git",
+						Commit:    "abc123",
+						Paths:     &flexible.Field[[]string]{Value: []string{"vars/prod.tfvars"}},
+					}},
+				},
+				BackendConfig: &ProvisionerBackendConfigWith{Type: "inline", Content: `bucket = "tf-state"`},
+				Targets:       &flexible.Field[[]string]{Value: []string{"module.vpc"}},
+				Envvars:       []map[string]string{{"key": "TF_LOG", "value": "DEBUG"}},
+				Flags:         []map[string]string{{"command": "plan", "flag": "-lock=false"}},
+			},
+			ExportPlanJson: &flexible.Field[bool]{Value: true},
+		},
+	}
+
+	got := ConvertStepTerraformPlan(step)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ConvertStepTerraformPlan() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConvertStepTerraformApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		step     *v0.Step
+		convert  func(*v0.Step) *v1.StepTemplate
+		expected *v1.StepTemplate
+	}{
+		{
+			name: "apply inherited from plan",
+			step: &v0.Step{
+				Spec: &v0.StepTerraformApply{
+					ProvisionerIdentifier: "vpc",
+					Configuration: &v0.TerraformStepConfiguration{
+						Type: "InheritFromPlan",
+					},
+				},
+			},
+			convert: ConvertStepTerraformApply,
+			expected: &v1.StepTemplate{
+				Uses: "terraformApplyStep",
+				With: TerraformApplyWith{
+					ProvisionerIdentifier: "vpc",
+					InheritFrom:           "plan",
+				},
+			},
+		},
+		{
+			name: "inline destroy",
+			step: &v0.Step{
+				Spec: &v0.StepTerraformDestroy{
+					ProvisionerIdentifier: "vpc",
+					Configuration: &v0.TerraformStepConfiguration{
+						Type: "Inline",
+						Spec: &v0.TerraformExecutionData{
+							Workspace: "prod",
+							ConfigFiles: &v0.TerraformConfigFiles{
+								Store: &v0.ProvisionerStore{
+									Type: "Harness",
+									Spec: &v0.ProvisionerStoreSpec{Files: &flexible.Field[[]string]{Value: []string{"/terraform/vpc"}}},
+								},
+							},
+						},
+						CommandFlags: []*v0.ProvisionerCommandFlag{{CommandType: "DESTROY", Flag: "-parallelism=5"}},
+					},
+				},
+			},
+			convert: ConvertStepTerraformDestroy,
+			expected: &v1.StepTemplate{
+				Uses: "terraformDestroyStep",
+				With: TerraformApplyWith{
+					ProvisionerIdentifier: "vpc",
+					TerraformExecutionWith: TerraformExecutionWith{
+						Workspace: "prod",
+						ConfigFiles: &ProvisionerStoreWith{
+							Type:  "harness",
+							Paths: &flexible.Field[[]string]{Value: []string{"/terraform/vpc"}},
+						},
+						Flags: []map[string]string{{"command": "destroy", "flag": "-parallelism=5"}},
+					},
+				},
+			},
+		},
+		{
+			name: "rollback",
+			step: &v0.Step{
+				Spec: &v0.StepTerraformRollback{
+					ProvisionerIdentifier: "vpc",
+					SkipRefreshCommand:    &flexible.Field[bool]{Value: true},
+				},
+			},
+			convert: ConvertStepTerraformRollback,
+			expected: &v1.StepTemplate{
+				Uses: "terraformRollbackStep",
+				With: TerraformRollbackWith{
+					ProvisionerIdentifier: "vpc",
+					SkipRefresh:           &flexible.Field[bool]{Value: true},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.convert(tt.step)
+			if diff := cmp.Diff(tt.expected, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConvertStepTerragruntPlan(t *testing.T) {
+	step := &v0.Step{
+		Spec: &v0.StepTerragruntPlan{
+			ProvisionerIdentifier: "eks",
+			Configuration: &v0.TerragruntPlanConfiguration{
+				Command: "Destroy",
+				TerragruntExecutionData: v0.TerragruntExecutionData{
+					TerraformExecutionData: v0.TerraformExecutionData{
+						Workspace: "<+input>",
+					},
+					ModuleConfig: &v0.TerragruntModuleConfig{
+						TerragruntRunType: "RunModule",
+						Path:              "live/prod/eks",
+					},
+				},
+			},
+		},
+	}
+	want := &v1.StepTemplate{
+		Uses: "terragruntPlanStep",
+		With: TerragruntPlanWith{
+			ProvisionerIdentifier: "eks",
+			Command:               "destroy",
+			TerraformExecutionWith: TerraformExecutionWith{
+				Workspace: "<+input>",
+			},
+			RunType:    "run_module",
+			ModulePath: "live/prod/eks",
+		},
+	}
+	got := ConvertStepTerragruntPlan(step)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ConvertStepTerragruntPlan() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConvertStepCloudformation(t *testing.T) {
+	tests := []struct {
+		name     string
+		step     *v0.Step
+		convert  func(*v0.Step) *v1.StepTemplate
+		expected *v1.StepTemplate
+	}{
+		{
+			name: "create stack from remote template",
+			step: &v0.Step{
+				Spec: &v0.StepCloudformationCreateStack{
+					ProvisionerIdentifier: "bucket",
+					Configuration: &v0.CloudformationCreateStackConfig{
+						StackName:    "app-bucket",
+						ConnectorRef: "aws",
+						Region:       "us-east-1",
+						TemplateFile: &v0.CloudformationTemplateFile{
+							Type: "Remote",
+							Spec: &v0.CloudformationTemplateFileSpec{
+								Store: &v0.ProvisionerStore{
+									Type: "Github",
+									Spec: &v0.ProvisionerStoreSpec{
+										ConnectorRef: "github",
+										Branch:       "main",
+										Paths:        &flexible.Field[[]string]{Value: []string{"cfn/bucket.yaml"}},
+									},
+								},
+							},
+						},
+						ParameterOverrides: []*v0.ProvisionerVariable{{Name: "Env", Value: "prod"}},
+						Capabilities:       &flexible.Field[[]string]{Value: []string{"CAPABILITY_IAM"}},
+					},
+				},
+			},
+			convert: ConvertStepCloudformationCreateStack,
+			expected: &v1.StepTemplate{
+				Uses: "cloudformationCreateStackStep",
+				With: CloudformationCreateStackWith{
+					ProvisionerIdentifier: "bucket",
+					StackName:             "app-bucket",
+					Connector:             "aws",
+					Region:                "us-east-1",
+					TemplateType:          "remote",
+					TemplateStore: &ProvisionerStoreWith{
+						Type:      "github",
+						Connector: "github",
+						Branch:    "main",
+						Paths:     &flexible.Field[[]string]{Value: []string{"cfn/bucket.yaml"}},
+					},
+					ParameterOverrides: []map[string]string{{"key": "Env", "value": "prod"}},
+					Capabilities:       &flexible.Field[[]string]{Value: []string{"CAPABILITY_IAM"}},
+				},
+			},
+		},
+		{
+			name: "delete inherited stack",
+			step: &v0.Step{
+				Spec: &v0.StepCloudformationDeleteStack{
+					Configuration: &v0.CloudformationDeleteStackConfig{
+						Type: "Inherited",
+						Spec: &v0.CloudformationDeleteStackConfigSpec{ProvisionerIdentifier: "bucket"},
+					},
+				},
+			},
+			convert: ConvertStepCloudformationDeleteStack,
+			expected: &v1.StepTemplate{
+				Uses: "cloudformationDeleteStackStep",
+				With: CloudformationDeleteStackWith{ProvisionerIdentifier: "bucket"},
+			},
+		},
+		{
+			name: "rollback stack",
+			step: &v0.Step{
+				Spec: &v0.StepCloudformationRollbackStack{
+					Configuration: &v0.CloudformationRollbackStackConfig{ProvisionerIdentifier: "bucket"},
+				},
+			},
+			convert: ConvertStepCloudformationRollbackStack,
+			expected: &v1.StepTemplate{
+				Uses: "cloudformationRollbackStackStep",
+				With: CloudformationRollbackStackWith{ProvisionerIdentifier: "bucket"},
+			},
+		},
+		{
+			name:     "wrong spec type",
+			step:     &v0.Step{Spec: &v0.StepTerraformPlan{}},
+			convert:  ConvertStepCloudformationCreateStack,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.convert(tt.step)
+			if diff := cmp.Diff(tt.expected, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
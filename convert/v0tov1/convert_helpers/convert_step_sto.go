@@ -0,0 +1,138 @@
+package converthelpers
+
+import (
+	"strings"
+
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+)
+
+// stoScannerTemplates maps a v0 STO scanner step type to the v1 scanner template.
+var stoScannerTemplates = map[string]string{
+	v0.StepTypeAquaTrivy:    v1.StepTypeSTOAquaTrivy,
+	v0.StepTypeSnyk:         v1.StepTypeSTOSnyk,
+	v0.StepTypeSonarqube:    v1.StepTypeSTOSonarqube,
+	v0.StepTypeCheckmarx:    v1.StepTypeSTOCheckmarx,
+	v0.StepTypeGrype:        v1.StepTypeSTOGrype,
+	v0.StepTypeWiz:          v1.StepTypeSTOWiz,
+	v0.StepTypeSemgrep:      v1.StepTypeSTOSemgrep,
+	v0.StepTypeGitleaks:     v1.StepTypeSTOGitleaks,
+	v0.StepTypePrismaCloud:  v1.StepTypeSTOPrismaCloud,
+	v0.StepTypeCustomIngest: v1.StepTypeSTOCustomIngest,
+}
+
+// setSTOInput sets the scanner template input, skipping empty values.
+func setSTOInput(with map[string]interface{}, key, value string) {
+	if value != "" {
+		with[key] = value
+	}
+}
+
+// ConvertStepSTOScan converts a v0 STO scanner step (AquaTrivy, Snyk, Sonarqube,
+// Checkmarx, Grype, Wiz, Semgrep, Gitleaks, PrismaCloud, CustomIngest) to the
+// corresponding v1 scanner template. The scanners share one spec layout, so the
+// nested v0 blocks are flattened to `<block>_<field>` template inputs.
+func ConvertStepSTOScan(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepSTOScan)
+	if !ok || spec == nil {
+		return nil
+	}
+	uses, ok := stoScannerTemplates[src.Type]
+	if !ok {
+		return nil
+	}
+
+	with := make(map[string]interface{})
+	setSTOInput(with, "mode", strings.ToLower(spec.Mode))
+	setSTOInput(with, "config", spec.Config)
+
+	if t := spec.Target; t != nil {
+		setSTOInput(with, "target_type", t.Type)
+		setSTOInput(with, "target_detection", t.Detection)
+		setSTOInput(with, "target_name", t.Name)
+		setSTOInput(with, "target_variant", t.Variant)
+		setSTOInput(with, "target_workspace", t.Workspace)
+	}
+	if spec.Ingestion != nil {
+		setSTOInput(with, "ingestion_file", spec.Ingestion.File)
+	}
+	if a := spec.Advanced; a != nil {
+		if a.Log != nil {
+			setSTOInput(with, "log_level", a.Log.Level)
+			if a.Log.Cli != nil {
+				with["log_cli"] = a.Log.Cli
+			}
+		}
+		if a.Args != nil {
+			setSTOInput(with, "cli_args", a.Args.Cli)
+			setSTOInput(with, "passthrough_args", a.Args.Passthrough)
+		}
+		setSTOInput(with, "fail_on_severity", a.FailOnSeverity)
+		if a.IncludeRaw != nil {
+			with["include_raw"] = a.IncludeRaw
+		}
+	}
+	if i := spec.Image; i != nil {
+		setSTOInput(with, "image_type", i.Type)
+		setSTOInput(with, "image_name", i.Name)
+		setSTOInput(with, "image_tag", i.Tag)
+		setSTOInput(with, "image_domain", i.Domain)
+		setSTOInput(with, "image_access_id", i.AccessId)
+		setSTOInput(with, "image_access_token", i.AccessToken)
+		setSTOInput(with, "image_region", i.Region)
+	}
+	if a := spec.Auth; a != nil {
+		setSTOInput(with, "auth_domain", a.Domain)
+		setSTOInput(with, "auth_access_id", a.AccessId)
+		setSTOInput(with, "auth_access_token", a.AccessToken)
+		setSTOInput(with, "auth_region", a.Region)
+		setSTOInput(with, "auth_type", a.Type)
+		setSTOInput(with, "auth_version", a.Version)
+		if a.Ssl != nil {
+			with["auth_ssl"] = a.Ssl
+		}
+	}
+	if t := spec.Tool; t != nil {
+		setSTOInput(with, "tool_project_name", t.ProjectName)
+		setSTOInput(with, "tool_project_key", t.ProjectKey)
+		setSTOInput(with, "tool_project_version", t.ProjectVersion)
+		setSTOInput(with, "tool_team_name", t.TeamName)
+		setSTOInput(with, "tool_include", t.Include)
+		setSTOInput(with, "tool_exclude", t.Exclude)
+		setSTOInput(with, "tool_context", t.Context)
+		setSTOInput(with, "tool_image_name", t.ImageName)
+		setSTOInput(with, "tool_pull_request_number", t.PullRequestNumber)
+		if t.Java != nil {
+			setSTOInput(with, "tool_java_libraries", t.Java.Libraries)
+			setSTOInput(with, "tool_java_binaries", t.Java.Binaries)
+		}
+		if t.DetectSecrets != nil {
+			with["tool_detect_secrets"] = t.DetectSecrets
+		}
+	}
+	if i := spec.Instance; i != nil {
+		setSTOInput(with, "instance_domain", i.Domain)
+		setSTOInput(with, "instance_protocol", i.Protocol)
+		setSTOInput(with, "instance_port", i.Port)
+		setSTOInput(with, "instance_path", i.Path)
+		setSTOInput(with, "instance_username", i.Username)
+		setSTOInput(with, "instance_password", i.Password)
+	}
+	if spec.Settings != nil {
+		with["settings"] = spec.Settings
+	}
+
+	return &v1.StepTemplate{
+		Uses: uses,
+		With: with,
+		Container: ConvertTemplateContainer(
+			spec.RunAsUser,
+			spec.Resources,
+			WithPrivileged(spec.Privileged),
+			WithImagePullPolicy(spec.ImagePullPolicy),
+		),
+	}
+}
@@ -0,0 +1,129 @@
+package converthelpers
+
+import (
+	"encoding/json"
+	"testing"
+
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+	"github.com/drone/go-convert/internal/flexible"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConvertStepSTOScan(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected *v1.StepTemplate
+	}{
+		{
+			name: "aqua trivy container scan",
+			src: `{
+				"identifier": "trivy",
+				"type": "AquaTrivy",
+				"spec": {
+					"mode": "orchestration",
+					"config": "default",
+					"target": {"type": "container", "detection": "auto"},
+					"advanced": {"log": {"level": "info"}, "fail_on_severity": "critical"},
+					"privileged": true,
+					"image": {"type": "docker_v2", "name": "nginx", "tag": "latest"}
+				}
+			}`,
+			expected: &v1.StepTemplate{
+				Uses: "aquaTrivyStep",
+				With: map[string]interface{}{
+					"mode":             "orchestration",
+					"config":           "default",
+					"target_type":      "container",
+					"target_detection": "auto",
+					"log_level":        "info",
+					"fail_on_severity": "critical",
+					"image_type":       "docker_v2",
+					"image_name":       "nginx",
+					"image_tag":        "latest",
+				},
+				Container: &v1.Container{
+					Privileged: &flexible.Field[bool]{Value: true},
+				},
+			},
+		},
+		{
+			name: "sonarqube extraction",
+			src: `{
+				"identifier": "sonar",
+				"type": "Sonarqube",
+				"spec": {
+					"mode": "extraction",
+					"config": "default",
+					"target": {"type": "repository", "name": "app", "variant": "main", "workspace": "/harness"},
+					"auth": {"domain": "https://sonar.example.com", "access_token": "<+secrets.getValue(\"sonar\")>", "ssl": true},
+					"tool": {"project_key": "app", "java": {"libraries": "lib/*.jar"}}
+				}
+			}`,
+			expected: &v1.StepTemplate{
+				Uses: "sonarqubeStep",
+				With: map[string]interface{}{
+					"mode":                "extraction",
+					"config":              "default",
+					"target_type":         "repository",
+					"target_name":         "app",
+					"target_variant":      "main",
+					"target_workspace":    "/harness",
+					"auth_domain":         "https://sonar.example.com",
+					"auth_access_token":   `<+secrets.getValue("sonar")>`,
+					"auth_ssl":            &flexible.Field[bool]{Value: true},
+					"tool_project_key":    "app",
+					"tool_java_libraries": "lib/*.jar",
+				},
+			},
+		},
+		{
+			name: "custom ingest",
+			src: `{
+				"identifier": "ingest",
+				"type": "CustomIngest",
+				"spec": {
+					"mode": "ingestion",
+					"config": "sarif",
+					"target": {"type": "repository", "name": "app", "variant": "main"},
+					"ingestion": {"file": "/shared/results.sarif"}
+				}
+			}`,
+			expected: &v1.StepTemplate{
+				Uses: "customIngestStep",
+				With: map[string]interface{}{
+					"mode":           "ingestion",
+					"config":         "sarif",
+					"target_type":    "repository",
+					"target_name":    "app",
+					"target_variant": "main",
+					"ingestion_file": "/shared/results.sarif",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := new(v0.Step)
+			if err := json.Unmarshal([]byte(tt.src), step); err != nil {
+				t.Fatal(err)
+			}
+			got := ConvertStepSTOScan(step)
+			if diff := cmp.Diff(tt.expected, got); diff != "" {
+				t.Errorf("ConvertStepSTOScan() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConvertStepSTOScan_Invalid(t *testing.T) {
+	if got := ConvertStepSTOScan(nil); got != nil {
+		t.Errorf("expected nil for nil step, got %v", got)
+	}
+	step := &v0.Step{Type: v0.StepTypeRun, Spec: &v0.StepSTOScan{}}
+	if got := ConvertStepSTOScan(step); got != nil {
+		t.Errorf("expected nil for non-scanner step type, got %v", got)
+	}
+}
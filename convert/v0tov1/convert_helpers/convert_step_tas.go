@@ -0,0 +1,182 @@
+package converthelpers
+
+import (
+	"strings"
+
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+	"github.com/drone/go-convert/internal/flexible"
+)
+
+// tasInstanceCountType maps the v0 tasInstanceCountType enum (FromManifest,
+// MatchRunningInstances) to the template `instance_count` input value.
+func tasInstanceCountType(countType string) string {
+	switch countType {
+	case "FromManifest":
+		return "from_manifest"
+	case "MatchRunningInstances":
+		return "match_running_instances"
+	default:
+		return countType
+	}
+}
+
+// tasAppSetupWith returns the inputs shared by the TAS app setup templates.
+func tasAppSetupWith(countType string, existing *flexible.Field[int], routes *flexible.Field[[]string]) map[string]interface{} {
+	with := make(map[string]interface{})
+	if countType != "" {
+		with["instance_count"] = tasInstanceCountType(countType)
+	}
+	if existing != nil {
+		with["existing_versions_to_keep"] = existing
+	}
+	if routes != nil {
+		with["additional_routes"] = routes
+	}
+	return with
+}
+
+// ConvertStepTasBasicAppSetup converts a v0 BasicAppSetup step to the v1 tasBasicAppSetupStep template.
+func ConvertStepTasBasicAppSetup(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepTasBasicAppSetup)
+	if !ok || spec == nil {
+		return nil
+	}
+	with := tasAppSetupWith(spec.TasInstanceCountType, spec.ExistingVersionToKeep, spec.AdditionalRoutes)
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeTasBasicAppSetup,
+		With: with,
+	}
+}
+
+// ConvertStepTasBGAppSetup converts a v0 BGAppSetup step to the v1 tasBlueGreenAppSetupStep template.
+func ConvertStepTasBGAppSetup(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepTasBGAppSetup)
+	if !ok || spec == nil {
+		return nil
+	}
+	with := tasAppSetupWith(spec.TasInstanceCountType, spec.ExistingVersionToKeep, spec.AdditionalRoutes)
+	if spec.TempRoutes != nil {
+		with["temp_routes"] = spec.TempRoutes
+	}
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeTasBGAppSetup,
+		With: with,
+	}
+}
+
+// ConvertStepTasCanaryAppSetup converts a v0 CanaryAppSetup step to the v1 tasCanaryAppSetupStep template.
+func ConvertStepTasCanaryAppSetup(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepTasCanaryAppSetup)
+	if !ok || spec == nil {
+		return nil
+	}
+	with := tasAppSetupWith(spec.TasInstanceCountType, spec.ExistingVersionToKeep, spec.AdditionalRoutes)
+	switch spec.ResizeStrategy {
+	case "":
+	case "UpscaleNewFirst":
+		with["resize_strategy"] = "upscale_new_first"
+	case "DownscaleOldFirst":
+		with["resize_strategy"] = "downscale_old_first"
+	default:
+		with["resize_strategy"] = spec.ResizeStrategy
+	}
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeTasCanaryAppSetup,
+		With: with,
+	}
+}
+
+// ConvertStepTasAppResize converts a v0 AppResize step to the v1 tasAppResizeStep template.
+// The Count/Percentage instance selections map to the `<app>_instances` value and
+// `<app>_instances_unit` (count, percentage) inputs.
+func ConvertStepTasAppResize(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepTasAppResize)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := make(map[string]interface{})
+	for prefix, sel := range map[string]*v0.TasInstanceSelection{
+		"new_app": spec.NewAppInstances,
+		"old_app": spec.OldAppInstances,
+	} {
+		if sel == nil {
+			continue
+		}
+		if sel.Type != "" {
+			with[prefix+"_instances_unit"] = strings.ToLower(sel.Type)
+		}
+		if sel.Spec != nil && sel.Spec.Value != nil {
+			with[prefix+"_instances"] = instancesToString(sel.Spec.Value)
+		}
+	}
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeTasAppResize,
+		With: with,
+	}
+}
+
+// ConvertStepTasSwapRoutes converts a v0 BGAppSwapRoutes step to the v1 tasSwapRoutesStep template.
+func ConvertStepTasSwapRoutes(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepTasSwapRoutes)
+	if !ok || spec == nil {
+		return nil
+	}
+	with := make(map[string]interface{})
+	if spec.DownSizeOldApplication != nil {
+		with["downsize_old_app"] = spec.DownSizeOldApplication
+	}
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeTasSwapRoutes,
+		With: with,
+	}
+}
+
+// ConvertStepTasSwapRollback converts a v0 SwapRollback step to the v1 tasSwapRollbackStep template.
+func ConvertStepTasSwapRollback(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepTasSwapRollback)
+	if !ok || spec == nil {
+		return nil
+	}
+	with := make(map[string]interface{})
+	if spec.UpsizeInActiveApp != nil {
+		with["upsize_inactive_app"] = spec.UpsizeInActiveApp
+	}
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeTasSwapRollback,
+		With: with,
+	}
+}
+
+// ConvertStepTasAppRollback converts a v0 AppRollback step to the v1 tasAppRollbackStep template.
+func ConvertStepTasAppRollback(src *v0.Step) *v1.StepTemplate {
+	if src == nil {
+		return nil
+	}
+	if _, ok := src.Spec.(*v0.StepTasAppRollback); src.Spec != nil && !ok {
+		return nil
+	}
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeTasAppRollback,
+	}
+}
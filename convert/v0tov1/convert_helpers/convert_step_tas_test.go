@@ -0,0 +1,103 @@
+package converthelpers
+
+import (
+	"testing"
+
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+	"github.com/drone/go-convert/internal/flexible"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConvertStepTas(t *testing.T) {
+	tests := []struct {
+		name     string
+		step     *v0.Step
+		convert  func(*v0.Step) *v1.StepTemplate
+		expected *v1.StepTemplate
+	}{
+		{
+			name: "blue green app setup",
+			step: &v0.Step{
+				Spec: &v0.StepTasBGAppSetup{
+					TasInstanceCountType:  "MatchRunningInstances",
+					ExistingVersionToKeep: &flexible.Field[int]{Value: 3},
+					TempRoutes:            &flexible.Field[[]string]{Value: []string{"app-temp.example.com"}},
+				},
+			},
+			convert: ConvertStepTasBGAppSetup,
+			expected: &v1.StepTemplate{
+				Uses: "tasBlueGreenAppSetupStep",
+				With: map[string]interface{}{
+					"instance_count":            "match_running_instances",
+					"existing_versions_to_keep": &flexible.Field[int]{Value: 3},
+					"temp_routes":               &flexible.Field[[]string]{Value: []string{"app-temp.example.com"}},
+				},
+			},
+		},
+		{
+			name: "canary app setup",
+			step: &v0.Step{
+				Spec: &v0.StepTasCanaryAppSetup{
+					TasInstanceCountType: "FromManifest",
+					ResizeStrategy:       "DownscaleOldFirst",
+				},
+			},
+			convert: ConvertStepTasCanaryAppSetup,
+			expected: &v1.StepTemplate{
+				Uses: "tasCanaryAppSetupStep",
+				With: map[string]interface{}{
+					"instance_count":  "from_manifest",
+					"resize_strategy": "downscale_old_first",
+				},
+			},
+		},
+		{
+			name: "app resize",
+			step: &v0.Step{
+				Spec: &v0.StepTasAppResize{
+					NewAppInstances: &v0.TasInstanceSelection{
+						Type: "Percentage",
+						Spec: &v0.TasInstanceSelectionSpec{Value: &flexible.Field[int]{Value: 50}},
+					},
+					OldAppInstances: &v0.TasInstanceSelection{
+						Type: "Count",
+						Spec: &v0.TasInstanceSelectionSpec{Value: &flexible.Field[int]{Value: "<+input>"}},
+					},
+				},
+			},
+			convert: ConvertStepTasAppResize,
+			expected: &v1.StepTemplate{
+				Uses: "tasAppResizeStep",
+				With: map[string]interface{}{
+					"new_app_instances_unit": "percentage",
+					"new_app_instances":      "50",
+					"old_app_instances_unit": "count",
+					"old_app_instances":      "<+input>",
+				},
+			},
+		},
+		{
+			name: "swap routes",
+			step: &v0.Step{
+				Spec: &v0.StepTasSwapRoutes{DownSizeOldApplication: &flexible.Field[bool]{Value: true}},
+			},
+			convert: ConvertStepTasSwapRoutes,
+			expected: &v1.StepTemplate{
+				Uses: "tasSwapRoutesStep",
+				With: map[string]interface{}{
+					"downsize_old_app": &flexible.Field[bool]{Value: true},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.convert(tt.step)
+			if diff := cmp.Diff(tt.expected, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
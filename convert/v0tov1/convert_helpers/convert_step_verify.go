@@ -0,0 +1,95 @@
+package converthelpers
+
+import (
+	"strings"
+
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+)
+
+// A failed analysis or resilience assertion fails the verifyStep and chaosStep
+// templates with a verification error, so the step failure strategies
+// (e.g. Verification -> StageRollback) convert unchanged.
+
+// verifyTypes maps the v0 Verify step type to the v1 template input.
+var verifyTypes = map[string]string{
+	v0.VerifyTypeCanary:    "canary",
+	v0.VerifyTypeBlueGreen: "blue_green",
+	v0.VerifyTypeRolling:   "rolling",
+	v0.VerifyTypeAuto:      "auto",
+	v0.VerifyTypeLoadTest:  "load_test",
+}
+
+// ConvertStepVerify converts a v0 Verify step to the v1 verifyStep template.
+func ConvertStepVerify(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepVerify)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := make(map[string]interface{})
+	if spec.Type != "" {
+		if t, ok := verifyTypes[spec.Type]; ok {
+			with["type"] = t
+		} else {
+			with["type"] = spec.Type
+		}
+	}
+	// The Default monitored service is resolved from the stage service and
+	// environment, so only an explicitly configured one is passed on.
+	if ms := spec.MonitoredService; ms != nil && ms.Spec != nil && ms.Spec.MonitoredServiceRef != "" {
+		with["monitored_service"] = ms.Spec.MonitoredServiceRef
+	}
+	if s := spec.Spec; s != nil {
+		if s.Sensitivity != "" {
+			with["sensitivity"] = strings.ToLower(s.Sensitivity)
+		}
+		if s.Duration != "" {
+			with["duration"] = s.Duration
+		}
+		if s.DeploymentTag != "" {
+			with["deployment_tag"] = s.DeploymentTag
+		}
+		if s.FailOnNoAnalysis != nil {
+			with["fail_on_no_analysis"] = s.FailOnNoAnalysis
+		}
+	}
+	if spec.IsMultiServicesOrEnvs != nil {
+		with["multi_services_or_envs"] = spec.IsMultiServicesOrEnvs
+	}
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeVerify,
+		With: with,
+	}
+}
+
+// ConvertStepChaos converts a v0 Chaos step to the v1 chaosStep template.
+func ConvertStepChaos(src *v0.Step) *v1.StepTemplate {
+	if src == nil || src.Spec == nil {
+		return nil
+	}
+	spec, ok := src.Spec.(*v0.StepChaos)
+	if !ok || spec == nil {
+		return nil
+	}
+
+	with := make(map[string]interface{})
+	if spec.ExperimentRef != "" {
+		with["experiment"] = spec.ExperimentRef
+	}
+	if spec.ExpectedResilienceScore != nil {
+		with["expected_resilience_score"] = spec.ExpectedResilienceScore
+	}
+	if spec.Assertion != "" {
+		with["assertion"] = spec.Assertion
+	}
+
+	return &v1.StepTemplate{
+		Uses: v1.StepTypeChaos,
+		With: with,
+	}
+}
@@ -0,0 +1,105 @@
+package converthelpers
+
+import (
+	"encoding/json"
+	"testing"
+
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+	"github.com/drone/go-convert/internal/flexible"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConvertStepVerify(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		convert  func(*v0.Step) *v1.StepTemplate
+		expected *v1.StepTemplate
+	}{
+		{
+			name: "canary verification with configured monitored service",
+			src: `{
+				"identifier": "verify",
+				"type": "Verify",
+				"spec": {
+					"type": "Canary",
+					"monitoredService": {"type": "Configured", "spec": {"monitoredServiceRef": "checkout_prod"}},
+					"spec": {
+						"sensitivity": "HIGH",
+						"duration": "10m",
+						"deploymentTag": "<+artifacts.primary.tag>",
+						"failOnNoAnalysis": true
+					}
+				}
+			}`,
+			convert: ConvertStepVerify,
+			expected: &v1.StepTemplate{
+				Uses: "verifyStep",
+				With: map[string]interface{}{
+					"type":                "canary",
+					"monitored_service":   "checkout_prod",
+					"sensitivity":         "high",
+					"duration":            "10m",
+					"deployment_tag":      "<+artifacts.primary.tag>",
+					"fail_on_no_analysis": &flexible.Field[bool]{Value: true},
+				},
+			},
+		},
+		{
+			name: "blue green verification with default monitored service",
+			src: `{
+				"identifier": "verify",
+				"type": "Verify",
+				"spec": {
+					"type": "Bluegreen",
+					"monitoredService": {"type": "Default", "spec": {}},
+					"spec": {"sensitivity": "MEDIUM", "duration": "5m"}
+				}
+			}`,
+			convert: ConvertStepVerify,
+			expected: &v1.StepTemplate{
+				Uses: "verifyStep",
+				With: map[string]interface{}{
+					"type":        "blue_green",
+					"sensitivity": "medium",
+					"duration":    "5m",
+				},
+			},
+		},
+		{
+			name: "chaos experiment",
+			src: `{
+				"identifier": "chaos",
+				"type": "Chaos",
+				"spec": {
+					"experimentRef": "pod-delete",
+					"expectedResilienceScore": 80,
+					"assertion": "<+resilienceScore> > 80"
+				}
+			}`,
+			convert: ConvertStepChaos,
+			expected: &v1.StepTemplate{
+				Uses: "chaosStep",
+				With: map[string]interface{}{
+					"experiment":                "pod-delete",
+					"expected_resilience_score": &flexible.Field[float64]{Value: float64(80)},
+					"assertion":                 "<+resilienceScore> > 80",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := new(v0.Step)
+			if err := json.Unmarshal([]byte(tt.src), step); err != nil {
+				t.Fatal(err)
+			}
+			got := tt.convert(step)
+			if diff := cmp.Diff(tt.expected, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -178,6 +178,13 @@ func convertSingleFile(inputPath string) {
 		}
 		writeDur := time.Since(writeStart)
 		fmt.Printf("Converted template %s -> %s (read=%v, convert=%v, write=%v)\n", inputPath, outputPath, readDur, convDur, writeDur)
+	} else if kind, err := convertEntityFile(converter, v0Config, outputPath); kind != "" {
+		// Service, environment, infrastructure or override conversion
+		convDur := time.Since(convStart)
+		if err != nil {
+			log.Fatalf("Failed to convert %s to v1 format: %v", kind, err)
+		}
+		fmt.Printf("Converted %s %s -> %s (read=%v, convert+write=%v)\n", kind, inputPath, outputPath, readDur, convDur)
 	} else {
 		// Pipeline conversion (default)
 		v1Pipeline := converter.ConvertPipeline(&v0Config.Pipeline)
@@ -556,6 +563,14 @@ func convertFile(inputPath, outputPath string) bool {
 			return false
 		}
 		fmt.Printf("Converted template %s -> %s (read=%v, convert=%v, write=%v)\n", inputPath, outputPath, readDur, convDur, writeDur)
+	} else if kind, err := convertEntityFile(converter, v0Config, outputPath); kind != "" {
+		// Service, environment, infrastructure or override conversion
+		convDur := time.Since(convStart)
+		if err != nil {
+			log.Printf("Skipping %s: failed to convert %s to v1 format: %v", inputPath, kind, err)
+			return false
+		}
+		fmt.Printf("Converted %s %s -> %s (read=%v, convert+write=%v)\n", kind, inputPath, outputPath, readDur, convDur)
 	} else {
 		// Pipeline conversion (default)
 		v1Pipeline := converter.ConvertPipeline(&v0Config.Pipeline)
@@ -577,6 +592,31 @@ func convertFile(inputPath, outputPath string) bool {
 	return true
 }
 
+// convertEntityFile converts a standalone service, environment,
+// infrastructure definition or override and writes it to outputPath. It
+// returns the entity kind, or "" when the config holds none of them.
+func convertEntityFile(converter *pipeline_converter.PipelineConverter, cfg *v0.Config, outputPath string) (string, error) {
+	switch {
+	case cfg.Service != nil:
+		dst := converter.ConvertService(cfg.Service)
+		pipeline_converter.PostProcessExpressions(dst, nil, false)
+		return "service", v1.WriteServiceFile(outputPath, dst)
+	case cfg.Environment != nil:
+		dst := converter.ConvertEnvironment(cfg.Environment)
+		pipeline_converter.PostProcessExpressions(dst, nil, false)
+		return "environment", v1.WriteEnvironmentFile(outputPath, dst)
+	case cfg.Infrastructure != nil:
+		dst := converter.ConvertInfrastructure(cfg.Infrastructure)
+		pipeline_converter.PostProcessExpressions(dst, nil, false)
+		return "infrastructure", v1.WriteInfrastructureFile(outputPath, dst)
+	case cfg.Overrides != nil:
+		dst := converter.ConvertOverride(cfg.Overrides)
+		pipeline_converter.PostProcessExpressions(dst, nil, false)
+		return "override", v1.WriteOverrideFile(outputPath, dst)
+	}
+	return "", nil
+}
+
 func setupLogFile(outputDir string) (*os.File, error) {
 	logFileName := fmt.Sprintf("conversion.log")
 	logFilePath := filepath.Join(outputDir, logFileName)
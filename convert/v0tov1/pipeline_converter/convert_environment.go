@@ -0,0 +1,41 @@
+package pipelineconverter
+
+import (
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+)
+
+// ConvertEnvironment converts a standalone v0 environment to a v1
+// Environment. Environment variables become inputs.
+func (c *PipelineConverter) ConvertEnvironment(src *v0.EnvironmentEntity) *v1.Environment {
+	if src == nil {
+		return nil
+	}
+
+	return &v1.Environment{
+		Id:        src.ID,
+		Name:      src.Name,
+		Desc:      src.Desc,
+		Org:       src.Org,
+		Project:   src.Project,
+		Tags:      src.Tags,
+		Type:      convertEnvironmentType(src.Type),
+		Inputs:    c.convertVariables(src.Variables),
+		Overrides: c.convertOverridesSpec("environment", src.ID, src.Overrides),
+	}
+}
+
+// convertEnvironmentType maps the v0 Production/PreProduction environment
+// type to the v1 enum. Expressions are passed through.
+func convertEnvironmentType(src string) v1.EnvironmentType {
+	switch src {
+	case "":
+		return v1.EnvironmentTypeNone
+	case "Production":
+		return v1.EnvironmentTypeProduction
+	case "PreProduction":
+		return v1.EnvironmentTypeNonProduction
+	default:
+		return v1.EnvironmentType(src)
+	}
+}
@@ -0,0 +1,29 @@
+package pipelineconverter
+
+import (
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+)
+
+// ConvertInfrastructure converts a standalone v0 infrastructure definition
+// to a v1 infrastructure. The type-specific spec has no v1 schema and is
+// carried over unchanged with a warning.
+func (c *PipelineConverter) ConvertInfrastructure(src *v0.InfrastructureEntity) *v1.InfraSchema {
+	if src == nil {
+		return nil
+	}
+
+	return &v1.InfraSchema{
+		Id:                src.ID,
+		Name:              src.Name,
+		Desc:              src.Desc,
+		Org:               src.Org,
+		Project:           src.Project,
+		Tags:              src.Tags,
+		Environment:       src.EnvironmentRef,
+		Deployment:        src.DeploymentType,
+		Type:              src.Type,
+		Spec:              passThrough("infrastructure", src.ID, "spec", src.Spec),
+		AllowSimultaneous: src.AllowSimultaneousDeployments,
+	}
+}
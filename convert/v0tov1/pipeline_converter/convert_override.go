@@ -0,0 +1,67 @@
+package pipelineconverter
+
+import (
+	"fmt"
+
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+)
+
+// ConvertOverride converts a standalone v0 service/environment override to
+// a v1 Override.
+func (c *PipelineConverter) ConvertOverride(src *v0.OverridesEntity) *v1.Override {
+	if src == nil {
+		return nil
+	}
+
+	return &v1.Override{
+		Id:             src.ID,
+		Org:            src.Org,
+		Project:        src.Project,
+		Type:           convertOverrideType(src),
+		Environment:    src.EnvironmentRef,
+		Service:        src.ServiceRef,
+		Infrastructure: src.InfraIdentifier,
+		Overrides:      c.convertOverridesSpec("override", src.ID, src.Spec),
+	}
+}
+
+// convertOverrideType maps the v0 override type to the v1 override scope.
+// Unknown types are kept unchanged with a warning.
+func convertOverrideType(src *v0.OverridesEntity) string {
+	switch src.Type {
+	case v0.OverrideTypeEnvGlobal:
+		return v1.OverrideTypeEnvironment
+	case v0.OverrideTypeEnvService:
+		return v1.OverrideTypeEnvironmentService
+	case v0.OverrideTypeInfraGlobal:
+		return v1.OverrideTypeInfrastructure
+	case v0.OverrideTypeInfraService:
+		return v1.OverrideTypeInfrastructureService
+	case "":
+		return ""
+	}
+	GetMessageLogger().LogWarning(
+		"UNSUPPORTED_OVERRIDE_TYPE",
+		fmt.Sprintf("override type %q has no v1 equivalent; kept unchanged", src.Type),
+		WithContext(map[string]string{"override_id": src.ID, "type": src.Type}),
+	)
+	return src.Type
+}
+
+// convertOverridesSpec converts the overridden values shared by environments
+// and overrides. Variables become inputs; manifests, config files and Azure
+// settings have no v1 schema and are carried over in their v0 shape with a
+// warning naming the owning kind and id.
+func (c *PipelineConverter) convertOverridesSpec(kind, id string, src *v0.OverridesSpec) *v1.Overrides {
+	if src == nil {
+		return nil
+	}
+	return &v1.Overrides{
+		Inputs:              c.convertVariables(src.Variables),
+		Manifests:           passThroughList(kind, id, "manifests", src.Manifests),
+		ConfigFiles:         passThroughList(kind, id, "configFiles", src.ConfigFiles),
+		ApplicationSettings: passThrough(kind, id, "applicationSettings", src.ApplicationSettings),
+		ConnectionStrings:   passThrough(kind, id, "connectionStrings", src.ConnectionStrings),
+	}
+}
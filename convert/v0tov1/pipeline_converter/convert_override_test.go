@@ -0,0 +1,79 @@
+package pipelineconverter
+
+import (
+	"testing"
+
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+)
+
+func TestConvertEnvironment(t *testing.T) {
+	tests := []struct {
+		src  string
+		want v1.EnvironmentType
+	}{
+		{"Production", v1.EnvironmentTypeProduction},
+		{"PreProduction", v1.EnvironmentTypeNonProduction},
+		{"<+input>", v1.EnvironmentType("<+input>")},
+		{"", v1.EnvironmentTypeNone},
+	}
+	for _, tt := range tests {
+		got := NewPipelineConverter().ConvertEnvironment(&v0.EnvironmentEntity{
+			ID:        "prod",
+			Type:      tt.src,
+			Variables: []*v0.Variable{{Name: "region", Type: "String", Value: "us-east-1"}},
+			Overrides: &v0.OverridesSpec{ConfigFiles: []interface{}{"config"}},
+		})
+		if got.Type != tt.want {
+			t.Errorf("type %q: want %q, got %q", tt.src, tt.want, got.Type)
+		}
+		if _, ok := got.Inputs["region"]; !ok {
+			t.Errorf("expected region input, got %+v", got.Inputs)
+		}
+		if got.Overrides == nil || len(got.Overrides.ConfigFiles) != 1 {
+			t.Errorf("expected overrides to be carried over, got %+v", got.Overrides)
+		}
+	}
+}
+
+func TestConvertOverride(t *testing.T) {
+	tests := []struct {
+		src      string
+		want     string
+		wantWarn bool
+	}{
+		{v0.OverrideTypeEnvGlobal, v1.OverrideTypeEnvironment, false},
+		{v0.OverrideTypeEnvService, v1.OverrideTypeEnvironmentService, false},
+		{v0.OverrideTypeInfraGlobal, v1.OverrideTypeInfrastructure, false},
+		{v0.OverrideTypeInfraService, v1.OverrideTypeInfrastructureService, false},
+		{"CLUSTER_GLOBAL_OVERRIDE", "CLUSTER_GLOBAL_OVERRIDE", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			defer startEntityLog()()
+
+			got := NewPipelineConverter().ConvertOverride(&v0.OverridesEntity{
+				Type:            tt.src,
+				EnvironmentRef:  "prod",
+				ServiceRef:      "checkout",
+				InfraIdentifier: "prod_k8s",
+				Spec: &v0.OverridesSpec{
+					Variables: []*v0.Variable{{Name: "replicas", Type: "String", Value: "4"}},
+				},
+			})
+			if got.Type != tt.want {
+				t.Errorf("want type %q, got %q", tt.want, got.Type)
+			}
+			if got.Environment != "prod" || got.Service != "checkout" || got.Infrastructure != "prod_k8s" {
+				t.Errorf("unexpected refs %+v", got)
+			}
+			if got.Overrides == nil || got.Overrides.Inputs["replicas"] == nil {
+				t.Errorf("expected replicas input, got %+v", got.Overrides)
+			}
+			codes := messageCodes(entityTestLogFile)
+			if warned := len(codes) == 1 && codes[0] == "UNSUPPORTED_OVERRIDE_TYPE"; warned != tt.wantWarn {
+				t.Errorf("want warning %v, got messages %v", tt.wantWarn, codes)
+			}
+		})
+	}
+}
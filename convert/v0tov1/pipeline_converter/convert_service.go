@@ -0,0 +1,72 @@
+package pipelineconverter
+
+import (
+	"fmt"
+
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+)
+
+// ConvertService converts a standalone v0 service to a v1 Service. Service
+// variables become inputs; manifests, artifacts, config files, hooks and
+// Azure settings have no v1 schema and are carried over in their v0 shape
+// with a warning.
+func (c *PipelineConverter) ConvertService(src *v0.ServiceEntity) *v1.Service {
+	if src == nil {
+		return nil
+	}
+
+	dst := &v1.Service{
+		Id:      src.ID,
+		Name:    src.Name,
+		Desc:    src.Desc,
+		Org:     src.Org,
+		Project: src.Project,
+		Tags:    src.Tags,
+		GitOps:  src.GitOpsEnabled,
+	}
+
+	if def := src.ServiceDefinition; def != nil {
+		dst.Type = def.Type
+		if spec := def.Spec; spec != nil {
+			dst.Inputs = c.convertVariables(spec.Variables)
+			dst.Manifests = passThroughList("service", src.ID, "manifests", spec.Manifests)
+			dst.Artifacts = passThrough("service", src.ID, "artifacts", spec.Artifacts)
+			dst.ConfigFiles = passThroughList("service", src.ID, "configFiles", spec.ConfigFiles)
+			dst.Hooks = passThroughList("service", src.ID, "hooks", spec.Hooks)
+			if spec.ManifestConfigurations != nil {
+				dst.PrimaryManifest = spec.ManifestConfigurations.PrimaryManifestRef
+			}
+			dst.ApplicationSettings = passThrough("service", src.ID, "applicationSettings", spec.ApplicationSettings)
+			dst.ConnectionStrings = passThrough("service", src.ID, "connectionStrings", spec.ConnectionStrings)
+			dst.StartupCommand = passThrough("service", src.ID, "startupCommand", spec.StartupCommand)
+		}
+	}
+
+	return dst
+}
+
+// passThrough returns a v0 block that has no v1 schema unchanged, and logs
+// a warning when it is set.
+func passThrough(kind, id, field string, src interface{}) interface{} {
+	if src != nil {
+		logPassThrough(kind, id, field)
+	}
+	return src
+}
+
+// passThroughList is passThrough for a list of v0 blocks.
+func passThroughList(kind, id, field string, src []interface{}) []interface{} {
+	if len(src) != 0 {
+		logPassThrough(kind, id, field)
+	}
+	return src
+}
+
+func logPassThrough(kind, id, field string) {
+	GetMessageLogger().LogWarning(
+		"UNSUPPORTED_FIELD",
+		fmt.Sprintf("%s field %q has no v1 equivalent; passed through in its v0 shape", kind, field),
+		WithContext(map[string]string{kind + "_id": id, "field": field}),
+	)
+}
@@ -0,0 +1,131 @@
+package pipelineconverter
+
+import (
+	"testing"
+
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	"github.com/drone/go-convert/convert/v0tov1/messagelog"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+	"github.com/drone/go-convert/internal/flexible"
+	"github.com/google/go-cmp/cmp"
+)
+
+const entityTestLogFile = "entity_test"
+
+// messageCodes returns the message codes recorded for the file.
+func messageCodes(file string) []string {
+	fl := messagelog.GetMessageLogger().GetFileLog(file)
+	if fl == nil {
+		return nil
+	}
+	var codes []string
+	for _, m := range fl.Messages {
+		codes = append(codes, m.Code)
+	}
+	return codes
+}
+
+// startEntityLog resets the message logger and records messages for
+// entityTestLogFile until the returned function is called.
+func startEntityLog() func() {
+	messagelog.ResetMessageLogger()
+	messagelog.GetMessageLogger().Enable("")
+	messagelog.GetMessageLogger().SetCurrentFile(entityTestLogFile)
+	return messagelog.ResetMessageLogger
+}
+
+func TestConvertService(t *testing.T) {
+	config, err := v0.ParseString(`
+service:
+  name: checkout
+  identifier: checkout
+  orgIdentifier: default
+  projectIdentifier: shop
+  tags:
+    team: payments
+  serviceDefinition:
+    type: Kubernetes
+    spec:
+      variables:
+        - name: replicas
+          type: String
+          value: "2"
+      manifests:
+        - manifest:
+            identifier: k8s
+            type: K8sManifest
+      manifestConfigurations:
+        primaryManifestRef: k8s
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer startEntityLog()()
+
+	got := NewPipelineConverter().ConvertService(config.Service)
+	if got == nil {
+		t.Fatal("expected service")
+	}
+	if _, ok := got.Inputs["replicas"]; !ok {
+		t.Errorf("expected replicas input, got %+v", got.Inputs)
+	}
+	got.Inputs = nil
+
+	want := &v1.Service{
+		Id:      "checkout",
+		Name:    "checkout",
+		Org:     "default",
+		Project: "shop",
+		Tags:    &flexible.Field[map[string]string]{Value: map[string]string{"team": "payments"}},
+		Type:    "Kubernetes",
+		Manifests: []interface{}{
+			map[string]interface{}{"manifest": map[string]interface{}{"identifier": "k8s", "type": "K8sManifest"}},
+		},
+		PrimaryManifest: "k8s",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	// the manifests are passed through with a warning.
+	if diff := cmp.Diff([]string{"UNSUPPORTED_FIELD"}, messageCodes(entityTestLogFile)); diff != "" {
+		t.Errorf("messages mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConvertInfrastructure(t *testing.T) {
+	config, err := v0.ParseString(`
+infrastructureDefinition:
+  name: prod k8s
+  identifier: prod_k8s
+  environmentRef: prod
+  deploymentType: Kubernetes
+  type: KubernetesDirect
+  spec:
+    connectorRef: k8s
+    namespace: checkout
+  allowSimultaneousDeployments: true
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer startEntityLog()()
+
+	got := NewPipelineConverter().ConvertInfrastructure(config.Infrastructure)
+	want := &v1.InfraSchema{
+		Id:                "prod_k8s",
+		Name:              "prod k8s",
+		Environment:       "prod",
+		Deployment:        "Kubernetes",
+		Type:              "KubernetesDirect",
+		Spec:              map[string]interface{}{"connectorRef": "k8s", "namespace": "checkout"},
+		AllowSimultaneous: &flexible.Field[bool]{Value: true},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"UNSUPPORTED_FIELD"}, messageCodes(entityTestLogFile)); diff != "" {
+		t.Errorf("messages mismatch (-want +got):\n%s", diff)
+	}
+}
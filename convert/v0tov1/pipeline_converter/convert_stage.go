@@ -111,6 +111,17 @@ func (c *PipelineConverter) convertStage(src *v0.Stage, basePath string) *v1.Sta
 			}
 		}
 
+	case v0.StageTypeFeatureFlag:
+		spec, ok := src.Spec.(*v0.StageFeatureFlag)
+		if ok && spec != nil {
+			if spec.Execution != nil {
+				stage.Steps = c.ConvertSteps(spec.Execution.Steps, false, stepsPath, src.ID, "", nil)
+				if spec.Execution.RollbackSteps != nil {
+					stage.Rollback = c.ConvertSteps(spec.Execution.RollbackSteps, true, rollbackStepsPath, src.ID, "", nil)
+				}
+			}
+		}
+
 	case v0.StageTypeIACM:
 		spec, ok := src.Spec.(*v0.StageIACM)
 		if ok && spec != nil {
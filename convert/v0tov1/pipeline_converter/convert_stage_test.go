@@ -0,0 +1,91 @@
+package pipelineconverter
+
+import (
+	"testing"
+
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+	"github.com/drone/go-convert/internal/flexible"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConvertStage_FeatureFlag(t *testing.T) {
+	converter := NewPipelineConverter()
+
+	src := &v0.Stage{
+		ID:   "toggle",
+		Name: "Toggle",
+		Type: v0.StageTypeFeatureFlag,
+		Spec: &v0.StageFeatureFlag{
+			Execution: &v0.Execution{
+				Steps: []*v0.Steps{
+					{Step: &v0.Step{
+						ID:   "enable",
+						Name: "Enable",
+						Type: v0.StepTypeFlagConfiguration,
+						Spec: &v0.StepFlagConfiguration{
+							Feature:     "new_checkout",
+							Environment: "prod",
+							Instructions: []*v0.FlagInstruction{
+								{Type: v0.FlagInstructionSetFeatureFlagState, Spec: &v0.FlagInstructionSpec{State: "on"}},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	stage := converter.convertStage(src, "pipeline")
+	if stage == nil {
+		t.Fatal("expected a stage, got nil")
+	}
+	if len(stage.Steps) != 1 {
+		t.Fatalf("expected 1 step, got %d", len(stage.Steps))
+	}
+	step := stage.Steps[0]
+	if step.Template == nil {
+		t.Fatal("expected the flag configuration step to convert to a template step")
+	}
+	if step.Template.Uses != v1.StepTypeFeatureFlagConfiguration {
+		t.Errorf("expected uses %q, got %q", v1.StepTypeFeatureFlagConfiguration, step.Template.Uses)
+	}
+}
+
+func TestConvertSingleStep_VerifyFailureStrategy(t *testing.T) {
+	converter := NewPipelineConverter()
+
+	src := &v0.Step{
+		ID:   "verify",
+		Name: "Verify",
+		Type: v0.StepTypeVerify,
+		Spec: &v0.StepVerify{
+			Type: v0.VerifyTypeCanary,
+			Spec: &v0.VerifySpec{Sensitivity: "HIGH", Duration: "5m"},
+		},
+		FailureStrategies: &flexible.Field[[]*v0.FailureStrategy]{Value: []*v0.FailureStrategy{{
+			OnFailure: &v0.OnFailure{
+				Errors: []v0.FailureType{v0.FailureTypeVerification},
+				Action: &v0.Action{Type: v0.ActionTypeStageRollback},
+			},
+		}}},
+	}
+
+	step := converter.ConvertSingleStep(src, false, "pipeline.stages.deploy.spec.execution.steps", "deploy", "", nil)
+	if step == nil || step.Template == nil {
+		t.Fatal("expected the verify step to convert to a template step")
+	}
+	if step.Template.Uses != v1.StepTypeVerify {
+		t.Errorf("expected uses %q, got %q", v1.StepTypeVerify, step.Template.Uses)
+	}
+	strategies, ok := step.OnFailure.AsStruct()
+	if !ok || len(strategies) != 1 {
+		t.Fatalf("expected 1 failure strategy, got %v", step.OnFailure)
+	}
+	if diff := cmp.Diff([]v1.FailureType{v1.FailureTypeVerification}, strategies[0].Errors); diff != "" {
+		t.Errorf("errors mismatch (-want +got):\n%s", diff)
+	}
+	if strategies[0].Action != v1.ActionTypeStageRollback {
+		t.Errorf("expected action %q, got %v", v1.ActionTypeStageRollback, strategies[0].Action)
+	}
+}
@@ -199,6 +199,109 @@ func (c *PipelineConverter) ConvertSingleStep(src *v0.Step, isRollback bool, bas
 		step.Template = convert_helpers.ConvertStepServerlessAwsLambdaPackageV2(src)
 	case v0.StepTypeServerlessAwsLambdaRollbackV2:
 		step.Template = convert_helpers.ConvertStepServerlessAwsLambdaRollbackV2(src)
+	case v0.StepTypeTerraformPlan:
+		step.Template = convert_helpers.ConvertStepTerraformPlan(src)
+	case v0.StepTypeTerraformApply:
+		step.Template = convert_helpers.ConvertStepTerraformApply(src)
+	case v0.StepTypeTerraformDestroy:
+		step.Template = convert_helpers.ConvertStepTerraformDestroy(src)
+	case v0.StepTypeTerraformRollback:
+		step.Template = convert_helpers.ConvertStepTerraformRollback(src)
+	case v0.StepTypeTerragruntPlan:
+		step.Template = convert_helpers.ConvertStepTerragruntPlan(src)
+	case v0.StepTypeTerragruntApply:
+		step.Template = convert_helpers.ConvertStepTerragruntApply(src)
+	case v0.StepTypeTerragruntDestroy:
+		step.Template = convert_helpers.ConvertStepTerragruntDestroy(src)
+	case v0.StepTypeTerragruntRollback:
+		step.Template = convert_helpers.ConvertStepTerragruntRollback(src)
+	case v0.StepTypeCloudformationCreateStack:
+		step.Template = convert_helpers.ConvertStepCloudformationCreateStack(src)
+	case v0.StepTypeCloudformationDeleteStack:
+		step.Template = convert_helpers.ConvertStepCloudformationDeleteStack(src)
+	case v0.StepTypeCloudformationRollbackStack:
+		step.Template = convert_helpers.ConvertStepCloudformationRollbackStack(src)
+	case v0.StepTypeEcsRollingDeploy:
+		step.Template = convert_helpers.ConvertStepEcsRollingDeploy(src)
+	case v0.StepTypeEcsRollingRollback:
+		step.Template = convert_helpers.ConvertStepEcsRollingRollback(src)
+	case v0.StepTypeEcsCanaryDeploy:
+		step.Template = convert_helpers.ConvertStepEcsCanaryDeploy(src)
+	case v0.StepTypeEcsCanaryDelete:
+		step.Template = convert_helpers.ConvertStepEcsCanaryDelete(src)
+	case v0.StepTypeEcsBlueGreenCreateService:
+		step.Template = convert_helpers.ConvertStepEcsBlueGreenCreateService(src)
+	case v0.StepTypeEcsBlueGreenSwapTargetGroups:
+		step.Template = convert_helpers.ConvertStepEcsBlueGreenSwapTargetGroups(src)
+	case v0.StepTypeEcsBlueGreenRollback:
+		step.Template = convert_helpers.ConvertStepEcsBlueGreenRollback(src)
+	case v0.StepTypeEcsRunTask:
+		step.Template = convert_helpers.ConvertStepEcsRunTask(src)
+	case v0.StepTypeAzureSlotDeployment:
+		step.Template = convert_helpers.ConvertStepAzureSlotDeployment(src)
+	case v0.StepTypeAzureTrafficShift:
+		step.Template = convert_helpers.ConvertStepAzureTrafficShift(src)
+	case v0.StepTypeAzureSwapSlot:
+		step.Template = convert_helpers.ConvertStepAzureSwapSlot(src)
+	case v0.StepTypeAzureWebAppRollback:
+		step.Template = convert_helpers.ConvertStepAzureWebAppRollback(src)
+	case v0.StepTypeTasBasicAppSetup:
+		step.Template = convert_helpers.ConvertStepTasBasicAppSetup(src)
+	case v0.StepTypeTasBGAppSetup:
+		step.Template = convert_helpers.ConvertStepTasBGAppSetup(src)
+	case v0.StepTypeTasCanaryAppSetup:
+		step.Template = convert_helpers.ConvertStepTasCanaryAppSetup(src)
+	case v0.StepTypeTasAppResize:
+		step.Template = convert_helpers.ConvertStepTasAppResize(src)
+	case v0.StepTypeTasSwapRoutes:
+		step.Template = convert_helpers.ConvertStepTasSwapRoutes(src)
+	case v0.StepTypeTasSwapRollback:
+		step.Template = convert_helpers.ConvertStepTasSwapRollback(src)
+	case v0.StepTypeTasAppRollback:
+		step.Template = convert_helpers.ConvertStepTasAppRollback(src)
+	case v0.StepTypeAsgRollingDeploy:
+		step.Template = convert_helpers.ConvertStepAsgRollingDeploy(src)
+	case v0.StepTypeAsgRollingRollback:
+		step.Template = convert_helpers.ConvertStepAsgRollingRollback(src)
+	case v0.StepTypeAsgBlueGreenDeploy:
+		step.Template = convert_helpers.ConvertStepAsgBlueGreenDeploy(src)
+	case v0.StepTypeAsgBlueGreenSwapService:
+		step.Template = convert_helpers.ConvertStepAsgBlueGreenSwapService(src)
+	case v0.StepTypeAsgBlueGreenRollback:
+		step.Template = convert_helpers.ConvertStepAsgBlueGreenRollback(src)
+	case v0.StepTypeDeployCloudFunction:
+		step.Template = convert_helpers.ConvertStepDeployCloudFunction(src)
+	case v0.StepTypeDeployCloudFunctionWithNoTraffic:
+		step.Template = convert_helpers.ConvertStepDeployCloudFunctionWithNoTraffic(src)
+	case v0.StepTypeCloudFunctionTrafficShift:
+		step.Template = convert_helpers.ConvertStepCloudFunctionTrafficShift(src)
+	case v0.StepTypeCloudFunctionRollback:
+		step.Template = convert_helpers.ConvertStepCloudFunctionRollback(src)
+	case v0.StepTypeAwsLambdaDeploy:
+		step.Template = convert_helpers.ConvertStepAwsLambdaDeploy(src)
+	case v0.StepTypeAwsLambdaRollback:
+		step.Template = convert_helpers.ConvertStepAwsLambdaRollback(src)
+	case v0.StepTypeAquaTrivy, v0.StepTypeSnyk, v0.StepTypeSonarqube, v0.StepTypeCheckmarx, v0.StepTypeGrype, v0.StepTypeWiz,
+		v0.StepTypeSemgrep, v0.StepTypeGitleaks, v0.StepTypePrismaCloud, v0.StepTypeCustomIngest:
+		step.Template = convert_helpers.ConvertStepSTOScan(src)
+	case v0.StepTypeFlagConfiguration:
+		step.Template = convert_helpers.ConvertStepFlagConfiguration(src)
+	case v0.StepTypeVerify:
+		step.Template = convert_helpers.ConvertStepVerify(src)
+	case v0.StepTypeChaos:
+		step.Template = convert_helpers.ConvertStepChaos(src)
+	case v0.StepTypeGitOpsUpdateReleaseRepo:
+		step.Template = convert_helpers.ConvertStepGitOpsUpdateReleaseRepo(src)
+	case v0.StepTypeGitOpsMergePR:
+		step.Template = convert_helpers.ConvertStepGitOpsMergePR(src)
+	case v0.StepTypeGitOpsFetchLinkedApps:
+		step.Template = convert_helpers.ConvertStepGitOpsFetchLinkedApps(src)
+	case v0.StepTypeGitOpsSync:
+		step.Template = convert_helpers.ConvertStepGitOpsSync(src)
+	case v0.StepTypeGitOpsRevertPR:
+		step.Template = convert_helpers.ConvertStepGitOpsRevertPR(src)
+	case v0.StepTypePolicy:
+		step.Template = convert_helpers.ConvertStepPolicy(src)
 	case v0.StepTypeWait:
 		step.Wait = convert_helpers.ConvertStepWait(src)
 	case v0.StepTypeHTTP:
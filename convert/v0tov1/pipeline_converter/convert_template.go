@@ -1,6 +1,8 @@
 package pipelineconverter
 
 import (
+	"fmt"
+
 	v0 "github.com/drone/go-convert/convert/harness/yaml"
 	convert_helpers "github.com/drone/go-convert/convert/v0tov1/convert_helpers"
 	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
@@ -20,15 +22,15 @@ func (c *PipelineConverter) ConvertTemplate(src *v0.Template) *v1.Template {
 	}
 	// Based on the type of template, convert it
 	switch src.Type {
-	case "Stage":
+	case v0.TemplateTypeStage:
 		if spec, ok := src.Spec.(*v0.Stage); ok {
 			dst.Stage = c.convertStage(spec, "")
 		}
-	case "Step":
+	case v0.TemplateTypeStep:
 		if spec, ok := src.Spec.(*v0.Step); ok {
 			dst.Step = c.ConvertSingleStep(spec, false, "", "", "", nil)
 		}
-	case "StepGroup":
+	case v0.TemplateTypeStepGroup:
 		if spec, ok := src.Spec.(*v0.StepGroup); ok {
 			dst.Env = spec.Env
 			dst.Group = &v1.StepGroup{
@@ -41,11 +43,108 @@ func (c *PipelineConverter) ConvertTemplate(src *v0.Template) *v1.Template {
 			dst.Delegate = convert_helpers.ConvertDelegate(spec.DelegateSelectors, nil)
 			dst.If = convert_helpers.ConvertStepWhen(spec.When, spec.Skip)
 		}
-	case "Pipeline":
+	case v0.TemplateTypePipeline:
 		if spec, ok := src.Spec.(*v0.Pipeline); ok {
 			dst.Pipeline = c.ConvertPipeline(spec)
 		}
+	case v0.TemplateTypeCustomDeployment:
+		if spec, ok := src.Spec.(*v0.TemplateCustomDeployment); ok {
+			dst.CustomDeployment = c.convertCustomDeploymentTemplate(spec)
+		}
+	case v0.TemplateTypeArtifactSource:
+		dst.ArtifactSource = src.Spec
+		logUnsupportedTemplateType(src)
+	case v0.TemplateTypeMonitoredService:
+		dst.MonitoredService = src.Spec
+		logUnsupportedTemplateType(src)
+	case v0.TemplateTypeSecretManager:
+		dst.SecretManager = src.Spec
+		logUnsupportedTemplateType(src)
+	}
+
+	// Unknown template types, and specs that do not match the template
+	// type, leave the template without a spec.
+	if TemplateStatus(dst) == TemplateStatusDropped {
+		logDroppedTemplate(src)
+	}
+
+	// Lift the v0 runtime inputs of the converted body into typed template
+	// inputs referenced as <+inputs.name>.
+	switch src.Type {
+	case v0.TemplateTypeStage, v0.TemplateTypeStep, v0.TemplateTypeStepGroup, v0.TemplateTypePipeline:
+		dst.Inputs = extractRuntimeInputs(dst)
 	}
 
 	return dst
 }
+
+// Template conversion statuses, reported for template conversions.
+const (
+	TemplateStatusConverted     = "CONVERTED"
+	TemplateStatusPassedThrough = "PASSED_THROUGH"
+	TemplateStatusDropped       = "DROPPED"
+)
+
+// TemplateStatus reports whether the converted template carries a v1 model,
+// the v0 spec passed through unchanged, or no spec at all.
+func TemplateStatus(dst *v1.Template) string {
+	switch {
+	case dst == nil:
+		return TemplateStatusDropped
+	case dst.Stage != nil, dst.Step != nil, dst.Group != nil,
+		dst.Pipeline != nil, dst.CustomDeployment != nil:
+		return TemplateStatusConverted
+	case dst.ArtifactSource != nil, dst.MonitoredService != nil, dst.SecretManager != nil:
+		return TemplateStatusPassedThrough
+	}
+	return TemplateStatusDropped
+}
+
+// logUnsupportedTemplateType records a template whose spec has no v1 model and
+// is passed through unchanged.
+func logUnsupportedTemplateType(src *v0.Template) {
+	GetMessageLogger().LogWarning(
+		"UNSUPPORTED_TEMPLATE_TYPE",
+		fmt.Sprintf("template type %q has no v1 equivalent; spec passed through unchanged", src.Type),
+		WithContext(map[string]string{"template_id": src.ID, "template_type": src.Type}),
+	)
+}
+
+// logDroppedTemplate records a template whose spec could not be converted or
+// passed through, and is dropped from the v1 template.
+func logDroppedTemplate(src *v0.Template) {
+	GetMessageLogger().LogWarning(
+		"TEMPLATE_SPEC_DROPPED",
+		fmt.Sprintf("template type %q could not be converted; spec dropped", src.Type),
+		WithContext(map[string]string{"template_id": src.ID, "template_type": src.Type}),
+	)
+}
+
+// convertCustomDeploymentTemplate converts a v0 CustomDeployment template spec.
+func (c *PipelineConverter) convertCustomDeploymentTemplate(src *v0.TemplateCustomDeployment) *v1.CustomDeploymentTemplate {
+	dst := &v1.CustomDeploymentTemplate{}
+	if infra := src.Infrastructure; infra != nil {
+		dst.Inputs = c.convertVariables(infra.Variables)
+
+		instances := &v1.CustomDeploymentInstances{Path: infra.InstancesListPath}
+		if script := infra.FetchInstancesScript; script != nil && script.Store != nil && script.Store.Spec != nil {
+			instances.Script = script.Store.Spec.Content
+			instances.Files = script.Store.Spec.Files
+		}
+		for _, attr := range infra.InstanceAttributes {
+			if attr == nil {
+				continue
+			}
+			instances.Attributes = append(instances.Attributes, &v1.CustomDeploymentAttribute{
+				Name:        attr.Name,
+				Path:        attr.JsonPath,
+				Description: attr.Description,
+			})
+		}
+		dst.Instances = instances
+	}
+	if src.Execution != nil {
+		dst.Steps = src.Execution.StepTemplateRefs
+	}
+	return dst
+}
@@ -0,0 +1,176 @@
+package pipelineconverter
+
+import (
+	"testing"
+
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	"github.com/drone/go-convert/convert/v0tov1/messagelog"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+	"github.com/google/go-cmp/cmp"
+)
+
+const templateTestLogFile = "template_test"
+
+func parseTemplate(t *testing.T, src string) *v0.Template {
+	t.Helper()
+	config, err := v0.ParseString(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Template == nil {
+		t.Fatal("template parsing returned nil")
+	}
+	return config.Template
+}
+
+// templateMessageCodes returns the message codes recorded for the test file.
+func templateMessageCodes() []string {
+	fl := messagelog.GetMessageLogger().GetFileLog(templateTestLogFile)
+	if fl == nil {
+		return nil
+	}
+	var codes []string
+	for _, m := range fl.Messages {
+		codes = append(codes, m.Code)
+	}
+	return codes
+}
+
+func TestConvertTemplate_CustomDeployment(t *testing.T) {
+	src := parseTemplate(t, `
+template:
+  name: vm pool
+  identifier: vm_pool
+  versionLabel: v1
+  type: CustomDeployment
+  spec:
+    infrastructure:
+      variables:
+        - name: region
+          type: String
+          value: <+input>
+      fetchInstancesScript:
+        store:
+          type: Inline
+          spec:
+            content: ./list-instances.sh
+      instanceAttributes:
+        - name: instancename
+          jsonPath: hostname
+          description: instance host
+      instancesListPath: instances
+    execution:
+      stepTemplateRefs:
+        - account.deploy_vm
+`)
+
+	messagelog.ResetMessageLogger()
+	messagelog.GetMessageLogger().Enable("")
+	messagelog.GetMessageLogger().SetCurrentFile(templateTestLogFile)
+	defer messagelog.ResetMessageLogger()
+
+	got := NewPipelineConverter().ConvertTemplate(src)
+	if got == nil || got.CustomDeployment == nil {
+		t.Fatalf("expected custom-deployment template, got %+v", got)
+	}
+	if _, ok := got.CustomDeployment.Inputs["region"]; !ok {
+		t.Errorf("expected region input, got %+v", got.CustomDeployment.Inputs)
+	}
+
+	wantInstances := &v1.CustomDeploymentInstances{
+		Script: "./list-instances.sh",
+		Path:   "instances",
+		Attributes: []*v1.CustomDeploymentAttribute{
+			{Name: "instancename", Path: "hostname", Description: "instance host"},
+		},
+	}
+	if diff := cmp.Diff(wantInstances, got.CustomDeployment.Instances); diff != "" {
+		t.Errorf("instances mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"account.deploy_vm"}, got.CustomDeployment.Steps); diff != "" {
+		t.Errorf("steps mismatch (-want +got):\n%s", diff)
+	}
+	if codes := templateMessageCodes(); len(codes) != 0 {
+		t.Errorf("expected no messages, got %v", codes)
+	}
+	if status := TemplateStatus(got); status != TemplateStatusConverted {
+		t.Errorf("expected status %s, got %s", TemplateStatusConverted, status)
+	}
+}
+
+func TestConvertTemplate_PassThrough(t *testing.T) {
+	src := parseTemplate(t, `
+template:
+  name: docker image
+  identifier: docker_image
+  versionLabel: v1
+  type: ArtifactSource
+  spec:
+    type: DockerRegistry
+    spec:
+      connectorRef: dockerhub
+      imagePath: library/nginx
+`)
+
+	messagelog.ResetMessageLogger()
+	messagelog.GetMessageLogger().Enable("")
+	messagelog.GetMessageLogger().SetCurrentFile(templateTestLogFile)
+	defer messagelog.ResetMessageLogger()
+
+	got := NewPipelineConverter().ConvertTemplate(src)
+	if got == nil || got.ArtifactSource == nil {
+		t.Fatalf("expected artifact-source spec to be passed through, got %+v", got)
+	}
+	spec, ok := got.ArtifactSource.(map[string]interface{})
+	if !ok || spec["type"] != "DockerRegistry" {
+		t.Errorf("expected the v0 spec unchanged, got %+v", got.ArtifactSource)
+	}
+	if diff := cmp.Diff([]string{"UNSUPPORTED_TEMPLATE_TYPE"}, templateMessageCodes()); diff != "" {
+		t.Errorf("messages mismatch (-want +got):\n%s", diff)
+	}
+	if status := TemplateStatus(got); status != TemplateStatusPassedThrough {
+		t.Errorf("expected status %s, got %s", TemplateStatusPassedThrough, status)
+	}
+}
+
+func TestConvertTemplate_Dropped(t *testing.T) {
+	tests := []struct {
+		name string
+		src  *v0.Template
+	}{
+		{
+			name: "unknown type",
+			src: &v0.Template{
+				ID:   "pool",
+				Type: "InfrastructurePool",
+				Spec: map[string]interface{}{"type": "Pool"},
+			},
+		},
+		{
+			// the spec does not match the template type, so the
+			// custom-deployment template is not converted.
+			name: "mismatched spec",
+			src: &v0.Template{
+				ID:   "vm_pool",
+				Type: v0.TemplateTypeCustomDeployment,
+				Spec: map[string]interface{}{"infrastructure": "pool"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			messagelog.ResetMessageLogger()
+			messagelog.GetMessageLogger().Enable("")
+			messagelog.GetMessageLogger().SetCurrentFile(templateTestLogFile)
+			defer messagelog.ResetMessageLogger()
+
+			got := NewPipelineConverter().ConvertTemplate(test.src)
+			if status := TemplateStatus(got); status != TemplateStatusDropped {
+				t.Errorf("expected status %s, got %s", TemplateStatusDropped, status)
+			}
+			if diff := cmp.Diff([]string{"TEMPLATE_SPEC_DROPPED"}, templateMessageCodes()); diff != "" {
+				t.Errorf("messages mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
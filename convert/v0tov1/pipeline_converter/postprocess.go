@@ -408,6 +408,14 @@ func (p *expressionProcessor) processString(s string) string {
 		prev = span[1]
 	}
 	b.WriteString(s[prev:])
+
+	for _, fn := range ctx.UnsupportedFunctions {
+		GetMessageLogger().LogWarning(
+			"UNSUPPORTED_EXPRESSION_FUNCTION",
+			fn+" has no v1 equivalent; expression kept unchanged",
+			WithContext(map[string]string{"expression": s}),
+		)
+	}
 	return b.String()
 }
 
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	convertexpressions "github.com/drone/go-convert/convert/convertexpressions"
+	"github.com/drone/go-convert/convert/v0tov1/messagelog"
 	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
 	"github.com/drone/go-convert/internal/flexible"
 )
@@ -381,3 +382,21 @@ func TestPostProcessExpressions_NestedMapInterface(t *testing.T) {
 		t.Error("with.level1 should be a map")
 	}
 }
+
+const expressionTestLogFile = "expression_test"
+
+func TestProcessString_UnsupportedFunction(t *testing.T) {
+	messagelog.ResetMessageLogger()
+	messagelog.GetMessageLogger().Enable("")
+	messagelog.GetMessageLogger().SetCurrentFile(expressionTestLogFile)
+	defer messagelog.ResetMessageLogger()
+
+	p := &expressionProcessor{}
+	src := `<+json.format(<+pipeline.variables.body>)>`
+	if result := p.processString(src); result != src {
+		t.Errorf("expected %q unchanged, got %q", src, result)
+	}
+	if codes := messageCodes(expressionTestLogFile); len(codes) != 1 || codes[0] != "UNSUPPORTED_EXPRESSION_FUNCTION" {
+		t.Errorf("expected UNSUPPORTED_EXPRESSION_FUNCTION, got %v", codes)
+	}
+}
@@ -0,0 +1,246 @@
+package pipelineconverter
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	convert_helpers "github.com/drone/go-convert/convert/v0tov1/convert_helpers"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+)
+
+// runtimeInputExpr is the v0 runtime input placeholder. It may be followed by
+// .default(), .allowedValues(), .selectOneFrom(), .selectManyFrom(), .regex()
+// and .executionInput() modifiers.
+const runtimeInputExpr = "<+input>"
+
+var inputTypeV1 = reflect.TypeOf(v1.Input{})
+
+// runtimeInputExtractor walks a converted v1 template body and lifts each v0
+// runtime input into a typed input.
+type runtimeInputExtractor struct {
+	inputs map[string]*v1.Input
+	stepID string // id of the enclosing v1.Step, used to qualify clashing names
+}
+
+// extractRuntimeInputs replaces every v0 runtime input in the converted
+// targets with an <+inputs.name> reference and returns the typed inputs keyed
+// by name, or nil when there are none. The name is the json key (or map key)
+// of the field that held the input; a clash is qualified with the enclosing
+// step id and then numbered. Existing v1 inputs (pipeline and stage
+// variables) are left alone.
+func extractRuntimeInputs(targets ...any) map[string]*v1.Input {
+	e := &runtimeInputExtractor{inputs: make(map[string]*v1.Input)}
+	for _, target := range targets {
+		val := reflect.ValueOf(target)
+		if !val.IsValid() || (val.Kind() == reflect.Ptr && val.IsNil()) {
+			continue
+		}
+		e.walk(val, "")
+	}
+	if len(e.inputs) == 0 {
+		return nil
+	}
+	return e.inputs
+}
+
+func (e *runtimeInputExtractor) walk(val reflect.Value, name string) {
+	switch val.Kind() {
+	case reflect.Ptr:
+		if val.IsNil() || val.Elem().Type() == inputTypeV1 {
+			return
+		}
+		e.walk(val.Elem(), name)
+
+	case reflect.Interface:
+		if val.IsNil() {
+			return
+		}
+		elem := val.Elem()
+		if elem.Kind() == reflect.String {
+			if ref, ok := e.lift(elem.String(), name, "string"); ok && val.CanSet() {
+				val.Set(reflect.ValueOf(ref))
+			}
+			return
+		}
+		e.walk(elem, name)
+
+	case reflect.Struct:
+		e.walkStruct(val, name)
+
+	case reflect.Slice:
+		for i := 0; i < val.Len(); i++ {
+			elem := val.Index(i)
+			if elem.Kind() == reflect.String {
+				if ref, ok := e.lift(elem.String(), name, "string"); ok && elem.CanSet() {
+					elem.SetString(ref)
+				}
+				continue
+			}
+			e.walk(elem, name)
+		}
+
+	case reflect.Map:
+		if val.IsNil() || val.Type().Key().Kind() != reflect.String {
+			return
+		}
+		keys := val.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		for _, key := range keys {
+			elem := val.MapIndex(key)
+			if elem.Kind() == reflect.Interface && !elem.IsNil() {
+				elem = elem.Elem()
+			}
+			if elem.Kind() == reflect.String {
+				if ref, ok := e.lift(elem.String(), key.String(), "string"); ok {
+					val.SetMapIndex(key, reflect.ValueOf(ref).Convert(val.Type().Elem()))
+				}
+				continue
+			}
+			e.walk(elem, key.String())
+		}
+	}
+}
+
+func (e *runtimeInputExtractor) walkStruct(val reflect.Value, name string) {
+	t := val.Type()
+
+	if isFlexibleField(t) {
+		value := val.FieldByName("Value")
+		if !value.IsValid() || value.IsNil() {
+			return
+		}
+		if s, ok := value.Elem().Interface().(string); ok {
+			if ref, ok := e.lift(s, name, flexibleInputType(t)); ok && value.CanSet() {
+				value.Set(reflect.ValueOf(ref))
+			}
+			return
+		}
+		e.walk(value.Elem(), name)
+		return
+	}
+
+	if t == reflect.TypeOf(v1.Step{}) {
+		if id := val.FieldByName("Id").String(); id != "" {
+			parent := e.stepID
+			e.stepID = id
+			defer func() { e.stepID = parent }()
+		}
+	}
+
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Field(i)
+		fieldType := t.Field(i)
+		if !fieldType.IsExported() {
+			continue
+		}
+		tag := strings.Split(fieldType.Tag.Get("json"), ",")[0]
+		if tag == "-" {
+			continue
+		}
+		// Existing v1 inputs are already typed.
+		if field.Kind() == reflect.Map && field.Type().Elem().Kind() == reflect.Ptr && field.Type().Elem().Elem() == inputTypeV1 {
+			continue
+		}
+		fieldName := tag
+		if fieldType.Anonymous || fieldName == "" {
+			fieldName = name
+		}
+		if field.Kind() == reflect.String {
+			if ref, ok := e.lift(field.String(), fieldName, "string"); ok && field.CanSet() {
+				field.SetString(ref)
+			}
+			continue
+		}
+		e.walk(field, fieldName)
+	}
+}
+
+// lift registers s as a typed input when it is a runtime input and returns
+// the <+inputs.name> reference that replaces it.
+func (e *runtimeInputExtractor) lift(s, name, inputType string) (string, bool) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, runtimeInputExpr) {
+		return "", false
+	}
+	parsed := convert_helpers.ParseInputExpression(s)
+	if parsed.Value != runtimeInputExpr {
+		return "", false
+	}
+
+	input := &v1.Input{
+		Type:           inputType,
+		Pattern:        parsed.Regex,
+		ExecutionInput: parsed.ExecutionInput,
+		Required:       parsed.DefaultVal == "",
+	}
+	if parsed.DefaultVal != "" {
+		input.Default = convert_helpers.FormatValueForV1Type(parsed.DefaultVal, inputType)
+	}
+	if len(parsed.Enum) > 0 {
+		input.Enum = convert_helpers.FormatEnumForV1Type(parsed.Enum, inputType)
+	}
+
+	key := e.uniqueName(name)
+	e.inputs[key] = input
+	return "<+inputs." + key + ">", true
+}
+
+// uniqueName returns a template-wide unique input name for a field.
+func (e *runtimeInputExtractor) uniqueName(name string) string {
+	base := sanitizeInputName(name)
+	if _, taken := e.inputs[base]; !taken {
+		return base
+	}
+	if e.stepID != "" {
+		base = sanitizeInputName(e.stepID + "_" + name)
+		if _, taken := e.inputs[base]; !taken {
+			return base
+		}
+	}
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s_%d", base, i)
+		if _, taken := e.inputs[candidate]; !taken {
+			return candidate
+		}
+	}
+}
+
+// sanitizeInputName makes name usable in an <+inputs.name> expression.
+func sanitizeInputName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	if b.Len() == 0 {
+		return "input"
+	}
+	return b.String()
+}
+
+// flexibleInputType infers the v1 input type from the type argument of a
+// flexible.Field (e.g. Field[bool] is a boolean input).
+func flexibleInputType(t reflect.Type) string {
+	arg := t.Name()
+	if i := strings.Index(arg, "["); i >= 0 {
+		arg = strings.TrimSuffix(arg[i+1:], "]")
+	}
+	switch {
+	case arg == "bool":
+		return "boolean"
+	case strings.HasPrefix(arg, "int"), strings.HasPrefix(arg, "float"):
+		return "number"
+	case strings.HasPrefix(arg, "[]"):
+		return "array"
+	case strings.HasPrefix(arg, "map["):
+		return "object"
+	default:
+		return "string"
+	}
+}
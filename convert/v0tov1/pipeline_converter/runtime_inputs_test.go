@@ -0,0 +1,110 @@
+package pipelineconverter
+
+import (
+	"testing"
+
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+	"github.com/drone/go-convert/internal/flexible"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExtractRuntimeInputs(t *testing.T) {
+	stage := &v1.Stage{
+		Id: "build",
+		Steps: []*v1.Step{
+			{
+				Id:      "test",
+				Timeout: "<+input>.executionInput()",
+				Run: &v1.StepRun{
+					Script: v1.Stringorslice{"<+input>"},
+					Env: &flexible.Field[map[string]interface{}]{Value: map[string]interface{}{
+						"GOFLAGS": "<+input>.regex(^-.*$)",
+					}},
+					Container: &v1.Container{
+						Image:      "<+input>.default(golang:1.22).allowedValues(golang:1.21,golang:1.22)",
+						Privileged: &flexible.Field[bool]{Value: "<+input>"},
+					},
+				},
+			},
+			{
+				Id:      "lint",
+				Timeout: "10m",
+				Run: &v1.StepRun{
+					Script: v1.Stringorslice{"<+input>"},
+				},
+			},
+		},
+		Inputs: map[string]*v1.Input{
+			"keep": {Type: "string", Value: "<+input>"},
+		},
+	}
+
+	got := extractRuntimeInputs(stage)
+	want := map[string]*v1.Input{
+		"timeout":     {Type: "string", Required: true, ExecutionInput: true},
+		"script":      {Type: "string", Required: true},
+		"GOFLAGS":     {Type: "string", Required: true, Pattern: "^-.*$"},
+		"image":       {Type: "string", Default: "golang:1.22", Enum: []string{"golang:1.21", "golang:1.22"}},
+		"privileged":  {Type: "boolean", Required: true},
+		"lint_script": {Type: "string", Required: true},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("inputs mismatch (-want +got):\n%s", diff)
+	}
+
+	steps := stage.Steps
+	refs := map[string]string{
+		"timeout":     steps[0].Timeout,
+		"script":      steps[0].Run.Script[0],
+		"GOFLAGS":     steps[0].Run.Env.Value.(map[string]interface{})["GOFLAGS"].(string),
+		"image":       steps[0].Run.Container.Image,
+		"privileged":  steps[0].Run.Container.Privileged.Value.(string),
+		"lint_script": steps[1].Run.Script[0],
+	}
+	for name, ref := range refs {
+		if want := "<+inputs." + name + ">"; ref != want {
+			t.Errorf("want %s replaced with %q, got %q", name, want, ref)
+		}
+	}
+	if steps[1].Timeout != "10m" {
+		t.Errorf("expected plain values unchanged, got %q", steps[1].Timeout)
+	}
+	if stage.Inputs["keep"].Value != "<+input>" {
+		t.Errorf("expected existing inputs unchanged, got %+v", stage.Inputs["keep"])
+	}
+}
+
+func TestExtractRuntimeInputs_None(t *testing.T) {
+	step := &v1.Step{Id: "test", Run: &v1.StepRun{Script: v1.Stringorslice{"go test <+input>"}}}
+	if got := extractRuntimeInputs(step); got != nil {
+		t.Errorf("expected no inputs, got %+v", got)
+	}
+	if step.Run.Script[0] != "go test <+input>" {
+		t.Errorf("expected embedded runtime input unchanged, got %q", step.Run.Script[0])
+	}
+}
+
+func TestConvertTemplate_RuntimeInputs(t *testing.T) {
+	src := parseTemplate(t, `
+template:
+  name: deploy
+  identifier: deploy
+  versionLabel: v1
+  type: Step
+  spec:
+    type: Run
+    name: deploy
+    identifier: deploy
+    spec:
+      shell: Sh
+      command: <+input>
+`)
+
+	got := NewPipelineConverter().ConvertTemplate(src)
+	if diff := cmp.Diff(map[string]*v1.Input{"script": {Type: "string", Required: true}}, got.Inputs); diff != "" {
+		t.Errorf("inputs mismatch (-want +got):\n%s", diff)
+	}
+	if got.Step == nil || got.Step.Run == nil || got.Step.Run.Script[0] != "<+inputs.script>" {
+		t.Errorf("expected script to reference the input, got %+v", got.Step)
+	}
+}
@@ -74,10 +74,95 @@ const (
 	StepTypeServerlessPackage  = "serverlessPackageStep"
 	StepTypeServerlessRollback = "serverlessRollbackStep"
 
-	// Approval
-	StepTypeVerify = "Verify"
+	// Continuous Verification / Chaos
+	StepTypeVerify = "verifyStep"
+	StepTypeChaos  = "chaosStep"
 
 	// IACM
 	StepTypeIACMTerraformPlugin = "terraformStep"
 	StepTypeIACMOpenTofuPlugin  = "openTofuStep"
+
+	// CD / Terraform
+	StepTypeTerraformPlan     = "terraformPlanStep"
+	StepTypeTerraformApply    = "terraformApplyStep"
+	StepTypeTerraformDestroy  = "terraformDestroyStep"
+	StepTypeTerraformRollback = "terraformRollbackStep"
+
+	// CD / Terragrunt
+	StepTypeTerragruntPlan     = "terragruntPlanStep"
+	StepTypeTerragruntApply    = "terragruntApplyStep"
+	StepTypeTerragruntDestroy  = "terragruntDestroyStep"
+	StepTypeTerragruntRollback = "terragruntRollbackStep"
+
+	// CD / CloudFormation
+	StepTypeCloudformationCreateStack   = "cloudformationCreateStackStep"
+	StepTypeCloudformationDeleteStack   = "cloudformationDeleteStackStep"
+	StepTypeCloudformationRollbackStack = "cloudformationRollbackStackStep"
+
+	// CD / ECS
+	StepTypeEcsRollingDeploy             = "ecsRollingDeployStep"
+	StepTypeEcsRollingRollback           = "ecsRollingRollbackStep"
+	StepTypeEcsCanaryDeploy              = "ecsCanaryDeployStep"
+	StepTypeEcsCanaryDelete              = "ecsCanaryDeleteStep"
+	StepTypeEcsBlueGreenCreateService    = "ecsBlueGreenCreateServiceStep"
+	StepTypeEcsBlueGreenSwapTargetGroups = "ecsBlueGreenSwapTargetGroupsStep"
+	StepTypeEcsBlueGreenRollback         = "ecsBlueGreenRollbackStep"
+	StepTypeEcsRunTask                   = "ecsRunTaskStep"
+
+	// CD / Azure Web App
+	StepTypeAzureSlotDeployment = "azureWebAppSlotDeployStep"
+	StepTypeAzureTrafficShift   = "azureWebAppTrafficShiftStep"
+	StepTypeAzureSwapSlot       = "azureWebAppSwapSlotStep"
+	StepTypeAzureWebAppRollback = "azureWebAppRollbackStep"
+
+	// CD / Tanzu Application Service
+	StepTypeTasBasicAppSetup  = "tasBasicAppSetupStep"
+	StepTypeTasBGAppSetup     = "tasBlueGreenAppSetupStep"
+	StepTypeTasCanaryAppSetup = "tasCanaryAppSetupStep"
+	StepTypeTasAppResize      = "tasAppResizeStep"
+	StepTypeTasSwapRoutes     = "tasSwapRoutesStep"
+	StepTypeTasSwapRollback   = "tasSwapRollbackStep"
+	StepTypeTasAppRollback    = "tasAppRollbackStep"
+
+	// CD / ASG
+	StepTypeAsgRollingDeploy        = "asgRollingDeployStep"
+	StepTypeAsgRollingRollback      = "asgRollingRollbackStep"
+	StepTypeAsgBlueGreenDeploy      = "asgBlueGreenDeployStep"
+	StepTypeAsgBlueGreenSwapService = "asgBlueGreenSwapServiceStep"
+	StepTypeAsgBlueGreenRollback    = "asgBlueGreenRollbackStep"
+
+	// CD / Google Cloud Functions
+	StepTypeDeployCloudFunction              = "googleCloudFunctionDeployStep"
+	StepTypeDeployCloudFunctionWithNoTraffic = "googleCloudFunctionDeployWithoutTrafficStep"
+	StepTypeCloudFunctionTrafficShift        = "googleCloudFunctionTrafficShiftStep"
+	StepTypeCloudFunctionRollback            = "googleCloudFunctionRollbackStep"
+
+	// CD / AWS Lambda
+	StepTypeAwsLambdaDeploy   = "awsLambdaDeployStep"
+	StepTypeAwsLambdaRollback = "awsLambdaRollbackStep"
+
+	// STO
+	StepTypeSTOAquaTrivy    = "aquaTrivyStep"
+	StepTypeSTOSnyk         = "snykStep"
+	StepTypeSTOSonarqube    = "sonarqubeStep"
+	StepTypeSTOCheckmarx    = "checkmarxStep"
+	StepTypeSTOGrype        = "grypeStep"
+	StepTypeSTOWiz          = "wizStep"
+	StepTypeSTOSemgrep      = "semgrepStep"
+	StepTypeSTOGitleaks     = "gitleaksStep"
+	StepTypeSTOPrismaCloud  = "prismaCloudStep"
+	StepTypeSTOCustomIngest = "customIngestStep"
+
+	// Feature Flags / Flag Configuration
+	StepTypeFeatureFlagConfiguration = "flagConfigurationStep"
+
+	// CD / GitOps
+	StepTypeGitOpsUpdateReleaseRepo = "gitopsUpdateReleaseRepoStep"
+	StepTypeGitOpsMergePR           = "gitopsMergePrStep"
+	StepTypeGitOpsFetchLinkedApps   = "gitopsFetchLinkedAppsStep"
+	StepTypeGitOpsSync              = "gitopsSyncStep"
+	StepTypeGitOpsRevertPR          = "gitopsRevertPrStep"
+
+	// Governance
+	StepTypePolicy = "policyStep"
 )
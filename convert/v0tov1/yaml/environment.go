@@ -16,11 +16,17 @@
 
 package yaml
 
+import "github.com/drone/go-convert/internal/flexible"
+
+// Environment defines an environment.
 type Environment struct {
-	Id      string            `json:"id,omitempty"`
-	Name    string            `json:"name,omitempty"`
-	Org     string            `json:"org,omitempty"`
-	Project string            `json:"project,omitempty"`
-	Tags    map[string]string `json:"tags,omitempty"`
-	Type    EnvironmentType   `json:"type,omitempty"`
+	Id        string                             `json:"id,omitempty"`
+	Name      string                             `json:"name,omitempty"`
+	Desc      string                             `json:"description,omitempty"`
+	Org       string                             `json:"org,omitempty"`
+	Project   string                             `json:"project,omitempty"`
+	Tags      *flexible.Field[map[string]string] `json:"tags,omitempty"`
+	Type      EnvironmentType                    `json:"type,omitempty"`
+	Inputs    map[string]*Input                  `json:"inputs,omitempty"`
+	Overrides *Overrides                         `json:"overrides,omitempty"`
 }
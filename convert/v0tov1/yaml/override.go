@@ -0,0 +1,34 @@
+package yaml
+
+// Override scopes.
+const (
+	OverrideTypeEnvironment           = "environment"
+	OverrideTypeEnvironmentService    = "environment-service"
+	OverrideTypeInfrastructure        = "infrastructure"
+	OverrideTypeInfrastructureService = "infrastructure-service"
+)
+
+type (
+	// Override defines the values that replace service values when
+	// deploying to an environment or infrastructure.
+	Override struct {
+		Id             string `json:"id,omitempty"`
+		Org            string `json:"org,omitempty"`
+		Project        string `json:"project,omitempty"`
+		Type           string `json:"type,omitempty"`
+		Environment    string `json:"environment,omitempty"`
+		Service        string `json:"service,omitempty"`
+		Infrastructure string `json:"infrastructure,omitempty"`
+		*Overrides
+	}
+
+	// Overrides defines the overridden inputs, manifests, config files and
+	// Azure settings.
+	Overrides struct {
+		Inputs              map[string]*Input `json:"inputs,omitempty"`
+		Manifests           []interface{}     `json:"manifests,omitempty"`
+		ConfigFiles         []interface{}     `json:"config-files,omitempty"`
+		ApplicationSettings interface{}       `json:"application-settings,omitempty"`
+		ConnectionStrings   interface{}       `json:"connection-strings,omitempty"`
+	}
+)
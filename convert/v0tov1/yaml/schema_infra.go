@@ -16,10 +16,23 @@
 
 package yaml
 
+import "github.com/drone/go-convert/internal/flexible"
+
+// InfraSchema defines an infrastructure definition. The spec depends on the
+// infrastructure type.
 type InfraSchema struct {
-	Id      string            `json:"id,omitempty"`
-	Name    string            `json:"name,omitempty"`
-	Org     string            `json:"org,omitempty"`
-	Project string            `json:"project,omitempty"`
-	Tags    map[string]string `json:"tags,omitempty"`
+	Id          string                             `json:"id,omitempty"`
+	Name        string                             `json:"name,omitempty"`
+	Desc        string                             `json:"description,omitempty"`
+	Org         string                             `json:"org,omitempty"`
+	Project     string                             `json:"project,omitempty"`
+	Tags        *flexible.Field[map[string]string] `json:"tags,omitempty"`
+	Environment string                             `json:"environment,omitempty"`
+	Deployment  string                             `json:"deployment,omitempty"`
+	Type        string                             `json:"type,omitempty"`
+	Spec        interface{}                        `json:"spec,omitempty"`
+
+	// AllowSimultaneous allows more than one deployment to the
+	// infrastructure at a time.
+	AllowSimultaneous *flexible.Field[bool] `json:"allow-simultaneous,omitempty"`
 }
@@ -16,10 +16,28 @@
 
 package yaml
 
+import "github.com/drone/go-convert/internal/flexible"
+
+// Service defines a service.
 type Service struct {
-	Id      string            `json:"id,omitempty"`
-	Name    string            `json:"name,omitempty"`
-	Org     string            `json:"org,omitempty"`
-	Project string            `json:"project,omitempty"`
-	Tags    map[string]string `json:"tags,omitempty"`
+	Id          string                             `json:"id,omitempty"`
+	Name        string                             `json:"name,omitempty"`
+	Desc        string                             `json:"description,omitempty"`
+	Org         string                             `json:"org,omitempty"`
+	Project     string                             `json:"project,omitempty"`
+	Tags        *flexible.Field[map[string]string] `json:"tags,omitempty"`
+	Type        string                             `json:"type,omitempty"`
+	GitOps      *flexible.Field[bool]              `json:"gitops,omitempty"`
+	Inputs      map[string]*Input                  `json:"inputs,omitempty"`
+	Manifests   []interface{}                      `json:"manifests,omitempty"`
+	Artifacts   interface{}                        `json:"artifacts,omitempty"`
+	ConfigFiles []interface{}                      `json:"config-files,omitempty"`
+	Hooks       []interface{}                      `json:"hooks,omitempty"`
+
+	// PrimaryManifest names the manifest used when several are defined.
+	PrimaryManifest string `json:"primary-manifest,omitempty"`
+
+	ApplicationSettings interface{} `json:"application-settings,omitempty"`
+	ConnectionStrings   interface{} `json:"connection-strings,omitempty"`
+	StartupCommand      interface{} `json:"startup-command,omitempty"`
 }
@@ -36,4 +36,11 @@ type Template struct {
 	Timeout      string                             `json:"timeout,omitempty"`
 	Delegate     *flexible.Field[*Delegate]         `json:"delegate,omitempty"`
 	If           string                             `json:"if,omitempty"`
+
+	CustomDeployment *CustomDeploymentTemplate `json:"custom-deployment,omitempty"`
+
+	// Template kinds without a v1 model carry the v0 spec unchanged.
+	ArtifactSource   interface{} `json:"artifact-source,omitempty"`
+	MonitoredService interface{} `json:"monitored-service,omitempty"`
+	SecretManager    interface{} `json:"secret-manager,omitempty"`
 }
\ No newline at end of file
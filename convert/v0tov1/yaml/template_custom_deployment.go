@@ -0,0 +1,28 @@
+package yaml
+
+import "github.com/drone/go-convert/internal/flexible"
+
+type (
+	// CustomDeploymentTemplate defines the infrastructure inputs of a custom
+	// deployment and how its instances are discovered.
+	CustomDeploymentTemplate struct {
+		Inputs    map[string]*Input          `json:"inputs,omitempty"`
+		Instances *CustomDeploymentInstances `json:"instances,omitempty"`
+		Steps     []string                   `json:"steps,omitempty"`
+	}
+
+	// CustomDeploymentInstances defines the script that lists the instances,
+	// the path of the list in its output and the attributes of each entry.
+	CustomDeploymentInstances struct {
+		Script     string                       `json:"script,omitempty"`
+		Files      *flexible.Field[[]string]    `json:"files,omitempty"`
+		Path       string                       `json:"path,omitempty"`
+		Attributes []*CustomDeploymentAttribute `json:"attributes,omitempty"`
+	}
+
+	CustomDeploymentAttribute struct {
+		Name        string `json:"name,omitempty"`
+		Path        string `json:"path,omitempty"`
+		Description string `json:"description,omitempty"`
+	}
+)
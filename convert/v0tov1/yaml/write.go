@@ -158,6 +158,91 @@ func MarshalTrigger(t *Trigger) ([]byte, error) {
 	return yaml.Marshal(jsonData)
 }
 
+// MarshalService marshals the given Service into YAML with a top-level
+// 'service:' key.
+func MarshalService(s *Service) ([]byte, error) {
+	return marshalEntity(struct {
+		Service *Service `json:"service"`
+	}{Service: s})
+}
+
+// WriteServiceFile writes the Service to the given file path.
+func WriteServiceFile(path string, s *Service) error {
+	b, err := MarshalService(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o644)
+}
+
+// MarshalEnvironment marshals the given Environment into YAML with a
+// top-level 'environment:' key.
+func MarshalEnvironment(e *Environment) ([]byte, error) {
+	return marshalEntity(struct {
+		Environment *Environment `json:"environment"`
+	}{Environment: e})
+}
+
+// WriteEnvironmentFile writes the Environment to the given file path.
+func WriteEnvironmentFile(path string, e *Environment) error {
+	b, err := MarshalEnvironment(e)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o644)
+}
+
+// MarshalInfrastructure marshals the given infrastructure definition into
+// YAML with a top-level 'infrastructure:' key.
+func MarshalInfrastructure(i *InfraSchema) ([]byte, error) {
+	return marshalEntity(struct {
+		Infrastructure *InfraSchema `json:"infrastructure"`
+	}{Infrastructure: i})
+}
+
+// WriteInfrastructureFile writes the infrastructure definition to the given
+// file path.
+func WriteInfrastructureFile(path string, i *InfraSchema) error {
+	b, err := MarshalInfrastructure(i)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o644)
+}
+
+// MarshalOverride marshals the given Override into YAML with a top-level
+// 'override:' key.
+func MarshalOverride(o *Override) ([]byte, error) {
+	return marshalEntity(struct {
+		Override *Override `json:"override"`
+	}{Override: o})
+}
+
+// WriteOverrideFile writes the Override to the given file path.
+func WriteOverrideFile(path string, o *Override) error {
+	b, err := MarshalOverride(o)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o644)
+}
+
+// marshalEntity marshals a single-key entity wrapper to YAML by way of JSON,
+// so the v1 json tags and custom marshalers apply.
+func marshalEntity(wrapper interface{}) ([]byte, error) {
+	jsonBytes, err := json.Marshal(wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := jsonToInterface(jsonBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(jsonData)
+}
+
 // jsonToInterface decodes JSON bytes into an interface{} tree, using
 // json.Decoder with UseNumber() to preserve number representations.
 // The resulting tree is then walked to convert json.Number values
@@ -19,14 +19,18 @@ require (
 )
 
 require (
+	github.com/google/go-jsonnet v0.21.0
+	go.starlark.net v0.0.0-20250417143717-f57e51f710eb
 	google.golang.org/grpc v1.80.0
 	google.golang.org/protobuf v1.36.11
 )
 
 require (
+	golang.org/x/crypto v0.47.0 // indirect
 	golang.org/x/net v0.49.0 // indirect
 	golang.org/x/sys v0.40.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20260120221211-b8f7ae30c516 // indirect
+	sigs.k8s.io/yaml v1.4.0 // indirect
 )
 
 require (
@@ -0,0 +1,97 @@
+package converter
+
+import (
+	"fmt"
+
+	v0 "github.com/drone/go-convert/convert/harness/yaml"
+	pipelineconverter "github.com/drone/go-convert/convert/v0tov1/pipeline_converter"
+	v1 "github.com/drone/go-convert/convert/v0tov1/yaml"
+)
+
+// Service converts a Harness v0 service YAML string into v1 YAML bytes.
+// The input must have a top-level "service:" key.
+func Service(yamlStr string) (*Result, error) {
+	return convertEntity(yamlStr, "service", "service", func(c *pipelineconverter.PipelineConverter, cfg *v0.Config) (interface{}, func() ([]byte, error)) {
+		if cfg.Service == nil {
+			return nil, nil
+		}
+		dst := c.ConvertService(cfg.Service)
+		return dst, func() ([]byte, error) { return v1.MarshalService(dst) }
+	})
+}
+
+// Environment converts a Harness v0 environment YAML string into v1 YAML
+// bytes. The input must have a top-level "environment:" key.
+func Environment(yamlStr string) (*Result, error) {
+	return convertEntity(yamlStr, "environment", "environment", func(c *pipelineconverter.PipelineConverter, cfg *v0.Config) (interface{}, func() ([]byte, error)) {
+		if cfg.Environment == nil {
+			return nil, nil
+		}
+		dst := c.ConvertEnvironment(cfg.Environment)
+		return dst, func() ([]byte, error) { return v1.MarshalEnvironment(dst) }
+	})
+}
+
+// Infrastructure converts a Harness v0 infrastructure definition YAML string
+// into v1 YAML bytes. The input must have a top-level
+// "infrastructureDefinition:" key.
+func Infrastructure(yamlStr string) (*Result, error) {
+	return convertEntity(yamlStr, "infrastructureDefinition", "infrastructure", func(c *pipelineconverter.PipelineConverter, cfg *v0.Config) (interface{}, func() ([]byte, error)) {
+		if cfg.Infrastructure == nil {
+			return nil, nil
+		}
+		dst := c.ConvertInfrastructure(cfg.Infrastructure)
+		return dst, func() ([]byte, error) { return v1.MarshalInfrastructure(dst) }
+	})
+}
+
+// Override converts a Harness v0 service/environment override YAML string
+// into v1 YAML bytes. The input must have a top-level "overrides:" key.
+func Override(yamlStr string) (*Result, error) {
+	return convertEntity(yamlStr, "overrides", "override", func(c *pipelineconverter.PipelineConverter, cfg *v0.Config) (interface{}, func() ([]byte, error)) {
+		if cfg.Overrides == nil {
+			return nil, nil
+		}
+		dst := c.ConvertOverride(cfg.Overrides)
+		return dst, func() ([]byte, error) { return v1.MarshalOverride(dst) }
+	})
+}
+
+// convertEntity runs the shared parse / convert / post-process / marshal flow
+// for the standalone service, environment, infrastructure and override
+// entities. key is the expected v0 top-level key and kind names the entity
+// in errors. convert returns the converted v1 value and its marshaller, or a
+// nil value when the entity is missing. These entities hold no steps, so
+// expressions are post-processed without FQN context and no ref mappings
+// apply.
+func convertEntity(yamlStr, key, kind string, convert func(*pipelineconverter.PipelineConverter, *v0.Config) (interface{}, func() ([]byte, error))) (*Result, error) {
+	if err := validateTopLevelKey(yamlStr, key); err != nil {
+		return nil, err
+	}
+
+	done := beginAPIConversion()
+	defer done()
+
+	v0Config, unknownFields, err := v0.ParseStringWithUnknownFields(yamlStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse v0 %s: %w", kind, err)
+	}
+
+	c := pipelineconverter.NewPipelineConverter()
+	dst, marshal := convert(c, v0Config)
+	if dst == nil {
+		return nil, fmt.Errorf("%s conversion returned nil", kind)
+	}
+
+	pipelineconverter.PostProcessExpressions(dst, nil, false)
+
+	yamlBytes, err := marshal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal v1 %s: %w", kind, err)
+	}
+	return &Result{
+		YAML:          yamlBytes,
+		UnknownFields: unknownFields,
+		Summary:       buildAPISummary(unknownFields),
+	}, nil
+}
@@ -0,0 +1,60 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEntities_Convert(t *testing.T) {
+	tests := []struct {
+		name    string
+		convert func(string) (*Result, error)
+		in      string
+		want    []string
+	}{
+		{
+			name:    "service",
+			convert: Service,
+			in:      "service:\n  identifier: checkout\n  serviceDefinition:\n    type: Kubernetes\n",
+			want:    []string{"service:", "id: checkout", "type: Kubernetes"},
+		},
+		{
+			name:    "environment",
+			convert: Environment,
+			in:      "environment:\n  identifier: prod\n  type: PreProduction\n",
+			want:    []string{"environment:", "id: prod", "type: non-production"},
+		},
+		{
+			name:    "infrastructure",
+			convert: Infrastructure,
+			in:      "infrastructureDefinition:\n  identifier: prod_k8s\n  environmentRef: prod\n  type: KubernetesDirect\n",
+			want:    []string{"infrastructure:", "id: prod_k8s", "environment: prod"},
+		},
+		{
+			name:    "override",
+			convert: Override,
+			in:      "overrides:\n  environmentRef: prod\n  type: ENV_GLOBAL_OVERRIDE\n",
+			want:    []string{"override:", "environment: prod", "type: environment"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := tt.convert(tt.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(string(res.YAML), w) {
+					t.Errorf("expected %q in:\n%s", w, res.YAML)
+				}
+			}
+		})
+	}
+}
+
+func TestEntities_WrongTopLevelKey(t *testing.T) {
+	_, err := Service("environment:\n  identifier: prod\n")
+	if err == nil || !strings.Contains(err.Error(), "expected top-level 'service:' key") {
+		t.Fatalf("expected top-level key error, got %v", err)
+	}
+}
@@ -47,10 +47,15 @@ func buildContextFromPipelineYAML(contextPipelineYAML string) (map[string]*conve
 // input that do not match any field in the v0 schema (parsing does not fail
 // on unknown fields — they are surfaced for observability only). Summary
 // bundles converter messages, unknown fields, and expression conversions.
+// TemplateKind and TemplateStatus are only set for template conversions:
+// the v0 template type, and whether it was converted to a v1 model, its
+// spec passed through unchanged, or its spec dropped.
 type Result struct {
-	YAML          []byte
-	UnknownFields []string
-	Summary       *pipelineconverter.ConversionSummary
+	YAML           []byte
+	UnknownFields  []string
+	Summary        *pipelineconverter.ConversionSummary
+	TemplateKind   string
+	TemplateStatus string
 }
 
 // The three global loggers (expressions, unknown fields, messages) are
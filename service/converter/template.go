@@ -9,7 +9,10 @@ import (
 )
 
 // Template converts a Harness v0 template YAML string into v1 YAML bytes.
-// Supported template types are Pipeline, Stage, Step, and StepGroup.
+// Pipeline, Stage, Step, StepGroup and CustomDeployment templates are
+// converted; ArtifactSource, MonitoredService and SecretManager specs are
+// passed through unchanged with an UNSUPPORTED_TEMPLATE_TYPE warning, and
+// specs of other types are dropped with a TEMPLATE_SPEC_DROPPED warning.
 // The input must have a top-level "template:" key.
 // templateRefMapping rewrites template references in the output;
 // pipelineRefMapping rewrites pipeline identifiers (pipeline.id, chain.uses
@@ -34,7 +37,7 @@ func Template(yamlStr string, templateRefMapping, pipelineRefMapping map[string]
 	}
 
 	if v0Config.Template.Type == "" {
-		return nil, fmt.Errorf("template 'type' field is required (Pipeline, Stage, Step, or StepGroup)")
+		return nil, fmt.Errorf("template 'type' field is required (Pipeline, Stage, Step, StepGroup, CustomDeployment, ArtifactSource, MonitoredService, or SecretManager)")
 	}
 
 	if v0Config.Template.Spec == nil {
@@ -63,8 +66,10 @@ func Template(yamlStr string, templateRefMapping, pipelineRefMapping map[string]
 		return nil, err
 	}
 	return &Result{
-		YAML:          yamlBytes,
-		UnknownFields: unknownFields,
-		Summary:       buildAPISummary(unknownFields),
+		YAML:           yamlBytes,
+		UnknownFields:  unknownFields,
+		Summary:        buildAPISummary(unknownFields),
+		TemplateKind:   v0Config.Template.Type,
+		TemplateStatus: pipelineconverter.TemplateStatus(v1Template),
 	}, nil
 }
@@ -16,6 +16,11 @@ const (
 	entityTemplate = "template"
 	entityInputSet = "input-set"
 	entityTrigger  = "trigger"
+
+	entityService        = "service"
+	entityEnvironment    = "environment"
+	entityInfrastructure = "infrastructure"
+	entityOverride       = "override"
 )
 
 // Handler holds the HTTP handler methods for the conversion service.
@@ -63,6 +68,26 @@ func (h *Handler) ConvertTrigger(w http.ResponseWriter, r *http.Request) {
 	h.convertSingle(w, r, entityTrigger)
 }
 
+// ConvertService handles POST /api/v1/convert/service.
+func (h *Handler) ConvertService(w http.ResponseWriter, r *http.Request) {
+	h.convertSingle(w, r, entityService)
+}
+
+// ConvertEnvironment handles POST /api/v1/convert/environment.
+func (h *Handler) ConvertEnvironment(w http.ResponseWriter, r *http.Request) {
+	h.convertSingle(w, r, entityEnvironment)
+}
+
+// ConvertInfrastructure handles POST /api/v1/convert/infrastructure.
+func (h *Handler) ConvertInfrastructure(w http.ResponseWriter, r *http.Request) {
+	h.convertSingle(w, r, entityInfrastructure)
+}
+
+// ConvertOverride handles POST /api/v1/convert/override.
+func (h *Handler) ConvertOverride(w http.ResponseWriter, r *http.Request) {
+	h.convertSingle(w, r, entityOverride)
+}
+
 // ConvertBatch handles POST /api/v1/convert/batch.
 // The response is always HTTP 200; per-item errors are reported inline.
 func (h *Handler) ConvertBatch(w http.ResponseWriter, r *http.Request) {
@@ -99,7 +124,7 @@ func (h *Handler) ConvertBatch(w http.ResponseWriter, r *http.Request) {
 			cs := Checksum([]byte(item.YAML))
 			result.YAML = &s
 			result.Checksum = &cs
-			result.Report = buildReport(res.Summary, res.UnknownFields)
+			result.Report = buildResultReport(res)
 		}
 		results = append(results, result)
 	}
@@ -208,7 +233,7 @@ func (h *Handler) convertSingle(w http.ResponseWriter, r *http.Request, entityTy
 	writeJSON(w, http.StatusOK, ConvertResponse{
 		YAML:     string(res.YAML),
 		Checksum: Checksum([]byte(req.YAML)),
-		Report:   buildReport(res.Summary, res.UnknownFields),
+		Report:   buildResultReport(res),
 	})
 }
 
@@ -220,7 +245,9 @@ func (h *Handler) convertSingle(w http.ResponseWriter, r *http.Request, entityTy
 // trigger's embedded inputYaml).
 // contextPipelineYAML is forwarded to template / input-set / trigger
 // converters for postprocess context derivation; pipeline conversion ignores
-// it because it derives its own context from the input.
+// it because it derives its own context from the input. Service, environment,
+// infrastructure and override entities hold no template or pipeline refs and
+// no steps, so they ignore the mappings and the context pipeline.
 func dispatch(entityType, yamlStr string, templateRefMapping, pipelineRefMapping map[string]string, contextPipelineYAML string) (*converter.Result, error) {
 	switch entityType {
 	case entityPipeline:
@@ -231,8 +258,16 @@ func dispatch(entityType, yamlStr string, templateRefMapping, pipelineRefMapping
 		return converter.InputSet(yamlStr, templateRefMapping, pipelineRefMapping, contextPipelineYAML)
 	case entityTrigger:
 		return converter.Trigger(yamlStr, templateRefMapping, pipelineRefMapping, contextPipelineYAML)
+	case entityService:
+		return converter.Service(yamlStr)
+	case entityEnvironment:
+		return converter.Environment(yamlStr)
+	case entityInfrastructure:
+		return converter.Infrastructure(yamlStr)
+	case entityOverride:
+		return converter.Override(yamlStr)
 	default:
-		return nil, fmt.Errorf("unknown entity_type %q (must be pipeline, template, input-set, or trigger)", entityType)
+		return nil, fmt.Errorf("unknown entity_type %q (must be pipeline, template, input-set, trigger, service, environment, infrastructure, or override)", entityType)
 	}
 }
 
@@ -33,6 +33,11 @@ type metaEnvelope struct {
 	Template *metaFields `yaml:"template"`
 	InputSet *metaFields `yaml:"inputSet"`
 	Trigger  *metaFields `yaml:"trigger"`
+
+	Service        *metaFields `yaml:"service"`
+	Environment    *metaFields `yaml:"environment"`
+	Infrastructure *metaFields `yaml:"infrastructureDefinition"`
+	Overrides      *metaFields `yaml:"overrides"`
 }
 
 // extractEntityMetadata best-effort parses yamlStr and returns the account,
@@ -57,6 +62,14 @@ func extractEntityMetadata(entityType, yamlStr string) entityMetadata {
 		f = env.InputSet
 	case entityTrigger:
 		f = env.Trigger
+	case entityService:
+		f = env.Service
+	case entityEnvironment:
+		f = env.Environment
+	case entityInfrastructure:
+		f = env.Infrastructure
+	case entityOverride:
+		f = env.Overrides
 	}
 
 	// Fallback: if the requested type wasn't present, use whichever wrapper
@@ -71,6 +84,14 @@ func extractEntityMetadata(entityType, yamlStr string) entityMetadata {
 			f = env.InputSet
 		case env.Trigger != nil:
 			f = env.Trigger
+		case env.Service != nil:
+			f = env.Service
+		case env.Environment != nil:
+			f = env.Environment
+		case env.Infrastructure != nil:
+			f = env.Infrastructure
+		case env.Overrides != nil:
+			f = env.Overrides
 		}
 	}
 
@@ -2,6 +2,7 @@ package service
 
 import (
 	pipelineconverter "github.com/drone/go-convert/convert/v0tov1/pipeline_converter"
+	"github.com/drone/go-convert/service/converter"
 )
 
 // ConverterMessageDTO is the public-facing form of a converter notice.
@@ -30,6 +31,15 @@ type ConversionReport struct {
 	Messages           []ConverterMessageDTO `json:"messages,omitempty"`
 	UnrecognizedFields []string              `json:"unrecognized_fields,omitempty"`
 	Expressions        []ExpressionEntryDTO  `json:"expressions,omitempty"`
+	Template           *TemplateReportDTO    `json:"template,omitempty"`
+}
+
+// TemplateReportDTO records the kind of a converted template. Status is
+// "CONVERTED" when the spec was converted to a v1 model and "PASSED_THROUGH"
+// when it was copied unchanged.
+type TemplateReportDTO struct {
+	Kind   string `json:"kind"`
+	Status string `json:"status"`
 }
 
 // expressionStatus picks the ConversionStatus enum string for an entry.
@@ -40,6 +50,20 @@ func expressionStatus(original, converted string) string {
 	return "SUCCESS"
 }
 
+// buildResultReport builds the report for a conversion result, adding the
+// template kind for template conversions.
+func buildResultReport(res *converter.Result) *ConversionReport {
+	r := buildReport(res.Summary, res.UnknownFields)
+	if res.TemplateKind == "" {
+		return r
+	}
+	if r == nil {
+		r = &ConversionReport{}
+	}
+	r.Template = &TemplateReportDTO{Kind: res.TemplateKind, Status: res.TemplateStatus}
+	return r
+}
+
 // buildReport flattens an internal ConversionSummary plus the parse-time
 // unrecognised-fields list into a public ConversionReport. Returns nil when
 // there is nothing to report.
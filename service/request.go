@@ -41,7 +41,7 @@ type BatchConvertRequest struct {
 // BatchItem is one entity to convert inside a BatchConvertRequest.
 type BatchItem struct {
 	ID         string `json:"id"`
-	EntityType string `json:"entity_type"` // "pipeline" | "template" | "input-set" | "trigger"
+	EntityType string `json:"entity_type"` // "pipeline" | "template" | "input-set" | "trigger" | "service" | "environment" | "infrastructure" | "override"
 	YAML       string `json:"yaml"`
 
 	// TemplateRefMapping — same semantics as ConvertRequest.TemplateRefMapping.
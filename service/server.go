@@ -85,6 +85,10 @@ func buildMux(h *Handler) *http.ServeMux {
 	mux.HandleFunc("/api/v1/convert/template", methodFilter("POST", h.ConvertTemplate))
 	mux.HandleFunc("/api/v1/convert/input-set", methodFilter("POST", h.ConvertInputSet))
 	mux.HandleFunc("/api/v1/convert/trigger", methodFilter("POST", h.ConvertTrigger))
+	mux.HandleFunc("/api/v1/convert/service", methodFilter("POST", h.ConvertService))
+	mux.HandleFunc("/api/v1/convert/environment", methodFilter("POST", h.ConvertEnvironment))
+	mux.HandleFunc("/api/v1/convert/infrastructure", methodFilter("POST", h.ConvertInfrastructure))
+	mux.HandleFunc("/api/v1/convert/override", methodFilter("POST", h.ConvertOverride))
 	mux.HandleFunc("/api/v1/convert/batch", methodFilter("POST", h.ConvertBatch))
 	mux.HandleFunc("/api/v1/convert/expression", methodFilter("POST", h.ConvertExpression))
 	mux.HandleFunc("/api/v1/checksum", methodFilter("POST", h.ComputeChecksum))